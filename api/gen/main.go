@@ -0,0 +1,38 @@
+// gen は API 仕様からサーバーのインターフェースとリクエスト・レスポンスのモデルを生成する
+//
+// go generate ./api で実行する。分割ファイルを openapi.Load でまとめてから oapi-codegen に渡す。
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/oapi-codegen/oapi-codegen/v2/pkg/codegen"
+	"github.com/t-okuji/go-openapi-todo-demo/openapi"
+)
+
+func main() {
+	doc, err := openapi.Load(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	code, err := codegen.Generate(doc, codegen.Configuration{
+		PackageName: "api",
+		Generate: codegen.GenerateOptions{
+			Models:    true,
+			ChiServer: true,
+		},
+		OutputOptions: codegen.OutputOptions{
+			NameNormalizer: string(codegen.NameNormalizerFunctionToCamelCaseWithInitialisms),
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile("api.gen.go", []byte(code), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,7 @@
+// Package api は API 仕様（openapi パッケージ）から生成したサーバーのインターフェースとモデルを提供する
+//
+// handlers.Server が ServerInterface を実装するため、仕様の変更で操作・パラメーター・スキーマが
+// 実装と食い違うとビルドが失敗する。api.gen.go は編集せず、仕様の変更後に go generate ./api で再生成する。
+package api
+
+//go:generate go run ./gen
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+// ListAuditEventsOptions は GET /audit の絞り込み・ページングの条件を表す
+// ゼロ値の項目は指定しない
+type ListAuditEventsOptions struct {
+	EntityID string
+	// EntityType は対象エンティティの種別（todo・category）
+	EntityType string
+	Actor      string
+	// Limit は取得件数（0 の場合はサーバーのデフォルト）
+	Limit  int
+	Offset int
+}
+
+// values は opts をクエリパラメーターに変換する
+func (opts *ListAuditEventsOptions) values() url.Values {
+	query := url.Values{}
+	if opts == nil {
+		return query
+	}
+	setString(query, "entityId", opts.EntityID)
+	setString(query, "entityType", opts.EntityType)
+	setString(query, "actor", opts.Actor)
+	setInt(query, "limit", opts.Limit)
+	setInt(query, "offset", opts.Offset)
+	return query
+}
+
+// ListAuditEvents は条件に一致する監査イベントを新しい順に取得する
+func (c *Client) ListAuditEvents(ctx context.Context, opts *ListAuditEventsOptions) (*types.AuditEventListResponse, error) {
+	var list types.AuditEventListResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/audit", query: opts.values()}, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// AuditEvents は条件に一致する監査イベントを opts.Offset から順に返すイテレーター
+// ページの取得は Todos と同じ
+func (c *Client) AuditEvents(ctx context.Context, opts *ListAuditEventsOptions) iter.Seq2[types.AuditEventResponse, error] {
+	page := ListAuditEventsOptions{}
+	if opts != nil {
+		page = *opts
+	}
+	if page.Limit <= 0 {
+		page.Limit = defaultPageSize
+	}
+
+	return func(yield func(types.AuditEventResponse, error) bool) {
+		for {
+			list, err := c.ListAuditEvents(ctx, &page)
+			if err != nil {
+				yield(types.AuditEventResponse{}, err)
+				return
+			}
+			for _, event := range list.Items {
+				if !yield(event, nil) {
+					return
+				}
+			}
+			page.Offset += len(list.Items)
+			if len(list.Items) < page.Limit || page.Offset >= list.Total {
+				return
+			}
+		}
+	}
+}
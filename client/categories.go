@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+// ListCategories は全てのカテゴリを取得する
+func (c *Client) ListCategories(ctx context.Context) ([]types.CategoryResponse, error) {
+	var categories []types.CategoryResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/categories"}, &categories); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// GetCategory は指定した ID のカテゴリを取得する
+func (c *Client) GetCategory(ctx context.Context, id uuid.UUID) (*types.CategoryResponse, error) {
+	var category types.CategoryResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/categories/" + id.String()}, &category); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// CreateCategory はカテゴリを作成する
+// Idempotency-Key を自動的に付けて送信するため、一時的なエラーで再送しても重複して作成しない
+func (c *Client) CreateCategory(ctx context.Context, input types.CategoryInput) (*types.CategoryResponse, error) {
+	var category types.CategoryResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/categories", body: input, idempotent: true}, &category); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// UpdateCategory は指定した ID のカテゴリを更新する
+func (c *Client) UpdateCategory(ctx context.Context, id uuid.UUID, input types.CategoryInput) (*types.CategoryResponse, error) {
+	var category types.CategoryResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPut, path: "/categories/" + id.String(), body: input}, &category); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// DeleteCategory は指定した ID のカテゴリを削除する
+func (c *Client) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+	_, err := c.do(ctx, &request{method: http.MethodDelete, path: "/categories/" + id.String()}, nil)
+	return err
+}
@@ -0,0 +1,234 @@
+// Package client は Todo API の Go クライアント
+//
+// リクエスト・レスポンスは types パッケージ（API 仕様から生成したモデル）をそのまま使う。
+// エラーレスポンスは *Error に変換し、429・503 などの一時的なエラーは自動的に再送する。
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ヘッダー名（サーバーの handlers パッケージと同じ）
+const (
+	actorHeader          = "X-Actor"
+	idempotencyKeyHeader = "Idempotency-Key"
+	requestIDHeader      = "X-Request-Id"
+	totalCountHeader     = "X-Total-Count"
+)
+
+const (
+	defaultTimeout    = 30 * time.Second
+	defaultMaxRetries = 3
+	baseRetryDelay    = 500 * time.Millisecond
+	maxRetryDelay     = 30 * time.Second
+)
+
+// Client は Todo API のクライアント
+//
+// 複数のゴルーチンから同時に使用できる。
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	actor      string
+	apiKey     string
+	userAgent  string
+	maxRetries int
+}
+
+// Option は Client の設定を変更する
+type Option func(*Client)
+
+// WithHTTPClient はリクエストに使用する http.Client を設定する
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithActor は操作者（X-Actor ヘッダー）を設定する。監査ログの actor として記録される
+func WithActor(actor string) Option {
+	return func(c *Client) { c.actor = actor }
+}
+
+// WithAPIKey は Authorization ヘッダーで送信する API キーを設定する
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) { c.apiKey = apiKey }
+}
+
+// WithUserAgent は User-Agent ヘッダーを設定する
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) { c.userAgent = userAgent }
+}
+
+// WithMaxRetries は一時的なエラーで再送する最大回数を設定する（0 の場合は再送しない）
+func WithMaxRetries(n int) Option {
+	return func(c *Client) { c.maxRetries = max(n, 0) }
+}
+
+// New は baseURL（http://localhost:8080 など）の API に接続する Client を作成する
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL: scheme must be http or https: %s", baseURL)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		userAgent:  "go-openapi-todo-demo-client",
+		maxRetries: defaultMaxRetries,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// request は API へのリクエストを表す
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	body   any
+	// idempotent は Idempotency-Key を付けて送信する（同じキーで再送するため、POST でも再送できる）
+	idempotent bool
+	// idempotencyKey は呼び出し元が指定した冪等キー（空の場合は自動的に生成する）
+	idempotencyKey string
+	// requestID は X-Request-Id で送信するリクエスト ID（再送しても変えない）
+	requestID string
+}
+
+// retryable は method のリクエストを一時的なエラーで再送してよいかを返す
+func (req *request) retryable() bool {
+	switch req.method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.idempotent
+}
+
+// do はリクエストを送信し、成功した場合はレスポンスボディを out に読み込む（out が nil の場合は読み捨てる）
+// 429・503 と処理中の冪等キー（409 IDEMPOTENCY_KEY_IN_USE）は Retry-After または指数バックオフで待ってから再送する
+func (c *Client) do(ctx context.Context, req *request, out any) (*http.Response, error) {
+	var body []byte
+	if req.body != nil {
+		var err error
+		if body, err = json.Marshal(req.body); err != nil {
+			return nil, fmt.Errorf("encode request body: %w", err)
+		}
+	}
+	if req.idempotent && req.idempotencyKey == "" {
+		req.idempotencyKey = uuid.NewString()
+	}
+	req.requestID = uuid.NewString()
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, req, body)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode < 300 {
+			defer resp.Body.Close()
+			if out == nil {
+				io.Copy(io.Discard, resp.Body)
+				return resp, nil
+			}
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				return resp, fmt.Errorf("decode response body: %w", err)
+			}
+			return resp, nil
+		}
+
+		apiErr := decodeError(resp, req.requestID)
+		if attempt >= c.maxRetries || !req.retryable() || !apiErr.Temporary() {
+			return resp, apiErr
+		}
+		if err := sleep(ctx, retryDelay(resp, attempt)); err != nil {
+			return resp, err
+		}
+	}
+}
+
+// send はリクエストを1回送信する
+func (c *Client) send(ctx context.Context, req *request, body []byte) (*http.Response, error) {
+	u := *c.baseURL
+	u.Path += req.path
+	u.RawQuery = req.query.Encode()
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, u.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if c.userAgent != "" {
+		httpReq.Header.Set("User-Agent", c.userAgent)
+	}
+	if c.actor != "" {
+		httpReq.Header.Set(actorHeader, c.actor)
+	}
+	if c.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	httpReq.Header.Set(requestIDHeader, req.requestID)
+	if req.idempotencyKey != "" {
+		httpReq.Header.Set(idempotencyKeyHeader, req.idempotencyKey)
+	}
+	return c.httpClient.Do(httpReq)
+}
+
+// retryDelay は attempt 回目の再送までの待ち時間を返す
+// Retry-After（秒数）があればそれに従い、なければジッター付きの指数バックオフとする
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return min(time.Duration(seconds)*time.Second, maxRetryDelay)
+	}
+	delay := min(baseRetryDelay<<min(attempt, 10), maxRetryDelay)
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// sleep は d の間待つ。ctx が終了した場合はそのエラーを返す
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// totalCount は X-Total-Count ヘッダーの総件数を返す（ヘッダーがない場合は -1）
+func totalCount(resp *http.Response) (int, error) {
+	value := resp.Header.Get(totalCountHeader)
+	if value == "" {
+		return -1, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.New("invalid " + totalCountHeader + " header: " + value)
+	}
+	return n, nil
+}
@@ -0,0 +1,340 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/client"
+	"github.com/t-okuji/go-openapi-todo-demo/database"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/events"
+	"github.com/t-okuji/go-openapi-todo-demo/handlers"
+	"github.com/t-okuji/go-openapi-todo-demo/hooks"
+	"github.com/t-okuji/go-openapi-todo-demo/openapi"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+// testServer は SQLite のインメモリデータベースで実際のルーティング・検証・ハンドラーを動かすテスト用サーバー
+type testServer struct {
+	*httptest.Server
+	db *ent.Client
+
+	mu sync.Mutex
+	// requests は受け付けたリクエスト（「メソッド パス?クエリ」）
+	requests []string
+	// intercept が true を返したリクエストはハンドラーの処理後にレスポンスを 503 に差し替える
+	intercept func(r *http.Request) bool
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	conn, err := database.Open(context.Background(), database.DriverSQLite, fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	db := conn.Client
+	hooks.RegisterAudit(db)
+	hooks.RegisterVersioning(db)
+
+	spec, err := openapi.Spec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	validator, err := handlers.ValidateRequests(spec, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	r.Use(handlers.Actor)
+	r.Use(validator)
+	api.HandlerWithOptions(handlers.NewServer(db, events.NewBroker(16), time.Hour, conn.Features), api.ChiServerOptions{
+		BaseRouter:       r,
+		ErrorHandlerFunc: handlers.ParamErrorHandler,
+	})
+
+	ts := &testServer{db: db}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ts.mu.Lock()
+		ts.requests = append(ts.requests, req.Method+" "+req.URL.RequestURI())
+		intercept := ts.intercept != nil && ts.intercept(req)
+		ts.mu.Unlock()
+
+		if !intercept {
+			r.ServeHTTP(w, req)
+			return
+		}
+		// 処理は完了したがレスポンスが失われた場合（ゲートウェイのタイムアウトなど）を再現する
+		r.ServeHTTP(httptest.NewRecorder(), req)
+		w.Header().Set("Retry-After", "0")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error":{"code":"SERVICE_UNAVAILABLE","message":"upstream timed out"}}`))
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// requestsMatching は prefix で始まるリクエストを返す
+func (ts *testServer) requestsMatching(prefix string) []string {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	var matched []string
+	for _, req := range ts.requests {
+		if strings.HasPrefix(req, prefix) {
+			matched = append(matched, req)
+		}
+	}
+	return matched
+}
+
+func newClient(t *testing.T, ts *testServer, opts ...client.Option) *client.Client {
+	t.Helper()
+	c, err := client.New(ts.URL, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestCategoryCRUD(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	c := newClient(t, ts)
+
+	created, err := c.CreateCategory(ctx, types.CategoryInput{Name: "仕事", Color: ptr("#ff0000")})
+	if err != nil {
+		t.Fatalf("CreateCategory: %v", err)
+	}
+	if created.Name != "仕事" || created.Color != "#ff0000" {
+		t.Fatalf("CreateCategory = %+v", created)
+	}
+
+	updated, err := c.UpdateCategory(ctx, created.ID, types.CategoryInput{Name: "業務"})
+	if err != nil {
+		t.Fatalf("UpdateCategory: %v", err)
+	}
+	if updated.Name != "業務" || updated.Color != "#ff0000" {
+		t.Fatalf("UpdateCategory = %+v, want name 業務 with the color kept", updated)
+	}
+
+	categories, err := c.ListCategories(ctx)
+	if err != nil {
+		t.Fatalf("ListCategories: %v", err)
+	}
+	if len(categories) != 1 || categories[0].ID != created.ID {
+		t.Fatalf("ListCategories = %+v", categories)
+	}
+
+	if err := c.DeleteCategory(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteCategory: %v", err)
+	}
+	_, err = c.GetCategory(ctx, created.ID)
+	if !client.IsNotFound(err) {
+		t.Fatalf("GetCategory after delete: err = %v, want not found", err)
+	}
+}
+
+func TestTypedErrors(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	c := newClient(t, ts)
+
+	_, err := c.CreateCategory(ctx, types.CategoryInput{Name: "仕事", Color: ptr("red")})
+	if !errors.Is(err, client.ErrValidation) {
+		t.Fatalf("err = %v, want ErrValidation", err)
+	}
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %T, want *client.Error", err)
+	}
+	if len(apiErr.Details) != 1 || apiErr.Details[0].Field != "body.color" {
+		t.Errorf("Details = %+v, want a body.color error", apiErr.Details)
+	}
+	if apiErr.RequestID == "" {
+		t.Error("RequestID is empty")
+	}
+
+	_, err = c.CreateTodo(ctx, types.TodoInput{Title: "a", CategoryID: ptr("00000000-0000-0000-0000-000000000000")})
+	if !errors.As(err, &apiErr) || apiErr.Code != "CATEGORY_NOT_FOUND" || !errors.Is(err, client.ErrBadRequest) {
+		t.Fatalf("err = %v, want 400 CATEGORY_NOT_FOUND", err)
+	}
+	if errors.Is(err, client.ErrValidation) {
+		t.Error("CATEGORY_NOT_FOUND matched ErrValidation")
+	}
+}
+
+func TestTodosIterator(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	c := newClient(t, ts)
+
+	for i := range 7 {
+		if _, err := c.CreateTodo(ctx, types.TodoInput{Title: fmt.Sprintf("todo %d", i)}); err != nil {
+			t.Fatalf("CreateTodo: %v", err)
+		}
+	}
+
+	var titles []string
+	for todo, err := range c.Todos(ctx, &client.ListTodosOptions{Sort: "title", Order: "asc", Limit: 3}) {
+		if err != nil {
+			t.Fatalf("Todos: %v", err)
+		}
+		titles = append(titles, todo.Title)
+	}
+	if len(titles) != 7 || titles[0] != "todo 0" || titles[6] != "todo 6" {
+		t.Fatalf("titles = %v", titles)
+	}
+	if got := len(ts.requestsMatching("GET /todos?")); got != 3 {
+		t.Errorf("GET /todos requests = %d, want 3 pages", got)
+	}
+
+	// 中断した場合は次のページを取得しない
+	n := 0
+	for range c.Todos(ctx, &client.ListTodosOptions{Limit: 3}) {
+		n++
+		if n == 2 {
+			break
+		}
+	}
+	if got := len(ts.requestsMatching("GET /todos?")); got != 4 {
+		t.Errorf("GET /todos requests after break = %d, want 4", got)
+	}
+
+	page, err := c.ListTodos(ctx, &client.ListTodosOptions{Search: "todo 1", Limit: 1})
+	if err != nil {
+		t.Fatalf("ListTodos: %v", err)
+	}
+	if page.Total != 1 || len(page.Todos) != 1 {
+		t.Errorf("ListTodos = %+v, want 1 todo", page)
+	}
+}
+
+func TestIteratorError(t *testing.T) {
+	ts := newTestServer(t)
+	c := newClient(t, ts)
+
+	var errs []error
+	for _, err := range c.Todos(context.Background(), &client.ListTodosOptions{Sort: "priority"}) {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], client.ErrValidation) {
+		t.Fatalf("errs = %v, want a single validation error", errs)
+	}
+}
+
+func TestRetryWithIdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	c := newClient(t, ts)
+
+	// 1回目は作成されたがレスポンスが 503 に差し替えられる
+	attempts := 0
+	var keys []string
+	ts.intercept = func(r *http.Request) bool {
+		if r.Method != http.MethodPost || r.URL.Path != "/todos" {
+			return false
+		}
+		attempts++
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		return attempts == 1
+	}
+
+	todo, err := c.CreateTodo(ctx, types.TodoInput{Title: "once"})
+	if err != nil {
+		t.Fatalf("CreateTodo: %v", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("attempts = %d, want 2", attempts)
+	}
+	if keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("Idempotency-Key = %q, want the same key on retry", keys)
+	}
+
+	count, err := ts.db.Todo.Query().Count(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("todos = %d, want 1", count)
+	}
+	if got, err := c.GetTodo(ctx, todo.ID); err != nil || got.Title != "once" {
+		t.Errorf("GetTodo = %+v, %v", got, err)
+	}
+}
+
+func TestRetryLimit(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	ts.intercept = func(r *http.Request) bool { return r.Method == http.MethodGet }
+
+	c := newClient(t, ts, client.WithMaxRetries(2))
+	_, err := c.ListCategories(ctx)
+	if !errors.Is(err, client.ErrServiceUnavailable) {
+		t.Fatalf("err = %v, want ErrServiceUnavailable", err)
+	}
+	if got := len(ts.requestsMatching("GET /categories")); got != 3 {
+		t.Errorf("requests = %d, want 3", got)
+	}
+
+	c = newClient(t, ts, client.WithMaxRetries(0))
+	if _, err := c.ListCategories(ctx); !errors.Is(err, client.ErrServiceUnavailable) {
+		t.Fatalf("err = %v, want ErrServiceUnavailable", err)
+	}
+	if got := len(ts.requestsMatching("GET /categories")); got != 4 {
+		t.Errorf("requests = %d, want 4", got)
+	}
+}
+
+func TestAuditEventsIterator(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t)
+	alice := newClient(t, ts, client.WithActor("alice"))
+	bob := newClient(t, ts, client.WithActor("bob"))
+
+	for i := range 3 {
+		if _, err := alice.CreateCategory(ctx, types.CategoryInput{Name: fmt.Sprintf("alice %d", i)}); err != nil {
+			t.Fatalf("CreateCategory: %v", err)
+		}
+	}
+	if _, err := bob.CreateCategory(ctx, types.CategoryInput{Name: "bob"}); err != nil {
+		t.Fatalf("CreateCategory: %v", err)
+	}
+
+	n := 0
+	for event, err := range bob.AuditEvents(ctx, &client.ListAuditEventsOptions{Actor: "alice", Limit: 2}) {
+		if err != nil {
+			t.Fatalf("AuditEvents: %v", err)
+		}
+		if event.Actor != "alice" || event.EntityType != "category" {
+			t.Errorf("event = %+v", event)
+		}
+		n++
+	}
+	if n != 3 {
+		t.Errorf("events = %d, want 3", n)
+	}
+}
+
+func TestNewInvalidBaseURL(t *testing.T) {
+	for _, baseURL := range []string{"", "localhost:8080", "ftp://example.com"} {
+		if _, err := client.New(baseURL); err == nil {
+			t.Errorf("New(%q) succeeded", baseURL)
+		}
+	}
+}
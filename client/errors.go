@@ -0,0 +1,99 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+// maxErrorBodySize はエラーレスポンスとして読み込むボディの上限
+const maxErrorBodySize = 64 << 10
+
+// Error は API のエラーレスポンス（types.ErrorResponse）を表す
+//
+// errors.Is で ErrNotFound などのステータスコードごとのエラーと比較できる。
+// エラーコード（TODO_NOT_FOUND など）で判定する場合は errors.As で取り出して Code を参照する。
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+	// Details は項目ごとの検証エラー（VALIDATION_ERROR の場合のみ）
+	Details []types.FieldError
+	// RequestID はクライアントが X-Request-Id で送信したリクエスト ID（監査ログ・サーバーのログと照合できる）
+	RequestID string
+}
+
+// Error は error インターフェースを実装する
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// Is は target が同じステータスコードの Error（ErrNotFound など）の場合に true を返す
+// target に Code が設定されている場合はエラーコードも比較する
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return t.StatusCode == e.StatusCode && (t.Code == "" || t.Code == e.Code)
+}
+
+// Temporary は再送すれば成功する可能性があるエラー（429・503・処理中の冪等キー）の場合に true を返す
+func (e *Error) Temporary() bool {
+	switch e.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusConflict:
+		return e.Code == "IDEMPOTENCY_KEY_IN_USE"
+	}
+	return false
+}
+
+// ステータスコードごとのエラー（errors.Is で比較する）
+var (
+	ErrBadRequest          = &Error{StatusCode: http.StatusBadRequest}
+	ErrValidation          = &Error{StatusCode: http.StatusBadRequest, Code: "VALIDATION_ERROR"}
+	ErrUnauthorized        = &Error{StatusCode: http.StatusUnauthorized}
+	ErrNotFound            = &Error{StatusCode: http.StatusNotFound}
+	ErrConflict            = &Error{StatusCode: http.StatusConflict}
+	ErrPreconditionFailed  = &Error{StatusCode: http.StatusPreconditionFailed}
+	ErrUnprocessableEntity = &Error{StatusCode: http.StatusUnprocessableEntity}
+	ErrTooManyRequests     = &Error{StatusCode: http.StatusTooManyRequests}
+	ErrServiceUnavailable  = &Error{StatusCode: http.StatusServiceUnavailable}
+)
+
+// IsNotFound は err が 404 のエラーの場合に true を返す
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// decodeError は requestID のリクエストのエラーレスポンスを読み込んで *Error に変換し、ボディを閉じる
+// ボディが types.ErrorResponse でない場合（プロキシのエラーページなど）は Code を HTTP_<ステータスコード> とする
+func decodeError(resp *http.Response, requestID string) *Error {
+	defer resp.Body.Close()
+	apiErr := &Error{
+		StatusCode: resp.StatusCode,
+		Code:       fmt.Sprintf("HTTP_%d", resp.StatusCode),
+		Message:    http.StatusText(resp.StatusCode),
+		RequestID:  requestID,
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		return apiErr
+	}
+	var errResp types.ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Error.Code == "" {
+		return apiErr
+	}
+	apiErr.Code = errResp.Error.Code
+	apiErr.Message = errResp.Error.Message
+	if errResp.Error.Details != nil {
+		apiErr.Details = *errResp.Error.Details
+	}
+	return apiErr
+}
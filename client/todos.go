@@ -0,0 +1,190 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+// defaultPageSize は Todos・AuditEvents で1回のリクエストで取得する件数
+const defaultPageSize = 100
+
+// ListTodosOptions は GET /todos の絞り込み・並び替え・ページングの条件を表す
+// ゼロ値の項目は指定しない
+type ListTodosOptions struct {
+	Completed *bool
+	// CategoryID はカテゴリ ID（none はカテゴリ未設定の Todo）
+	CategoryID    string
+	Search        string
+	CreatedBefore time.Time
+	CreatedAfter  time.Time
+	UpdatedBefore time.Time
+	UpdatedAfter  time.Time
+	// Sort は並び替えの項目（createdAt・updatedAt・title）
+	Sort string
+	// Order は並び順（asc・desc）
+	Order string
+	// Limit は取得件数（0 の場合は全件）
+	Limit  int
+	Offset int
+}
+
+// values は opts をクエリパラメーターに変換する
+func (opts *ListTodosOptions) values() url.Values {
+	query := url.Values{}
+	if opts == nil {
+		return query
+	}
+	if opts.Completed != nil {
+		query.Set("completed", strconv.FormatBool(*opts.Completed))
+	}
+	setString(query, "categoryId", opts.CategoryID)
+	setString(query, "search", opts.Search)
+	setTime(query, "createdBefore", opts.CreatedBefore)
+	setTime(query, "createdAfter", opts.CreatedAfter)
+	setTime(query, "updatedBefore", opts.UpdatedBefore)
+	setTime(query, "updatedAfter", opts.UpdatedAfter)
+	setString(query, "sort", opts.Sort)
+	setString(query, "order", opts.Order)
+	setInt(query, "limit", opts.Limit)
+	setInt(query, "offset", opts.Offset)
+	return query
+}
+
+// TodoPage は GET /todos の1ページ分の結果を表す
+type TodoPage struct {
+	Todos []types.TodoResponse
+	// Total はページングを除いて条件に一致する総件数（X-Total-Count）
+	Total int
+}
+
+// ListTodos は条件に一致する Todo を取得する
+func (c *Client) ListTodos(ctx context.Context, opts *ListTodosOptions) (*TodoPage, error) {
+	var todos []types.TodoResponse
+	resp, err := c.do(ctx, &request{method: http.MethodGet, path: "/todos", query: opts.values()}, &todos)
+	if err != nil {
+		return nil, err
+	}
+	total, err := totalCount(resp)
+	if err != nil {
+		return nil, err
+	}
+	if total < 0 {
+		total = len(todos)
+	}
+	return &TodoPage{Todos: todos, Total: total}, nil
+}
+
+// Todos は条件に一致する Todo を opts.Offset から順に返すイテレーター
+//
+// opts.Limit 件（0 の場合は 100 件）ずつページを取得し、全件を返すか呼び出し元が中断するまで続ける。
+// エラーが発生した場合はそのエラーを返して終了する。
+func (c *Client) Todos(ctx context.Context, opts *ListTodosOptions) iter.Seq2[types.TodoResponse, error] {
+	page := ListTodosOptions{}
+	if opts != nil {
+		page = *opts
+	}
+	if page.Limit <= 0 {
+		page.Limit = defaultPageSize
+	}
+
+	return func(yield func(types.TodoResponse, error) bool) {
+		for {
+			result, err := c.ListTodos(ctx, &page)
+			if err != nil {
+				yield(types.TodoResponse{}, err)
+				return
+			}
+			for _, todo := range result.Todos {
+				if !yield(todo, nil) {
+					return
+				}
+			}
+			page.Offset += len(result.Todos)
+			if len(result.Todos) < page.Limit || page.Offset >= result.Total {
+				return
+			}
+		}
+	}
+}
+
+// GetTodo は指定した ID の Todo を取得する
+func (c *Client) GetTodo(ctx context.Context, id uuid.UUID) (*types.TodoResponse, error) {
+	var todo types.TodoResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/todos/" + id.String()}, &todo); err != nil {
+		return nil, err
+	}
+	return &todo, nil
+}
+
+// CreateTodo は Todo を作成する
+// Idempotency-Key を自動的に付けて送信するため、一時的なエラーで再送しても重複して作成しない
+func (c *Client) CreateTodo(ctx context.Context, input types.TodoInput) (*types.TodoResponse, error) {
+	var todo types.TodoResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/todos", body: input, idempotent: true}, &todo); err != nil {
+		return nil, err
+	}
+	return &todo, nil
+}
+
+// UpdateTodo は指定した ID の Todo を更新する
+// input で省略した項目は変更しない（title は必須）
+func (c *Client) UpdateTodo(ctx context.Context, id uuid.UUID, input types.TodoInput) (*types.TodoResponse, error) {
+	var todo types.TodoResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPut, path: "/todos/" + id.String(), body: input}, &todo); err != nil {
+		return nil, err
+	}
+	return &todo, nil
+}
+
+// DeleteTodo は指定した ID の Todo を削除する
+func (c *Client) DeleteTodo(ctx context.Context, id uuid.UUID) error {
+	_, err := c.do(ctx, &request{method: http.MethodDelete, path: "/todos/" + id.String()}, nil)
+	return err
+}
+
+// BatchTodos は複数の Todo の作成・更新・削除を一括で実行する
+// Idempotency-Key を自動的に付けて送信するため、一時的なエラーで再送しても重複して実行しない
+func (c *Client) BatchTodos(ctx context.Context, batch types.BatchRequest) (*types.BatchResponse, error) {
+	var result types.BatchResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/todos:batch", body: batch, idempotent: true}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetTodoHistory は指定した ID の Todo のリビジョン一覧を取得する
+func (c *Client) GetTodoHistory(ctx context.Context, id uuid.UUID) ([]types.TodoRevisionResponse, error) {
+	var revisions []types.TodoRevisionResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/todos/" + id.String() + "/history"}, &revisions); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// setString は value が空でない場合にクエリパラメーターを設定する
+func setString(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+// setInt は value が 0 でない場合にクエリパラメーターを設定する
+func setInt(query url.Values, key string, value int) {
+	if value != 0 {
+		query.Set(key, strconv.Itoa(value))
+	}
+}
+
+// setTime は value がゼロ値でない場合に RFC 3339 形式のクエリパラメーターを設定する
+func setTime(query url.Values, key string, value time.Time) {
+	if !value.IsZero() {
+		query.Set(key, value.Format(time.RFC3339Nano))
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// newCategoriesCommand はカテゴリの一覧を表示する categories コマンドを作成する
+func newCategoriesCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:     "categories",
+		Aliases: []string{"cats"},
+		Short:   "カテゴリの一覧を表示する",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			categories, err := c.ListCategories(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return a.print(categories, func(w *tabwriter.Writer) {
+				fmt.Fprintln(w, "ID\tNAME\tCOLOR\tDESCRIPTION")
+				for _, category := range categories {
+					description := ""
+					if category.Description != nil {
+						description = *category.Description
+					}
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", shortID(category.ID), category.Name, category.Color, description)
+				}
+			})
+		},
+	}
+}
+
+// completeCategories はカテゴリ名を補完候補として返す
+func (a *app) completeCategories(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	c, err := a.client()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	categories, err := c.ListCategories(cmd.Context())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var names []string
+	for _, category := range categories {
+		if strings.HasPrefix(strings.ToLower(category.Name), strings.ToLower(toComplete)) {
+			names = append(names, category.Name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultProfile = "default"
+	defaultServer  = "http://localhost:8080"
+)
+
+// Profile は接続先のサーバーと認証情報を表す
+type Profile struct {
+	Server string `yaml:"server" json:"server"`
+	APIKey string `yaml:"apiKey,omitempty" json:"apiKey,omitempty"`
+	Actor  string `yaml:"actor,omitempty" json:"actor,omitempty"`
+}
+
+// Config は todoctl の設定ファイルを表す
+type Config struct {
+	// Current は --profile を省略した場合に使用するプロファイル
+	Current  string             `yaml:"current,omitempty"`
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+// path は設定ファイルのパスを返す
+func (a *app) path() (string, error) {
+	if a.configPath != "" {
+		return a.configPath, nil
+	}
+	if path := os.Getenv("TODOCTL_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "todoctl", "config.yaml"), nil
+}
+
+// loadConfig は設定ファイルを読み込む。ファイルがない場合は空の設定を返す
+func (a *app) loadConfig() (*Config, error) {
+	path, err := a.path()
+	if err != nil {
+		return nil, err
+	}
+	config := &Config{Profiles: map[string]Profile{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if config.Profiles == nil {
+		config.Profiles = map[string]Profile{}
+	}
+	return config, nil
+}
+
+// saveConfig は設定ファイルを保存する。API キーを含むため本人のみ読み書きできる権限で作成する
+func (a *app) saveConfig(config *Config) error {
+	path, err := a.path()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// profileName は使用するプロファイル名を返す（--profile・TODOCTL_PROFILE・設定ファイルの current・default の順）
+func (a *app) profileName(config *Config) string {
+	for _, name := range []string{a.profile, os.Getenv("TODOCTL_PROFILE"), config.Current} {
+		if name != "" {
+			return name
+		}
+	}
+	return defaultProfile
+}
+
+// currentProfile は使用するプロファイルにフラグ・環境変数の指定を反映して返す
+// 優先順位はフラグ・環境変数（TODOCTL_SERVER・TODOCTL_API_KEY・TODOCTL_ACTOR）・プロファイルの順
+func (a *app) currentProfile() (Profile, error) {
+	config, err := a.loadConfig()
+	if err != nil {
+		return Profile{}, err
+	}
+	name := a.profileName(config)
+	profile, ok := config.Profiles[name]
+	if !ok && (a.profile != "" || os.Getenv("TODOCTL_PROFILE") != "") {
+		return Profile{}, fmt.Errorf("profile %q is not configured (run: todoctl config set %s --server URL)", name, name)
+	}
+
+	override := func(value *string, flag, env string) {
+		if flag != "" {
+			*value = flag
+		} else if v := os.Getenv(env); v != "" {
+			*value = v
+		}
+	}
+	override(&profile.Server, a.server, "TODOCTL_SERVER")
+	override(&profile.APIKey, a.apiKey, "TODOCTL_API_KEY")
+	override(&profile.Actor, a.actor, "TODOCTL_ACTOR")
+	if profile.Server == "" {
+		profile.Server = defaultServer
+	}
+	return profile, nil
+}
+
+// completeProfiles は設定済みのプロファイル名を補完候補として返す
+func (a *app) completeProfiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	config, err := a.loadConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var names []string
+	for name, profile := range config.Profiles {
+		names = append(names, name+"\t"+profile.Server)
+	}
+	sort.Strings(names)
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// newConfigCommand は設定ファイルのプロファイルを管理する config コマンドを作成する
+func newConfigCommand(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "接続先のプロファイルを管理する",
+	}
+
+	var profile Profile
+	set := &cobra.Command{
+		Use:   "set <profile>",
+		Short: "プロファイルを作成・更新する（指定した項目のみ変更）",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := a.loadConfig()
+			if err != nil {
+				return err
+			}
+			existing := config.Profiles[args[0]]
+			if cmd.Flags().Changed("server") {
+				existing.Server = profile.Server
+			}
+			if cmd.Flags().Changed("api-key") {
+				existing.APIKey = profile.APIKey
+			}
+			if cmd.Flags().Changed("actor") {
+				existing.Actor = profile.Actor
+			}
+			config.Profiles[args[0]] = existing
+			if config.Current == "" {
+				config.Current = args[0]
+			}
+			return a.saveConfig(config)
+		},
+	}
+	// ルートの --server・--api-key・--actor（実行時の上書き）と区別するため、ローカルフラグとして定義する
+	set.Flags().StringVar(&profile.Server, "server", "", "API サーバーの URL")
+	set.Flags().StringVar(&profile.APIKey, "api-key", "", "API キー（認証を行うリバースプロキシに Authorization: Bearer で送信する）")
+	set.Flags().StringVar(&profile.Actor, "actor", "", "操作者（X-Actor）")
+
+	use := &cobra.Command{
+		Use:               "use <profile>",
+		Short:             "--profile を省略した場合に使用するプロファイルを設定する",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: a.completeProfiles,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := a.loadConfig()
+			if err != nil {
+				return err
+			}
+			if _, ok := config.Profiles[args[0]]; !ok {
+				return fmt.Errorf("profile %q is not configured", args[0])
+			}
+			config.Current = args[0]
+			return a.saveConfig(config)
+		},
+	}
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "プロファイルの一覧を表示する（API キーは伏せて表示）",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := a.loadConfig()
+			if err != nil {
+				return err
+			}
+			current := a.profileName(config)
+			names := make([]string, 0, len(config.Profiles))
+			masked := make(map[string]Profile, len(config.Profiles))
+			for name, profile := range config.Profiles {
+				names = append(names, name)
+				profile.APIKey = maskSecret(profile.APIKey)
+				masked[name] = profile
+			}
+			sort.Strings(names)
+			return a.print(masked, func(w *tabwriter.Writer) {
+				fmt.Fprintln(w, "CURRENT\tNAME\tSERVER\tACTOR\tAPI KEY")
+				for _, name := range names {
+					profile := masked[name]
+					mark := ""
+					if name == current {
+						mark = "*"
+					}
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", mark, name, profile.Server, profile.Actor, profile.APIKey)
+				}
+			})
+		},
+	}
+
+	cmd.AddCommand(set, use, list)
+	return cmd
+}
+
+// maskSecret は API キーの末尾4文字以外を伏せた文字列を返す
+func maskSecret(secret string) string {
+	if len(secret) <= 4 {
+		return strings.Repeat("*", len(secret))
+	}
+	return strings.Repeat("*", 8) + secret[len(secret)-4:]
+}
@@ -0,0 +1,96 @@
+// todoctl は Todo API のコマンドラインクライアント
+//
+// client パッケージで REST API を呼び出す。接続先・API キー・操作者はプロファイルとして設定ファイルに保存できる。
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/t-okuji/go-openapi-todo-demo/client"
+)
+
+// app はコマンド全体で共有するグローバルフラグと出力先を表す
+type app struct {
+	configPath string
+	profile    string
+	server     string
+	apiKey     string
+	actor      string
+	output     string
+	out        io.Writer
+}
+
+func main() {
+	a := &app{out: os.Stdout}
+	if err := newRootCommand(a).ExecuteContext(context.Background()); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", errorMessage(err))
+		os.Exit(1)
+	}
+}
+
+// newRootCommand は todoctl のルートコマンドを作成する
+func newRootCommand(a *app) *cobra.Command {
+	root := &cobra.Command{
+		Use:           "todoctl",
+		Short:         "Todo API のコマンドラインクライアント",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateOutput(a.output)
+		},
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&a.configPath, "config", "", "設定ファイルのパス（省略時は $TODOCTL_CONFIG または ~/.config/todoctl/config.yaml）")
+	flags.StringVarP(&a.profile, "profile", "p", "", "使用するプロファイル（省略時は $TODOCTL_PROFILE または設定ファイルの current）")
+	flags.StringVar(&a.server, "server", "", "API サーバーの URL（プロファイルの設定より優先）")
+	flags.StringVar(&a.apiKey, "api-key", "", "API キー（Authorization: Bearer で送信し、プロファイルの設定より優先。API サーバーは検証しないため認証を行うリバースプロキシ向け）")
+	flags.StringVar(&a.actor, "actor", "", "操作者（X-Actor、プロファイルの設定より優先）")
+	flags.StringVarP(&a.output, "output", "o", outputTable, "出力形式（table・json・yaml）")
+	root.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(outputFormats, cobra.ShellCompDirectiveNoFileComp))
+	root.RegisterFlagCompletionFunc("profile", a.completeProfiles)
+
+	root.AddCommand(
+		newListCommand(a),
+		newAddCommand(a),
+		newDoneCommand(a),
+		newRemoveCommand(a),
+		newCategoriesCommand(a),
+		newConfigCommand(a),
+	)
+	return root
+}
+
+// client は選択したプロファイルとフラグ・環境変数から API クライアントを作成する
+func (a *app) client() (*client.Client, error) {
+	profile, err := a.currentProfile()
+	if err != nil {
+		return nil, err
+	}
+	opts := []client.Option{client.WithUserAgent("todoctl")}
+	if profile.APIKey != "" {
+		opts = append(opts, client.WithAPIKey(profile.APIKey))
+	}
+	if profile.Actor != "" {
+		opts = append(opts, client.WithActor(profile.Actor))
+	}
+	return client.New(profile.Server, opts...)
+}
+
+// errorMessage は API エラーを「メッセージ（エラーコード）」と項目ごとのエラーの形式で返す
+func errorMessage(err error) string {
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+	message := fmt.Sprintf("%s (%s)", apiErr.Message, apiErr.Code)
+	for _, d := range apiErr.Details {
+		message += fmt.Sprintf("\n  %s: %s", d.Field, d.Message)
+	}
+	return message
+}
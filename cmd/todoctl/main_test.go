@@ -0,0 +1,304 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/client"
+	"github.com/t-okuji/go-openapi-todo-demo/database"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/events"
+	"github.com/t-okuji/go-openapi-todo-demo/handlers"
+	"github.com/t-okuji/go-openapi-todo-demo/hooks"
+	"github.com/t-okuji/go-openapi-todo-demo/openapi"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// testServer は SQLite のインメモリデータベースで実際のルーティング・検証・ハンドラーを動かすテスト用サーバー
+type testServer struct {
+	*httptest.Server
+	db *ent.Client
+	// listRequests は受け付けた GET /todos の回数
+	listRequests atomic.Int32
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	conn, err := database.Open(context.Background(), database.DriverSQLite, fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	db := conn.Client
+	hooks.RegisterAudit(db)
+	hooks.RegisterVersioning(db)
+
+	spec, err := openapi.Spec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	validator, err := handlers.ValidateRequests(spec, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	r.Use(handlers.Actor)
+	r.Use(validator)
+	api.HandlerWithOptions(handlers.NewServer(db, events.NewBroker(16), time.Hour, conn.Features), api.ChiServerOptions{
+		BaseRouter:       r,
+		ErrorHandlerFunc: handlers.ParamErrorHandler,
+	})
+
+	ts := &testServer{db: db}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodGet && req.URL.Path == "/todos" {
+			ts.listRequests.Add(1)
+		}
+		r.ServeHTTP(w, req)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// createTodo は ID と作成日時を指定して Todo を作成する
+func (ts *testServer) createTodo(t *testing.T, id string, title string, createdAt time.Time) {
+	t.Helper()
+	ctx := context.Background()
+	err := utils.WithTx(ctx, ts.db, func(tx *ent.Tx) error {
+		return tx.Todo.Create().SetID(uuid.MustParse(id)).SetTitle(title).SetCreatedAt(createdAt).Exec(ctx)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// run は todoctl を args で実行し、標準出力の内容を返す
+// 利用者の設定ファイルを読まないよう、空の設定ファイルのパスを TODOCTL_CONFIG に設定する
+func run(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	t.Setenv("TODOCTL_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+	var out bytes.Buffer
+	cmd := newRootCommand(&app{out: &out})
+	cmd.SetArgs(args)
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+func TestNormalizeIDPrefix(t *testing.T) {
+	for _, tt := range []struct {
+		prefix string
+		want   string
+		err    string
+	}{
+		{prefix: "3f2a", want: "3f2a"},
+		{prefix: " 3F2A-B ", want: "3f2a-b"},
+		{prefix: "0123456789abcdef", want: "0123456789abcdef"},
+		{prefix: "", err: "must not be empty"},
+		{prefix: "  ", err: "must not be empty"},
+		{prefix: "3f2g", err: `invalid id "3f2g"`},
+		{prefix: "仕事", err: "must be a UUID or its prefix"},
+	} {
+		got, err := normalizeIDPrefix(tt.prefix)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("normalizeIDPrefix(%q) error = %v, want %q", tt.prefix, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("normalizeIDPrefix(%q) = %q, %v, want %q", tt.prefix, got, err, tt.want)
+		}
+	}
+}
+
+func TestResolveTodo(t *testing.T) {
+	ts := newTestServer(t)
+	c, err := client.New(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 一覧の2ページ目（既定は1ページ100件）に及ぶ件数を、曖昧な ID の Todo より前に作成する
+	base := time.Now().Add(-time.Hour)
+	for i := range 150 {
+		ts.createTodo(t, fmt.Sprintf("00000000-0000-4000-8000-%012d", i), fmt.Sprintf("filler %d", i), base.Add(time.Duration(i)*time.Second))
+	}
+	ts.createTodo(t, "abc00000-0000-4000-8000-000000000001", "first", base.Add(time.Hour))
+	ts.createTodo(t, "abc00000-0000-4000-8000-000000000002", "second", base.Add(time.Hour+time.Second))
+	ts.createTodo(t, "def00000-0000-4000-8000-000000000001", "unique", base.Add(-time.Hour))
+
+	for _, tt := range []struct {
+		name  string
+		id    string
+		title string
+		err   string
+		// lists は Todo の一覧を取得する回数
+		lists int32
+	}{
+		{name: "full UUID", id: "abc00000-0000-4000-8000-000000000002", title: "second", lists: 0},
+		{name: "prefix", id: "def0", title: "unique", lists: 2},
+		{name: "upper-case prefix", id: "DEF00000-0000", title: "unique", lists: 2},
+		{name: "ambiguous", id: "abc", err: `id "abc" is ambiguous`, lists: 1},
+		{name: "missing", id: "fff", err: `no todo matches id "fff"`, lists: 2},
+		{name: "invalid", id: "xyz", err: `invalid id "xyz"`, lists: 0},
+		{name: "missing UUID", id: "fff00000-0000-4000-8000-000000000000", err: "not found", lists: 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ts.listRequests.Store(0)
+			todo, err := resolveTodo(context.Background(), c, tt.id)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("error = %v, want %q", err, tt.err)
+				}
+			} else if err != nil || todo.Title != tt.title {
+				t.Errorf("resolveTodo(%q) = %+v, %v, want %q", tt.id, todo, err, tt.title)
+			}
+			// 2件目が一致した時点で一覧の読み出しを打ち切る
+			if got := ts.listRequests.Load(); got != tt.lists {
+				t.Errorf("listed todos %d times, want %d", got, tt.lists)
+			}
+		})
+	}
+}
+
+func TestProfileSelection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	config := `current: staging
+profiles:
+  default:
+    server: http://default.example.com
+  staging:
+    server: http://staging.example.com
+    actor: alice
+  prod:
+    server: https://prod.example.com
+    apiKey: secret
+`
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name string
+		app  app
+		env  map[string]string
+		want Profile
+		err  string
+	}{
+		{name: "current", want: Profile{Server: "http://staging.example.com", Actor: "alice"}},
+		{name: "flag", app: app{profile: "prod"}, want: Profile{Server: "https://prod.example.com", APIKey: "secret"}},
+		{name: "environment", env: map[string]string{"TODOCTL_PROFILE": "default"}, want: Profile{Server: "http://default.example.com"}},
+		{name: "flag over environment", app: app{profile: "prod"}, env: map[string]string{"TODOCTL_PROFILE": "default"}, want: Profile{Server: "https://prod.example.com", APIKey: "secret"}},
+		{
+			name: "overrides",
+			app:  app{profile: "prod", actor: "bob"},
+			env:  map[string]string{"TODOCTL_SERVER": "http://localhost:9000", "TODOCTL_ACTOR": "carol"},
+			want: Profile{Server: "http://localhost:9000", APIKey: "secret", Actor: "bob"},
+		},
+		{name: "missing profile", app: app{profile: "qa"}, err: `profile "qa" is not configured`},
+		{name: "missing profile from environment", env: map[string]string{"TODOCTL_PROFILE": "qa"}, err: `profile "qa" is not configured`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"TODOCTL_PROFILE", "TODOCTL_SERVER", "TODOCTL_API_KEY", "TODOCTL_ACTOR"} {
+				t.Setenv(key, tt.env[key])
+			}
+			a := tt.app
+			a.configPath = path
+			got, err := a.currentProfile()
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("currentProfile() = %+v, %v, want %+v", got, err, tt.want)
+			}
+		})
+	}
+
+	// 設定ファイルがない場合は既定のサーバーに接続する
+	a := app{configPath: filepath.Join(t.TempDir(), "missing.yaml")}
+	if got, err := a.currentProfile(); err != nil || got != (Profile{Server: defaultServer}) {
+		t.Errorf("without a config file: %+v, %v", got, err)
+	}
+}
+
+func TestOutput(t *testing.T) {
+	ts := newTestServer(t)
+	if _, err := run(t, "--server", ts.URL, "add", "報告書を書く", "--due", "2026-11-01T09:00:00Z"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run(t, "--server", ts.URL, "add", "yes"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		output string
+		check  func(t *testing.T, out string)
+	}{
+		{output: "json", check: func(t *testing.T, out string) {
+			var todos []types.TodoResponse
+			if err := json.Unmarshal([]byte(out), &todos); err != nil || len(todos) != 2 {
+				t.Fatalf("decode %q: %v", out, err)
+			}
+			if !strings.Contains(out, `"dueAt": "2026-11-01T09:00:00Z"`) {
+				t.Errorf("output does not use the API field names:\n%s", out)
+			}
+		}},
+		{output: "yaml", check: func(t *testing.T, out string) {
+			var todos []map[string]any
+			if err := yaml.Unmarshal([]byte(out), &todos); err != nil || len(todos) != 2 {
+				t.Fatalf("decode %q: %v", out, err)
+			}
+			// ブロックスタイルで出力し、YAML 1.1 で真偽値と解釈される文字列には引用符を付ける
+			if strings.Contains(out, "{") || !strings.Contains(out, `title: "yes"`) || !strings.Contains(out, "title: 報告書を書く") {
+				t.Errorf("unexpected yaml:\n%s", out)
+			}
+		}},
+		{output: "table", check: func(t *testing.T, out string) {
+			lines := strings.Split(strings.TrimSpace(out), "\n")
+			if len(lines) != 3 || strings.Join(strings.Fields(lines[0]), " ") != "ID DONE TITLE CATEGORY DUE" {
+				t.Fatalf("unexpected table:\n%s", out)
+			}
+			// 列を揃え、ID は先頭の8文字を表示する
+			if column := strings.Index(lines[0], "TITLE"); strings.Index(lines[1], "yes") != column {
+				t.Errorf("columns are not aligned:\n%s", out)
+			}
+			if id := strings.Fields(lines[1])[0]; len(id) != shortIDLength {
+				t.Errorf("id column = %q, want %d characters", id, shortIDLength)
+			}
+		}},
+	} {
+		t.Run(tt.output, func(t *testing.T) {
+			out, err := run(t, "--server", ts.URL, "list", "-o", tt.output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, out)
+		})
+	}
+
+	if _, err := run(t, "--server", ts.URL, "list", "-o", "xml"); err == nil || !strings.Contains(err.Error(), `invalid output format "xml"`) {
+		t.Errorf("-o xml: error = %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 出力形式
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+var outputFormats = []string{outputTable, outputJSON, outputYAML}
+
+// yaml11Bools は YAML 1.1 のパーサーが真偽値と解釈する文字列
+// yaml.v3 は YAML 1.2 に従って引用符なしで出力するため、引用符を残す
+var yaml11Bools = []string{"y", "Y", "yes", "Yes", "YES", "n", "N", "no", "No", "NO", "on", "On", "ON", "off", "Off", "OFF"}
+
+// validateOutput は --output の値を検証する
+func validateOutput(format string) error {
+	for _, f := range outputFormats {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output format %q (must be table, json or yaml)", format)
+}
+
+// print は v を --output の形式で出力する
+// table の場合は table に tabwriter を渡して列を揃えて出力し、json・yaml の場合は API と同じフィールド名で v を出力する
+func (a *app) print(v any, table func(w *tabwriter.Writer)) error {
+	switch a.output {
+	case outputJSON:
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(a.out, string(data))
+		return err
+	case outputYAML:
+		data, err := toYAML(v)
+		if err != nil {
+			return err
+		}
+		_, err = a.out.Write(data)
+		return err
+	default:
+		w := tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+		table(w)
+		return w.Flush()
+	}
+}
+
+// toYAML は v を YAML に変換する
+// JSON を経由して、API と同じフィールド名・フィールドの順序で出力する
+func toYAML(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	blockStyle(&node)
+	return yaml.Marshal(&node)
+}
+
+// blockStyle は JSON から読み込んだノードのフロースタイル（{...}・[...]）をブロックスタイルに変換する
+func blockStyle(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode {
+		node.Style = 0
+	}
+	if node.Kind == yaml.ScalarNode && node.Style == yaml.DoubleQuotedStyle && node.Tag == "!!str" && !slices.Contains(yaml11Bools, node.Value) {
+		// 必要な場合だけ引用符を付ける（"true"・"123" などは yaml.v3 が自動的に引用符を付ける）
+		node.Style = 0
+	}
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/client"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+// shortIDLength は一覧に表示する ID の長さ（ID の先頭の一部を指定して操作できる）
+const shortIDLength = 8
+
+// shortID は id の先頭 shortIDLength 文字を返す
+func shortID(id uuid.UUID) string {
+	return id.String()[:shortIDLength]
+}
+
+// normalizeIDPrefix は ID の先頭の一部を小文字にして検証する
+func normalizeIDPrefix(prefix string) (string, error) {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	if prefix == "" {
+		return "", fmt.Errorf("id must not be empty")
+	}
+	for _, r := range prefix {
+		if !strings.ContainsRune("0123456789abcdef-", r) {
+			return "", fmt.Errorf("invalid id %q: must be a UUID or its prefix", prefix)
+		}
+	}
+	return prefix, nil
+}
+
+// resolveTodo は ID またはその先頭の一部に一致する Todo を返す
+// 完全な UUID の場合はそのまま取得し、先頭の一部の場合は Todo を順に読み出して一致するものを探す
+// 2件目が一致した時点で読み出しを打ち切り、曖昧な ID としてエラーを返す
+func resolveTodo(ctx context.Context, c *client.Client, prefix string) (*types.TodoResponse, error) {
+	if id, err := uuid.Parse(prefix); err == nil {
+		return c.GetTodo(ctx, id)
+	}
+	prefix, err := normalizeIDPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []types.TodoResponse
+	for todo, err := range c.Todos(ctx, nil) {
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(todo.ID.String(), prefix) {
+			continue
+		}
+		matches = append(matches, todo)
+		if len(matches) == 2 {
+			break
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no todo matches id %q", prefix)
+	case 1:
+		return &matches[0], nil
+	}
+	candidates := make([]string, len(matches))
+	for i, todo := range matches {
+		candidates[i] = fmt.Sprintf("  %s  %s", todo.ID, todo.Title)
+	}
+	return nil, fmt.Errorf("id %q is ambiguous, matches at least:\n%s", prefix, strings.Join(candidates, "\n"))
+}
+
+// resolveCategory は名前（大文字小文字を区別しない）または ID の先頭の一部に一致するカテゴリを返す
+// 名前の一致を優先し、複数一致する場合はエラーとする
+func resolveCategory(ctx context.Context, c *client.Client, nameOrID string) (*types.CategoryResponse, error) {
+	categories, err := c.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []types.CategoryResponse
+	for _, category := range categories {
+		if strings.EqualFold(category.Name, nameOrID) {
+			matches = append(matches, category)
+		}
+	}
+	if len(matches) == 0 {
+		if prefix, err := normalizeIDPrefix(nameOrID); err == nil {
+			for _, category := range categories {
+				if strings.HasPrefix(category.ID.String(), prefix) {
+					matches = append(matches, category)
+				}
+			}
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no category matches %q (see: todoctl categories)", nameOrID)
+	case 1:
+		return &matches[0], nil
+	}
+	candidates := make([]string, len(matches))
+	for i, category := range matches {
+		candidates[i] = fmt.Sprintf("  %s  %s", category.ID, category.Name)
+	}
+	return nil, fmt.Errorf("category %q is ambiguous, matches:\n%s", nameOrID, strings.Join(candidates, "\n"))
+}
+
+// categoryNames はカテゴリ ID から名前への対応を返す
+func categoryNames(ctx context.Context, c *client.Client) (map[uuid.UUID]string, error) {
+	categories, err := c.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uuid.UUID]string, len(categories))
+	for _, category := range categories {
+		names[category.ID] = category.Name
+	}
+	return names, nil
+}
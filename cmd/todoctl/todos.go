@@ -0,0 +1,273 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/t-okuji/go-openapi-todo-demo/client"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+// categoryNone は list --category でカテゴリ未設定の Todo を指定する値（API の categoryId=none）
+const categoryNone = "none"
+
+// newListCommand は Todo の一覧を表示する list コマンドを作成する
+func newListCommand(a *app) *cobra.Command {
+	var (
+		category string
+		open     bool
+		done     bool
+		opts     client.ListTodosOptions
+		limit    int
+	)
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Todo の一覧を表示する",
+		Example: "  todoctl list --category 仕事 --open\n  todoctl list -s 買い物 -o json",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			names, err := categoryNames(ctx, c)
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case open && done:
+				return fmt.Errorf("--open and --done cannot be used together")
+			case open:
+				opts.Completed = ptr(false)
+			case done:
+				opts.Completed = ptr(true)
+			}
+			if category == categoryNone {
+				opts.CategoryID = categoryNone
+			} else if category != "" {
+				resolved, err := resolveCategory(ctx, c, category)
+				if err != nil {
+					return err
+				}
+				opts.CategoryID = resolved.ID.String()
+			}
+			if limit > 0 {
+				opts.Limit = min(limit, 1000)
+			}
+
+			todos := []types.TodoResponse{}
+			for todo, err := range c.Todos(ctx, &opts) {
+				if err != nil {
+					return err
+				}
+				todos = append(todos, todo)
+				if limit > 0 && len(todos) >= limit {
+					break
+				}
+			}
+			return a.print(todos, todoTable(todos, names))
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&category, "category", "c", "", "カテゴリ名または ID（none はカテゴリ未設定）")
+	flags.BoolVar(&open, "open", false, "未完了の Todo のみ表示する")
+	flags.BoolVar(&done, "done", false, "完了した Todo のみ表示する")
+	flags.StringVarP(&opts.Search, "search", "s", "", "タイトル・説明のキーワード")
+	flags.StringVar(&opts.Sort, "sort", "", "並び替えの項目（createdAt・updatedAt・title）")
+	flags.StringVar(&opts.Order, "order", "", "並び順（asc・desc）")
+	flags.IntVarP(&limit, "limit", "n", 0, "表示する件数（0 の場合は全件）")
+	cmd.RegisterFlagCompletionFunc("category", a.completeCategories)
+	cmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions([]string{"createdAt", "updatedAt", "title"}, cobra.ShellCompDirectiveNoFileComp))
+	cmd.RegisterFlagCompletionFunc("order", cobra.FixedCompletions([]string{"asc", "desc"}, cobra.ShellCompDirectiveNoFileComp))
+	return cmd
+}
+
+// newAddCommand は Todo を作成する add コマンドを作成する
+func newAddCommand(a *app) *cobra.Command {
+	var (
+		category    string
+		description string
+		due         string
+	)
+	cmd := &cobra.Command{
+		Use:     "add <title>",
+		Short:   "Todo を作成する",
+		Example: "  todoctl add \"牛乳を買う\" -c 買い物 --due 2026-11-01",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+
+			input := types.TodoInput{Title: args[0]}
+			names := map[uuid.UUID]string{}
+			if category != "" {
+				resolved, err := resolveCategory(ctx, c, category)
+				if err != nil {
+					return err
+				}
+				input.CategoryID = ptr(resolved.ID.String())
+				names[resolved.ID] = resolved.Name
+			}
+			if description != "" {
+				input.Description = &description
+			}
+			if due != "" {
+				dueAt, err := parseDue(due)
+				if err != nil {
+					return err
+				}
+				input.DueAt = &dueAt
+			}
+
+			todo, err := c.CreateTodo(ctx, input)
+			if err != nil {
+				return err
+			}
+			return a.print(todo, todoTable([]types.TodoResponse{*todo}, names))
+		},
+	}
+
+	cmd.Flags().StringVarP(&category, "category", "c", "", "カテゴリ名または ID")
+	cmd.Flags().StringVarP(&description, "description", "d", "", "Todo の詳細説明")
+	cmd.Flags().StringVar(&due, "due", "", "期限（RFC 3339、または現地時刻の 2006-01-02・2006-01-02 15:04）")
+	cmd.RegisterFlagCompletionFunc("category", a.completeCategories)
+	return cmd
+}
+
+// newDoneCommand は Todo を完了にする done コマンドを作成する
+func newDoneCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:               "done <id-prefix>...",
+		Short:             "Todo を完了にする（ID は先頭の一部でもよい）",
+		Example:           "  todoctl done 3f2a",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: a.completeTodos(ptr(false)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			names, err := categoryNames(ctx, c)
+			if err != nil {
+				return err
+			}
+
+			var updated []types.TodoResponse
+			for _, prefix := range args {
+				todo, err := resolveTodo(ctx, c, prefix)
+				if err != nil {
+					return err
+				}
+				// PUT は省略した項目を変更しないため、必須のタイトルと完了状態だけを送る
+				todo, err = c.UpdateTodo(ctx, todo.ID, types.TodoInput{Title: todo.Title, Completed: ptr(true)})
+				if err != nil {
+					return err
+				}
+				updated = append(updated, *todo)
+			}
+			return a.print(updated, todoTable(updated, names))
+		},
+	}
+}
+
+// newRemoveCommand は Todo を削除する rm コマンドを作成する
+func newRemoveCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:               "rm <id-prefix>...",
+		Aliases:           []string{"delete"},
+		Short:             "Todo を削除する（ID は先頭の一部でもよい）",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: a.completeTodos(nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			c, err := a.client()
+			if err != nil {
+				return err
+			}
+			for _, prefix := range args {
+				todo, err := resolveTodo(ctx, c, prefix)
+				if err != nil {
+					return err
+				}
+				if err := c.DeleteTodo(ctx, todo.ID); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "Deleted %s %s\n", shortID(todo.ID), todo.Title)
+			}
+			return nil
+		},
+	}
+}
+
+// todoTable は Todo の一覧を表形式で出力する関数を返す
+func todoTable(todos []types.TodoResponse, categoryNames map[uuid.UUID]string) func(w *tabwriter.Writer) {
+	return func(w *tabwriter.Writer) {
+		fmt.Fprintln(w, "ID\tDONE\tTITLE\tCATEGORY\tDUE")
+		for _, todo := range todos {
+			done := ""
+			if todo.Completed {
+				done = "x"
+			}
+			category := ""
+			if todo.CategoryID != nil {
+				category = categoryNames[*todo.CategoryID]
+				if category == "" {
+					category = shortID(*todo.CategoryID)
+				}
+			}
+			due := ""
+			if todo.DueAt != nil {
+				due = todo.DueAt.Local().Format("2006-01-02 15:04")
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", shortID(todo.ID), done, todo.Title, category, due)
+		}
+	}
+}
+
+// parseDue は --due の値を RFC 3339 形式に変換する
+// 日付・日時のみの場合は現地時刻として扱う
+func parseDue(value string) (string, error) {
+	if _, err := time.Parse(time.RFC3339, value); err == nil {
+		return value, nil
+	}
+	for _, layout := range []string{"2006-01-02", "2006-01-02 15:04", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t.Format(time.RFC3339), nil
+		}
+	}
+	return "", fmt.Errorf("invalid --due %q: use RFC 3339, 2006-01-02 or 2006-01-02 15:04", value)
+}
+
+// completeTodos は completed（nil の場合は全て）の Todo の短い ID を、タイトルを説明として補完候補にする関数を返す
+func (a *app) completeTodos(completed *bool) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		c, err := a.client()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		var candidates []string
+		for todo, err := range c.Todos(cmd.Context(), &client.ListTodosOptions{Completed: completed}) {
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+			if id := shortID(todo.ID); strings.HasPrefix(id, strings.ToLower(toComplete)) {
+				candidates = append(candidates, id+"\t"+todo.Title)
+			}
+		}
+		return candidates, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
@@ -0,0 +1,184 @@
+// Package database はデータベースへの接続を開く
+//
+// 環境変数 DB_DRIVER で PostgreSQL（postgres、省略時）と SQLite（sqlite）を切り替える。
+// SQLite は外部のサービスなしで開発・CI を行うためのもので、起動時に Ent のスキーマから
+// テーブルを自動作成する。PostgreSQL に依存する機能は Features で無効にする。
+package database
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+)
+
+// DB_DRIVER に指定できるデータベースの種類
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// defaultSQLitePath は SQLITE_PATH を省略した場合のデータベースファイル
+const defaultSQLitePath = "todo.db"
+
+// sqliteTriggers は PostgreSQL の CHECK 制約・トリガー（db/schema.sql）に相当する SQLite のトリガー
+//
+//go:embed sqlite.sql
+var sqliteTriggers string
+
+// Features はデータベースの種類によって使える機能を表す
+type Features struct {
+	// Sync は監査ログのトランザクションID（PostgreSQL の xid8）による同期（/sync）に対応しているか
+	Sync bool
+	// Notify は LISTEN/NOTIFY で変更イベントを全てのレプリカに配信できるか
+	// 対応していない場合、変更イベントは同じプロセスの購読者にのみ配信する
+	Notify bool
+	// SkipLocked は SELECT ... FOR UPDATE SKIP LOCKED で他のレプリカが処理中の行を読み飛ばせるか
+	// 対応していない場合、書き込みはデータベース全体で直列化されるため行ロックは使わない
+	SkipLocked bool
+}
+
+// DB はデータベースへの接続
+type DB struct {
+	Client *ent.Client
+	SQL    *sql.DB
+	// Driver はデータベースの種類（DriverPostgres・DriverSQLite）
+	Driver string
+	// DSN は接続先（PostgreSQL の接続文字列、SQLite のデータベースファイル）
+	DSN      string
+	Features Features
+}
+
+// Config は環境変数から接続先を読み込む
+//
+// postgres の場合は POSTGRES_USER・POSTGRES_PASSWORD・POSTGRES_HOST・POSTGRES_PORT・POSTGRES_DB、
+// sqlite の場合は SQLITE_PATH（省略時は todo.db）を使う。
+func Config() (driver, dsn string, err error) {
+	switch driver = os.Getenv("DB_DRIVER"); driver {
+	case "", DriverPostgres:
+		return DriverPostgres, fmt.Sprintf("postgres://%s:%s@%s:%s/%s",
+			os.Getenv("POSTGRES_USER"),
+			os.Getenv("POSTGRES_PASSWORD"),
+			os.Getenv("POSTGRES_HOST"),
+			os.Getenv("POSTGRES_PORT"),
+			os.Getenv("POSTGRES_DB"),
+		), nil
+	case DriverSQLite:
+		path := os.Getenv("SQLITE_PATH")
+		if path == "" {
+			path = defaultSQLitePath
+		}
+		return DriverSQLite, path, nil
+	default:
+		return "", "", fmt.Errorf("unknown DB_DRIVER: %s", driver)
+	}
+}
+
+// Open は driver の dsn に接続する
+//
+// sqlite の場合、dsn はデータベースファイルのパス（または SQLite の file: URI）で、
+// 接続後に Ent のスキーマからテーブル・インデックスを作成し、トリガーを登録する。
+// postgres のスキーマは db/migrations のマイグレーションで作成する。
+func Open(ctx context.Context, driver, dsn string) (*DB, error) {
+	switch driver {
+	case DriverPostgres:
+		db, err := sql.Open("pgx", dsn)
+		if err != nil {
+			return nil, err
+		}
+		return &DB{
+			Client:   ent.NewClient(ent.Driver(entsql.OpenDB(dialect.Postgres, db))),
+			SQL:      db,
+			Driver:   driver,
+			DSN:      dsn,
+			Features: Features{Sync: true, Notify: true, SkipLocked: true},
+		}, nil
+	case DriverSQLite:
+		return openSQLite(ctx, dsn)
+	default:
+		return nil, fmt.Errorf("unknown database driver: %s", driver)
+	}
+}
+
+// openSQLite は SQLite のデータベースを開き、スキーマを自動作成する
+//
+// ファイルのデータベースは WAL モードで開き、読み取りのトランザクションと書き込みを並行できるようにする。
+// 書き込みのトランザクションは開始時にロックを取得して読み取りからの昇格による SQLITE_BUSY を避けるが、
+// 読み取り専用のトランザクション（sql.TxOptions の ReadOnly）は書き込みのロックを取得しない別の接続で開始する。
+// エクスポート・カレンダー配信がスナップショットを読み出している間も書き込みを待たせないためである。
+func openSQLite(ctx context.Context, path string) (*DB, error) {
+	dsn := path
+	if !strings.HasPrefix(dsn, "file:") {
+		dsn = "file:" + dsn
+	}
+	if strings.Contains(dsn, "?") {
+		dsn += "&"
+	} else {
+		dsn += "?"
+	}
+	// 外部キー制約を有効にし、ロックの解放はタイムアウトまで待つ（インメモリのデータベースは WAL にならない）
+	dsn += "_fk=1&_busy_timeout=5000&_journal_mode=WAL"
+
+	db, err := sql.Open("sqlite3", dsn+"&_txlock=immediate")
+	if err != nil {
+		return nil, err
+	}
+	readOnly, err := sql.Open("sqlite3", dsn+"&_txlock=deferred&_query_only=1")
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	client := ent.NewClient(ent.Driver(sqliteDriver{
+		Driver:   entsql.OpenDB(dialect.SQLite, db),
+		readOnly: entsql.OpenDB(dialect.SQLite, readOnly),
+	}))
+
+	if err := client.Schema.Create(ctx); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("create sqlite schema: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, sqliteTriggers); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("create sqlite triggers: %w", err)
+	}
+
+	return &DB{
+		Client: client,
+		SQL:    db,
+		Driver: DriverSQLite,
+		DSN:    path,
+	}, nil
+}
+
+// sqliteDriver は読み取り専用のトランザクションを readOnly の接続で開始する Ent のドライバー
+type sqliteDriver struct {
+	*entsql.Driver
+	readOnly *entsql.Driver
+}
+
+// BeginTx はトランザクションを開始する。opts.ReadOnly の場合は書き込みのロックを取得しない
+func (d sqliteDriver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	if opts != nil && opts.ReadOnly {
+		return d.readOnly.BeginTx(ctx, opts)
+	}
+	return d.Driver.BeginTx(ctx, opts)
+}
+
+// Close は両方の接続を閉じる
+func (d sqliteDriver) Close() error {
+	return errors.Join(d.Driver.Close(), d.readOnly.Close())
+}
+
+// Close は接続を閉じる
+func (db *DB) Close() error {
+	return db.Client.Close()
+}
@@ -0,0 +1,61 @@
+package database_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/t-okuji/go-openapi-todo-demo/database"
+)
+
+func TestOpenSQLite(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.Open(ctx, database.DriverSQLite, "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if db.Features != (database.Features{}) {
+		t.Errorf("Features = %+v, want all disabled", db.Features)
+	}
+
+	// Ent のスキーマから作成したテーブルを使える
+	category, err := db.Client.Category.Create().SetName("仕事").Save(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// PostgreSQL の CHECK 制約・トリガーに相当する制約はトリガーで検証する
+	for _, tt := range []struct {
+		query string
+		args  []any
+		want  string
+	}{
+		{"UPDATE categories SET color = ? WHERE id = ?", []any{"red", category.ID}, "categories.color must be a hex color code"},
+		{"INSERT INTO audit_events (actor, operation, entity_type, entity_id, changes, created_at) VALUES ('a', 'create', 'todo', ?, '{}', CURRENT_TIMESTAMP)", []any{category.ID}, ""},
+		{"UPDATE audit_events SET actor = 'b'", nil, "audit_events is append-only"},
+		{"DELETE FROM audit_events", nil, "audit_events is append-only"},
+	} {
+		_, err := db.SQL.ExecContext(ctx, tt.query, tt.args...)
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("%s: %v", tt.query, err)
+		case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("%s: err = %v, want %q", tt.query, err, tt.want)
+		}
+	}
+}
+
+func TestConfig(t *testing.T) {
+	t.Setenv("DB_DRIVER", "sqlite")
+	t.Setenv("SQLITE_PATH", "")
+	if driver, dsn, err := database.Config(); err != nil || driver != database.DriverSQLite || dsn != "todo.db" {
+		t.Errorf("Config() = %q, %q, %v, want sqlite todo.db", driver, dsn, err)
+	}
+
+	t.Setenv("DB_DRIVER", "mysql")
+	if _, _, err := database.Config(); err == nil {
+		t.Error("Config() with unknown DB_DRIVER: want error")
+	}
+}
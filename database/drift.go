@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	atlas "ariga.io/atlas/sql/schema"
+	"entgo.io/ent/dialect/sql/schema"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+)
+
+// Drift は Ent のスキーマとデータベースのスキーマの違いを返す
+//
+// Ent のマイグレーションエンジンでデータベースを読み取り、Ent のスキーマが必要とするテーブル・列・
+// インデックス・外部キーがデータベースにあるかを確認する。データベースは変更しない。
+// マイグレーションで追加した CHECK 制約・トリガー・デフォルト値・Ent にない列などは違いとしない。
+// 列の型は種類（文字列・整数・日時など）が同じであれば、長さや整数の幅が異なっても違いとしない。
+func Drift(ctx context.Context, client *ent.Client) ([]string, error) {
+	var drift []string
+	err := client.Schema.Create(ctx, schema.WithDiffHook(func(schema.Differ) schema.Differ {
+		return schema.DiffFunc(func(current, desired *atlas.Schema) ([]atlas.Change, error) {
+			drift = diffSchema(current, desired)
+			// 変更を返さないため、データベースには何も実行しない
+			return nil, nil
+		})
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return drift, nil
+}
+
+// diffSchema は desired（Ent のスキーマ）にあって current（データベース）にないものを返す
+func diffSchema(current, desired *atlas.Schema) []string {
+	var drift []string
+	for _, want := range desired.Tables {
+		have, ok := current.Table(want.Name)
+		if !ok {
+			drift = append(drift, fmt.Sprintf("%s: table is missing", want.Name))
+			continue
+		}
+
+		for _, wc := range want.Columns {
+			hc, ok := have.Column(wc.Name)
+			if !ok {
+				drift = append(drift, fmt.Sprintf("%s.%s: column is missing", want.Name, wc.Name))
+				continue
+			}
+			if typeKind(hc.Type.Type) != typeKind(wc.Type.Type) {
+				drift = append(drift, fmt.Sprintf("%s.%s: column type is %s, want %s",
+					want.Name, wc.Name, columnType(hc), columnType(wc)))
+			}
+			// NULL を許可しない列に Ent が NULL を書き込むと失敗する（逆の場合、Ent は NULL を書き込まない）
+			if wc.Type.Null && !hc.Type.Null {
+				drift = append(drift, fmt.Sprintf("%s.%s: column is NOT NULL, want nullable", want.Name, wc.Name))
+			}
+		}
+
+		if want.PrimaryKey != nil && (have.PrimaryKey == nil || !slices.Equal(indexColumns(have.PrimaryKey), indexColumns(want.PrimaryKey))) {
+			drift = append(drift, fmt.Sprintf("%s: primary key is not %v", want.Name, indexColumns(want.PrimaryKey)))
+		}
+
+		// インデックス・外部キーの名前はマイグレーションと Ent で異なるため、列で比較する
+		for _, wi := range want.Indexes {
+			if !slices.ContainsFunc(have.Indexes, func(hi *atlas.Index) bool {
+				return (hi.Unique || !wi.Unique) && slices.Equal(indexColumns(hi), indexColumns(wi))
+			}) {
+				kind := "index"
+				if wi.Unique {
+					kind = "unique index"
+				}
+				drift = append(drift, fmt.Sprintf("%s: %s on %v is missing", want.Name, kind, indexColumns(wi)))
+			}
+		}
+		for _, wf := range want.ForeignKeys {
+			i := slices.IndexFunc(have.ForeignKeys, func(hf *atlas.ForeignKey) bool {
+				return hf.RefTable != nil && hf.RefTable.Name == wf.RefTable.Name &&
+					slices.Equal(columnNames(hf.Columns), columnNames(wf.Columns)) &&
+					slices.Equal(columnNames(hf.RefColumns), columnNames(wf.RefColumns))
+			})
+			switch {
+			case i < 0:
+				drift = append(drift, fmt.Sprintf("%s: foreign key %v referencing %s is missing",
+					want.Name, columnNames(wf.Columns), wf.RefTable.Name))
+			case referenceAction(have.ForeignKeys[i].OnDelete) != referenceAction(wf.OnDelete):
+				drift = append(drift, fmt.Sprintf("%s: foreign key %v is ON DELETE %s, want ON DELETE %s",
+					want.Name, columnNames(wf.Columns), referenceAction(have.ForeignKeys[i].OnDelete), referenceAction(wf.OnDelete)))
+			}
+		}
+	}
+	return drift
+}
+
+// typeKind は列の型の種類を返す（serial は整数として扱う）
+func typeKind(t atlas.Type) reflect.Type {
+	if s, ok := t.(interface{ IntegerType() *atlas.IntegerType }); ok {
+		t = s.IntegerType()
+	}
+	return reflect.TypeOf(t)
+}
+
+// columnType はメッセージに表示する列の型を返す
+func columnType(c *atlas.Column) string {
+	if c.Type.Raw != "" {
+		return c.Type.Raw
+	}
+	return strings.TrimSuffix(typeKind(c.Type.Type).Elem().Name(), "Type")
+}
+
+func indexColumns(idx *atlas.Index) []string {
+	names := make([]string, 0, len(idx.Parts))
+	for _, part := range idx.Parts {
+		if part.C != nil {
+			names = append(names, part.C.Name)
+		}
+	}
+	return names
+}
+
+func columnNames(columns []*atlas.Column) []string {
+	names := make([]string, 0, len(columns))
+	for _, c := range columns {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// referenceAction は外部キーの削除時の動作を返す（指定なしは NO ACTION）
+func referenceAction(a atlas.ReferenceOption) atlas.ReferenceOption {
+	if a == "" {
+		return atlas.NoAction
+	}
+	return a
+}
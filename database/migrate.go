@@ -0,0 +1,472 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// マイグレーションの適用状況を記録するテーブル
+const (
+	migrationsTable     = "schema_migrations"
+	migrationsLockTable = "schema_migrations_lock"
+)
+
+// migrationFilePattern はマイグレーションファイル名（<バージョン>_<名前>.up.sql・.down.sql）
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// migrationNamePattern は migrate create で指定できる名前
+var migrationNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// ErrMigrationLocked は他のプロセスがマイグレーションを実行中（またはロックが残っている）ことを表す
+var ErrMigrationLocked = errors.New("migration lock is held")
+
+// Migration はバージョン付きのマイグレーション
+type Migration struct {
+	Version int64
+	Name    string
+	// Up・Down は適用・取り消しの SQL。Down は取り消しのファイルがない場合は空
+	Up   string
+	Down string
+	// Checksum は Up の SHA-256。適用後にファイルが変更されていないかの確認に使う
+	Checksum string
+}
+
+// File は Up のファイル名
+func (m Migration) File() string {
+	return fmt.Sprintf("%03d_%s.up.sql", m.Version, m.Name)
+}
+
+// Migration の適用状況
+const (
+	MigrationPending  = "pending"
+	MigrationApplied  = "applied"
+	MigrationModified = "modified" // 適用後にファイルが変更された
+	MigrationMissing  = "missing"  // 適用済みだがファイルがない
+)
+
+// MigrationStatus はマイグレーションの適用状況
+type MigrationStatus struct {
+	Migration
+	State string
+	// AppliedAt は適用日時（未適用の場合はゼロ値）
+	AppliedAt time.Time
+}
+
+// LoadMigrations は fsys の直下にあるマイグレーションファイルをバージョン順に読み込む
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		m := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if m == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version: %s", entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = migration
+		} else if migration.Name != m[2] {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", version, migration.Name, m[2])
+		}
+		if m[3] == "up" {
+			sum := sha256.Sum256(content)
+			migration.Up = string(content)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Checksum == "" {
+			return nil, fmt.Errorf("migration %03d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// CreateMigration は dir に次のバージョンの空のマイグレーションファイル（up・down）を作成し、そのパスを返す
+func CreateMigration(dir, name string) (up, down string, err error) {
+	name = strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(name)))
+	if !migrationNamePattern.MatchString(name) {
+		return "", "", fmt.Errorf("invalid migration name %q: use letters, digits and underscores", name)
+	}
+	migrations, err := LoadMigrations(os.DirFS(dir))
+	if err != nil {
+		return "", "", err
+	}
+	var version int64 = 1
+	if len(migrations) > 0 {
+		version = migrations[len(migrations)-1].Version + 1
+	}
+
+	title := strings.ReplaceAll(name, "_", " ")
+	title = strings.ToUpper(title[:1]) + title[1:]
+	prefix := filepath.Join(dir, fmt.Sprintf("%03d_%s", version, name))
+	up, down = prefix+".up.sql", prefix+".down.sql"
+	for path, content := range map[string]string{
+		up:   fmt.Sprintf("-- Migration: %s\n-- Description: \n\n", title),
+		down: fmt.Sprintf("-- Migration rollback: %s\n-- Description: Revert migration %03d\n\n", title, version),
+	} {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return "", "", err
+		}
+		_, err = f.WriteString(content)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return "", "", err
+		}
+	}
+	return up, down, nil
+}
+
+// Migrator はマイグレーションをデータベースに適用・取り消す
+//
+// 適用したバージョンとチェックサムは schema_migrations に記録し、適用後にファイルが変更された場合は
+// 適用を中止する。同時に複数のプロセスが実行しないよう、schema_migrations_lock の行をロックとして使う。
+// 各マイグレーションは記録の更新と同じトランザクションで実行する。
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	// Log には実行したマイグレーションを出力する。DryRun の場合は実行する SQL を出力する
+	Log io.Writer
+	// DryRun は実行せずに実行する SQL を Log に出力するか
+	DryRun bool
+}
+
+// NewMigrator は migrations を db に適用する Migrator を作成する
+func NewMigrator(db *sql.DB, migrations []Migration) *Migrator {
+	return &Migrator{db: db, migrations: migrations, Log: io.Discard}
+}
+
+// init は適用状況を記録するテーブルを作成する
+func (m *Migrator) init(ctx context.Context) error {
+	for _, query := range []string{
+		`CREATE TABLE IF NOT EXISTS ` + migrationsTable + ` (
+			version BIGINT PRIMARY KEY,
+			name TEXT NOT NULL,
+			checksum VARCHAR(64) NOT NULL,
+			applied_at TIMESTAMP WITH TIME ZONE NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS ` + migrationsLockTable + ` (
+			id INTEGER PRIMARY KEY CHECK (id = 1),
+			locked_by TEXT NOT NULL,
+			locked_at TIMESTAMP WITH TIME ZONE NOT NULL
+		)`,
+	} {
+		if _, err := m.db.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("creating migration tables: %w", err)
+		}
+	}
+	return nil
+}
+
+// Status は全てのマイグレーションの適用状況をバージョン順に返す
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	if err := m.init(ctx); err != nil {
+		return nil, err
+	}
+	return m.status(ctx)
+}
+
+func (m *Migrator) status(ctx context.Context) ([]MigrationStatus, error) {
+	rows, err := m.db.QueryContext(ctx, `SELECT version, name, checksum, applied_at FROM `+migrationsTable)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int64]MigrationStatus{}
+	for rows.Next() {
+		var s MigrationStatus
+		var appliedAt any
+		if err := rows.Scan(&s.Version, &s.Name, &s.Checksum, &appliedAt); err != nil {
+			return nil, err
+		}
+		if s.AppliedAt, err = scanTime(appliedAt); err != nil {
+			return nil, err
+		}
+		applied[s.Version] = s
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		s := MigrationStatus{Migration: migration, State: MigrationPending}
+		if a, ok := applied[migration.Version]; ok {
+			s.AppliedAt = a.AppliedAt
+			s.State = MigrationApplied
+			if a.Checksum != migration.Checksum {
+				s.State = MigrationModified
+			}
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, s)
+	}
+	for _, a := range applied {
+		a.State = MigrationMissing
+		statuses = append(statuses, a)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// Up は未適用のマイグレーションを全てバージョン順に適用し、適用した（DryRun の場合は適用する）マイグレーションを返す
+//
+// 適用済みのマイグレーションのファイルが変更・削除されている場合や、適用済みのバージョンより
+// 前のバージョンが未適用の場合は何も適用せずにエラーを返す。
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var pending []Migration
+	err := m.run(ctx, func(statuses []MigrationStatus) error {
+		var latest int64
+		for _, s := range statuses {
+			switch s.State {
+			case MigrationModified:
+				return fmt.Errorf("migration %s was modified after it was applied (checksum mismatch)", s.File())
+			case MigrationMissing:
+				return fmt.Errorf("applied migration %03d_%s has no file", s.Version, s.Name)
+			case MigrationApplied:
+				latest = s.Version
+			}
+		}
+		for _, s := range statuses {
+			if s.State != MigrationPending {
+				continue
+			}
+			if s.Version < latest {
+				return fmt.Errorf("migration %s is pending but a later migration (%03d) is already applied", s.File(), latest)
+			}
+			pending = append(pending, s.Migration)
+		}
+
+		for _, migration := range pending {
+			if err := m.apply(ctx, migration.File(), migration.Up,
+				`INSERT INTO `+migrationsTable+` (version, name, checksum, applied_at) VALUES ($1, $2, $3, $4)`,
+				migration.Version, migration.Name, migration.Checksum, time.Now().UTC(),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return pending, err
+}
+
+// Down は適用済みのマイグレーションを新しいものから steps 件取り消し、取り消した（DryRun の場合は取り消す）マイグレーションを返す
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("steps must be at least 1")
+	}
+	var reverted []Migration
+	err := m.run(ctx, func(statuses []MigrationStatus) error {
+		for i := len(statuses) - 1; i >= 0 && len(reverted) < steps; i-- {
+			switch s := statuses[i]; s.State {
+			case MigrationPending:
+				continue
+			case MigrationMissing:
+				return fmt.Errorf("applied migration %03d_%s has no file", s.Version, s.Name)
+			case MigrationModified:
+				return fmt.Errorf("migration %s was modified after it was applied (checksum mismatch)", s.File())
+			default:
+				if strings.TrimSpace(s.Down) == "" {
+					return fmt.Errorf("migration %s has no down file", s.File())
+				}
+				reverted = append(reverted, s.Migration)
+			}
+		}
+
+		for _, migration := range reverted {
+			file := strings.TrimSuffix(migration.File(), ".up.sql") + ".down.sql"
+			if err := m.apply(ctx, file, migration.Down,
+				`DELETE FROM `+migrationsTable+` WHERE version = $1`, migration.Version,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Baseline は version 以下の未適用のマイグレーションを、SQL を実行せずに適用済みとして記録し、記録した
+// （DryRun の場合は記録する）マイグレーションを返す
+//
+// マイグレーションの導入前に db/schema.sql などでスキーマを作成したデータベースを、スキーマに相当する
+// バージョンから up で更新できるようにするために使う。version はマイグレーションのバージョンでなければならず、
+// version より新しいマイグレーションが適用済みの場合や、適用済みのファイルが変更・削除されている場合はエラーを返す。
+func (m *Migrator) Baseline(ctx context.Context, version int64) ([]Migration, error) {
+	if !slices.ContainsFunc(m.migrations, func(migration Migration) bool { return migration.Version == version }) {
+		return nil, fmt.Errorf("no migration with version %03d", version)
+	}
+	var recorded []Migration
+	err := m.run(ctx, func(statuses []MigrationStatus) error {
+		for _, s := range statuses {
+			switch s.State {
+			case MigrationModified:
+				return fmt.Errorf("migration %s was modified after it was applied (checksum mismatch)", s.File())
+			case MigrationMissing:
+				return fmt.Errorf("applied migration %03d_%s has no file", s.Version, s.Name)
+			case MigrationApplied:
+				if s.Version > version {
+					return fmt.Errorf("migration %s is already applied; baseline only records versions up to the first applied one", s.File())
+				}
+			case MigrationPending:
+				if s.Version <= version {
+					recorded = append(recorded, s.Migration)
+				}
+			}
+		}
+
+		for _, migration := range recorded {
+			if err := m.apply(ctx, migration.File(), "",
+				`INSERT INTO `+migrationsTable+` (version, name, checksum, applied_at) VALUES ($1, $2, $3, $4)`,
+				migration.Version, migration.Name, migration.Checksum, time.Now().UTC(),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return recorded, err
+}
+
+// run はロックを取得して fn を実行する。DryRun の場合はロックを取得しない
+func (m *Migrator) run(ctx context.Context, fn func([]MigrationStatus) error) error {
+	if err := m.init(ctx); err != nil {
+		return err
+	}
+	if !m.DryRun {
+		if err := m.lock(ctx); err != nil {
+			return err
+		}
+		defer m.Unlock(context.WithoutCancel(ctx))
+	}
+	statuses, err := m.status(ctx)
+	if err != nil {
+		return err
+	}
+	return fn(statuses)
+}
+
+// apply は file の SQL と適用状況の記録（record）を1つのトランザクションで実行する
+// query が空の場合は記録のみを行う（Baseline）
+func (m *Migrator) apply(ctx context.Context, file, query, record string, args ...any) error {
+	if m.DryRun {
+		if query == "" {
+			fmt.Fprintf(m.Log, "-- %s (baseline: record only)\n", file)
+			return nil
+		}
+		fmt.Fprintf(m.Log, "-- %s\n%s\n", file, strings.TrimSpace(query))
+		return nil
+	}
+
+	start := time.Now()
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if query != "" {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: recording migration: %w", file, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	if query == "" {
+		fmt.Fprintf(m.Log, "%s (recorded without running)\n", file)
+		return nil
+	}
+	fmt.Fprintf(m.Log, "%s (%s)\n", file, time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// lock はロックの行を挿入する。既に行がある場合は ErrMigrationLocked を返す
+func (m *Migrator) lock(ctx context.Context) error {
+	host, _ := os.Hostname()
+	holder := fmt.Sprintf("%s (pid %d)", host, os.Getpid())
+	_, err := m.db.ExecContext(ctx,
+		`INSERT INTO `+migrationsLockTable+` (id, locked_by, locked_at) VALUES (1, $1, $2)`,
+		holder, time.Now().UTC(),
+	)
+	if err == nil {
+		return nil
+	}
+
+	var lockedBy string
+	var lockedAt any
+	if qerr := m.db.QueryRowContext(ctx,
+		`SELECT locked_by, locked_at FROM `+migrationsLockTable+` WHERE id = 1`,
+	).Scan(&lockedBy, &lockedAt); qerr != nil {
+		return fmt.Errorf("acquiring migration lock: %w", err)
+	}
+	at, _ := scanTime(lockedAt)
+	return fmt.Errorf("%w by %s since %s", ErrMigrationLocked, lockedBy, at.Format(time.RFC3339))
+}
+
+// Unlock はロックを解除する。異常終了したプロセスのロックが残った場合にも使う
+func (m *Migrator) Unlock(ctx context.Context) error {
+	if err := m.init(ctx); err != nil {
+		return err
+	}
+	_, err := m.db.ExecContext(ctx, `DELETE FROM `+migrationsLockTable+` WHERE id = 1`)
+	return err
+}
+
+// scanTime は日時の列の値を time.Time に変換する
+// PostgreSQL は time.Time、SQLite は文字列で返す
+func scanTime(v any) (time.Time, error) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		return time.Parse("2006-01-02 15:04:05.999999999-07:00", v)
+	case []byte:
+		return time.Parse("2006-01-02 15:04:05.999999999-07:00", string(v))
+	default:
+		return time.Time{}, fmt.Errorf("unexpected time value: %T", v)
+	}
+}
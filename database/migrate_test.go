@@ -0,0 +1,238 @@
+package database_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/t-okuji/go-openapi-todo-demo/database"
+	"github.com/t-okuji/go-openapi-todo-demo/db/migrations"
+)
+
+var testMigrations = fstest.MapFS{
+	"001_notes.up.sql":     {Data: []byte("CREATE TABLE notes (id INTEGER PRIMARY KEY);")},
+	"001_notes.down.sql":   {Data: []byte("DROP TABLE notes;")},
+	"002_tags.up.sql":      {Data: []byte("CREATE TABLE tags (id INTEGER PRIMARY KEY);\nCREATE INDEX idx_tags ON tags(id);")},
+	"002_tags.down.sql":    {Data: []byte("DROP TABLE tags;")},
+	"003_invalid.up.sql":   {Data: []byte("CREATE TABLE broken (;")},
+	"003_invalid.down.sql": {Data: []byte("")},
+}
+
+// states は適用状況をバージョン順に返す
+func states(t *testing.T, m *database.Migrator) []string {
+	t.Helper()
+	statuses, err := m.Status(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var states []string
+	for _, s := range statuses {
+		states = append(states, s.State)
+	}
+	return states
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	all, err := database.LoadMigrations(testMigrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := database.NewMigrator(db, all[:2])
+
+	// DryRun は SQL を出力するだけで適用しない
+	var out bytes.Buffer
+	m.Log, m.DryRun = &out, true
+	if applied, err := m.Up(ctx); err != nil || len(applied) != 2 {
+		t.Fatalf("dry-run Up() = %d, %v", len(applied), err)
+	}
+	if !strings.Contains(out.String(), "-- 002_tags.up.sql\nCREATE TABLE tags") {
+		t.Errorf("dry-run output = %q", out.String())
+	}
+	if got := states(t, m); !slices.Equal(got, []string{"pending", "pending"}) {
+		t.Fatalf("states after dry-run = %v", got)
+	}
+
+	m.DryRun = false
+	if applied, err := m.Up(ctx); err != nil || len(applied) != 2 {
+		t.Fatalf("Up() = %d, %v", len(applied), err)
+	}
+	if applied, err := m.Up(ctx); err != nil || len(applied) != 0 {
+		t.Fatalf("second Up() = %d, %v, want nothing to apply", len(applied), err)
+	}
+
+	// 失敗したマイグレーションはロールバックされ、記録されない
+	m = database.NewMigrator(db, all)
+	if _, err := m.Up(ctx); err == nil || !strings.Contains(err.Error(), "003_invalid.up.sql") {
+		t.Fatalf("Up() with invalid migration: err = %v", err)
+	}
+	if got := states(t, m); !slices.Equal(got, []string{"applied", "applied", "pending"}) {
+		t.Fatalf("states after failed migration = %v", got)
+	}
+
+	// 他のプロセスがロックを保持している間は実行しない
+	if _, err := db.Exec(`INSERT INTO schema_migrations_lock (id, locked_by, locked_at) VALUES (1, 'other', '2026-01-01 00:00:00+00:00')`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Down(ctx, 1); !errors.Is(err, database.ErrMigrationLocked) {
+		t.Fatalf("Down() while locked: err = %v, want ErrMigrationLocked", err)
+	}
+	if err := m.Unlock(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// 適用後に変更されたマイグレーションは適用・取り消しを中止する
+	modified := fstest.MapFS{"001_notes.up.sql": {Data: []byte("CREATE TABLE notes (id TEXT);")}}
+	changed, err := database.LoadMigrations(modified)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m = database.NewMigrator(db, append(changed, all[1]))
+	if got := states(t, m); !slices.Equal(got, []string{"modified", "applied"}) {
+		t.Fatalf("states with modified migration = %v", got)
+	}
+	if _, err := m.Up(ctx); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("Up() with modified migration: err = %v", err)
+	}
+
+	m = database.NewMigrator(db, all[:2])
+	if reverted, err := m.Down(ctx, 2); err != nil || len(reverted) != 2 || reverted[0].Version != 2 {
+		t.Fatalf("Down(2) = %v, %v", reverted, err)
+	}
+	if got := states(t, m); !slices.Equal(got, []string{"pending", "pending"}) {
+		t.Fatalf("states after Down = %v", got)
+	}
+	if _, err := db.Exec("SELECT * FROM notes"); err == nil {
+		t.Error("notes table still exists after Down")
+	}
+}
+
+func TestMigratorBaseline(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	all, err := database.LoadMigrations(testMigrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := database.NewMigrator(db, all[:2])
+
+	// マイグレーションの導入前に 001 と同じスキーマを直接作成したデータベース
+	if _, err := db.Exec("CREATE TABLE notes (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.Baseline(ctx, 5); err == nil {
+		t.Error("Baseline(5) succeeded for an unknown version")
+	}
+	var out bytes.Buffer
+	m.Log, m.DryRun = &out, true
+	if recorded, err := m.Baseline(ctx, 1); err != nil || len(recorded) != 1 || !strings.Contains(out.String(), "001_notes.up.sql") {
+		t.Fatalf("dry-run Baseline(1) = %v, %v, output %q", recorded, err, out.String())
+	}
+	if got := states(t, m); !slices.Equal(got, []string{"pending", "pending"}) {
+		t.Fatalf("states after dry-run baseline = %v", got)
+	}
+
+	// SQL を実行せずに記録するため、既存のテーブルと衝突しない
+	m.DryRun = false
+	if recorded, err := m.Baseline(ctx, 1); err != nil || len(recorded) != 1 || recorded[0].Version != 1 {
+		t.Fatalf("Baseline(1) = %v, %v", recorded, err)
+	}
+	if got := states(t, m); !slices.Equal(got, []string{"applied", "pending"}) {
+		t.Fatalf("states after baseline = %v", got)
+	}
+	if applied, err := m.Up(ctx); err != nil || len(applied) != 1 || applied[0].Version != 2 {
+		t.Fatalf("Up() after baseline = %v, %v, want only 002", applied, err)
+	}
+
+	// 記録済みのバージョンは何もせず、より新しいバージョンが適用済みの場合はエラーにする
+	if _, err := m.Baseline(ctx, 1); err == nil {
+		t.Error("Baseline(1) succeeded after 002 was applied")
+	}
+	if recorded, err := m.Baseline(ctx, 2); err != nil || len(recorded) != 0 {
+		t.Errorf("Baseline(2) = %v, %v, want nothing to record", recorded, err)
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	// リポジトリのマイグレーションは全て up・down の組になっている
+	all, err := database.LoadMigrations(migrations.FS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, m := range all {
+		if m.Version != int64(i+1) || m.Down == "" {
+			t.Errorf("migration %s: version %d at position %d, down file %t", m.File(), m.Version, i, m.Down != "")
+		}
+	}
+
+	dir := t.TempDir()
+	for name, file := range testMigrations {
+		if err := os.WriteFile(filepath.Join(dir, name), file.Data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	up, down, err := database.CreateMigration(dir, "Add Labels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(up) != "004_add_labels.up.sql" || filepath.Base(down) != "004_add_labels.down.sql" {
+		t.Errorf("CreateMigration() = %s, %s", up, down)
+	}
+	if _, _, err := database.CreateMigration(dir, "drop;table"); err == nil {
+		t.Error("CreateMigration() with invalid name: want error")
+	}
+}
+
+func TestDrift(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.Open(ctx, database.DriverSQLite, "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if drift, err := database.Drift(ctx, db.Client); err != nil || len(drift) != 0 {
+		t.Fatalf("Drift() = %v, %v, want no drift", drift, err)
+	}
+
+	for _, query := range []string{
+		"DROP INDEX reminder_status_fire_at",
+		"ALTER TABLE todos DROP COLUMN due_at",
+		"DROP TABLE calendar_tokens",
+	} {
+		if _, err := db.SQL.ExecContext(ctx, query); err != nil {
+			t.Fatal(err)
+		}
+	}
+	drift, err := database.Drift(ctx, db.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"calendar_tokens: table is missing",
+		"reminders: index on [status fire_at] is missing",
+		"todos.due_at: column is missing",
+	}
+	if !slices.Equal(drift, want) {
+		t.Errorf("Drift() = %q, want %q", drift, want)
+	}
+}
@@ -0,0 +1,34 @@
+-- PostgreSQL の CHECK 制約・トリガー（db/schema.sql）に相当する SQLite のトリガー
+-- updated_at は Ent のスキーマ（UpdateDefault）で更新するため、トリガーは作成しない
+
+-- categories.color CHECK (color ~ '^#[0-9A-Fa-f]{6}$')
+CREATE TRIGGER IF NOT EXISTS categories_color_check_insert
+    BEFORE INSERT ON categories
+    FOR EACH ROW
+    WHEN NEW.color NOT GLOB '#[0-9A-Fa-f][0-9A-Fa-f][0-9A-Fa-f][0-9A-Fa-f][0-9A-Fa-f][0-9A-Fa-f]'
+BEGIN
+    SELECT RAISE(ABORT, 'categories.color must be a hex color code');
+END;
+
+CREATE TRIGGER IF NOT EXISTS categories_color_check_update
+    BEFORE UPDATE OF color ON categories
+    FOR EACH ROW
+    WHEN NEW.color NOT GLOB '#[0-9A-Fa-f][0-9A-Fa-f][0-9A-Fa-f][0-9A-Fa-f][0-9A-Fa-f][0-9A-Fa-f]'
+BEGIN
+    SELECT RAISE(ABORT, 'categories.color must be a hex color code');
+END;
+
+-- audit_events_append_only
+CREATE TRIGGER IF NOT EXISTS audit_events_append_only_update
+    BEFORE UPDATE ON audit_events
+    FOR EACH ROW
+BEGIN
+    SELECT RAISE(ABORT, 'audit_events is append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS audit_events_append_only_delete
+    BEFORE DELETE ON audit_events
+    FOR EACH ROW
+BEGIN
+    SELECT RAISE(ABORT, 'audit_events is append-only');
+END;
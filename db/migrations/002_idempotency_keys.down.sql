@@ -0,0 +1,8 @@
+-- Migration rollback: Remove idempotency keys
+-- Description: Drop the idempotency_keys table created in migration 002
+
+-- Drop indexes (they'll be dropped automatically with tables, but explicitly for clarity)
+DROP INDEX IF EXISTS idx_idempotency_keys_expires_at;
+
+-- Drop tables
+DROP TABLE IF EXISTS idempotency_keys;
@@ -0,0 +1,23 @@
+-- Migration rollback: Remove audit events
+-- Description: Drop the audit_events table, trigger and function and the idempotency key actor created in migration 003
+
+-- Keys used by several actors cannot be unique without the actor
+DELETE FROM idempotency_keys a USING idempotency_keys b
+WHERE a.key = b.key AND a.created_at > b.created_at;
+
+DROP INDEX IF EXISTS idx_idempotency_keys_actor_key;
+ALTER TABLE idempotency_keys ADD CONSTRAINT idempotency_keys_key_key UNIQUE (key);
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS actor;
+
+-- Drop triggers
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+
+-- Drop function
+DROP FUNCTION IF EXISTS reject_audit_event_modification();
+
+-- Drop indexes (they'll be dropped automatically with tables, but explicitly for clarity)
+DROP INDEX IF EXISTS idx_audit_events_entity_id;
+DROP INDEX IF EXISTS idx_audit_events_entity;
+
+-- Drop tables
+DROP TABLE IF EXISTS audit_events;
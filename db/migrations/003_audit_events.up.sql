@@ -0,0 +1,37 @@
+-- Migration: Audit events
+-- Description: Append-only audit log of todo and category mutations, and idempotency keys scoped to the actor
+
+-- Audit events table
+CREATE TABLE audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    actor VARCHAR(255) NOT NULL,
+    operation VARCHAR(10) NOT NULL CHECK (operation IN ('create', 'update', 'delete')),
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id UUID NOT NULL,
+    changes JSONB NOT NULL,
+    request_id VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Indexes for better query performance
+CREATE INDEX idx_audit_events_entity ON audit_events(entity_type, entity_id);
+CREATE INDEX idx_audit_events_entity_id ON audit_events(entity_id);
+
+-- Function to reject modification of audit events
+CREATE OR REPLACE FUNCTION reject_audit_event_modification()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+-- Trigger for audit_events table
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW
+    EXECUTE FUNCTION reject_audit_event_modification();
+
+-- Idempotency keys are unique per actor
+ALTER TABLE idempotency_keys ADD COLUMN actor VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_key_key;
+CREATE UNIQUE INDEX idx_idempotency_keys_actor_key ON idempotency_keys(actor, key);
@@ -0,0 +1,5 @@
+-- Migration rollback: Remove change event sequence
+-- Description: Drop the sequence created in migration 004
+
+-- Drop sequences
+DROP SEQUENCE IF EXISTS change_event_id_seq;
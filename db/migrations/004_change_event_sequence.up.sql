@@ -0,0 +1,5 @@
+-- Migration: Change event sequence
+-- Description: Sequence for change event IDs shared by all API replicas through LISTEN/NOTIFY
+
+-- Change event ID sequence
+CREATE SEQUENCE change_event_id_seq;
@@ -0,0 +1,13 @@
+-- Migration rollback: Remove webhooks
+-- Description: Drop the webhook tables created in migration 005
+
+-- Drop triggers
+DROP TRIGGER IF EXISTS update_webhook_subscriptions_updated_at ON webhook_subscriptions;
+
+-- Drop indexes (they'll be dropped automatically with tables, but explicitly for clarity)
+DROP INDEX IF EXISTS idx_webhook_deliveries_subscription_id;
+DROP INDEX IF EXISTS idx_webhook_deliveries_status_next_attempt_at;
+
+-- Drop tables
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
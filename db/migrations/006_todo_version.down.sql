@@ -0,0 +1,5 @@
+-- Migration rollback: Remove todo version
+-- Description: Drop the version column added in migration 006
+
+-- Drop columns
+ALTER TABLE todos DROP COLUMN IF EXISTS version;
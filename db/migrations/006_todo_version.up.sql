@@ -0,0 +1,5 @@
+-- Migration: Todo version
+-- Description: Version number of todos for optimistic concurrency control, incremented on every update
+
+-- Add version column to todos table
+ALTER TABLE todos ADD COLUMN version INTEGER NOT NULL DEFAULT 1 CHECK (version >= 1);
@@ -0,0 +1,8 @@
+-- Migration rollback: Remove audit event transaction ID
+-- Description: Drop the transaction_id column added in migration 007
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_audit_events_transaction_id;
+
+-- Drop columns
+ALTER TABLE audit_events DROP COLUMN IF EXISTS transaction_id;
@@ -0,0 +1,9 @@
+-- Migration: Audit event transaction ID
+-- Description: ID of the transaction that wrote each audit event, used as the watermark of delta sync tokens
+
+-- Add transaction_id column to audit_events table
+-- Existing rows receive the ID of the migrating transaction
+ALTER TABLE audit_events ADD COLUMN transaction_id XID8 NOT NULL DEFAULT pg_current_xact_id();
+
+-- Index for delta sync queries
+CREATE INDEX idx_audit_events_transaction_id ON audit_events(transaction_id);
@@ -0,0 +1,11 @@
+-- Migration rollback: Remove calendar feed
+-- Description: Drop the calendar token table and the due_at column added in migration 008
+
+-- Drop tables
+DROP TABLE IF EXISTS calendar_tokens;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_todos_due_at;
+
+-- Drop columns
+ALTER TABLE todos DROP COLUMN IF EXISTS due_at;
@@ -0,0 +1,17 @@
+-- Migration: Calendar feed
+-- Description: Due date of todos and per-actor tokens for the iCalendar feed
+
+-- Add due_at column to todos table
+ALTER TABLE todos ADD COLUMN due_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_todos_due_at ON todos(due_at);
+
+-- Calendar feed tokens table (only the SHA-256 of the token is stored, with the filter the feed is scoped to)
+CREATE TABLE calendar_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor VARCHAR(255) NOT NULL UNIQUE CHECK (LENGTH(actor) >= 1),
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    filter JSONB,
+    all_todos BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,12 @@
+-- Migration rollback: Remove todo recurrence
+-- Description: Drop the recurrence columns added in migration 009
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_todos_series;
+
+-- Drop columns
+ALTER TABLE todos DROP COLUMN IF EXISTS occurrence;
+ALTER TABLE todos DROP COLUMN IF EXISTS series_start;
+ALTER TABLE todos DROP COLUMN IF EXISTS series_id;
+ALTER TABLE todos DROP COLUMN IF EXISTS recurrence_time_zone;
+ALTER TABLE todos DROP COLUMN IF EXISTS recurrence;
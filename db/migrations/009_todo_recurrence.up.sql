@@ -0,0 +1,11 @@
+-- Migration: Todo recurrence
+-- Description: Recurrence rule of todos and the series that recurring instances belong to
+
+-- Add recurrence columns to todos table
+ALTER TABLE todos ADD COLUMN recurrence TEXT;
+ALTER TABLE todos ADD COLUMN recurrence_time_zone VARCHAR(64);
+ALTER TABLE todos ADD COLUMN series_id UUID;
+ALTER TABLE todos ADD COLUMN series_start TIMESTAMP WITH TIME ZONE;
+ALTER TABLE todos ADD COLUMN occurrence INTEGER CHECK (occurrence >= 1);
+
+CREATE INDEX idx_todos_series ON todos(series_id, occurrence);
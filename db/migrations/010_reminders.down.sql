@@ -0,0 +1,5 @@
+-- Migration rollback: Remove reminders
+-- Description: Drop the reminder table added in migration 010
+
+-- Drop tables
+DROP TABLE IF EXISTS reminders;
@@ -0,0 +1,21 @@
+-- Migration: Reminders
+-- Description: Reminders of todos fired by the background scheduler
+
+-- Reminders table (absolute remind_at, or offset_minutes relative to the due date of the todo)
+CREATE TABLE reminders (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    todo_id UUID NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+    remind_at TIMESTAMP WITH TIME ZONE,
+    offset_minutes INTEGER,
+    fire_at TIMESTAMP WITH TIME ZONE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'skipped', 'dead')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((remind_at IS NULL) <> (offset_minutes IS NULL))
+);
+
+-- Indexes for the scheduler and the reminder list
+CREATE INDEX idx_reminders_status_fire_at ON reminders(status, fire_at);
+CREATE INDEX idx_reminders_todo_id ON reminders(todo_id, created_at);
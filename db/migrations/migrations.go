@@ -0,0 +1,12 @@
+// Package migrations は PostgreSQL のスキーマを作成・変更するバージョン付きの SQL ファイルを提供する
+//
+// ファイル名は <バージョン>_<名前>.up.sql（適用）と <バージョン>_<名前>.down.sql（取り消し）で、
+// server migrate コマンドがバージョン順に適用する。
+package migrations
+
+import "embed"
+
+// FS はこのディレクトリのマイグレーションファイル
+//
+//go:embed *.sql
+var FS embed.FS
@@ -7,6 +7,13 @@ CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 -- Drop existing tables if they exist (for clean setup)
 DROP TABLE IF EXISTS todos CASCADE;
 DROP TABLE IF EXISTS categories CASCADE;
+DROP TABLE IF EXISTS idempotency_keys CASCADE;
+DROP TABLE IF EXISTS audit_events CASCADE;
+DROP TABLE IF EXISTS webhook_deliveries CASCADE;
+DROP TABLE IF EXISTS webhook_subscriptions CASCADE;
+DROP TABLE IF EXISTS calendar_tokens CASCADE;
+DROP TABLE IF EXISTS reminders CASCADE;
+DROP SEQUENCE IF EXISTS change_event_id_seq;
 
 -- Categories table
 CREATE TABLE categories (
@@ -24,6 +31,13 @@ CREATE TABLE todos (
     title TEXT NOT NULL,
     description TEXT,
     completed BOOLEAN NOT NULL DEFAULT FALSE,
+    version INTEGER NOT NULL DEFAULT 1 CHECK (version >= 1),
+    due_at TIMESTAMP WITH TIME ZONE,
+    recurrence TEXT,
+    recurrence_time_zone VARCHAR(64),
+    series_id UUID,
+    series_start TIMESTAMP WITH TIME ZONE,
+    occurrence INTEGER CHECK (occurrence >= 1),
     category_id UUID REFERENCES categories(id) ON DELETE SET NULL,
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE
@@ -33,8 +47,107 @@ CREATE TABLE todos (
 CREATE INDEX idx_todos_category_id ON todos(category_id);
 CREATE INDEX idx_todos_completed ON todos(completed);
 CREATE INDEX idx_todos_created_at ON todos(created_at);
+CREATE INDEX idx_todos_due_at ON todos(due_at);
+CREATE INDEX idx_todos_series ON todos(series_id, occurrence);
 CREATE INDEX idx_categories_name ON categories(name);
 
+-- Idempotency keys table
+CREATE TABLE idempotency_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor VARCHAR(255) NOT NULL DEFAULT '',
+    key VARCHAR(255) NOT NULL CHECK (LENGTH(key) >= 1),
+    fingerprint VARCHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'processing' CHECK (status IN ('processing', 'completed')),
+    locked_until TIMESTAMP WITH TIME ZONE,
+    response_status INTEGER,
+    response_content_type VARCHAR(255),
+    response_body BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_idempotency_keys_actor_key ON idempotency_keys(actor, key);
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+
+-- Audit events table (append-only)
+CREATE TABLE audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    actor VARCHAR(255) NOT NULL,
+    operation VARCHAR(10) NOT NULL CHECK (operation IN ('create', 'update', 'delete')),
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id UUID NOT NULL,
+    changes JSONB NOT NULL,
+    request_id VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    transaction_id XID8 NOT NULL DEFAULT pg_current_xact_id()
+);
+
+CREATE INDEX idx_audit_events_entity ON audit_events(entity_type, entity_id);
+CREATE INDEX idx_audit_events_entity_id ON audit_events(entity_id);
+CREATE INDEX idx_audit_events_transaction_id ON audit_events(transaction_id);
+
+-- Webhook subscriptions table
+CREATE TABLE webhook_subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    url TEXT NOT NULL CHECK (LENGTH(url) >= 1),
+    event_types JSONB NOT NULL,
+    secret VARCHAR(255) NOT NULL CHECK (LENGTH(secret) >= 1),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Webhook deliveries table (outbox and delivery log)
+CREATE TABLE webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    subscription_id UUID NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'dead')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_attempt_at TIMESTAMP WITH TIME ZONE,
+    response_status INTEGER,
+    last_error TEXT,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_webhook_deliveries_status_next_attempt_at ON webhook_deliveries(status, next_attempt_at);
+CREATE INDEX idx_webhook_deliveries_subscription_id ON webhook_deliveries(subscription_id, created_at);
+
+-- Calendar feed tokens table (only the SHA-256 of the token is stored, with the filter the feed is scoped to)
+CREATE TABLE calendar_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor VARCHAR(255) NOT NULL UNIQUE CHECK (LENGTH(actor) >= 1),
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    filter JSONB,
+    all_todos BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Reminders table (absolute remind_at, or offset_minutes relative to the due date of the todo)
+CREATE TABLE reminders (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    todo_id UUID NOT NULL REFERENCES todos(id) ON DELETE CASCADE,
+    remind_at TIMESTAMP WITH TIME ZONE,
+    offset_minutes INTEGER,
+    fire_at TIMESTAMP WITH TIME ZONE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'skipped', 'dead')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((remind_at IS NULL) <> (offset_minutes IS NULL))
+);
+
+CREATE INDEX idx_reminders_status_fire_at ON reminders(status, fire_at);
+CREATE INDEX idx_reminders_todo_id ON reminders(todo_id, created_at);
+
+-- Change event ID sequence (shared by all API replicas through LISTEN/NOTIFY)
+CREATE SEQUENCE change_event_id_seq;
+
 -- Function to automatically update updated_at timestamp
 CREATE OR REPLACE FUNCTION update_updated_at_column()
 RETURNS TRIGGER AS $$
@@ -56,6 +169,26 @@ CREATE TRIGGER update_todos_updated_at
     FOR EACH ROW
     EXECUTE FUNCTION update_updated_at_column();
 
+-- Trigger for webhook_subscriptions table
+CREATE TRIGGER update_webhook_subscriptions_updated_at
+    BEFORE UPDATE ON webhook_subscriptions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Function to reject modification of audit events
+CREATE OR REPLACE FUNCTION reject_audit_event_modification()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+-- Trigger for audit_events table
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW
+    EXECUTE FUNCTION reject_audit_event_modification();
+
 -- Insert sample data (optional)
 -- Uncomment the following lines to insert sample data
 
@@ -0,0 +1,298 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/calendartoken"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+// CalendarTokenCreate is the builder for creating a CalendarToken entity.
+type CalendarTokenCreate struct {
+	config
+	mutation *CalendarTokenMutation
+	hooks    []Hook
+}
+
+// SetActor sets the "actor" field.
+func (ctc *CalendarTokenCreate) SetActor(s string) *CalendarTokenCreate {
+	ctc.mutation.SetActor(s)
+	return ctc
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (ctc *CalendarTokenCreate) SetTokenHash(s string) *CalendarTokenCreate {
+	ctc.mutation.SetTokenHash(s)
+	return ctc
+}
+
+// SetFilter sets the "filter" field.
+func (ctc *CalendarTokenCreate) SetFilter(af *types.TodoFilter) *CalendarTokenCreate {
+	ctc.mutation.SetFilter(af)
+	return ctc
+}
+
+// SetAllTodos sets the "all_todos" field.
+func (ctc *CalendarTokenCreate) SetAllTodos(b bool) *CalendarTokenCreate {
+	ctc.mutation.SetAllTodos(b)
+	return ctc
+}
+
+// SetNillableAllTodos sets the "all_todos" field if the given value is not nil.
+func (ctc *CalendarTokenCreate) SetNillableAllTodos(b *bool) *CalendarTokenCreate {
+	if b != nil {
+		ctc.SetAllTodos(*b)
+	}
+	return ctc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (ctc *CalendarTokenCreate) SetCreatedAt(t time.Time) *CalendarTokenCreate {
+	ctc.mutation.SetCreatedAt(t)
+	return ctc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (ctc *CalendarTokenCreate) SetNillableCreatedAt(t *time.Time) *CalendarTokenCreate {
+	if t != nil {
+		ctc.SetCreatedAt(*t)
+	}
+	return ctc
+}
+
+// SetID sets the "id" field.
+func (ctc *CalendarTokenCreate) SetID(u uuid.UUID) *CalendarTokenCreate {
+	ctc.mutation.SetID(u)
+	return ctc
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (ctc *CalendarTokenCreate) SetNillableID(u *uuid.UUID) *CalendarTokenCreate {
+	if u != nil {
+		ctc.SetID(*u)
+	}
+	return ctc
+}
+
+// Mutation returns the CalendarTokenMutation object of the builder.
+func (ctc *CalendarTokenCreate) Mutation() *CalendarTokenMutation {
+	return ctc.mutation
+}
+
+// Save creates the CalendarToken in the database.
+func (ctc *CalendarTokenCreate) Save(ctx context.Context) (*CalendarToken, error) {
+	ctc.defaults()
+	return withHooks(ctx, ctc.sqlSave, ctc.mutation, ctc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (ctc *CalendarTokenCreate) SaveX(ctx context.Context) *CalendarToken {
+	v, err := ctc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ctc *CalendarTokenCreate) Exec(ctx context.Context) error {
+	_, err := ctc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ctc *CalendarTokenCreate) ExecX(ctx context.Context) {
+	if err := ctc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ctc *CalendarTokenCreate) defaults() {
+	if _, ok := ctc.mutation.AllTodos(); !ok {
+		v := calendartoken.DefaultAllTodos
+		ctc.mutation.SetAllTodos(v)
+	}
+	if _, ok := ctc.mutation.CreatedAt(); !ok {
+		v := calendartoken.DefaultCreatedAt()
+		ctc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := ctc.mutation.ID(); !ok {
+		v := calendartoken.DefaultID()
+		ctc.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ctc *CalendarTokenCreate) check() error {
+	if _, ok := ctc.mutation.Actor(); !ok {
+		return &ValidationError{Name: "actor", err: errors.New(`ent: missing required field "CalendarToken.actor"`)}
+	}
+	if v, ok := ctc.mutation.Actor(); ok {
+		if err := calendartoken.ActorValidator(v); err != nil {
+			return &ValidationError{Name: "actor", err: fmt.Errorf(`ent: validator failed for field "CalendarToken.actor": %w`, err)}
+		}
+	}
+	if _, ok := ctc.mutation.TokenHash(); !ok {
+		return &ValidationError{Name: "token_hash", err: errors.New(`ent: missing required field "CalendarToken.token_hash"`)}
+	}
+	if v, ok := ctc.mutation.TokenHash(); ok {
+		if err := calendartoken.TokenHashValidator(v); err != nil {
+			return &ValidationError{Name: "token_hash", err: fmt.Errorf(`ent: validator failed for field "CalendarToken.token_hash": %w`, err)}
+		}
+	}
+	if _, ok := ctc.mutation.AllTodos(); !ok {
+		return &ValidationError{Name: "all_todos", err: errors.New(`ent: missing required field "CalendarToken.all_todos"`)}
+	}
+	if _, ok := ctc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "CalendarToken.created_at"`)}
+	}
+	return nil
+}
+
+func (ctc *CalendarTokenCreate) sqlSave(ctx context.Context) (*CalendarToken, error) {
+	if err := ctc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := ctc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, ctc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	ctc.mutation.id = &_node.ID
+	ctc.mutation.done = true
+	return _node, nil
+}
+
+func (ctc *CalendarTokenCreate) createSpec() (*CalendarToken, *sqlgraph.CreateSpec) {
+	var (
+		_node = &CalendarToken{config: ctc.config}
+		_spec = sqlgraph.NewCreateSpec(calendartoken.Table, sqlgraph.NewFieldSpec(calendartoken.FieldID, field.TypeUUID))
+	)
+	if id, ok := ctc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := ctc.mutation.Actor(); ok {
+		_spec.SetField(calendartoken.FieldActor, field.TypeString, value)
+		_node.Actor = value
+	}
+	if value, ok := ctc.mutation.TokenHash(); ok {
+		_spec.SetField(calendartoken.FieldTokenHash, field.TypeString, value)
+		_node.TokenHash = value
+	}
+	if value, ok := ctc.mutation.Filter(); ok {
+		_spec.SetField(calendartoken.FieldFilter, field.TypeJSON, value)
+		_node.Filter = value
+	}
+	if value, ok := ctc.mutation.AllTodos(); ok {
+		_spec.SetField(calendartoken.FieldAllTodos, field.TypeBool, value)
+		_node.AllTodos = value
+	}
+	if value, ok := ctc.mutation.CreatedAt(); ok {
+		_spec.SetField(calendartoken.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// CalendarTokenCreateBulk is the builder for creating many CalendarToken entities in bulk.
+type CalendarTokenCreateBulk struct {
+	config
+	err      error
+	builders []*CalendarTokenCreate
+}
+
+// Save creates the CalendarToken entities in the database.
+func (ctcb *CalendarTokenCreateBulk) Save(ctx context.Context) ([]*CalendarToken, error) {
+	if ctcb.err != nil {
+		return nil, ctcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(ctcb.builders))
+	nodes := make([]*CalendarToken, len(ctcb.builders))
+	mutators := make([]Mutator, len(ctcb.builders))
+	for i := range ctcb.builders {
+		func(i int, root context.Context) {
+			builder := ctcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*CalendarTokenMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ctcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ctcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ctcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ctcb *CalendarTokenCreateBulk) SaveX(ctx context.Context) []*CalendarToken {
+	v, err := ctcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ctcb *CalendarTokenCreateBulk) Exec(ctx context.Context) error {
+	_, err := ctcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ctcb *CalendarTokenCreateBulk) ExecX(ctx context.Context) {
+	if err := ctcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
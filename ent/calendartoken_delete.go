@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/calendartoken"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/predicate"
+)
+
+// CalendarTokenDelete is the builder for deleting a CalendarToken entity.
+type CalendarTokenDelete struct {
+	config
+	hooks    []Hook
+	mutation *CalendarTokenMutation
+}
+
+// Where appends a list predicates to the CalendarTokenDelete builder.
+func (ctd *CalendarTokenDelete) Where(ps ...predicate.CalendarToken) *CalendarTokenDelete {
+	ctd.mutation.Where(ps...)
+	return ctd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (ctd *CalendarTokenDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, ctd.sqlExec, ctd.mutation, ctd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ctd *CalendarTokenDelete) ExecX(ctx context.Context) int {
+	n, err := ctd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (ctd *CalendarTokenDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(calendartoken.Table, sqlgraph.NewFieldSpec(calendartoken.FieldID, field.TypeUUID))
+	if ps := ctd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, ctd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	ctd.mutation.done = true
+	return affected, err
+}
+
+// CalendarTokenDeleteOne is the builder for deleting a single CalendarToken entity.
+type CalendarTokenDeleteOne struct {
+	ctd *CalendarTokenDelete
+}
+
+// Where appends a list predicates to the CalendarTokenDelete builder.
+func (ctdo *CalendarTokenDeleteOne) Where(ps ...predicate.CalendarToken) *CalendarTokenDeleteOne {
+	ctdo.ctd.mutation.Where(ps...)
+	return ctdo
+}
+
+// Exec executes the deletion query.
+func (ctdo *CalendarTokenDeleteOne) Exec(ctx context.Context) error {
+	n, err := ctdo.ctd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{calendartoken.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ctdo *CalendarTokenDeleteOne) ExecX(ctx context.Context) {
+	if err := ctdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,350 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/calendartoken"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/predicate"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+// CalendarTokenUpdate is the builder for updating CalendarToken entities.
+type CalendarTokenUpdate struct {
+	config
+	hooks    []Hook
+	mutation *CalendarTokenMutation
+}
+
+// Where appends a list predicates to the CalendarTokenUpdate builder.
+func (ctu *CalendarTokenUpdate) Where(ps ...predicate.CalendarToken) *CalendarTokenUpdate {
+	ctu.mutation.Where(ps...)
+	return ctu
+}
+
+// SetActor sets the "actor" field.
+func (ctu *CalendarTokenUpdate) SetActor(s string) *CalendarTokenUpdate {
+	ctu.mutation.SetActor(s)
+	return ctu
+}
+
+// SetNillableActor sets the "actor" field if the given value is not nil.
+func (ctu *CalendarTokenUpdate) SetNillableActor(s *string) *CalendarTokenUpdate {
+	if s != nil {
+		ctu.SetActor(*s)
+	}
+	return ctu
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (ctu *CalendarTokenUpdate) SetTokenHash(s string) *CalendarTokenUpdate {
+	ctu.mutation.SetTokenHash(s)
+	return ctu
+}
+
+// SetNillableTokenHash sets the "token_hash" field if the given value is not nil.
+func (ctu *CalendarTokenUpdate) SetNillableTokenHash(s *string) *CalendarTokenUpdate {
+	if s != nil {
+		ctu.SetTokenHash(*s)
+	}
+	return ctu
+}
+
+// SetFilter sets the "filter" field.
+func (ctu *CalendarTokenUpdate) SetFilter(af *types.TodoFilter) *CalendarTokenUpdate {
+	ctu.mutation.SetFilter(af)
+	return ctu
+}
+
+// ClearFilter clears the value of the "filter" field.
+func (ctu *CalendarTokenUpdate) ClearFilter() *CalendarTokenUpdate {
+	ctu.mutation.ClearFilter()
+	return ctu
+}
+
+// SetAllTodos sets the "all_todos" field.
+func (ctu *CalendarTokenUpdate) SetAllTodos(b bool) *CalendarTokenUpdate {
+	ctu.mutation.SetAllTodos(b)
+	return ctu
+}
+
+// SetNillableAllTodos sets the "all_todos" field if the given value is not nil.
+func (ctu *CalendarTokenUpdate) SetNillableAllTodos(b *bool) *CalendarTokenUpdate {
+	if b != nil {
+		ctu.SetAllTodos(*b)
+	}
+	return ctu
+}
+
+// Mutation returns the CalendarTokenMutation object of the builder.
+func (ctu *CalendarTokenUpdate) Mutation() *CalendarTokenMutation {
+	return ctu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (ctu *CalendarTokenUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, ctu.sqlSave, ctu.mutation, ctu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ctu *CalendarTokenUpdate) SaveX(ctx context.Context) int {
+	affected, err := ctu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (ctu *CalendarTokenUpdate) Exec(ctx context.Context) error {
+	_, err := ctu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ctu *CalendarTokenUpdate) ExecX(ctx context.Context) {
+	if err := ctu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ctu *CalendarTokenUpdate) check() error {
+	if v, ok := ctu.mutation.Actor(); ok {
+		if err := calendartoken.ActorValidator(v); err != nil {
+			return &ValidationError{Name: "actor", err: fmt.Errorf(`ent: validator failed for field "CalendarToken.actor": %w`, err)}
+		}
+	}
+	if v, ok := ctu.mutation.TokenHash(); ok {
+		if err := calendartoken.TokenHashValidator(v); err != nil {
+			return &ValidationError{Name: "token_hash", err: fmt.Errorf(`ent: validator failed for field "CalendarToken.token_hash": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (ctu *CalendarTokenUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := ctu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(calendartoken.Table, calendartoken.Columns, sqlgraph.NewFieldSpec(calendartoken.FieldID, field.TypeUUID))
+	if ps := ctu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ctu.mutation.Actor(); ok {
+		_spec.SetField(calendartoken.FieldActor, field.TypeString, value)
+	}
+	if value, ok := ctu.mutation.TokenHash(); ok {
+		_spec.SetField(calendartoken.FieldTokenHash, field.TypeString, value)
+	}
+	if value, ok := ctu.mutation.Filter(); ok {
+		_spec.SetField(calendartoken.FieldFilter, field.TypeJSON, value)
+	}
+	if ctu.mutation.FilterCleared() {
+		_spec.ClearField(calendartoken.FieldFilter, field.TypeJSON)
+	}
+	if value, ok := ctu.mutation.AllTodos(); ok {
+		_spec.SetField(calendartoken.FieldAllTodos, field.TypeBool, value)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, ctu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{calendartoken.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	ctu.mutation.done = true
+	return n, nil
+}
+
+// CalendarTokenUpdateOne is the builder for updating a single CalendarToken entity.
+type CalendarTokenUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *CalendarTokenMutation
+}
+
+// SetActor sets the "actor" field.
+func (ctuo *CalendarTokenUpdateOne) SetActor(s string) *CalendarTokenUpdateOne {
+	ctuo.mutation.SetActor(s)
+	return ctuo
+}
+
+// SetNillableActor sets the "actor" field if the given value is not nil.
+func (ctuo *CalendarTokenUpdateOne) SetNillableActor(s *string) *CalendarTokenUpdateOne {
+	if s != nil {
+		ctuo.SetActor(*s)
+	}
+	return ctuo
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (ctuo *CalendarTokenUpdateOne) SetTokenHash(s string) *CalendarTokenUpdateOne {
+	ctuo.mutation.SetTokenHash(s)
+	return ctuo
+}
+
+// SetNillableTokenHash sets the "token_hash" field if the given value is not nil.
+func (ctuo *CalendarTokenUpdateOne) SetNillableTokenHash(s *string) *CalendarTokenUpdateOne {
+	if s != nil {
+		ctuo.SetTokenHash(*s)
+	}
+	return ctuo
+}
+
+// SetFilter sets the "filter" field.
+func (ctuo *CalendarTokenUpdateOne) SetFilter(af *types.TodoFilter) *CalendarTokenUpdateOne {
+	ctuo.mutation.SetFilter(af)
+	return ctuo
+}
+
+// ClearFilter clears the value of the "filter" field.
+func (ctuo *CalendarTokenUpdateOne) ClearFilter() *CalendarTokenUpdateOne {
+	ctuo.mutation.ClearFilter()
+	return ctuo
+}
+
+// SetAllTodos sets the "all_todos" field.
+func (ctuo *CalendarTokenUpdateOne) SetAllTodos(b bool) *CalendarTokenUpdateOne {
+	ctuo.mutation.SetAllTodos(b)
+	return ctuo
+}
+
+// SetNillableAllTodos sets the "all_todos" field if the given value is not nil.
+func (ctuo *CalendarTokenUpdateOne) SetNillableAllTodos(b *bool) *CalendarTokenUpdateOne {
+	if b != nil {
+		ctuo.SetAllTodos(*b)
+	}
+	return ctuo
+}
+
+// Mutation returns the CalendarTokenMutation object of the builder.
+func (ctuo *CalendarTokenUpdateOne) Mutation() *CalendarTokenMutation {
+	return ctuo.mutation
+}
+
+// Where appends a list predicates to the CalendarTokenUpdate builder.
+func (ctuo *CalendarTokenUpdateOne) Where(ps ...predicate.CalendarToken) *CalendarTokenUpdateOne {
+	ctuo.mutation.Where(ps...)
+	return ctuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (ctuo *CalendarTokenUpdateOne) Select(field string, fields ...string) *CalendarTokenUpdateOne {
+	ctuo.fields = append([]string{field}, fields...)
+	return ctuo
+}
+
+// Save executes the query and returns the updated CalendarToken entity.
+func (ctuo *CalendarTokenUpdateOne) Save(ctx context.Context) (*CalendarToken, error) {
+	return withHooks(ctx, ctuo.sqlSave, ctuo.mutation, ctuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ctuo *CalendarTokenUpdateOne) SaveX(ctx context.Context) *CalendarToken {
+	node, err := ctuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (ctuo *CalendarTokenUpdateOne) Exec(ctx context.Context) error {
+	_, err := ctuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ctuo *CalendarTokenUpdateOne) ExecX(ctx context.Context) {
+	if err := ctuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ctuo *CalendarTokenUpdateOne) check() error {
+	if v, ok := ctuo.mutation.Actor(); ok {
+		if err := calendartoken.ActorValidator(v); err != nil {
+			return &ValidationError{Name: "actor", err: fmt.Errorf(`ent: validator failed for field "CalendarToken.actor": %w`, err)}
+		}
+	}
+	if v, ok := ctuo.mutation.TokenHash(); ok {
+		if err := calendartoken.TokenHashValidator(v); err != nil {
+			return &ValidationError{Name: "token_hash", err: fmt.Errorf(`ent: validator failed for field "CalendarToken.token_hash": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (ctuo *CalendarTokenUpdateOne) sqlSave(ctx context.Context) (_node *CalendarToken, err error) {
+	if err := ctuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(calendartoken.Table, calendartoken.Columns, sqlgraph.NewFieldSpec(calendartoken.FieldID, field.TypeUUID))
+	id, ok := ctuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "CalendarToken.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := ctuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, calendartoken.FieldID)
+		for _, f := range fields {
+			if !calendartoken.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != calendartoken.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := ctuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ctuo.mutation.Actor(); ok {
+		_spec.SetField(calendartoken.FieldActor, field.TypeString, value)
+	}
+	if value, ok := ctuo.mutation.TokenHash(); ok {
+		_spec.SetField(calendartoken.FieldTokenHash, field.TypeString, value)
+	}
+	if value, ok := ctuo.mutation.Filter(); ok {
+		_spec.SetField(calendartoken.FieldFilter, field.TypeJSON, value)
+	}
+	if ctuo.mutation.FilterCleared() {
+		_spec.ClearField(calendartoken.FieldFilter, field.TypeJSON)
+	}
+	if value, ok := ctuo.mutation.AllTodos(); ok {
+		_spec.SetField(calendartoken.FieldAllTodos, field.TypeBool, value)
+	}
+	_node = &CalendarToken{config: ctuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, ctuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{calendartoken.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	ctuo.mutation.done = true
+	return _node, nil
+}
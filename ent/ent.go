@@ -12,8 +12,14 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/auditevent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/calendartoken"
 	"github.com/t-okuji/go-openapi-todo-demo/ent/category"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/idempotencykey"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/reminder"
 	"github.com/t-okuji/go-openapi-todo-demo/ent/todo"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/webhookdelivery"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/webhooksubscription"
 )
 
 // ent aliases to avoid import conflicts in user's code.
@@ -74,8 +80,14 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			category.Table: category.ValidColumn,
-			todo.Table:     todo.ValidColumn,
+			auditevent.Table:          auditevent.ValidColumn,
+			calendartoken.Table:       calendartoken.ValidColumn,
+			category.Table:            category.ValidColumn,
+			idempotencykey.Table:      idempotencykey.ValidColumn,
+			reminder.Table:            reminder.ValidColumn,
+			todo.Table:                todo.ValidColumn,
+			webhookdelivery.Table:     webhookdelivery.ValidColumn,
+			webhooksubscription.Table: webhooksubscription.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
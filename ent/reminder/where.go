@@ -0,0 +1,530 @@
+// Code generated by ent, DO NOT EDIT.
+
+package reminder
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.Reminder {
+	return predicate.Reminder(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.Reminder {
+	return predicate.Reminder(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.Reminder {
+	return predicate.Reminder(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.Reminder {
+	return predicate.Reminder(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.Reminder {
+	return predicate.Reminder(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.Reminder {
+	return predicate.Reminder(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.Reminder {
+	return predicate.Reminder(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.Reminder {
+	return predicate.Reminder(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.Reminder {
+	return predicate.Reminder(sql.FieldLTE(FieldID, id))
+}
+
+// TodoID applies equality check predicate on the "todo_id" field. It's identical to TodoIDEQ.
+func TodoID(v uuid.UUID) predicate.Reminder {
+	return predicate.Reminder(sql.FieldEQ(FieldTodoID, v))
+}
+
+// RemindAt applies equality check predicate on the "remind_at" field. It's identical to RemindAtEQ.
+func RemindAt(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldEQ(FieldRemindAt, v))
+}
+
+// OffsetMinutes applies equality check predicate on the "offset_minutes" field. It's identical to OffsetMinutesEQ.
+func OffsetMinutes(v int) predicate.Reminder {
+	return predicate.Reminder(sql.FieldEQ(FieldOffsetMinutes, v))
+}
+
+// FireAt applies equality check predicate on the "fire_at" field. It's identical to FireAtEQ.
+func FireAt(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldEQ(FieldFireAt, v))
+}
+
+// Attempts applies equality check predicate on the "attempts" field. It's identical to AttemptsEQ.
+func Attempts(v int) predicate.Reminder {
+	return predicate.Reminder(sql.FieldEQ(FieldAttempts, v))
+}
+
+// LastError applies equality check predicate on the "last_error" field. It's identical to LastErrorEQ.
+func LastError(v string) predicate.Reminder {
+	return predicate.Reminder(sql.FieldEQ(FieldLastError, v))
+}
+
+// SentAt applies equality check predicate on the "sent_at" field. It's identical to SentAtEQ.
+func SentAt(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldEQ(FieldSentAt, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// TodoIDEQ applies the EQ predicate on the "todo_id" field.
+func TodoIDEQ(v uuid.UUID) predicate.Reminder {
+	return predicate.Reminder(sql.FieldEQ(FieldTodoID, v))
+}
+
+// TodoIDNEQ applies the NEQ predicate on the "todo_id" field.
+func TodoIDNEQ(v uuid.UUID) predicate.Reminder {
+	return predicate.Reminder(sql.FieldNEQ(FieldTodoID, v))
+}
+
+// TodoIDIn applies the In predicate on the "todo_id" field.
+func TodoIDIn(vs ...uuid.UUID) predicate.Reminder {
+	return predicate.Reminder(sql.FieldIn(FieldTodoID, vs...))
+}
+
+// TodoIDNotIn applies the NotIn predicate on the "todo_id" field.
+func TodoIDNotIn(vs ...uuid.UUID) predicate.Reminder {
+	return predicate.Reminder(sql.FieldNotIn(FieldTodoID, vs...))
+}
+
+// RemindAtEQ applies the EQ predicate on the "remind_at" field.
+func RemindAtEQ(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldEQ(FieldRemindAt, v))
+}
+
+// RemindAtNEQ applies the NEQ predicate on the "remind_at" field.
+func RemindAtNEQ(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldNEQ(FieldRemindAt, v))
+}
+
+// RemindAtIn applies the In predicate on the "remind_at" field.
+func RemindAtIn(vs ...time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldIn(FieldRemindAt, vs...))
+}
+
+// RemindAtNotIn applies the NotIn predicate on the "remind_at" field.
+func RemindAtNotIn(vs ...time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldNotIn(FieldRemindAt, vs...))
+}
+
+// RemindAtGT applies the GT predicate on the "remind_at" field.
+func RemindAtGT(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldGT(FieldRemindAt, v))
+}
+
+// RemindAtGTE applies the GTE predicate on the "remind_at" field.
+func RemindAtGTE(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldGTE(FieldRemindAt, v))
+}
+
+// RemindAtLT applies the LT predicate on the "remind_at" field.
+func RemindAtLT(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldLT(FieldRemindAt, v))
+}
+
+// RemindAtLTE applies the LTE predicate on the "remind_at" field.
+func RemindAtLTE(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldLTE(FieldRemindAt, v))
+}
+
+// RemindAtIsNil applies the IsNil predicate on the "remind_at" field.
+func RemindAtIsNil() predicate.Reminder {
+	return predicate.Reminder(sql.FieldIsNull(FieldRemindAt))
+}
+
+// RemindAtNotNil applies the NotNil predicate on the "remind_at" field.
+func RemindAtNotNil() predicate.Reminder {
+	return predicate.Reminder(sql.FieldNotNull(FieldRemindAt))
+}
+
+// OffsetMinutesEQ applies the EQ predicate on the "offset_minutes" field.
+func OffsetMinutesEQ(v int) predicate.Reminder {
+	return predicate.Reminder(sql.FieldEQ(FieldOffsetMinutes, v))
+}
+
+// OffsetMinutesNEQ applies the NEQ predicate on the "offset_minutes" field.
+func OffsetMinutesNEQ(v int) predicate.Reminder {
+	return predicate.Reminder(sql.FieldNEQ(FieldOffsetMinutes, v))
+}
+
+// OffsetMinutesIn applies the In predicate on the "offset_minutes" field.
+func OffsetMinutesIn(vs ...int) predicate.Reminder {
+	return predicate.Reminder(sql.FieldIn(FieldOffsetMinutes, vs...))
+}
+
+// OffsetMinutesNotIn applies the NotIn predicate on the "offset_minutes" field.
+func OffsetMinutesNotIn(vs ...int) predicate.Reminder {
+	return predicate.Reminder(sql.FieldNotIn(FieldOffsetMinutes, vs...))
+}
+
+// OffsetMinutesGT applies the GT predicate on the "offset_minutes" field.
+func OffsetMinutesGT(v int) predicate.Reminder {
+	return predicate.Reminder(sql.FieldGT(FieldOffsetMinutes, v))
+}
+
+// OffsetMinutesGTE applies the GTE predicate on the "offset_minutes" field.
+func OffsetMinutesGTE(v int) predicate.Reminder {
+	return predicate.Reminder(sql.FieldGTE(FieldOffsetMinutes, v))
+}
+
+// OffsetMinutesLT applies the LT predicate on the "offset_minutes" field.
+func OffsetMinutesLT(v int) predicate.Reminder {
+	return predicate.Reminder(sql.FieldLT(FieldOffsetMinutes, v))
+}
+
+// OffsetMinutesLTE applies the LTE predicate on the "offset_minutes" field.
+func OffsetMinutesLTE(v int) predicate.Reminder {
+	return predicate.Reminder(sql.FieldLTE(FieldOffsetMinutes, v))
+}
+
+// OffsetMinutesIsNil applies the IsNil predicate on the "offset_minutes" field.
+func OffsetMinutesIsNil() predicate.Reminder {
+	return predicate.Reminder(sql.FieldIsNull(FieldOffsetMinutes))
+}
+
+// OffsetMinutesNotNil applies the NotNil predicate on the "offset_minutes" field.
+func OffsetMinutesNotNil() predicate.Reminder {
+	return predicate.Reminder(sql.FieldNotNull(FieldOffsetMinutes))
+}
+
+// FireAtEQ applies the EQ predicate on the "fire_at" field.
+func FireAtEQ(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldEQ(FieldFireAt, v))
+}
+
+// FireAtNEQ applies the NEQ predicate on the "fire_at" field.
+func FireAtNEQ(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldNEQ(FieldFireAt, v))
+}
+
+// FireAtIn applies the In predicate on the "fire_at" field.
+func FireAtIn(vs ...time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldIn(FieldFireAt, vs...))
+}
+
+// FireAtNotIn applies the NotIn predicate on the "fire_at" field.
+func FireAtNotIn(vs ...time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldNotIn(FieldFireAt, vs...))
+}
+
+// FireAtGT applies the GT predicate on the "fire_at" field.
+func FireAtGT(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldGT(FieldFireAt, v))
+}
+
+// FireAtGTE applies the GTE predicate on the "fire_at" field.
+func FireAtGTE(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldGTE(FieldFireAt, v))
+}
+
+// FireAtLT applies the LT predicate on the "fire_at" field.
+func FireAtLT(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldLT(FieldFireAt, v))
+}
+
+// FireAtLTE applies the LTE predicate on the "fire_at" field.
+func FireAtLTE(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldLTE(FieldFireAt, v))
+}
+
+// FireAtIsNil applies the IsNil predicate on the "fire_at" field.
+func FireAtIsNil() predicate.Reminder {
+	return predicate.Reminder(sql.FieldIsNull(FieldFireAt))
+}
+
+// FireAtNotNil applies the NotNil predicate on the "fire_at" field.
+func FireAtNotNil() predicate.Reminder {
+	return predicate.Reminder(sql.FieldNotNull(FieldFireAt))
+}
+
+// StatusEQ applies the EQ predicate on the "status" field.
+func StatusEQ(v Status) predicate.Reminder {
+	return predicate.Reminder(sql.FieldEQ(FieldStatus, v))
+}
+
+// StatusNEQ applies the NEQ predicate on the "status" field.
+func StatusNEQ(v Status) predicate.Reminder {
+	return predicate.Reminder(sql.FieldNEQ(FieldStatus, v))
+}
+
+// StatusIn applies the In predicate on the "status" field.
+func StatusIn(vs ...Status) predicate.Reminder {
+	return predicate.Reminder(sql.FieldIn(FieldStatus, vs...))
+}
+
+// StatusNotIn applies the NotIn predicate on the "status" field.
+func StatusNotIn(vs ...Status) predicate.Reminder {
+	return predicate.Reminder(sql.FieldNotIn(FieldStatus, vs...))
+}
+
+// AttemptsEQ applies the EQ predicate on the "attempts" field.
+func AttemptsEQ(v int) predicate.Reminder {
+	return predicate.Reminder(sql.FieldEQ(FieldAttempts, v))
+}
+
+// AttemptsNEQ applies the NEQ predicate on the "attempts" field.
+func AttemptsNEQ(v int) predicate.Reminder {
+	return predicate.Reminder(sql.FieldNEQ(FieldAttempts, v))
+}
+
+// AttemptsIn applies the In predicate on the "attempts" field.
+func AttemptsIn(vs ...int) predicate.Reminder {
+	return predicate.Reminder(sql.FieldIn(FieldAttempts, vs...))
+}
+
+// AttemptsNotIn applies the NotIn predicate on the "attempts" field.
+func AttemptsNotIn(vs ...int) predicate.Reminder {
+	return predicate.Reminder(sql.FieldNotIn(FieldAttempts, vs...))
+}
+
+// AttemptsGT applies the GT predicate on the "attempts" field.
+func AttemptsGT(v int) predicate.Reminder {
+	return predicate.Reminder(sql.FieldGT(FieldAttempts, v))
+}
+
+// AttemptsGTE applies the GTE predicate on the "attempts" field.
+func AttemptsGTE(v int) predicate.Reminder {
+	return predicate.Reminder(sql.FieldGTE(FieldAttempts, v))
+}
+
+// AttemptsLT applies the LT predicate on the "attempts" field.
+func AttemptsLT(v int) predicate.Reminder {
+	return predicate.Reminder(sql.FieldLT(FieldAttempts, v))
+}
+
+// AttemptsLTE applies the LTE predicate on the "attempts" field.
+func AttemptsLTE(v int) predicate.Reminder {
+	return predicate.Reminder(sql.FieldLTE(FieldAttempts, v))
+}
+
+// LastErrorEQ applies the EQ predicate on the "last_error" field.
+func LastErrorEQ(v string) predicate.Reminder {
+	return predicate.Reminder(sql.FieldEQ(FieldLastError, v))
+}
+
+// LastErrorNEQ applies the NEQ predicate on the "last_error" field.
+func LastErrorNEQ(v string) predicate.Reminder {
+	return predicate.Reminder(sql.FieldNEQ(FieldLastError, v))
+}
+
+// LastErrorIn applies the In predicate on the "last_error" field.
+func LastErrorIn(vs ...string) predicate.Reminder {
+	return predicate.Reminder(sql.FieldIn(FieldLastError, vs...))
+}
+
+// LastErrorNotIn applies the NotIn predicate on the "last_error" field.
+func LastErrorNotIn(vs ...string) predicate.Reminder {
+	return predicate.Reminder(sql.FieldNotIn(FieldLastError, vs...))
+}
+
+// LastErrorGT applies the GT predicate on the "last_error" field.
+func LastErrorGT(v string) predicate.Reminder {
+	return predicate.Reminder(sql.FieldGT(FieldLastError, v))
+}
+
+// LastErrorGTE applies the GTE predicate on the "last_error" field.
+func LastErrorGTE(v string) predicate.Reminder {
+	return predicate.Reminder(sql.FieldGTE(FieldLastError, v))
+}
+
+// LastErrorLT applies the LT predicate on the "last_error" field.
+func LastErrorLT(v string) predicate.Reminder {
+	return predicate.Reminder(sql.FieldLT(FieldLastError, v))
+}
+
+// LastErrorLTE applies the LTE predicate on the "last_error" field.
+func LastErrorLTE(v string) predicate.Reminder {
+	return predicate.Reminder(sql.FieldLTE(FieldLastError, v))
+}
+
+// LastErrorContains applies the Contains predicate on the "last_error" field.
+func LastErrorContains(v string) predicate.Reminder {
+	return predicate.Reminder(sql.FieldContains(FieldLastError, v))
+}
+
+// LastErrorHasPrefix applies the HasPrefix predicate on the "last_error" field.
+func LastErrorHasPrefix(v string) predicate.Reminder {
+	return predicate.Reminder(sql.FieldHasPrefix(FieldLastError, v))
+}
+
+// LastErrorHasSuffix applies the HasSuffix predicate on the "last_error" field.
+func LastErrorHasSuffix(v string) predicate.Reminder {
+	return predicate.Reminder(sql.FieldHasSuffix(FieldLastError, v))
+}
+
+// LastErrorIsNil applies the IsNil predicate on the "last_error" field.
+func LastErrorIsNil() predicate.Reminder {
+	return predicate.Reminder(sql.FieldIsNull(FieldLastError))
+}
+
+// LastErrorNotNil applies the NotNil predicate on the "last_error" field.
+func LastErrorNotNil() predicate.Reminder {
+	return predicate.Reminder(sql.FieldNotNull(FieldLastError))
+}
+
+// LastErrorEqualFold applies the EqualFold predicate on the "last_error" field.
+func LastErrorEqualFold(v string) predicate.Reminder {
+	return predicate.Reminder(sql.FieldEqualFold(FieldLastError, v))
+}
+
+// LastErrorContainsFold applies the ContainsFold predicate on the "last_error" field.
+func LastErrorContainsFold(v string) predicate.Reminder {
+	return predicate.Reminder(sql.FieldContainsFold(FieldLastError, v))
+}
+
+// SentAtEQ applies the EQ predicate on the "sent_at" field.
+func SentAtEQ(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldEQ(FieldSentAt, v))
+}
+
+// SentAtNEQ applies the NEQ predicate on the "sent_at" field.
+func SentAtNEQ(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldNEQ(FieldSentAt, v))
+}
+
+// SentAtIn applies the In predicate on the "sent_at" field.
+func SentAtIn(vs ...time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldIn(FieldSentAt, vs...))
+}
+
+// SentAtNotIn applies the NotIn predicate on the "sent_at" field.
+func SentAtNotIn(vs ...time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldNotIn(FieldSentAt, vs...))
+}
+
+// SentAtGT applies the GT predicate on the "sent_at" field.
+func SentAtGT(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldGT(FieldSentAt, v))
+}
+
+// SentAtGTE applies the GTE predicate on the "sent_at" field.
+func SentAtGTE(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldGTE(FieldSentAt, v))
+}
+
+// SentAtLT applies the LT predicate on the "sent_at" field.
+func SentAtLT(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldLT(FieldSentAt, v))
+}
+
+// SentAtLTE applies the LTE predicate on the "sent_at" field.
+func SentAtLTE(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldLTE(FieldSentAt, v))
+}
+
+// SentAtIsNil applies the IsNil predicate on the "sent_at" field.
+func SentAtIsNil() predicate.Reminder {
+	return predicate.Reminder(sql.FieldIsNull(FieldSentAt))
+}
+
+// SentAtNotNil applies the NotNil predicate on the "sent_at" field.
+func SentAtNotNil() predicate.Reminder {
+	return predicate.Reminder(sql.FieldNotNull(FieldSentAt))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.Reminder {
+	return predicate.Reminder(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// HasTodo applies the HasEdge predicate on the "todo" edge.
+func HasTodo() predicate.Reminder {
+	return predicate.Reminder(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, TodoTable, TodoColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasTodoWith applies the HasEdge predicate on the "todo" edge with a given conditions (other predicates).
+func HasTodoWith(preds ...predicate.Todo) predicate.Reminder {
+	return predicate.Reminder(func(s *sql.Selector) {
+		step := newTodoStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.Reminder) predicate.Reminder {
+	return predicate.Reminder(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.Reminder) predicate.Reminder {
+	return predicate.Reminder(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.Reminder) predicate.Reminder {
+	return predicate.Reminder(sql.NotPredicates(p))
+}
@@ -0,0 +1,406 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/reminder"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/todo"
+)
+
+// ReminderCreate is the builder for creating a Reminder entity.
+type ReminderCreate struct {
+	config
+	mutation *ReminderMutation
+	hooks    []Hook
+}
+
+// SetTodoID sets the "todo_id" field.
+func (rc *ReminderCreate) SetTodoID(u uuid.UUID) *ReminderCreate {
+	rc.mutation.SetTodoID(u)
+	return rc
+}
+
+// SetRemindAt sets the "remind_at" field.
+func (rc *ReminderCreate) SetRemindAt(t time.Time) *ReminderCreate {
+	rc.mutation.SetRemindAt(t)
+	return rc
+}
+
+// SetNillableRemindAt sets the "remind_at" field if the given value is not nil.
+func (rc *ReminderCreate) SetNillableRemindAt(t *time.Time) *ReminderCreate {
+	if t != nil {
+		rc.SetRemindAt(*t)
+	}
+	return rc
+}
+
+// SetOffsetMinutes sets the "offset_minutes" field.
+func (rc *ReminderCreate) SetOffsetMinutes(i int) *ReminderCreate {
+	rc.mutation.SetOffsetMinutes(i)
+	return rc
+}
+
+// SetNillableOffsetMinutes sets the "offset_minutes" field if the given value is not nil.
+func (rc *ReminderCreate) SetNillableOffsetMinutes(i *int) *ReminderCreate {
+	if i != nil {
+		rc.SetOffsetMinutes(*i)
+	}
+	return rc
+}
+
+// SetFireAt sets the "fire_at" field.
+func (rc *ReminderCreate) SetFireAt(t time.Time) *ReminderCreate {
+	rc.mutation.SetFireAt(t)
+	return rc
+}
+
+// SetNillableFireAt sets the "fire_at" field if the given value is not nil.
+func (rc *ReminderCreate) SetNillableFireAt(t *time.Time) *ReminderCreate {
+	if t != nil {
+		rc.SetFireAt(*t)
+	}
+	return rc
+}
+
+// SetStatus sets the "status" field.
+func (rc *ReminderCreate) SetStatus(r reminder.Status) *ReminderCreate {
+	rc.mutation.SetStatus(r)
+	return rc
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (rc *ReminderCreate) SetNillableStatus(r *reminder.Status) *ReminderCreate {
+	if r != nil {
+		rc.SetStatus(*r)
+	}
+	return rc
+}
+
+// SetAttempts sets the "attempts" field.
+func (rc *ReminderCreate) SetAttempts(i int) *ReminderCreate {
+	rc.mutation.SetAttempts(i)
+	return rc
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (rc *ReminderCreate) SetNillableAttempts(i *int) *ReminderCreate {
+	if i != nil {
+		rc.SetAttempts(*i)
+	}
+	return rc
+}
+
+// SetLastError sets the "last_error" field.
+func (rc *ReminderCreate) SetLastError(s string) *ReminderCreate {
+	rc.mutation.SetLastError(s)
+	return rc
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (rc *ReminderCreate) SetNillableLastError(s *string) *ReminderCreate {
+	if s != nil {
+		rc.SetLastError(*s)
+	}
+	return rc
+}
+
+// SetSentAt sets the "sent_at" field.
+func (rc *ReminderCreate) SetSentAt(t time.Time) *ReminderCreate {
+	rc.mutation.SetSentAt(t)
+	return rc
+}
+
+// SetNillableSentAt sets the "sent_at" field if the given value is not nil.
+func (rc *ReminderCreate) SetNillableSentAt(t *time.Time) *ReminderCreate {
+	if t != nil {
+		rc.SetSentAt(*t)
+	}
+	return rc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (rc *ReminderCreate) SetCreatedAt(t time.Time) *ReminderCreate {
+	rc.mutation.SetCreatedAt(t)
+	return rc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (rc *ReminderCreate) SetNillableCreatedAt(t *time.Time) *ReminderCreate {
+	if t != nil {
+		rc.SetCreatedAt(*t)
+	}
+	return rc
+}
+
+// SetID sets the "id" field.
+func (rc *ReminderCreate) SetID(u uuid.UUID) *ReminderCreate {
+	rc.mutation.SetID(u)
+	return rc
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (rc *ReminderCreate) SetNillableID(u *uuid.UUID) *ReminderCreate {
+	if u != nil {
+		rc.SetID(*u)
+	}
+	return rc
+}
+
+// SetTodo sets the "todo" edge to the Todo entity.
+func (rc *ReminderCreate) SetTodo(t *Todo) *ReminderCreate {
+	return rc.SetTodoID(t.ID)
+}
+
+// Mutation returns the ReminderMutation object of the builder.
+func (rc *ReminderCreate) Mutation() *ReminderMutation {
+	return rc.mutation
+}
+
+// Save creates the Reminder in the database.
+func (rc *ReminderCreate) Save(ctx context.Context) (*Reminder, error) {
+	rc.defaults()
+	return withHooks(ctx, rc.sqlSave, rc.mutation, rc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (rc *ReminderCreate) SaveX(ctx context.Context) *Reminder {
+	v, err := rc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (rc *ReminderCreate) Exec(ctx context.Context) error {
+	_, err := rc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rc *ReminderCreate) ExecX(ctx context.Context) {
+	if err := rc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (rc *ReminderCreate) defaults() {
+	if _, ok := rc.mutation.Status(); !ok {
+		v := reminder.DefaultStatus
+		rc.mutation.SetStatus(v)
+	}
+	if _, ok := rc.mutation.Attempts(); !ok {
+		v := reminder.DefaultAttempts
+		rc.mutation.SetAttempts(v)
+	}
+	if _, ok := rc.mutation.CreatedAt(); !ok {
+		v := reminder.DefaultCreatedAt()
+		rc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := rc.mutation.ID(); !ok {
+		v := reminder.DefaultID()
+		rc.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (rc *ReminderCreate) check() error {
+	if _, ok := rc.mutation.TodoID(); !ok {
+		return &ValidationError{Name: "todo_id", err: errors.New(`ent: missing required field "Reminder.todo_id"`)}
+	}
+	if _, ok := rc.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "Reminder.status"`)}
+	}
+	if v, ok := rc.mutation.Status(); ok {
+		if err := reminder.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Reminder.status": %w`, err)}
+		}
+	}
+	if _, ok := rc.mutation.Attempts(); !ok {
+		return &ValidationError{Name: "attempts", err: errors.New(`ent: missing required field "Reminder.attempts"`)}
+	}
+	if _, ok := rc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Reminder.created_at"`)}
+	}
+	if len(rc.mutation.TodoIDs()) == 0 {
+		return &ValidationError{Name: "todo", err: errors.New(`ent: missing required edge "Reminder.todo"`)}
+	}
+	return nil
+}
+
+func (rc *ReminderCreate) sqlSave(ctx context.Context) (*Reminder, error) {
+	if err := rc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := rc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, rc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	rc.mutation.id = &_node.ID
+	rc.mutation.done = true
+	return _node, nil
+}
+
+func (rc *ReminderCreate) createSpec() (*Reminder, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Reminder{config: rc.config}
+		_spec = sqlgraph.NewCreateSpec(reminder.Table, sqlgraph.NewFieldSpec(reminder.FieldID, field.TypeUUID))
+	)
+	if id, ok := rc.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := rc.mutation.RemindAt(); ok {
+		_spec.SetField(reminder.FieldRemindAt, field.TypeTime, value)
+		_node.RemindAt = &value
+	}
+	if value, ok := rc.mutation.OffsetMinutes(); ok {
+		_spec.SetField(reminder.FieldOffsetMinutes, field.TypeInt, value)
+		_node.OffsetMinutes = &value
+	}
+	if value, ok := rc.mutation.FireAt(); ok {
+		_spec.SetField(reminder.FieldFireAt, field.TypeTime, value)
+		_node.FireAt = &value
+	}
+	if value, ok := rc.mutation.Status(); ok {
+		_spec.SetField(reminder.FieldStatus, field.TypeEnum, value)
+		_node.Status = value
+	}
+	if value, ok := rc.mutation.Attempts(); ok {
+		_spec.SetField(reminder.FieldAttempts, field.TypeInt, value)
+		_node.Attempts = value
+	}
+	if value, ok := rc.mutation.LastError(); ok {
+		_spec.SetField(reminder.FieldLastError, field.TypeString, value)
+		_node.LastError = &value
+	}
+	if value, ok := rc.mutation.SentAt(); ok {
+		_spec.SetField(reminder.FieldSentAt, field.TypeTime, value)
+		_node.SentAt = &value
+	}
+	if value, ok := rc.mutation.CreatedAt(); ok {
+		_spec.SetField(reminder.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := rc.mutation.TodoIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   reminder.TodoTable,
+			Columns: []string{reminder.TodoColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(todo.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.TodoID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// ReminderCreateBulk is the builder for creating many Reminder entities in bulk.
+type ReminderCreateBulk struct {
+	config
+	err      error
+	builders []*ReminderCreate
+}
+
+// Save creates the Reminder entities in the database.
+func (rcb *ReminderCreateBulk) Save(ctx context.Context) ([]*Reminder, error) {
+	if rcb.err != nil {
+		return nil, rcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(rcb.builders))
+	nodes := make([]*Reminder, len(rcb.builders))
+	mutators := make([]Mutator, len(rcb.builders))
+	for i := range rcb.builders {
+		func(i int, root context.Context) {
+			builder := rcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ReminderMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, rcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, rcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, rcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (rcb *ReminderCreateBulk) SaveX(ctx context.Context) []*Reminder {
+	v, err := rcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (rcb *ReminderCreateBulk) Exec(ctx context.Context) error {
+	_, err := rcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (rcb *ReminderCreateBulk) ExecX(ctx context.Context) {
+	if err := rcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,464 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/predicate"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/reminder"
+)
+
+// ReminderUpdate is the builder for updating Reminder entities.
+type ReminderUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ReminderMutation
+}
+
+// Where appends a list predicates to the ReminderUpdate builder.
+func (ru *ReminderUpdate) Where(ps ...predicate.Reminder) *ReminderUpdate {
+	ru.mutation.Where(ps...)
+	return ru
+}
+
+// SetFireAt sets the "fire_at" field.
+func (ru *ReminderUpdate) SetFireAt(t time.Time) *ReminderUpdate {
+	ru.mutation.SetFireAt(t)
+	return ru
+}
+
+// SetNillableFireAt sets the "fire_at" field if the given value is not nil.
+func (ru *ReminderUpdate) SetNillableFireAt(t *time.Time) *ReminderUpdate {
+	if t != nil {
+		ru.SetFireAt(*t)
+	}
+	return ru
+}
+
+// ClearFireAt clears the value of the "fire_at" field.
+func (ru *ReminderUpdate) ClearFireAt() *ReminderUpdate {
+	ru.mutation.ClearFireAt()
+	return ru
+}
+
+// SetStatus sets the "status" field.
+func (ru *ReminderUpdate) SetStatus(r reminder.Status) *ReminderUpdate {
+	ru.mutation.SetStatus(r)
+	return ru
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (ru *ReminderUpdate) SetNillableStatus(r *reminder.Status) *ReminderUpdate {
+	if r != nil {
+		ru.SetStatus(*r)
+	}
+	return ru
+}
+
+// SetAttempts sets the "attempts" field.
+func (ru *ReminderUpdate) SetAttempts(i int) *ReminderUpdate {
+	ru.mutation.ResetAttempts()
+	ru.mutation.SetAttempts(i)
+	return ru
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (ru *ReminderUpdate) SetNillableAttempts(i *int) *ReminderUpdate {
+	if i != nil {
+		ru.SetAttempts(*i)
+	}
+	return ru
+}
+
+// AddAttempts adds i to the "attempts" field.
+func (ru *ReminderUpdate) AddAttempts(i int) *ReminderUpdate {
+	ru.mutation.AddAttempts(i)
+	return ru
+}
+
+// SetLastError sets the "last_error" field.
+func (ru *ReminderUpdate) SetLastError(s string) *ReminderUpdate {
+	ru.mutation.SetLastError(s)
+	return ru
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (ru *ReminderUpdate) SetNillableLastError(s *string) *ReminderUpdate {
+	if s != nil {
+		ru.SetLastError(*s)
+	}
+	return ru
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (ru *ReminderUpdate) ClearLastError() *ReminderUpdate {
+	ru.mutation.ClearLastError()
+	return ru
+}
+
+// SetSentAt sets the "sent_at" field.
+func (ru *ReminderUpdate) SetSentAt(t time.Time) *ReminderUpdate {
+	ru.mutation.SetSentAt(t)
+	return ru
+}
+
+// SetNillableSentAt sets the "sent_at" field if the given value is not nil.
+func (ru *ReminderUpdate) SetNillableSentAt(t *time.Time) *ReminderUpdate {
+	if t != nil {
+		ru.SetSentAt(*t)
+	}
+	return ru
+}
+
+// ClearSentAt clears the value of the "sent_at" field.
+func (ru *ReminderUpdate) ClearSentAt() *ReminderUpdate {
+	ru.mutation.ClearSentAt()
+	return ru
+}
+
+// Mutation returns the ReminderMutation object of the builder.
+func (ru *ReminderUpdate) Mutation() *ReminderMutation {
+	return ru.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (ru *ReminderUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, ru.sqlSave, ru.mutation, ru.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ru *ReminderUpdate) SaveX(ctx context.Context) int {
+	affected, err := ru.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (ru *ReminderUpdate) Exec(ctx context.Context) error {
+	_, err := ru.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ru *ReminderUpdate) ExecX(ctx context.Context) {
+	if err := ru.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ru *ReminderUpdate) check() error {
+	if v, ok := ru.mutation.Status(); ok {
+		if err := reminder.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Reminder.status": %w`, err)}
+		}
+	}
+	if ru.mutation.TodoCleared() && len(ru.mutation.TodoIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Reminder.todo"`)
+	}
+	return nil
+}
+
+func (ru *ReminderUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := ru.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(reminder.Table, reminder.Columns, sqlgraph.NewFieldSpec(reminder.FieldID, field.TypeUUID))
+	if ps := ru.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if ru.mutation.RemindAtCleared() {
+		_spec.ClearField(reminder.FieldRemindAt, field.TypeTime)
+	}
+	if ru.mutation.OffsetMinutesCleared() {
+		_spec.ClearField(reminder.FieldOffsetMinutes, field.TypeInt)
+	}
+	if value, ok := ru.mutation.FireAt(); ok {
+		_spec.SetField(reminder.FieldFireAt, field.TypeTime, value)
+	}
+	if ru.mutation.FireAtCleared() {
+		_spec.ClearField(reminder.FieldFireAt, field.TypeTime)
+	}
+	if value, ok := ru.mutation.Status(); ok {
+		_spec.SetField(reminder.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := ru.mutation.Attempts(); ok {
+		_spec.SetField(reminder.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := ru.mutation.AddedAttempts(); ok {
+		_spec.AddField(reminder.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := ru.mutation.LastError(); ok {
+		_spec.SetField(reminder.FieldLastError, field.TypeString, value)
+	}
+	if ru.mutation.LastErrorCleared() {
+		_spec.ClearField(reminder.FieldLastError, field.TypeString)
+	}
+	if value, ok := ru.mutation.SentAt(); ok {
+		_spec.SetField(reminder.FieldSentAt, field.TypeTime, value)
+	}
+	if ru.mutation.SentAtCleared() {
+		_spec.ClearField(reminder.FieldSentAt, field.TypeTime)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, ru.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{reminder.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	ru.mutation.done = true
+	return n, nil
+}
+
+// ReminderUpdateOne is the builder for updating a single Reminder entity.
+type ReminderUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ReminderMutation
+}
+
+// SetFireAt sets the "fire_at" field.
+func (ruo *ReminderUpdateOne) SetFireAt(t time.Time) *ReminderUpdateOne {
+	ruo.mutation.SetFireAt(t)
+	return ruo
+}
+
+// SetNillableFireAt sets the "fire_at" field if the given value is not nil.
+func (ruo *ReminderUpdateOne) SetNillableFireAt(t *time.Time) *ReminderUpdateOne {
+	if t != nil {
+		ruo.SetFireAt(*t)
+	}
+	return ruo
+}
+
+// ClearFireAt clears the value of the "fire_at" field.
+func (ruo *ReminderUpdateOne) ClearFireAt() *ReminderUpdateOne {
+	ruo.mutation.ClearFireAt()
+	return ruo
+}
+
+// SetStatus sets the "status" field.
+func (ruo *ReminderUpdateOne) SetStatus(r reminder.Status) *ReminderUpdateOne {
+	ruo.mutation.SetStatus(r)
+	return ruo
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (ruo *ReminderUpdateOne) SetNillableStatus(r *reminder.Status) *ReminderUpdateOne {
+	if r != nil {
+		ruo.SetStatus(*r)
+	}
+	return ruo
+}
+
+// SetAttempts sets the "attempts" field.
+func (ruo *ReminderUpdateOne) SetAttempts(i int) *ReminderUpdateOne {
+	ruo.mutation.ResetAttempts()
+	ruo.mutation.SetAttempts(i)
+	return ruo
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (ruo *ReminderUpdateOne) SetNillableAttempts(i *int) *ReminderUpdateOne {
+	if i != nil {
+		ruo.SetAttempts(*i)
+	}
+	return ruo
+}
+
+// AddAttempts adds i to the "attempts" field.
+func (ruo *ReminderUpdateOne) AddAttempts(i int) *ReminderUpdateOne {
+	ruo.mutation.AddAttempts(i)
+	return ruo
+}
+
+// SetLastError sets the "last_error" field.
+func (ruo *ReminderUpdateOne) SetLastError(s string) *ReminderUpdateOne {
+	ruo.mutation.SetLastError(s)
+	return ruo
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (ruo *ReminderUpdateOne) SetNillableLastError(s *string) *ReminderUpdateOne {
+	if s != nil {
+		ruo.SetLastError(*s)
+	}
+	return ruo
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (ruo *ReminderUpdateOne) ClearLastError() *ReminderUpdateOne {
+	ruo.mutation.ClearLastError()
+	return ruo
+}
+
+// SetSentAt sets the "sent_at" field.
+func (ruo *ReminderUpdateOne) SetSentAt(t time.Time) *ReminderUpdateOne {
+	ruo.mutation.SetSentAt(t)
+	return ruo
+}
+
+// SetNillableSentAt sets the "sent_at" field if the given value is not nil.
+func (ruo *ReminderUpdateOne) SetNillableSentAt(t *time.Time) *ReminderUpdateOne {
+	if t != nil {
+		ruo.SetSentAt(*t)
+	}
+	return ruo
+}
+
+// ClearSentAt clears the value of the "sent_at" field.
+func (ruo *ReminderUpdateOne) ClearSentAt() *ReminderUpdateOne {
+	ruo.mutation.ClearSentAt()
+	return ruo
+}
+
+// Mutation returns the ReminderMutation object of the builder.
+func (ruo *ReminderUpdateOne) Mutation() *ReminderMutation {
+	return ruo.mutation
+}
+
+// Where appends a list predicates to the ReminderUpdate builder.
+func (ruo *ReminderUpdateOne) Where(ps ...predicate.Reminder) *ReminderUpdateOne {
+	ruo.mutation.Where(ps...)
+	return ruo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (ruo *ReminderUpdateOne) Select(field string, fields ...string) *ReminderUpdateOne {
+	ruo.fields = append([]string{field}, fields...)
+	return ruo
+}
+
+// Save executes the query and returns the updated Reminder entity.
+func (ruo *ReminderUpdateOne) Save(ctx context.Context) (*Reminder, error) {
+	return withHooks(ctx, ruo.sqlSave, ruo.mutation, ruo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ruo *ReminderUpdateOne) SaveX(ctx context.Context) *Reminder {
+	node, err := ruo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (ruo *ReminderUpdateOne) Exec(ctx context.Context) error {
+	_, err := ruo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ruo *ReminderUpdateOne) ExecX(ctx context.Context) {
+	if err := ruo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ruo *ReminderUpdateOne) check() error {
+	if v, ok := ruo.mutation.Status(); ok {
+		if err := reminder.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Reminder.status": %w`, err)}
+		}
+	}
+	if ruo.mutation.TodoCleared() && len(ruo.mutation.TodoIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Reminder.todo"`)
+	}
+	return nil
+}
+
+func (ruo *ReminderUpdateOne) sqlSave(ctx context.Context) (_node *Reminder, err error) {
+	if err := ruo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(reminder.Table, reminder.Columns, sqlgraph.NewFieldSpec(reminder.FieldID, field.TypeUUID))
+	id, ok := ruo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Reminder.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := ruo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, reminder.FieldID)
+		for _, f := range fields {
+			if !reminder.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != reminder.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := ruo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if ruo.mutation.RemindAtCleared() {
+		_spec.ClearField(reminder.FieldRemindAt, field.TypeTime)
+	}
+	if ruo.mutation.OffsetMinutesCleared() {
+		_spec.ClearField(reminder.FieldOffsetMinutes, field.TypeInt)
+	}
+	if value, ok := ruo.mutation.FireAt(); ok {
+		_spec.SetField(reminder.FieldFireAt, field.TypeTime, value)
+	}
+	if ruo.mutation.FireAtCleared() {
+		_spec.ClearField(reminder.FieldFireAt, field.TypeTime)
+	}
+	if value, ok := ruo.mutation.Status(); ok {
+		_spec.SetField(reminder.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := ruo.mutation.Attempts(); ok {
+		_spec.SetField(reminder.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := ruo.mutation.AddedAttempts(); ok {
+		_spec.AddField(reminder.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := ruo.mutation.LastError(); ok {
+		_spec.SetField(reminder.FieldLastError, field.TypeString, value)
+	}
+	if ruo.mutation.LastErrorCleared() {
+		_spec.ClearField(reminder.FieldLastError, field.TypeString)
+	}
+	if value, ok := ruo.mutation.SentAt(); ok {
+		_spec.SetField(reminder.FieldSentAt, field.TypeTime, value)
+	}
+	if ruo.mutation.SentAtCleared() {
+		_spec.ClearField(reminder.FieldSentAt, field.TypeTime)
+	}
+	_node = &Reminder{config: ruo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, ruo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{reminder.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	ruo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,67 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+// AuditEvent holds the schema definition for the AuditEvent entity.
+// Rows are append-only: they are written by mutation hooks and never updated or deleted.
+// The transaction_id XID8 column is filled in by the database and is only read by the delta sync queries.
+type AuditEvent struct {
+	ent.Schema
+}
+
+// Fields of the AuditEvent.
+func (AuditEvent) Fields() []ent.Field {
+	return []ent.Field{
+		// actor VARCHAR(255) NOT NULL
+		field.String("actor").
+			MaxLen(255).
+			Immutable(),
+
+		// operation VARCHAR(10) NOT NULL CHECK (operation IN ('create', 'update', 'delete'))
+		field.Enum("operation").
+			Values("create", "update", "delete").
+			Immutable(),
+
+		// entity_type VARCHAR(50) NOT NULL
+		field.String("entity_type").
+			MaxLen(50).
+			Immutable(),
+
+		// entity_id UUID NOT NULL
+		field.UUID("entity_id", uuid.UUID{}).
+			Immutable(),
+
+		// changes JSONB NOT NULL
+		field.JSON("changes", map[string]types.FieldChange{}).
+			Immutable(),
+
+		// request_id VARCHAR(255)
+		field.String("request_id").
+			MaxLen(255).
+			Optional().
+			Immutable(),
+
+		// created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Indexes of the AuditEvent.
+func (AuditEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		// CREATE INDEX idx_audit_events_entity ON audit_events(entity_type, entity_id)
+		index.Fields("entity_type", "entity_id"),
+		// CREATE INDEX idx_audit_events_entity_id ON audit_events(entity_id)
+		index.Fields("entity_id"),
+	}
+}
@@ -0,0 +1,51 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+// CalendarToken holds the schema definition for the CalendarToken entity.
+type CalendarToken struct {
+	ent.Schema
+}
+
+// Fields of the CalendarToken.
+func (CalendarToken) Fields() []ent.Field {
+	return []ent.Field{
+		// id UUID PRIMARY KEY DEFAULT gen_random_uuid()
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+
+		// actor VARCHAR(255) NOT NULL UNIQUE
+		field.String("actor").
+			MaxLen(255).
+			NotEmpty().
+			Unique(),
+
+		// token_hash VARCHAR(64) NOT NULL UNIQUE（トークンの SHA-256。トークン自体は保存しない）
+		field.String("token_hash").
+			MaxLen(64).
+			NotEmpty().
+			Unique().
+			Sensitive(),
+
+		// filter JSONB（配信する Todo の絞り込み条件）
+		field.JSON("filter", &types.TodoFilter{}).
+			Optional(),
+
+		// all_todos BOOLEAN NOT NULL DEFAULT FALSE（ワークスペースの全ての Todo を配信するか）
+		field.Bool("all_todos").
+			Default(false),
+
+		// created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
@@ -0,0 +1,84 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// IdempotencyKey holds the schema definition for the IdempotencyKey entity.
+type IdempotencyKey struct {
+	ent.Schema
+}
+
+// Fields of the IdempotencyKey.
+func (IdempotencyKey) Fields() []ent.Field {
+	return []ent.Field{
+		// id UUID PRIMARY KEY DEFAULT gen_random_uuid()
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+
+		// actor VARCHAR(255) NOT NULL DEFAULT ''（キーは操作者ごとに一意）
+		field.String("actor").
+			MaxLen(255).
+			Default("").
+			Immutable(),
+
+		// key VARCHAR(255) NOT NULL
+		field.String("key").
+			MaxLen(255).
+			NotEmpty().
+			Immutable(),
+
+		// fingerprint VARCHAR(64) NOT NULL
+		field.String("fingerprint").
+			MaxLen(64).
+			Immutable(),
+
+		// status VARCHAR NOT NULL DEFAULT 'processing'
+		field.Enum("status").
+			Values("processing", "completed").
+			Default("processing"),
+
+		// locked_until TIMESTAMP WITH TIME ZONE（処理中のキーのロックの期限。過ぎたキーは再試行のリクエストが引き継ぐ）
+		field.Time("locked_until").
+			Optional().
+			Nillable(),
+
+		// response_status INTEGER
+		field.Int("response_status").
+			Optional(),
+
+		// response_content_type VARCHAR(255)
+		field.String("response_content_type").
+			MaxLen(255).
+			Optional(),
+
+		// response_body BYTEA
+		field.Bytes("response_body").
+			Optional(),
+
+		// created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+
+		// expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+		field.Time("expires_at"),
+	}
+}
+
+// Indexes of the IdempotencyKey.
+func (IdempotencyKey) Indexes() []ent.Index {
+	return []ent.Index{
+		// CREATE UNIQUE INDEX idx_idempotency_keys_actor_key ON idempotency_keys(actor, key)
+		index.Fields("actor", "key").
+			Unique(),
+		// CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at)
+		index.Fields("expires_at"),
+	}
+}
@@ -0,0 +1,95 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Reminder holds the schema definition for the Reminder entity.
+// 日時を指定したリマインダー（remind_at）と、期限からの相対時間で指定したリマインダー（offset_minutes）のいずれか
+type Reminder struct {
+	ent.Schema
+}
+
+// Fields of the Reminder.
+func (Reminder) Fields() []ent.Field {
+	return []ent.Field{
+		// id UUID PRIMARY KEY DEFAULT gen_random_uuid()
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+
+		// todo_id UUID NOT NULL REFERENCES todos(id) ON DELETE CASCADE
+		field.UUID("todo_id", uuid.UUID{}).
+			Immutable(),
+
+		// remind_at TIMESTAMP WITH TIME ZONE
+		field.Time("remind_at").
+			Optional().
+			Nillable().
+			Immutable(),
+
+		// offset_minutes INTEGER（期限からの相対時間。負の値は期限より前）
+		field.Int("offset_minutes").
+			Optional().
+			Nillable().
+			Immutable(),
+
+		// fire_at TIMESTAMP WITH TIME ZONE（通知予定日時。期限のない Todo の相対リマインダーは NULL）
+		field.Time("fire_at").
+			Optional().
+			Nillable(),
+
+		// status VARCHAR NOT NULL DEFAULT 'pending'
+		field.Enum("status").
+			Values("pending", "sent", "skipped", "dead").
+			Default("pending"),
+
+		// attempts INTEGER NOT NULL DEFAULT 0
+		field.Int("attempts").
+			Default(0),
+
+		// last_error TEXT
+		field.Text("last_error").
+			Optional().
+			Nillable(),
+
+		// sent_at TIMESTAMP WITH TIME ZONE
+		field.Time("sent_at").
+			Optional().
+			Nillable(),
+
+		// created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Reminder.
+func (Reminder) Edges() []ent.Edge {
+	return []ent.Edge{
+		// todo_id UUID NOT NULL REFERENCES todos(id) ON DELETE CASCADE
+		edge.From("todo", Todo.Type).
+			Ref("reminders").
+			Unique().
+			Required().
+			Immutable().
+			Field("todo_id"),
+	}
+}
+
+// Indexes of the Reminder.
+func (Reminder) Indexes() []ent.Index {
+	return []ent.Index{
+		// CREATE INDEX idx_reminders_status_fire_at ON reminders(status, fire_at)
+		index.Fields("status", "fire_at"),
+		// CREATE INDEX idx_reminders_todo_id ON reminders(todo_id, created_at)
+		index.Fields("todo_id", "created_at"),
+	}
+}
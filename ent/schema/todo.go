@@ -4,8 +4,10 @@ import (
 	"time"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 	"github.com/google/uuid"
 )
 
@@ -34,6 +36,41 @@ func (Todo) Fields() []ent.Field {
 		field.Bool("completed").
 			Default(false),
 
+		// version INTEGER NOT NULL DEFAULT 1
+		field.Int("version").
+			Default(1).
+			Positive(),
+
+		// due_at TIMESTAMP WITH TIME ZONE
+		field.Time("due_at").
+			Optional().
+			Nillable(),
+
+		// recurrence TEXT（RRULE 形式の繰り返しルール）
+		field.Text("recurrence").
+			Optional(),
+
+		// recurrence_time_zone VARCHAR(64)（繰り返しの基準となる IANA のタイムゾーン名）
+		field.String("recurrence_time_zone").
+			MaxLen(64).
+			Optional(),
+
+		// series_id UUID（同じ繰り返しの系列に属する Todo で共通）
+		field.UUID("series_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+
+		// series_start TIMESTAMP WITH TIME ZONE（系列の1回目の日時）
+		field.Time("series_start").
+			Optional().
+			Nillable(),
+
+		// occurrence INTEGER（系列の何回目か）
+		field.Int("occurrence").
+			Optional().
+			Nillable().
+			Positive(),
+
 		// category_id UUID REFERENCES categories(id) ON DELETE SET NULL
 		field.UUID("category_id", uuid.UUID{}).
 			Optional().
@@ -58,5 +95,17 @@ func (Todo) Edges() []ent.Edge {
 		edge.To("category", Category.Type).
 			Unique().
 			Field("category_id"),
+
+		// One-to-many relationship with reminders
+		edge.To("reminders", Reminder.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
+
+// Indexes of the Todo.
+func (Todo) Indexes() []ent.Index {
+	return []ent.Index{
+		// CREATE INDEX idx_todos_series ON todos(series_id, occurrence)
+		index.Fields("series_id", "occurrence"),
 	}
 }
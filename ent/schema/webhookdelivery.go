@@ -0,0 +1,106 @@
+package schema
+
+import (
+	"encoding/json"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// WebhookDelivery holds the schema definition for the WebhookDelivery entity.
+// 送信待ちの Webhook（アウトボックス）と送信結果のログを兼ねる
+type WebhookDelivery struct {
+	ent.Schema
+}
+
+// Fields of the WebhookDelivery.
+func (WebhookDelivery) Fields() []ent.Field {
+	return []ent.Field{
+		// id UUID PRIMARY KEY DEFAULT gen_random_uuid()
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+
+		// subscription_id UUID NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE
+		field.UUID("subscription_id", uuid.UUID{}).
+			Immutable(),
+
+		// event_id UUID NOT NULL
+		field.UUID("event_id", uuid.UUID{}).
+			Immutable(),
+
+		// event_type VARCHAR(50) NOT NULL
+		field.String("event_type").
+			MaxLen(50).
+			Immutable(),
+
+		// payload JSONB NOT NULL
+		field.JSON("payload", json.RawMessage{}).
+			Immutable(),
+
+		// status VARCHAR NOT NULL DEFAULT 'pending'
+		field.Enum("status").
+			Values("pending", "succeeded", "dead").
+			Default("pending"),
+
+		// attempts INTEGER NOT NULL DEFAULT 0
+		field.Int("attempts").
+			Default(0),
+
+		// next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		field.Time("next_attempt_at").
+			Default(time.Now),
+
+		// last_attempt_at TIMESTAMP WITH TIME ZONE
+		field.Time("last_attempt_at").
+			Optional().
+			Nillable(),
+
+		// response_status INTEGER
+		field.Int("response_status").
+			Optional().
+			Nillable(),
+
+		// last_error TEXT
+		field.Text("last_error").
+			Optional().
+			Nillable(),
+
+		// delivered_at TIMESTAMP WITH TIME ZONE
+		field.Time("delivered_at").
+			Optional().
+			Nillable(),
+
+		// created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the WebhookDelivery.
+func (WebhookDelivery) Edges() []ent.Edge {
+	return []ent.Edge{
+		// subscription_id UUID NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE
+		edge.From("subscription", WebhookSubscription.Type).
+			Ref("deliveries").
+			Unique().
+			Required().
+			Immutable().
+			Field("subscription_id"),
+	}
+}
+
+// Indexes of the WebhookDelivery.
+func (WebhookDelivery) Indexes() []ent.Index {
+	return []ent.Index{
+		// CREATE INDEX idx_webhook_deliveries_status_next_attempt_at ON webhook_deliveries(status, next_attempt_at)
+		index.Fields("status", "next_attempt_at"),
+		// CREATE INDEX idx_webhook_deliveries_subscription_id ON webhook_deliveries(subscription_id, created_at)
+		index.Fields("subscription_id", "created_at"),
+	}
+}
@@ -0,0 +1,62 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// WebhookSubscription holds the schema definition for the WebhookSubscription entity.
+type WebhookSubscription struct {
+	ent.Schema
+}
+
+// Fields of the WebhookSubscription.
+func (WebhookSubscription) Fields() []ent.Field {
+	return []ent.Field{
+		// id UUID PRIMARY KEY DEFAULT gen_random_uuid()
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New).
+			StorageKey("id"),
+
+		// url TEXT NOT NULL
+		field.Text("url").
+			NotEmpty(),
+
+		// event_types JSONB NOT NULL
+		field.Strings("event_types"),
+
+		// secret VARCHAR(255) NOT NULL
+		field.String("secret").
+			MaxLen(255).
+			NotEmpty().
+			Sensitive(),
+
+		// active BOOLEAN NOT NULL DEFAULT TRUE
+		field.Bool("active").
+			Default(true),
+
+		// created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+
+		// updated_at TIMESTAMP WITH TIME ZONE
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the WebhookSubscription.
+func (WebhookSubscription) Edges() []ent.Edge {
+	return []ent.Edge{
+		// One-to-many relationship with deliveries
+		edge.To("deliveries", WebhookDelivery.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
+	}
+}
@@ -25,6 +25,20 @@ type Todo struct {
 	Description string `json:"description,omitempty"`
 	// Completed holds the value of the "completed" field.
 	Completed bool `json:"completed,omitempty"`
+	// Version holds the value of the "version" field.
+	Version int `json:"version,omitempty"`
+	// DueAt holds the value of the "due_at" field.
+	DueAt *time.Time `json:"due_at,omitempty"`
+	// Recurrence holds the value of the "recurrence" field.
+	Recurrence string `json:"recurrence,omitempty"`
+	// RecurrenceTimeZone holds the value of the "recurrence_time_zone" field.
+	RecurrenceTimeZone string `json:"recurrence_time_zone,omitempty"`
+	// SeriesID holds the value of the "series_id" field.
+	SeriesID *uuid.UUID `json:"series_id,omitempty"`
+	// SeriesStart holds the value of the "series_start" field.
+	SeriesStart *time.Time `json:"series_start,omitempty"`
+	// Occurrence holds the value of the "occurrence" field.
+	Occurrence *int `json:"occurrence,omitempty"`
 	// CategoryID holds the value of the "category_id" field.
 	CategoryID *uuid.UUID `json:"category_id,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
@@ -41,9 +55,11 @@ type Todo struct {
 type TodoEdges struct {
 	// Category holds the value of the category edge.
 	Category *Category `json:"category,omitempty"`
+	// Reminders holds the value of the reminders edge.
+	Reminders []*Reminder `json:"reminders,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [1]bool
+	loadedTypes [2]bool
 }
 
 // CategoryOrErr returns the Category value or an error if the edge
@@ -57,18 +73,29 @@ func (e TodoEdges) CategoryOrErr() (*Category, error) {
 	return nil, &NotLoadedError{edge: "category"}
 }
 
+// RemindersOrErr returns the Reminders value or an error if the edge
+// was not loaded in eager-loading.
+func (e TodoEdges) RemindersOrErr() ([]*Reminder, error) {
+	if e.loadedTypes[1] {
+		return e.Reminders, nil
+	}
+	return nil, &NotLoadedError{edge: "reminders"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Todo) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case todo.FieldCategoryID:
+		case todo.FieldSeriesID, todo.FieldCategoryID:
 			values[i] = &sql.NullScanner{S: new(uuid.UUID)}
 		case todo.FieldCompleted:
 			values[i] = new(sql.NullBool)
-		case todo.FieldTitle, todo.FieldDescription:
+		case todo.FieldVersion, todo.FieldOccurrence:
+			values[i] = new(sql.NullInt64)
+		case todo.FieldTitle, todo.FieldDescription, todo.FieldRecurrence, todo.FieldRecurrenceTimeZone:
 			values[i] = new(sql.NullString)
-		case todo.FieldCreatedAt, todo.FieldUpdatedAt:
+		case todo.FieldDueAt, todo.FieldSeriesStart, todo.FieldCreatedAt, todo.FieldUpdatedAt:
 			values[i] = new(sql.NullTime)
 		case todo.FieldID:
 			values[i] = new(uuid.UUID)
@@ -111,6 +138,52 @@ func (t *Todo) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				t.Completed = value.Bool
 			}
+		case todo.FieldVersion:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field version", values[i])
+			} else if value.Valid {
+				t.Version = int(value.Int64)
+			}
+		case todo.FieldDueAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field due_at", values[i])
+			} else if value.Valid {
+				t.DueAt = new(time.Time)
+				*t.DueAt = value.Time
+			}
+		case todo.FieldRecurrence:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field recurrence", values[i])
+			} else if value.Valid {
+				t.Recurrence = value.String
+			}
+		case todo.FieldRecurrenceTimeZone:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field recurrence_time_zone", values[i])
+			} else if value.Valid {
+				t.RecurrenceTimeZone = value.String
+			}
+		case todo.FieldSeriesID:
+			if value, ok := values[i].(*sql.NullScanner); !ok {
+				return fmt.Errorf("unexpected type %T for field series_id", values[i])
+			} else if value.Valid {
+				t.SeriesID = new(uuid.UUID)
+				*t.SeriesID = *value.S.(*uuid.UUID)
+			}
+		case todo.FieldSeriesStart:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field series_start", values[i])
+			} else if value.Valid {
+				t.SeriesStart = new(time.Time)
+				*t.SeriesStart = value.Time
+			}
+		case todo.FieldOccurrence:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field occurrence", values[i])
+			} else if value.Valid {
+				t.Occurrence = new(int)
+				*t.Occurrence = int(value.Int64)
+			}
 		case todo.FieldCategoryID:
 			if value, ok := values[i].(*sql.NullScanner); !ok {
 				return fmt.Errorf("unexpected type %T for field category_id", values[i])
@@ -148,6 +221,11 @@ func (t *Todo) QueryCategory() *CategoryQuery {
 	return NewTodoClient(t.config).QueryCategory(t)
 }
 
+// QueryReminders queries the "reminders" edge of the Todo entity.
+func (t *Todo) QueryReminders() *ReminderQuery {
+	return NewTodoClient(t.config).QueryReminders(t)
+}
+
 // Update returns a builder for updating this Todo.
 // Note that you need to call Todo.Unwrap() before calling this method if this Todo
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -180,6 +258,35 @@ func (t *Todo) String() string {
 	builder.WriteString("completed=")
 	builder.WriteString(fmt.Sprintf("%v", t.Completed))
 	builder.WriteString(", ")
+	builder.WriteString("version=")
+	builder.WriteString(fmt.Sprintf("%v", t.Version))
+	builder.WriteString(", ")
+	if v := t.DueAt; v != nil {
+		builder.WriteString("due_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("recurrence=")
+	builder.WriteString(t.Recurrence)
+	builder.WriteString(", ")
+	builder.WriteString("recurrence_time_zone=")
+	builder.WriteString(t.RecurrenceTimeZone)
+	builder.WriteString(", ")
+	if v := t.SeriesID; v != nil {
+		builder.WriteString("series_id=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := t.SeriesStart; v != nil {
+		builder.WriteString("series_start=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := t.Occurrence; v != nil {
+		builder.WriteString("occurrence=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
 	if v := t.CategoryID; v != nil {
 		builder.WriteString("category_id=")
 		builder.WriteString(fmt.Sprintf("%v", *v))
@@ -71,6 +71,41 @@ func Completed(v bool) predicate.Todo {
 	return predicate.Todo(sql.FieldEQ(FieldCompleted, v))
 }
 
+// Version applies equality check predicate on the "version" field. It's identical to VersionEQ.
+func Version(v int) predicate.Todo {
+	return predicate.Todo(sql.FieldEQ(FieldVersion, v))
+}
+
+// DueAt applies equality check predicate on the "due_at" field. It's identical to DueAtEQ.
+func DueAt(v time.Time) predicate.Todo {
+	return predicate.Todo(sql.FieldEQ(FieldDueAt, v))
+}
+
+// Recurrence applies equality check predicate on the "recurrence" field. It's identical to RecurrenceEQ.
+func Recurrence(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldEQ(FieldRecurrence, v))
+}
+
+// RecurrenceTimeZone applies equality check predicate on the "recurrence_time_zone" field. It's identical to RecurrenceTimeZoneEQ.
+func RecurrenceTimeZone(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldEQ(FieldRecurrenceTimeZone, v))
+}
+
+// SeriesID applies equality check predicate on the "series_id" field. It's identical to SeriesIDEQ.
+func SeriesID(v uuid.UUID) predicate.Todo {
+	return predicate.Todo(sql.FieldEQ(FieldSeriesID, v))
+}
+
+// SeriesStart applies equality check predicate on the "series_start" field. It's identical to SeriesStartEQ.
+func SeriesStart(v time.Time) predicate.Todo {
+	return predicate.Todo(sql.FieldEQ(FieldSeriesStart, v))
+}
+
+// Occurrence applies equality check predicate on the "occurrence" field. It's identical to OccurrenceEQ.
+func Occurrence(v int) predicate.Todo {
+	return predicate.Todo(sql.FieldEQ(FieldOccurrence, v))
+}
+
 // CategoryID applies equality check predicate on the "category_id" field. It's identical to CategoryIDEQ.
 func CategoryID(v uuid.UUID) predicate.Todo {
 	return predicate.Todo(sql.FieldEQ(FieldCategoryID, v))
@@ -236,6 +271,396 @@ func CompletedNEQ(v bool) predicate.Todo {
 	return predicate.Todo(sql.FieldNEQ(FieldCompleted, v))
 }
 
+// VersionEQ applies the EQ predicate on the "version" field.
+func VersionEQ(v int) predicate.Todo {
+	return predicate.Todo(sql.FieldEQ(FieldVersion, v))
+}
+
+// VersionNEQ applies the NEQ predicate on the "version" field.
+func VersionNEQ(v int) predicate.Todo {
+	return predicate.Todo(sql.FieldNEQ(FieldVersion, v))
+}
+
+// VersionIn applies the In predicate on the "version" field.
+func VersionIn(vs ...int) predicate.Todo {
+	return predicate.Todo(sql.FieldIn(FieldVersion, vs...))
+}
+
+// VersionNotIn applies the NotIn predicate on the "version" field.
+func VersionNotIn(vs ...int) predicate.Todo {
+	return predicate.Todo(sql.FieldNotIn(FieldVersion, vs...))
+}
+
+// VersionGT applies the GT predicate on the "version" field.
+func VersionGT(v int) predicate.Todo {
+	return predicate.Todo(sql.FieldGT(FieldVersion, v))
+}
+
+// VersionGTE applies the GTE predicate on the "version" field.
+func VersionGTE(v int) predicate.Todo {
+	return predicate.Todo(sql.FieldGTE(FieldVersion, v))
+}
+
+// VersionLT applies the LT predicate on the "version" field.
+func VersionLT(v int) predicate.Todo {
+	return predicate.Todo(sql.FieldLT(FieldVersion, v))
+}
+
+// VersionLTE applies the LTE predicate on the "version" field.
+func VersionLTE(v int) predicate.Todo {
+	return predicate.Todo(sql.FieldLTE(FieldVersion, v))
+}
+
+// DueAtEQ applies the EQ predicate on the "due_at" field.
+func DueAtEQ(v time.Time) predicate.Todo {
+	return predicate.Todo(sql.FieldEQ(FieldDueAt, v))
+}
+
+// DueAtNEQ applies the NEQ predicate on the "due_at" field.
+func DueAtNEQ(v time.Time) predicate.Todo {
+	return predicate.Todo(sql.FieldNEQ(FieldDueAt, v))
+}
+
+// DueAtIn applies the In predicate on the "due_at" field.
+func DueAtIn(vs ...time.Time) predicate.Todo {
+	return predicate.Todo(sql.FieldIn(FieldDueAt, vs...))
+}
+
+// DueAtNotIn applies the NotIn predicate on the "due_at" field.
+func DueAtNotIn(vs ...time.Time) predicate.Todo {
+	return predicate.Todo(sql.FieldNotIn(FieldDueAt, vs...))
+}
+
+// DueAtGT applies the GT predicate on the "due_at" field.
+func DueAtGT(v time.Time) predicate.Todo {
+	return predicate.Todo(sql.FieldGT(FieldDueAt, v))
+}
+
+// DueAtGTE applies the GTE predicate on the "due_at" field.
+func DueAtGTE(v time.Time) predicate.Todo {
+	return predicate.Todo(sql.FieldGTE(FieldDueAt, v))
+}
+
+// DueAtLT applies the LT predicate on the "due_at" field.
+func DueAtLT(v time.Time) predicate.Todo {
+	return predicate.Todo(sql.FieldLT(FieldDueAt, v))
+}
+
+// DueAtLTE applies the LTE predicate on the "due_at" field.
+func DueAtLTE(v time.Time) predicate.Todo {
+	return predicate.Todo(sql.FieldLTE(FieldDueAt, v))
+}
+
+// DueAtIsNil applies the IsNil predicate on the "due_at" field.
+func DueAtIsNil() predicate.Todo {
+	return predicate.Todo(sql.FieldIsNull(FieldDueAt))
+}
+
+// DueAtNotNil applies the NotNil predicate on the "due_at" field.
+func DueAtNotNil() predicate.Todo {
+	return predicate.Todo(sql.FieldNotNull(FieldDueAt))
+}
+
+// RecurrenceEQ applies the EQ predicate on the "recurrence" field.
+func RecurrenceEQ(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldEQ(FieldRecurrence, v))
+}
+
+// RecurrenceNEQ applies the NEQ predicate on the "recurrence" field.
+func RecurrenceNEQ(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldNEQ(FieldRecurrence, v))
+}
+
+// RecurrenceIn applies the In predicate on the "recurrence" field.
+func RecurrenceIn(vs ...string) predicate.Todo {
+	return predicate.Todo(sql.FieldIn(FieldRecurrence, vs...))
+}
+
+// RecurrenceNotIn applies the NotIn predicate on the "recurrence" field.
+func RecurrenceNotIn(vs ...string) predicate.Todo {
+	return predicate.Todo(sql.FieldNotIn(FieldRecurrence, vs...))
+}
+
+// RecurrenceGT applies the GT predicate on the "recurrence" field.
+func RecurrenceGT(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldGT(FieldRecurrence, v))
+}
+
+// RecurrenceGTE applies the GTE predicate on the "recurrence" field.
+func RecurrenceGTE(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldGTE(FieldRecurrence, v))
+}
+
+// RecurrenceLT applies the LT predicate on the "recurrence" field.
+func RecurrenceLT(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldLT(FieldRecurrence, v))
+}
+
+// RecurrenceLTE applies the LTE predicate on the "recurrence" field.
+func RecurrenceLTE(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldLTE(FieldRecurrence, v))
+}
+
+// RecurrenceContains applies the Contains predicate on the "recurrence" field.
+func RecurrenceContains(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldContains(FieldRecurrence, v))
+}
+
+// RecurrenceHasPrefix applies the HasPrefix predicate on the "recurrence" field.
+func RecurrenceHasPrefix(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldHasPrefix(FieldRecurrence, v))
+}
+
+// RecurrenceHasSuffix applies the HasSuffix predicate on the "recurrence" field.
+func RecurrenceHasSuffix(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldHasSuffix(FieldRecurrence, v))
+}
+
+// RecurrenceIsNil applies the IsNil predicate on the "recurrence" field.
+func RecurrenceIsNil() predicate.Todo {
+	return predicate.Todo(sql.FieldIsNull(FieldRecurrence))
+}
+
+// RecurrenceNotNil applies the NotNil predicate on the "recurrence" field.
+func RecurrenceNotNil() predicate.Todo {
+	return predicate.Todo(sql.FieldNotNull(FieldRecurrence))
+}
+
+// RecurrenceEqualFold applies the EqualFold predicate on the "recurrence" field.
+func RecurrenceEqualFold(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldEqualFold(FieldRecurrence, v))
+}
+
+// RecurrenceContainsFold applies the ContainsFold predicate on the "recurrence" field.
+func RecurrenceContainsFold(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldContainsFold(FieldRecurrence, v))
+}
+
+// RecurrenceTimeZoneEQ applies the EQ predicate on the "recurrence_time_zone" field.
+func RecurrenceTimeZoneEQ(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldEQ(FieldRecurrenceTimeZone, v))
+}
+
+// RecurrenceTimeZoneNEQ applies the NEQ predicate on the "recurrence_time_zone" field.
+func RecurrenceTimeZoneNEQ(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldNEQ(FieldRecurrenceTimeZone, v))
+}
+
+// RecurrenceTimeZoneIn applies the In predicate on the "recurrence_time_zone" field.
+func RecurrenceTimeZoneIn(vs ...string) predicate.Todo {
+	return predicate.Todo(sql.FieldIn(FieldRecurrenceTimeZone, vs...))
+}
+
+// RecurrenceTimeZoneNotIn applies the NotIn predicate on the "recurrence_time_zone" field.
+func RecurrenceTimeZoneNotIn(vs ...string) predicate.Todo {
+	return predicate.Todo(sql.FieldNotIn(FieldRecurrenceTimeZone, vs...))
+}
+
+// RecurrenceTimeZoneGT applies the GT predicate on the "recurrence_time_zone" field.
+func RecurrenceTimeZoneGT(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldGT(FieldRecurrenceTimeZone, v))
+}
+
+// RecurrenceTimeZoneGTE applies the GTE predicate on the "recurrence_time_zone" field.
+func RecurrenceTimeZoneGTE(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldGTE(FieldRecurrenceTimeZone, v))
+}
+
+// RecurrenceTimeZoneLT applies the LT predicate on the "recurrence_time_zone" field.
+func RecurrenceTimeZoneLT(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldLT(FieldRecurrenceTimeZone, v))
+}
+
+// RecurrenceTimeZoneLTE applies the LTE predicate on the "recurrence_time_zone" field.
+func RecurrenceTimeZoneLTE(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldLTE(FieldRecurrenceTimeZone, v))
+}
+
+// RecurrenceTimeZoneContains applies the Contains predicate on the "recurrence_time_zone" field.
+func RecurrenceTimeZoneContains(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldContains(FieldRecurrenceTimeZone, v))
+}
+
+// RecurrenceTimeZoneHasPrefix applies the HasPrefix predicate on the "recurrence_time_zone" field.
+func RecurrenceTimeZoneHasPrefix(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldHasPrefix(FieldRecurrenceTimeZone, v))
+}
+
+// RecurrenceTimeZoneHasSuffix applies the HasSuffix predicate on the "recurrence_time_zone" field.
+func RecurrenceTimeZoneHasSuffix(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldHasSuffix(FieldRecurrenceTimeZone, v))
+}
+
+// RecurrenceTimeZoneIsNil applies the IsNil predicate on the "recurrence_time_zone" field.
+func RecurrenceTimeZoneIsNil() predicate.Todo {
+	return predicate.Todo(sql.FieldIsNull(FieldRecurrenceTimeZone))
+}
+
+// RecurrenceTimeZoneNotNil applies the NotNil predicate on the "recurrence_time_zone" field.
+func RecurrenceTimeZoneNotNil() predicate.Todo {
+	return predicate.Todo(sql.FieldNotNull(FieldRecurrenceTimeZone))
+}
+
+// RecurrenceTimeZoneEqualFold applies the EqualFold predicate on the "recurrence_time_zone" field.
+func RecurrenceTimeZoneEqualFold(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldEqualFold(FieldRecurrenceTimeZone, v))
+}
+
+// RecurrenceTimeZoneContainsFold applies the ContainsFold predicate on the "recurrence_time_zone" field.
+func RecurrenceTimeZoneContainsFold(v string) predicate.Todo {
+	return predicate.Todo(sql.FieldContainsFold(FieldRecurrenceTimeZone, v))
+}
+
+// SeriesIDEQ applies the EQ predicate on the "series_id" field.
+func SeriesIDEQ(v uuid.UUID) predicate.Todo {
+	return predicate.Todo(sql.FieldEQ(FieldSeriesID, v))
+}
+
+// SeriesIDNEQ applies the NEQ predicate on the "series_id" field.
+func SeriesIDNEQ(v uuid.UUID) predicate.Todo {
+	return predicate.Todo(sql.FieldNEQ(FieldSeriesID, v))
+}
+
+// SeriesIDIn applies the In predicate on the "series_id" field.
+func SeriesIDIn(vs ...uuid.UUID) predicate.Todo {
+	return predicate.Todo(sql.FieldIn(FieldSeriesID, vs...))
+}
+
+// SeriesIDNotIn applies the NotIn predicate on the "series_id" field.
+func SeriesIDNotIn(vs ...uuid.UUID) predicate.Todo {
+	return predicate.Todo(sql.FieldNotIn(FieldSeriesID, vs...))
+}
+
+// SeriesIDGT applies the GT predicate on the "series_id" field.
+func SeriesIDGT(v uuid.UUID) predicate.Todo {
+	return predicate.Todo(sql.FieldGT(FieldSeriesID, v))
+}
+
+// SeriesIDGTE applies the GTE predicate on the "series_id" field.
+func SeriesIDGTE(v uuid.UUID) predicate.Todo {
+	return predicate.Todo(sql.FieldGTE(FieldSeriesID, v))
+}
+
+// SeriesIDLT applies the LT predicate on the "series_id" field.
+func SeriesIDLT(v uuid.UUID) predicate.Todo {
+	return predicate.Todo(sql.FieldLT(FieldSeriesID, v))
+}
+
+// SeriesIDLTE applies the LTE predicate on the "series_id" field.
+func SeriesIDLTE(v uuid.UUID) predicate.Todo {
+	return predicate.Todo(sql.FieldLTE(FieldSeriesID, v))
+}
+
+// SeriesIDIsNil applies the IsNil predicate on the "series_id" field.
+func SeriesIDIsNil() predicate.Todo {
+	return predicate.Todo(sql.FieldIsNull(FieldSeriesID))
+}
+
+// SeriesIDNotNil applies the NotNil predicate on the "series_id" field.
+func SeriesIDNotNil() predicate.Todo {
+	return predicate.Todo(sql.FieldNotNull(FieldSeriesID))
+}
+
+// SeriesStartEQ applies the EQ predicate on the "series_start" field.
+func SeriesStartEQ(v time.Time) predicate.Todo {
+	return predicate.Todo(sql.FieldEQ(FieldSeriesStart, v))
+}
+
+// SeriesStartNEQ applies the NEQ predicate on the "series_start" field.
+func SeriesStartNEQ(v time.Time) predicate.Todo {
+	return predicate.Todo(sql.FieldNEQ(FieldSeriesStart, v))
+}
+
+// SeriesStartIn applies the In predicate on the "series_start" field.
+func SeriesStartIn(vs ...time.Time) predicate.Todo {
+	return predicate.Todo(sql.FieldIn(FieldSeriesStart, vs...))
+}
+
+// SeriesStartNotIn applies the NotIn predicate on the "series_start" field.
+func SeriesStartNotIn(vs ...time.Time) predicate.Todo {
+	return predicate.Todo(sql.FieldNotIn(FieldSeriesStart, vs...))
+}
+
+// SeriesStartGT applies the GT predicate on the "series_start" field.
+func SeriesStartGT(v time.Time) predicate.Todo {
+	return predicate.Todo(sql.FieldGT(FieldSeriesStart, v))
+}
+
+// SeriesStartGTE applies the GTE predicate on the "series_start" field.
+func SeriesStartGTE(v time.Time) predicate.Todo {
+	return predicate.Todo(sql.FieldGTE(FieldSeriesStart, v))
+}
+
+// SeriesStartLT applies the LT predicate on the "series_start" field.
+func SeriesStartLT(v time.Time) predicate.Todo {
+	return predicate.Todo(sql.FieldLT(FieldSeriesStart, v))
+}
+
+// SeriesStartLTE applies the LTE predicate on the "series_start" field.
+func SeriesStartLTE(v time.Time) predicate.Todo {
+	return predicate.Todo(sql.FieldLTE(FieldSeriesStart, v))
+}
+
+// SeriesStartIsNil applies the IsNil predicate on the "series_start" field.
+func SeriesStartIsNil() predicate.Todo {
+	return predicate.Todo(sql.FieldIsNull(FieldSeriesStart))
+}
+
+// SeriesStartNotNil applies the NotNil predicate on the "series_start" field.
+func SeriesStartNotNil() predicate.Todo {
+	return predicate.Todo(sql.FieldNotNull(FieldSeriesStart))
+}
+
+// OccurrenceEQ applies the EQ predicate on the "occurrence" field.
+func OccurrenceEQ(v int) predicate.Todo {
+	return predicate.Todo(sql.FieldEQ(FieldOccurrence, v))
+}
+
+// OccurrenceNEQ applies the NEQ predicate on the "occurrence" field.
+func OccurrenceNEQ(v int) predicate.Todo {
+	return predicate.Todo(sql.FieldNEQ(FieldOccurrence, v))
+}
+
+// OccurrenceIn applies the In predicate on the "occurrence" field.
+func OccurrenceIn(vs ...int) predicate.Todo {
+	return predicate.Todo(sql.FieldIn(FieldOccurrence, vs...))
+}
+
+// OccurrenceNotIn applies the NotIn predicate on the "occurrence" field.
+func OccurrenceNotIn(vs ...int) predicate.Todo {
+	return predicate.Todo(sql.FieldNotIn(FieldOccurrence, vs...))
+}
+
+// OccurrenceGT applies the GT predicate on the "occurrence" field.
+func OccurrenceGT(v int) predicate.Todo {
+	return predicate.Todo(sql.FieldGT(FieldOccurrence, v))
+}
+
+// OccurrenceGTE applies the GTE predicate on the "occurrence" field.
+func OccurrenceGTE(v int) predicate.Todo {
+	return predicate.Todo(sql.FieldGTE(FieldOccurrence, v))
+}
+
+// OccurrenceLT applies the LT predicate on the "occurrence" field.
+func OccurrenceLT(v int) predicate.Todo {
+	return predicate.Todo(sql.FieldLT(FieldOccurrence, v))
+}
+
+// OccurrenceLTE applies the LTE predicate on the "occurrence" field.
+func OccurrenceLTE(v int) predicate.Todo {
+	return predicate.Todo(sql.FieldLTE(FieldOccurrence, v))
+}
+
+// OccurrenceIsNil applies the IsNil predicate on the "occurrence" field.
+func OccurrenceIsNil() predicate.Todo {
+	return predicate.Todo(sql.FieldIsNull(FieldOccurrence))
+}
+
+// OccurrenceNotNil applies the NotNil predicate on the "occurrence" field.
+func OccurrenceNotNil() predicate.Todo {
+	return predicate.Todo(sql.FieldNotNull(FieldOccurrence))
+}
+
 // CategoryIDEQ applies the EQ predicate on the "category_id" field.
 func CategoryIDEQ(v uuid.UUID) predicate.Todo {
 	return predicate.Todo(sql.FieldEQ(FieldCategoryID, v))
@@ -369,6 +794,29 @@ func HasCategoryWith(preds ...predicate.Category) predicate.Todo {
 	})
 }
 
+// HasReminders applies the HasEdge predicate on the "reminders" edge.
+func HasReminders() predicate.Todo {
+	return predicate.Todo(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, RemindersTable, RemindersColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasRemindersWith applies the HasEdge predicate on the "reminders" edge with a given conditions (other predicates).
+func HasRemindersWith(preds ...predicate.Reminder) predicate.Todo {
+	return predicate.Todo(func(s *sql.Selector) {
+		step := newRemindersStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Todo) predicate.Todo {
 	return predicate.Todo(sql.AndPredicates(predicates...))
@@ -12,6 +12,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"github.com/google/uuid"
 	"github.com/t-okuji/go-openapi-todo-demo/ent/category"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/reminder"
 	"github.com/t-okuji/go-openapi-todo-demo/ent/todo"
 )
 
@@ -56,6 +57,104 @@ func (tc *TodoCreate) SetNillableCompleted(b *bool) *TodoCreate {
 	return tc
 }
 
+// SetVersion sets the "version" field.
+func (tc *TodoCreate) SetVersion(i int) *TodoCreate {
+	tc.mutation.SetVersion(i)
+	return tc
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (tc *TodoCreate) SetNillableVersion(i *int) *TodoCreate {
+	if i != nil {
+		tc.SetVersion(*i)
+	}
+	return tc
+}
+
+// SetDueAt sets the "due_at" field.
+func (tc *TodoCreate) SetDueAt(t time.Time) *TodoCreate {
+	tc.mutation.SetDueAt(t)
+	return tc
+}
+
+// SetNillableDueAt sets the "due_at" field if the given value is not nil.
+func (tc *TodoCreate) SetNillableDueAt(t *time.Time) *TodoCreate {
+	if t != nil {
+		tc.SetDueAt(*t)
+	}
+	return tc
+}
+
+// SetRecurrence sets the "recurrence" field.
+func (tc *TodoCreate) SetRecurrence(s string) *TodoCreate {
+	tc.mutation.SetRecurrence(s)
+	return tc
+}
+
+// SetNillableRecurrence sets the "recurrence" field if the given value is not nil.
+func (tc *TodoCreate) SetNillableRecurrence(s *string) *TodoCreate {
+	if s != nil {
+		tc.SetRecurrence(*s)
+	}
+	return tc
+}
+
+// SetRecurrenceTimeZone sets the "recurrence_time_zone" field.
+func (tc *TodoCreate) SetRecurrenceTimeZone(s string) *TodoCreate {
+	tc.mutation.SetRecurrenceTimeZone(s)
+	return tc
+}
+
+// SetNillableRecurrenceTimeZone sets the "recurrence_time_zone" field if the given value is not nil.
+func (tc *TodoCreate) SetNillableRecurrenceTimeZone(s *string) *TodoCreate {
+	if s != nil {
+		tc.SetRecurrenceTimeZone(*s)
+	}
+	return tc
+}
+
+// SetSeriesID sets the "series_id" field.
+func (tc *TodoCreate) SetSeriesID(u uuid.UUID) *TodoCreate {
+	tc.mutation.SetSeriesID(u)
+	return tc
+}
+
+// SetNillableSeriesID sets the "series_id" field if the given value is not nil.
+func (tc *TodoCreate) SetNillableSeriesID(u *uuid.UUID) *TodoCreate {
+	if u != nil {
+		tc.SetSeriesID(*u)
+	}
+	return tc
+}
+
+// SetSeriesStart sets the "series_start" field.
+func (tc *TodoCreate) SetSeriesStart(t time.Time) *TodoCreate {
+	tc.mutation.SetSeriesStart(t)
+	return tc
+}
+
+// SetNillableSeriesStart sets the "series_start" field if the given value is not nil.
+func (tc *TodoCreate) SetNillableSeriesStart(t *time.Time) *TodoCreate {
+	if t != nil {
+		tc.SetSeriesStart(*t)
+	}
+	return tc
+}
+
+// SetOccurrence sets the "occurrence" field.
+func (tc *TodoCreate) SetOccurrence(i int) *TodoCreate {
+	tc.mutation.SetOccurrence(i)
+	return tc
+}
+
+// SetNillableOccurrence sets the "occurrence" field if the given value is not nil.
+func (tc *TodoCreate) SetNillableOccurrence(i *int) *TodoCreate {
+	if i != nil {
+		tc.SetOccurrence(*i)
+	}
+	return tc
+}
+
 // SetCategoryID sets the "category_id" field.
 func (tc *TodoCreate) SetCategoryID(u uuid.UUID) *TodoCreate {
 	tc.mutation.SetCategoryID(u)
@@ -117,6 +216,21 @@ func (tc *TodoCreate) SetCategory(c *Category) *TodoCreate {
 	return tc.SetCategoryID(c.ID)
 }
 
+// AddReminderIDs adds the "reminders" edge to the Reminder entity by IDs.
+func (tc *TodoCreate) AddReminderIDs(ids ...uuid.UUID) *TodoCreate {
+	tc.mutation.AddReminderIDs(ids...)
+	return tc
+}
+
+// AddReminders adds the "reminders" edges to the Reminder entity.
+func (tc *TodoCreate) AddReminders(r ...*Reminder) *TodoCreate {
+	ids := make([]uuid.UUID, len(r))
+	for i := range r {
+		ids[i] = r[i].ID
+	}
+	return tc.AddReminderIDs(ids...)
+}
+
 // Mutation returns the TodoMutation object of the builder.
 func (tc *TodoCreate) Mutation() *TodoMutation {
 	return tc.mutation
@@ -156,6 +270,10 @@ func (tc *TodoCreate) defaults() {
 		v := todo.DefaultCompleted
 		tc.mutation.SetCompleted(v)
 	}
+	if _, ok := tc.mutation.Version(); !ok {
+		v := todo.DefaultVersion
+		tc.mutation.SetVersion(v)
+	}
 	if _, ok := tc.mutation.CreatedAt(); !ok {
 		v := todo.DefaultCreatedAt()
 		tc.mutation.SetCreatedAt(v)
@@ -183,6 +301,24 @@ func (tc *TodoCreate) check() error {
 	if _, ok := tc.mutation.Completed(); !ok {
 		return &ValidationError{Name: "completed", err: errors.New(`ent: missing required field "Todo.completed"`)}
 	}
+	if _, ok := tc.mutation.Version(); !ok {
+		return &ValidationError{Name: "version", err: errors.New(`ent: missing required field "Todo.version"`)}
+	}
+	if v, ok := tc.mutation.Version(); ok {
+		if err := todo.VersionValidator(v); err != nil {
+			return &ValidationError{Name: "version", err: fmt.Errorf(`ent: validator failed for field "Todo.version": %w`, err)}
+		}
+	}
+	if v, ok := tc.mutation.RecurrenceTimeZone(); ok {
+		if err := todo.RecurrenceTimeZoneValidator(v); err != nil {
+			return &ValidationError{Name: "recurrence_time_zone", err: fmt.Errorf(`ent: validator failed for field "Todo.recurrence_time_zone": %w`, err)}
+		}
+	}
+	if v, ok := tc.mutation.Occurrence(); ok {
+		if err := todo.OccurrenceValidator(v); err != nil {
+			return &ValidationError{Name: "occurrence", err: fmt.Errorf(`ent: validator failed for field "Todo.occurrence": %w`, err)}
+		}
+	}
 	if _, ok := tc.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Todo.created_at"`)}
 	}
@@ -236,6 +372,34 @@ func (tc *TodoCreate) createSpec() (*Todo, *sqlgraph.CreateSpec) {
 		_spec.SetField(todo.FieldCompleted, field.TypeBool, value)
 		_node.Completed = value
 	}
+	if value, ok := tc.mutation.Version(); ok {
+		_spec.SetField(todo.FieldVersion, field.TypeInt, value)
+		_node.Version = value
+	}
+	if value, ok := tc.mutation.DueAt(); ok {
+		_spec.SetField(todo.FieldDueAt, field.TypeTime, value)
+		_node.DueAt = &value
+	}
+	if value, ok := tc.mutation.Recurrence(); ok {
+		_spec.SetField(todo.FieldRecurrence, field.TypeString, value)
+		_node.Recurrence = value
+	}
+	if value, ok := tc.mutation.RecurrenceTimeZone(); ok {
+		_spec.SetField(todo.FieldRecurrenceTimeZone, field.TypeString, value)
+		_node.RecurrenceTimeZone = value
+	}
+	if value, ok := tc.mutation.SeriesID(); ok {
+		_spec.SetField(todo.FieldSeriesID, field.TypeUUID, value)
+		_node.SeriesID = &value
+	}
+	if value, ok := tc.mutation.SeriesStart(); ok {
+		_spec.SetField(todo.FieldSeriesStart, field.TypeTime, value)
+		_node.SeriesStart = &value
+	}
+	if value, ok := tc.mutation.Occurrence(); ok {
+		_spec.SetField(todo.FieldOccurrence, field.TypeInt, value)
+		_node.Occurrence = &value
+	}
 	if value, ok := tc.mutation.CreatedAt(); ok {
 		_spec.SetField(todo.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
@@ -261,6 +425,22 @@ func (tc *TodoCreate) createSpec() (*Todo, *sqlgraph.CreateSpec) {
 		_node.CategoryID = &nodes[0]
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := tc.mutation.RemindersIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.RemindersTable,
+			Columns: []string{todo.RemindersColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(reminder.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
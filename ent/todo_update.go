@@ -14,6 +14,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/t-okuji/go-openapi-todo-demo/ent/category"
 	"github.com/t-okuji/go-openapi-todo-demo/ent/predicate"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/reminder"
 	"github.com/t-okuji/go-openapi-todo-demo/ent/todo"
 )
 
@@ -78,6 +79,154 @@ func (tu *TodoUpdate) SetNillableCompleted(b *bool) *TodoUpdate {
 	return tu
 }
 
+// SetVersion sets the "version" field.
+func (tu *TodoUpdate) SetVersion(i int) *TodoUpdate {
+	tu.mutation.ResetVersion()
+	tu.mutation.SetVersion(i)
+	return tu
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (tu *TodoUpdate) SetNillableVersion(i *int) *TodoUpdate {
+	if i != nil {
+		tu.SetVersion(*i)
+	}
+	return tu
+}
+
+// AddVersion adds i to the "version" field.
+func (tu *TodoUpdate) AddVersion(i int) *TodoUpdate {
+	tu.mutation.AddVersion(i)
+	return tu
+}
+
+// SetDueAt sets the "due_at" field.
+func (tu *TodoUpdate) SetDueAt(t time.Time) *TodoUpdate {
+	tu.mutation.SetDueAt(t)
+	return tu
+}
+
+// SetNillableDueAt sets the "due_at" field if the given value is not nil.
+func (tu *TodoUpdate) SetNillableDueAt(t *time.Time) *TodoUpdate {
+	if t != nil {
+		tu.SetDueAt(*t)
+	}
+	return tu
+}
+
+// ClearDueAt clears the value of the "due_at" field.
+func (tu *TodoUpdate) ClearDueAt() *TodoUpdate {
+	tu.mutation.ClearDueAt()
+	return tu
+}
+
+// SetRecurrence sets the "recurrence" field.
+func (tu *TodoUpdate) SetRecurrence(s string) *TodoUpdate {
+	tu.mutation.SetRecurrence(s)
+	return tu
+}
+
+// SetNillableRecurrence sets the "recurrence" field if the given value is not nil.
+func (tu *TodoUpdate) SetNillableRecurrence(s *string) *TodoUpdate {
+	if s != nil {
+		tu.SetRecurrence(*s)
+	}
+	return tu
+}
+
+// ClearRecurrence clears the value of the "recurrence" field.
+func (tu *TodoUpdate) ClearRecurrence() *TodoUpdate {
+	tu.mutation.ClearRecurrence()
+	return tu
+}
+
+// SetRecurrenceTimeZone sets the "recurrence_time_zone" field.
+func (tu *TodoUpdate) SetRecurrenceTimeZone(s string) *TodoUpdate {
+	tu.mutation.SetRecurrenceTimeZone(s)
+	return tu
+}
+
+// SetNillableRecurrenceTimeZone sets the "recurrence_time_zone" field if the given value is not nil.
+func (tu *TodoUpdate) SetNillableRecurrenceTimeZone(s *string) *TodoUpdate {
+	if s != nil {
+		tu.SetRecurrenceTimeZone(*s)
+	}
+	return tu
+}
+
+// ClearRecurrenceTimeZone clears the value of the "recurrence_time_zone" field.
+func (tu *TodoUpdate) ClearRecurrenceTimeZone() *TodoUpdate {
+	tu.mutation.ClearRecurrenceTimeZone()
+	return tu
+}
+
+// SetSeriesID sets the "series_id" field.
+func (tu *TodoUpdate) SetSeriesID(u uuid.UUID) *TodoUpdate {
+	tu.mutation.SetSeriesID(u)
+	return tu
+}
+
+// SetNillableSeriesID sets the "series_id" field if the given value is not nil.
+func (tu *TodoUpdate) SetNillableSeriesID(u *uuid.UUID) *TodoUpdate {
+	if u != nil {
+		tu.SetSeriesID(*u)
+	}
+	return tu
+}
+
+// ClearSeriesID clears the value of the "series_id" field.
+func (tu *TodoUpdate) ClearSeriesID() *TodoUpdate {
+	tu.mutation.ClearSeriesID()
+	return tu
+}
+
+// SetSeriesStart sets the "series_start" field.
+func (tu *TodoUpdate) SetSeriesStart(t time.Time) *TodoUpdate {
+	tu.mutation.SetSeriesStart(t)
+	return tu
+}
+
+// SetNillableSeriesStart sets the "series_start" field if the given value is not nil.
+func (tu *TodoUpdate) SetNillableSeriesStart(t *time.Time) *TodoUpdate {
+	if t != nil {
+		tu.SetSeriesStart(*t)
+	}
+	return tu
+}
+
+// ClearSeriesStart clears the value of the "series_start" field.
+func (tu *TodoUpdate) ClearSeriesStart() *TodoUpdate {
+	tu.mutation.ClearSeriesStart()
+	return tu
+}
+
+// SetOccurrence sets the "occurrence" field.
+func (tu *TodoUpdate) SetOccurrence(i int) *TodoUpdate {
+	tu.mutation.ResetOccurrence()
+	tu.mutation.SetOccurrence(i)
+	return tu
+}
+
+// SetNillableOccurrence sets the "occurrence" field if the given value is not nil.
+func (tu *TodoUpdate) SetNillableOccurrence(i *int) *TodoUpdate {
+	if i != nil {
+		tu.SetOccurrence(*i)
+	}
+	return tu
+}
+
+// AddOccurrence adds i to the "occurrence" field.
+func (tu *TodoUpdate) AddOccurrence(i int) *TodoUpdate {
+	tu.mutation.AddOccurrence(i)
+	return tu
+}
+
+// ClearOccurrence clears the value of the "occurrence" field.
+func (tu *TodoUpdate) ClearOccurrence() *TodoUpdate {
+	tu.mutation.ClearOccurrence()
+	return tu
+}
+
 // SetCategoryID sets the "category_id" field.
 func (tu *TodoUpdate) SetCategoryID(u uuid.UUID) *TodoUpdate {
 	tu.mutation.SetCategoryID(u)
@@ -109,6 +258,21 @@ func (tu *TodoUpdate) SetCategory(c *Category) *TodoUpdate {
 	return tu.SetCategoryID(c.ID)
 }
 
+// AddReminderIDs adds the "reminders" edge to the Reminder entity by IDs.
+func (tu *TodoUpdate) AddReminderIDs(ids ...uuid.UUID) *TodoUpdate {
+	tu.mutation.AddReminderIDs(ids...)
+	return tu
+}
+
+// AddReminders adds the "reminders" edges to the Reminder entity.
+func (tu *TodoUpdate) AddReminders(r ...*Reminder) *TodoUpdate {
+	ids := make([]uuid.UUID, len(r))
+	for i := range r {
+		ids[i] = r[i].ID
+	}
+	return tu.AddReminderIDs(ids...)
+}
+
 // Mutation returns the TodoMutation object of the builder.
 func (tu *TodoUpdate) Mutation() *TodoMutation {
 	return tu.mutation
@@ -120,6 +284,27 @@ func (tu *TodoUpdate) ClearCategory() *TodoUpdate {
 	return tu
 }
 
+// ClearReminders clears all "reminders" edges to the Reminder entity.
+func (tu *TodoUpdate) ClearReminders() *TodoUpdate {
+	tu.mutation.ClearReminders()
+	return tu
+}
+
+// RemoveReminderIDs removes the "reminders" edge to Reminder entities by IDs.
+func (tu *TodoUpdate) RemoveReminderIDs(ids ...uuid.UUID) *TodoUpdate {
+	tu.mutation.RemoveReminderIDs(ids...)
+	return tu
+}
+
+// RemoveReminders removes "reminders" edges to Reminder entities.
+func (tu *TodoUpdate) RemoveReminders(r ...*Reminder) *TodoUpdate {
+	ids := make([]uuid.UUID, len(r))
+	for i := range r {
+		ids[i] = r[i].ID
+	}
+	return tu.RemoveReminderIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (tu *TodoUpdate) Save(ctx context.Context) (int, error) {
 	tu.defaults()
@@ -163,6 +348,21 @@ func (tu *TodoUpdate) check() error {
 			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Todo.title": %w`, err)}
 		}
 	}
+	if v, ok := tu.mutation.Version(); ok {
+		if err := todo.VersionValidator(v); err != nil {
+			return &ValidationError{Name: "version", err: fmt.Errorf(`ent: validator failed for field "Todo.version": %w`, err)}
+		}
+	}
+	if v, ok := tu.mutation.RecurrenceTimeZone(); ok {
+		if err := todo.RecurrenceTimeZoneValidator(v); err != nil {
+			return &ValidationError{Name: "recurrence_time_zone", err: fmt.Errorf(`ent: validator failed for field "Todo.recurrence_time_zone": %w`, err)}
+		}
+	}
+	if v, ok := tu.mutation.Occurrence(); ok {
+		if err := todo.OccurrenceValidator(v); err != nil {
+			return &ValidationError{Name: "occurrence", err: fmt.Errorf(`ent: validator failed for field "Todo.occurrence": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -190,6 +390,51 @@ func (tu *TodoUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if value, ok := tu.mutation.Completed(); ok {
 		_spec.SetField(todo.FieldCompleted, field.TypeBool, value)
 	}
+	if value, ok := tu.mutation.Version(); ok {
+		_spec.SetField(todo.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := tu.mutation.AddedVersion(); ok {
+		_spec.AddField(todo.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := tu.mutation.DueAt(); ok {
+		_spec.SetField(todo.FieldDueAt, field.TypeTime, value)
+	}
+	if tu.mutation.DueAtCleared() {
+		_spec.ClearField(todo.FieldDueAt, field.TypeTime)
+	}
+	if value, ok := tu.mutation.Recurrence(); ok {
+		_spec.SetField(todo.FieldRecurrence, field.TypeString, value)
+	}
+	if tu.mutation.RecurrenceCleared() {
+		_spec.ClearField(todo.FieldRecurrence, field.TypeString)
+	}
+	if value, ok := tu.mutation.RecurrenceTimeZone(); ok {
+		_spec.SetField(todo.FieldRecurrenceTimeZone, field.TypeString, value)
+	}
+	if tu.mutation.RecurrenceTimeZoneCleared() {
+		_spec.ClearField(todo.FieldRecurrenceTimeZone, field.TypeString)
+	}
+	if value, ok := tu.mutation.SeriesID(); ok {
+		_spec.SetField(todo.FieldSeriesID, field.TypeUUID, value)
+	}
+	if tu.mutation.SeriesIDCleared() {
+		_spec.ClearField(todo.FieldSeriesID, field.TypeUUID)
+	}
+	if value, ok := tu.mutation.SeriesStart(); ok {
+		_spec.SetField(todo.FieldSeriesStart, field.TypeTime, value)
+	}
+	if tu.mutation.SeriesStartCleared() {
+		_spec.ClearField(todo.FieldSeriesStart, field.TypeTime)
+	}
+	if value, ok := tu.mutation.Occurrence(); ok {
+		_spec.SetField(todo.FieldOccurrence, field.TypeInt, value)
+	}
+	if value, ok := tu.mutation.AddedOccurrence(); ok {
+		_spec.AddField(todo.FieldOccurrence, field.TypeInt, value)
+	}
+	if tu.mutation.OccurrenceCleared() {
+		_spec.ClearField(todo.FieldOccurrence, field.TypeInt)
+	}
 	if value, ok := tu.mutation.UpdatedAt(); ok {
 		_spec.SetField(todo.FieldUpdatedAt, field.TypeTime, value)
 	}
@@ -222,6 +467,51 @@ func (tu *TodoUpdate) sqlSave(ctx context.Context) (n int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if tu.mutation.RemindersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.RemindersTable,
+			Columns: []string{todo.RemindersColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(reminder.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := tu.mutation.RemovedRemindersIDs(); len(nodes) > 0 && !tu.mutation.RemindersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.RemindersTable,
+			Columns: []string{todo.RemindersColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(reminder.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := tu.mutation.RemindersIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.RemindersTable,
+			Columns: []string{todo.RemindersColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(reminder.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if n, err = sqlgraph.UpdateNodes(ctx, tu.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{todo.Label}
@@ -290,6 +580,154 @@ func (tuo *TodoUpdateOne) SetNillableCompleted(b *bool) *TodoUpdateOne {
 	return tuo
 }
 
+// SetVersion sets the "version" field.
+func (tuo *TodoUpdateOne) SetVersion(i int) *TodoUpdateOne {
+	tuo.mutation.ResetVersion()
+	tuo.mutation.SetVersion(i)
+	return tuo
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (tuo *TodoUpdateOne) SetNillableVersion(i *int) *TodoUpdateOne {
+	if i != nil {
+		tuo.SetVersion(*i)
+	}
+	return tuo
+}
+
+// AddVersion adds i to the "version" field.
+func (tuo *TodoUpdateOne) AddVersion(i int) *TodoUpdateOne {
+	tuo.mutation.AddVersion(i)
+	return tuo
+}
+
+// SetDueAt sets the "due_at" field.
+func (tuo *TodoUpdateOne) SetDueAt(t time.Time) *TodoUpdateOne {
+	tuo.mutation.SetDueAt(t)
+	return tuo
+}
+
+// SetNillableDueAt sets the "due_at" field if the given value is not nil.
+func (tuo *TodoUpdateOne) SetNillableDueAt(t *time.Time) *TodoUpdateOne {
+	if t != nil {
+		tuo.SetDueAt(*t)
+	}
+	return tuo
+}
+
+// ClearDueAt clears the value of the "due_at" field.
+func (tuo *TodoUpdateOne) ClearDueAt() *TodoUpdateOne {
+	tuo.mutation.ClearDueAt()
+	return tuo
+}
+
+// SetRecurrence sets the "recurrence" field.
+func (tuo *TodoUpdateOne) SetRecurrence(s string) *TodoUpdateOne {
+	tuo.mutation.SetRecurrence(s)
+	return tuo
+}
+
+// SetNillableRecurrence sets the "recurrence" field if the given value is not nil.
+func (tuo *TodoUpdateOne) SetNillableRecurrence(s *string) *TodoUpdateOne {
+	if s != nil {
+		tuo.SetRecurrence(*s)
+	}
+	return tuo
+}
+
+// ClearRecurrence clears the value of the "recurrence" field.
+func (tuo *TodoUpdateOne) ClearRecurrence() *TodoUpdateOne {
+	tuo.mutation.ClearRecurrence()
+	return tuo
+}
+
+// SetRecurrenceTimeZone sets the "recurrence_time_zone" field.
+func (tuo *TodoUpdateOne) SetRecurrenceTimeZone(s string) *TodoUpdateOne {
+	tuo.mutation.SetRecurrenceTimeZone(s)
+	return tuo
+}
+
+// SetNillableRecurrenceTimeZone sets the "recurrence_time_zone" field if the given value is not nil.
+func (tuo *TodoUpdateOne) SetNillableRecurrenceTimeZone(s *string) *TodoUpdateOne {
+	if s != nil {
+		tuo.SetRecurrenceTimeZone(*s)
+	}
+	return tuo
+}
+
+// ClearRecurrenceTimeZone clears the value of the "recurrence_time_zone" field.
+func (tuo *TodoUpdateOne) ClearRecurrenceTimeZone() *TodoUpdateOne {
+	tuo.mutation.ClearRecurrenceTimeZone()
+	return tuo
+}
+
+// SetSeriesID sets the "series_id" field.
+func (tuo *TodoUpdateOne) SetSeriesID(u uuid.UUID) *TodoUpdateOne {
+	tuo.mutation.SetSeriesID(u)
+	return tuo
+}
+
+// SetNillableSeriesID sets the "series_id" field if the given value is not nil.
+func (tuo *TodoUpdateOne) SetNillableSeriesID(u *uuid.UUID) *TodoUpdateOne {
+	if u != nil {
+		tuo.SetSeriesID(*u)
+	}
+	return tuo
+}
+
+// ClearSeriesID clears the value of the "series_id" field.
+func (tuo *TodoUpdateOne) ClearSeriesID() *TodoUpdateOne {
+	tuo.mutation.ClearSeriesID()
+	return tuo
+}
+
+// SetSeriesStart sets the "series_start" field.
+func (tuo *TodoUpdateOne) SetSeriesStart(t time.Time) *TodoUpdateOne {
+	tuo.mutation.SetSeriesStart(t)
+	return tuo
+}
+
+// SetNillableSeriesStart sets the "series_start" field if the given value is not nil.
+func (tuo *TodoUpdateOne) SetNillableSeriesStart(t *time.Time) *TodoUpdateOne {
+	if t != nil {
+		tuo.SetSeriesStart(*t)
+	}
+	return tuo
+}
+
+// ClearSeriesStart clears the value of the "series_start" field.
+func (tuo *TodoUpdateOne) ClearSeriesStart() *TodoUpdateOne {
+	tuo.mutation.ClearSeriesStart()
+	return tuo
+}
+
+// SetOccurrence sets the "occurrence" field.
+func (tuo *TodoUpdateOne) SetOccurrence(i int) *TodoUpdateOne {
+	tuo.mutation.ResetOccurrence()
+	tuo.mutation.SetOccurrence(i)
+	return tuo
+}
+
+// SetNillableOccurrence sets the "occurrence" field if the given value is not nil.
+func (tuo *TodoUpdateOne) SetNillableOccurrence(i *int) *TodoUpdateOne {
+	if i != nil {
+		tuo.SetOccurrence(*i)
+	}
+	return tuo
+}
+
+// AddOccurrence adds i to the "occurrence" field.
+func (tuo *TodoUpdateOne) AddOccurrence(i int) *TodoUpdateOne {
+	tuo.mutation.AddOccurrence(i)
+	return tuo
+}
+
+// ClearOccurrence clears the value of the "occurrence" field.
+func (tuo *TodoUpdateOne) ClearOccurrence() *TodoUpdateOne {
+	tuo.mutation.ClearOccurrence()
+	return tuo
+}
+
 // SetCategoryID sets the "category_id" field.
 func (tuo *TodoUpdateOne) SetCategoryID(u uuid.UUID) *TodoUpdateOne {
 	tuo.mutation.SetCategoryID(u)
@@ -321,6 +759,21 @@ func (tuo *TodoUpdateOne) SetCategory(c *Category) *TodoUpdateOne {
 	return tuo.SetCategoryID(c.ID)
 }
 
+// AddReminderIDs adds the "reminders" edge to the Reminder entity by IDs.
+func (tuo *TodoUpdateOne) AddReminderIDs(ids ...uuid.UUID) *TodoUpdateOne {
+	tuo.mutation.AddReminderIDs(ids...)
+	return tuo
+}
+
+// AddReminders adds the "reminders" edges to the Reminder entity.
+func (tuo *TodoUpdateOne) AddReminders(r ...*Reminder) *TodoUpdateOne {
+	ids := make([]uuid.UUID, len(r))
+	for i := range r {
+		ids[i] = r[i].ID
+	}
+	return tuo.AddReminderIDs(ids...)
+}
+
 // Mutation returns the TodoMutation object of the builder.
 func (tuo *TodoUpdateOne) Mutation() *TodoMutation {
 	return tuo.mutation
@@ -332,6 +785,27 @@ func (tuo *TodoUpdateOne) ClearCategory() *TodoUpdateOne {
 	return tuo
 }
 
+// ClearReminders clears all "reminders" edges to the Reminder entity.
+func (tuo *TodoUpdateOne) ClearReminders() *TodoUpdateOne {
+	tuo.mutation.ClearReminders()
+	return tuo
+}
+
+// RemoveReminderIDs removes the "reminders" edge to Reminder entities by IDs.
+func (tuo *TodoUpdateOne) RemoveReminderIDs(ids ...uuid.UUID) *TodoUpdateOne {
+	tuo.mutation.RemoveReminderIDs(ids...)
+	return tuo
+}
+
+// RemoveReminders removes "reminders" edges to Reminder entities.
+func (tuo *TodoUpdateOne) RemoveReminders(r ...*Reminder) *TodoUpdateOne {
+	ids := make([]uuid.UUID, len(r))
+	for i := range r {
+		ids[i] = r[i].ID
+	}
+	return tuo.RemoveReminderIDs(ids...)
+}
+
 // Where appends a list predicates to the TodoUpdate builder.
 func (tuo *TodoUpdateOne) Where(ps ...predicate.Todo) *TodoUpdateOne {
 	tuo.mutation.Where(ps...)
@@ -388,6 +862,21 @@ func (tuo *TodoUpdateOne) check() error {
 			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Todo.title": %w`, err)}
 		}
 	}
+	if v, ok := tuo.mutation.Version(); ok {
+		if err := todo.VersionValidator(v); err != nil {
+			return &ValidationError{Name: "version", err: fmt.Errorf(`ent: validator failed for field "Todo.version": %w`, err)}
+		}
+	}
+	if v, ok := tuo.mutation.RecurrenceTimeZone(); ok {
+		if err := todo.RecurrenceTimeZoneValidator(v); err != nil {
+			return &ValidationError{Name: "recurrence_time_zone", err: fmt.Errorf(`ent: validator failed for field "Todo.recurrence_time_zone": %w`, err)}
+		}
+	}
+	if v, ok := tuo.mutation.Occurrence(); ok {
+		if err := todo.OccurrenceValidator(v); err != nil {
+			return &ValidationError{Name: "occurrence", err: fmt.Errorf(`ent: validator failed for field "Todo.occurrence": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -432,6 +921,51 @@ func (tuo *TodoUpdateOne) sqlSave(ctx context.Context) (_node *Todo, err error)
 	if value, ok := tuo.mutation.Completed(); ok {
 		_spec.SetField(todo.FieldCompleted, field.TypeBool, value)
 	}
+	if value, ok := tuo.mutation.Version(); ok {
+		_spec.SetField(todo.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := tuo.mutation.AddedVersion(); ok {
+		_spec.AddField(todo.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := tuo.mutation.DueAt(); ok {
+		_spec.SetField(todo.FieldDueAt, field.TypeTime, value)
+	}
+	if tuo.mutation.DueAtCleared() {
+		_spec.ClearField(todo.FieldDueAt, field.TypeTime)
+	}
+	if value, ok := tuo.mutation.Recurrence(); ok {
+		_spec.SetField(todo.FieldRecurrence, field.TypeString, value)
+	}
+	if tuo.mutation.RecurrenceCleared() {
+		_spec.ClearField(todo.FieldRecurrence, field.TypeString)
+	}
+	if value, ok := tuo.mutation.RecurrenceTimeZone(); ok {
+		_spec.SetField(todo.FieldRecurrenceTimeZone, field.TypeString, value)
+	}
+	if tuo.mutation.RecurrenceTimeZoneCleared() {
+		_spec.ClearField(todo.FieldRecurrenceTimeZone, field.TypeString)
+	}
+	if value, ok := tuo.mutation.SeriesID(); ok {
+		_spec.SetField(todo.FieldSeriesID, field.TypeUUID, value)
+	}
+	if tuo.mutation.SeriesIDCleared() {
+		_spec.ClearField(todo.FieldSeriesID, field.TypeUUID)
+	}
+	if value, ok := tuo.mutation.SeriesStart(); ok {
+		_spec.SetField(todo.FieldSeriesStart, field.TypeTime, value)
+	}
+	if tuo.mutation.SeriesStartCleared() {
+		_spec.ClearField(todo.FieldSeriesStart, field.TypeTime)
+	}
+	if value, ok := tuo.mutation.Occurrence(); ok {
+		_spec.SetField(todo.FieldOccurrence, field.TypeInt, value)
+	}
+	if value, ok := tuo.mutation.AddedOccurrence(); ok {
+		_spec.AddField(todo.FieldOccurrence, field.TypeInt, value)
+	}
+	if tuo.mutation.OccurrenceCleared() {
+		_spec.ClearField(todo.FieldOccurrence, field.TypeInt)
+	}
 	if value, ok := tuo.mutation.UpdatedAt(); ok {
 		_spec.SetField(todo.FieldUpdatedAt, field.TypeTime, value)
 	}
@@ -464,6 +998,51 @@ func (tuo *TodoUpdateOne) sqlSave(ctx context.Context) (_node *Todo, err error)
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if tuo.mutation.RemindersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.RemindersTable,
+			Columns: []string{todo.RemindersColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(reminder.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := tuo.mutation.RemovedRemindersIDs(); len(nodes) > 0 && !tuo.mutation.RemindersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.RemindersTable,
+			Columns: []string{todo.RemindersColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(reminder.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := tuo.mutation.RemindersIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   todo.RemindersTable,
+			Columns: []string{todo.RemindersColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(reminder.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Todo{config: tuo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -0,0 +1,197 @@
+package events
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// 変更イベントの種別
+const (
+	TodoCreated     = "todo.created"
+	TodoUpdated     = "todo.updated"
+	TodoDeleted     = "todo.deleted"
+	CategoryCreated = "category.created"
+	CategoryUpdated = "category.updated"
+	CategoryDeleted = "category.deleted"
+
+	// Reset は再送用バッファから欠落したイベントがあり、クライアントに再取得を促すイベント
+	Reset = "stream.reset"
+)
+
+// Types は Todo・Category の変更イベントの種別の一覧
+var Types = []string{TodoCreated, TodoUpdated, TodoDeleted, CategoryCreated, CategoryUpdated, CategoryDeleted}
+
+// Event はクライアントに配信する Todo・Category の変更イベントを表す
+type Event struct {
+	// ID はイベントの連番（Last-Event-ID として使用する）
+	ID uint64 `json:"id"`
+	// Type はイベントの種別
+	Type string `json:"type"`
+	// CategoryIDs は絞り込みに使用するカテゴリID（Todo の場合は変更前後の所属カテゴリ）
+	CategoryIDs []string `json:"categoryIds,omitempty"`
+	// Data は types.TodoResponse または types.CategoryResponse の JSON
+	Data json.RawMessage `json:"data"`
+}
+
+// MatchesCategory はイベントが指定カテゴリに関係するかを判定する
+func (e Event) MatchesCategory(categoryID string) bool {
+	for _, id := range e.CategoryIDs {
+		if id == categoryID {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscription はイベントの購読を表す
+type Subscription struct {
+	// C は配信されるイベントのチャネル。購読者の処理が追いつかない場合は閉じられる
+	C chan Event
+
+	closed bool
+}
+
+// Broker は変更イベントをプロセス内の購読者に配信し、再接続時の再送用に直近のイベントを保持する
+type Broker struct {
+	mu     sync.Mutex
+	lastID uint64
+	// floor は再送できない最新のイベントID（これ以前のイベントは再送用バッファにない）
+	floor       uint64
+	buffer      []Event
+	bufferSize  int
+	subscribers map[*Subscription]struct{}
+}
+
+// subscriberBufferSize は購読者ごとのチャネルのバッファサイズ
+const subscriberBufferSize = 64
+
+// NewBroker は直近 bufferSize 件のイベントを保持する Broker を作成する
+//
+// イベントIDは起動時刻（マイクロ秒）から開始するため、再起動前の Last-Event-ID は
+// 再送用バッファより古いものとして扱われる。
+func NewBroker(bufferSize int) *Broker {
+	startID := uint64(time.Now().UnixMicro())
+	return &Broker{
+		lastID:      startID,
+		floor:       startID,
+		bufferSize:  bufferSize,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish はイベントにIDを割り当て、全ての購読者に配信する
+func (b *Broker) Publish(e Event) Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	e.ID = b.lastID
+	b.deliverLocked(e)
+	return e
+}
+
+// Deliver は採番済みのイベントを全ての購読者に配信する
+//
+// 他のレプリカと共通のIDが割り当てられたイベントを配信するために使用する。
+// 既に配信したIDより前のイベントは重複として無視する。
+func (b *Broker) Deliver(e Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if e.ID <= b.lastID {
+		return
+	}
+	b.lastID = e.ID
+	b.deliverLocked(e)
+}
+
+// Reset は再送用バッファを破棄して lastID から配信を再開し、購読者に Reset イベントを送信する
+//
+// イベントの受信が途切れ、欠落したイベントがある可能性がある場合に使用する。
+func (b *Broker) Reset(lastID uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.resetLocked(lastID)
+}
+
+func (b *Broker) resetLocked(lastID uint64) {
+	b.lastID = lastID
+	b.floor = lastID
+	b.buffer = nil
+	b.sendLocked(Event{ID: lastID, Type: Reset, Data: json.RawMessage("{}")})
+}
+
+// resetToLast は最後に配信したイベントIDで Reset する
+// 配信できなかったイベントがあり、購読者に再取得を促す場合に使用する
+func (b *Broker) resetToLast() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.resetLocked(b.lastID)
+}
+
+func (b *Broker) deliverLocked(e Event) {
+	b.buffer = append(b.buffer, e)
+	if len(b.buffer) > b.bufferSize {
+		evicted := b.buffer[len(b.buffer)-b.bufferSize-1]
+		b.floor = evicted.ID
+		b.buffer = b.buffer[len(b.buffer)-b.bufferSize:]
+	}
+	b.sendLocked(e)
+}
+
+func (b *Broker) sendLocked(e Event) {
+	for sub := range b.subscribers {
+		select {
+		case sub.C <- e:
+		default:
+			// 処理が追いつかない購読者は切断し、Last-Event-ID による再接続で再送させる
+			b.closeLocked(sub)
+		}
+	}
+}
+
+// Subscribe はイベントの購読を開始し、lastEventID より後の再送対象イベントを返す
+//
+// lastEventID が 0 の場合は再送を行わない。再送用バッファから欠落したイベントがある場合、
+// 再送イベントの先頭に Reset イベントを含める。
+func (b *Broker) Subscribe(lastEventID uint64) (*Subscription, []Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := &Subscription{C: make(chan Event, subscriberBufferSize)}
+	b.subscribers[sub] = struct{}{}
+
+	if lastEventID == 0 || lastEventID == b.lastID {
+		return sub, nil
+	}
+
+	var replay []Event
+	if lastEventID < b.floor || lastEventID > b.lastID {
+		replay = append(replay, Event{ID: b.floor, Type: Reset, Data: json.RawMessage("{}")})
+		lastEventID = 0
+	}
+	for _, e := range b.buffer {
+		if e.ID > lastEventID {
+			replay = append(replay, e)
+		}
+	}
+
+	return sub, replay
+}
+
+// Unsubscribe はイベントの購読を終了する
+func (b *Broker) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closeLocked(sub)
+}
+
+func (b *Broker) closeLocked(sub *Subscription) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	delete(b.subscribers, sub)
+	close(sub.C)
+}
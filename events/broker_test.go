@@ -0,0 +1,101 @@
+package events_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/t-okuji/go-openapi-todo-demo/events"
+)
+
+// eventTypes はイベントの種別を順に返す
+func eventTypes(es []events.Event) []string {
+	types := make([]string, len(es))
+	for i, e := range es {
+		types[i] = e.Type
+	}
+	return types
+}
+
+func TestBrokerReplay(t *testing.T) {
+	b := events.NewBroker(3)
+	var published []events.Event
+	for _, typ := range []string{events.TodoCreated, events.TodoUpdated, events.TodoDeleted, events.CategoryCreated} {
+		published = append(published, b.Publish(events.Event{Type: typ, Data: json.RawMessage("{}")}))
+	}
+	for i := 1; i < len(published); i++ {
+		if published[i].ID != published[i-1].ID+1 {
+			t.Fatalf("event IDs = %d, %d, want consecutive", published[i-1].ID, published[i].ID)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		lastEventID uint64
+		want        []string
+	}{
+		{"no Last-Event-ID", 0, nil},
+		{"up to date", published[3].ID, nil},
+		{"within buffer", published[1].ID, []string{events.TodoDeleted, events.CategoryCreated}},
+		{"oldest buffered", published[0].ID, []string{events.TodoUpdated, events.TodoDeleted, events.CategoryCreated}},
+		// 再送用バッファから溢れたイベントがある場合は Reset の後にバッファの全イベントを再送する
+		{"evicted", published[0].ID - 1, []string{events.Reset, events.TodoUpdated, events.TodoDeleted, events.CategoryCreated}},
+		// 再起動前の（未来の）IDも欠落として扱う
+		{"unknown", published[3].ID + 10, []string{events.Reset, events.TodoUpdated, events.TodoDeleted, events.CategoryCreated}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, replay := b.Subscribe(tt.lastEventID)
+			defer b.Unsubscribe(sub)
+			got := eventTypes(replay)
+			if len(got) != len(tt.want) {
+				t.Fatalf("replay = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("replay = %q, want %q", got, tt.want)
+				}
+			}
+			if len(replay) > 0 && replay[0].Type == events.Reset && replay[0].ID != published[0].ID {
+				t.Errorf("reset ID = %d, want %d", replay[0].ID, published[0].ID)
+			}
+		})
+	}
+}
+
+func TestBrokerSubscribers(t *testing.T) {
+	b := events.NewBroker(10)
+	slow, _ := b.Subscribe(0)
+	fast, _ := b.Subscribe(0)
+
+	// 処理が追いつかない購読者はチャネルを閉じて切断し、他の購読者への配信は続ける
+	for range cap(slow.C) + 1 {
+		b.Publish(events.Event{Type: events.TodoCreated})
+		<-fast.C
+	}
+	for range slow.C {
+	}
+	e := b.Publish(events.Event{Type: events.TodoUpdated})
+	if got := <-fast.C; got.ID != e.ID {
+		t.Errorf("fast subscriber received %d, want %d", got.ID, e.ID)
+	}
+	b.Unsubscribe(slow)
+
+	// Deliver は既に配信したIDのイベントを無視する
+	b.Deliver(events.Event{ID: e.ID, Type: events.TodoDeleted})
+	b.Deliver(events.Event{ID: e.ID + 5, Type: events.TodoDeleted})
+	if got := <-fast.C; got.ID != e.ID+5 || got.Type != events.TodoDeleted {
+		t.Errorf("delivered %d %s, want %d", got.ID, got.Type, e.ID+5)
+	}
+
+	// Reset は購読者に通知し、以前のIDからの再送を Reset として扱う
+	b.Reset(e.ID + 100)
+	if got := <-fast.C; got.Type != events.Reset || got.ID != e.ID+100 {
+		t.Errorf("reset event = %d %s", got.ID, got.Type)
+	}
+	sub, replay := b.Subscribe(e.ID)
+	defer b.Unsubscribe(sub)
+	if got := eventTypes(replay); len(got) != 1 || got[0] != events.Reset {
+		t.Errorf("replay after reset = %q, want [%s]", got, events.Reset)
+	}
+	b.Unsubscribe(fast)
+}
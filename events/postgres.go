@@ -0,0 +1,280 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	// PostgresChannel は変更イベントの通知に使用する LISTEN/NOTIFY のチャネル名
+	PostgresChannel = "todo_events"
+	// postgresSequence はイベントIDの採番に使用するシーケンス
+	postgresSequence = "change_event_id_seq"
+	// postgresLockKey はイベントIDの採番順と通知の配信順を一致させるためのアドバイザリロックのキー
+	postgresLockKey = 0x746f646f5f6576
+
+	// maxNotifyChunkSize は1回の NOTIFY で送信するイベント本文の最大バイト数
+	// NOTIFY のペイロードは 8000 バイト未満に制限されるため、ヘッダー分の余裕を残す
+	maxNotifyChunkSize = 7900
+
+	// publishQueueSize は送信待ちにできるイベントの最大件数
+	publishQueueSize = 1024
+
+	publishTimeout       = 5 * time.Second
+	listenPingInterval   = 30 * time.Second
+	minReconnectInterval = 1 * time.Second
+	maxReconnectInterval = 30 * time.Second
+)
+
+// Postgres は PostgreSQL の LISTEN/NOTIFY を介して変更イベントを全てのレプリカに配信する
+//
+// Publish で送信したイベントは、送信元を含む全てのレプリカが LISTEN で受信し、
+// それぞれのローカルの Broker の購読者に配信する。イベントIDはデータベースのシーケンスで
+// 採番するため、どのレプリカに再接続しても同じ Last-Event-ID で再開できる。
+type Postgres struct {
+	db     *sql.DB
+	dsn    string
+	broker *Broker
+	queue  chan Event
+}
+
+// NewPostgres は db で NOTIFY を送信し、dsn への専用接続で LISTEN する Postgres を作成する
+func NewPostgres(db *sql.DB, dsn string, broker *Broker) *Postgres {
+	return &Postgres{db: db, dsn: dsn, broker: broker, queue: make(chan Event, publishQueueSize)}
+}
+
+// Publish はイベントを送信待ちに追加する。Run がIDを割り当て、NOTIFY で全てのレプリカに送信する
+//
+// ミューテーションのコミット後に呼び出されるため、送信を待たずに戻る（返すイベントのIDは未採番）。
+// 送信待ちが溢れた場合や送信に失敗した場合はイベントを破棄し、購読者に Reset イベントを送信して再取得を促す。
+func (p *Postgres) Publish(e Event) Event {
+	select {
+	case p.queue <- e:
+	default:
+		log.Printf("Event publish queue is full, discarding %s event", e.Type)
+		p.broker.resetToLast()
+	}
+	return e
+}
+
+// publishQueued は ctx が終了するまで送信待ちのイベントを追加された順に送信する
+func (p *Postgres) publishQueued(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-p.queue:
+			publishCtx, cancel := context.WithTimeout(ctx, publishTimeout)
+			_, err := p.publish(publishCtx, e)
+			cancel()
+			if err != nil {
+				log.Printf("Event publish error: %v", err)
+				p.broker.resetToLast()
+			}
+		}
+	}
+}
+
+func (p *Postgres) publish(ctx context.Context, e Event) (Event, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return e, err
+	}
+	defer tx.Rollback()
+
+	// 採番からコミットまでロックを保持し、IDの順序と通知が配信される順序（コミット順）を一致させる
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", postgresLockKey); err != nil {
+		return e, fmt.Errorf("acquiring lock: %w", err)
+	}
+	if err := tx.QueryRowContext(ctx, "SELECT nextval('"+postgresSequence+"')").Scan(&e.ID); err != nil {
+		return e, fmt.Errorf("assigning event id: %w", err)
+	}
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return e, err
+	}
+	// 同一トランザクション内の通知は連続して配信されるため、分割したまま順に送信できる
+	for _, message := range splitNotifyPayload(e.ID, payload) {
+		if _, err := tx.ExecContext(ctx, "SELECT pg_notify($1, $2)", PostgresChannel, message); err != nil {
+			return e, fmt.Errorf("sending notification: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return e, fmt.Errorf("committing notification: %w", err)
+	}
+	return e, nil
+}
+
+// Run は ctx が終了するまで Publish されたイベントを送信し、LISTEN で受信したイベントを Broker に配信する
+//
+// 接続が切断された場合は間隔を空けて再接続し、切断中のイベントは受信できないため
+// 購読者に Reset イベントを送信して再取得を促す。
+func (p *Postgres) Run(ctx context.Context) {
+	go p.publishQueued(ctx)
+
+	interval := minReconnectInterval
+	for {
+		connected, err := p.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			interval = minReconnectInterval
+		}
+		log.Printf("Event listener disconnected: %v (reconnecting in %s)", err, interval)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+		interval = min(interval*2, maxReconnectInterval)
+	}
+}
+
+// listen は LISTEN を開始し、接続が切断されるまで通知を受信する
+func (p *Postgres) listen(ctx context.Context) (connected bool, err error) {
+	conn, err := pgx.Connect(ctx, p.dsn)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{PostgresChannel}.Sanitize()); err != nil {
+		return false, err
+	}
+
+	// LISTEN 開始前のイベントは受信できないため、現在の採番位置から配信を再開する
+	lastID, err := currentEventID(ctx, conn)
+	if err != nil {
+		return false, err
+	}
+	p.broker.Reset(lastID)
+	log.Printf("Listening for events on channel %q from event %d", PostgresChannel, lastID)
+
+	var chunks notifyChunks
+	for {
+		waitCtx, cancel := context.WithTimeout(ctx, listenPingInterval)
+		notification, err := conn.WaitForNotification(waitCtx)
+		cancel()
+		if err != nil {
+			if ctx.Err() == nil && pgconn.Timeout(err) {
+				// 通知がない間も接続が生きていることを確認する
+				if err := conn.Ping(ctx); err != nil {
+					return true, err
+				}
+				continue
+			}
+			return true, err
+		}
+
+		e, ok, err := chunks.add(notification.Payload)
+		if err != nil {
+			log.Printf("Event notification discarded: %v", err)
+			continue
+		}
+		if ok {
+			p.broker.Deliver(e)
+		}
+	}
+}
+
+// currentEventID は最後に採番されたイベントIDを返す
+//
+// 採番時と同じロックを取得するため、返すIDまでのイベントは全て通知済みとなる。
+func currentEventID(ctx context.Context, conn *pgx.Conn) (uint64, error) {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", postgresLockKey); err != nil {
+		return 0, fmt.Errorf("acquiring lock: %w", err)
+	}
+	var id int64
+	if err := tx.QueryRow(ctx, "SELECT CASE WHEN is_called THEN last_value ELSE 0 END FROM "+postgresSequence).Scan(&id); err != nil {
+		return 0, fmt.Errorf("reading event id: %w", err)
+	}
+	return uint64(id), tx.Commit(ctx)
+}
+
+// splitNotifyPayload はイベントの JSON を NOTIFY で送信できる大きさに分割する
+//
+// 各メッセージは "イベントID:番号:分割数:本文" の形式となる。
+func splitNotifyPayload(id uint64, payload []byte) []string {
+	var parts [][]byte
+	for len(payload) > maxNotifyChunkSize {
+		// マルチバイト文字の途中で分割しない
+		n := maxNotifyChunkSize
+		for n > 0 && !utf8.RuneStart(payload[n]) {
+			n--
+		}
+		parts = append(parts, payload[:n])
+		payload = payload[n:]
+	}
+	parts = append(parts, payload)
+
+	messages := make([]string, len(parts))
+	for i, part := range parts {
+		messages[i] = fmt.Sprintf("%d:%d:%d:%s", id, i, len(parts), part)
+	}
+	return messages
+}
+
+// notifyChunks は分割して送信されたイベントを組み立てる
+type notifyChunks struct {
+	id    uint64
+	next  int
+	total int
+	buf   []byte
+}
+
+// add はメッセージを追加し、イベントが揃った場合はそのイベントを返す
+func (c *notifyChunks) add(message string) (Event, bool, error) {
+	fields := strings.SplitN(message, ":", 4)
+	if len(fields) != 4 {
+		return Event{}, false, fmt.Errorf("malformed message")
+	}
+	id, err := strconv.ParseUint(fields[0], 10, 64)
+	if err != nil {
+		return Event{}, false, fmt.Errorf("malformed event id %q", fields[0])
+	}
+	index, err1 := strconv.Atoi(fields[1])
+	total, err2 := strconv.Atoi(fields[2])
+	if err1 != nil || err2 != nil || index < 0 || index >= total {
+		return Event{}, false, fmt.Errorf("event %d: malformed chunk header", id)
+	}
+
+	if index == 0 {
+		c.id, c.next, c.total, c.buf = id, 0, total, c.buf[:0]
+	}
+	if id != c.id || index != c.next || total != c.total {
+		c.next = -1
+		return Event{}, false, fmt.Errorf("event %d: chunk %d/%d out of order", id, index+1, total)
+	}
+	c.buf = append(c.buf, fields[3]...)
+	c.next++
+	if c.next < c.total {
+		return Event{}, false, nil
+	}
+
+	var e Event
+	if err := json.Unmarshal(c.buf, &e); err != nil {
+		return Event{}, false, fmt.Errorf("event %d: %w", id, err)
+	}
+	e.ID = id
+	return e, true, nil
+}
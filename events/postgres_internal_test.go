@@ -0,0 +1,156 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+func TestSplitNotifyPayload(t *testing.T) {
+	if got := splitNotifyPayload(7, []byte(`{"type":"todo.created"}`)); len(got) != 1 || got[0] != `7:0:1:{"type":"todo.created"}` {
+		t.Errorf("splitNotifyPayload(small) = %q", got)
+	}
+
+	// 3バイト文字の途中が分割位置になる本文でも、文字の境界で分割する
+	e := Event{Type: TodoUpdated, Data: json.RawMessage(`{"title":"` + strings.Repeat("あ", 6000) + `"}`)}
+	payload, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages := splitNotifyPayload(42, payload)
+	if len(messages) != 3 {
+		t.Fatalf("splitNotifyPayload() = %d messages, want 3", len(messages))
+	}
+	var joined strings.Builder
+	for i, message := range messages {
+		fields := strings.SplitN(message, ":", 4)
+		if fields[0] != "42" || fields[1] != string(rune('0'+i)) || fields[2] != "3" {
+			t.Errorf("message %d header = %q", i, fields[:3])
+		}
+		if len(fields[3]) > maxNotifyChunkSize || !utf8.ValidString(fields[3]) {
+			t.Errorf("message %d: %d bytes, valid UTF-8 %t", i, len(fields[3]), utf8.ValidString(fields[3]))
+		}
+		joined.WriteString(fields[3])
+	}
+	if joined.String() != string(payload) {
+		t.Error("joined chunks differ from the payload")
+	}
+
+	// 分割したメッセージを順に追加するとイベントに戻る
+	var chunks notifyChunks
+	for i, message := range messages {
+		got, ok, err := chunks.add(message)
+		if err != nil || ok != (i == len(messages)-1) {
+			t.Fatalf("add(chunk %d) = %t, %v", i, ok, err)
+		}
+		if ok && (got.ID != 42 || got.Type != TodoUpdated || string(got.Data) != string(e.Data)) {
+			t.Errorf("assembled event = %d %s, %d data bytes", got.ID, got.Type, len(got.Data))
+		}
+	}
+}
+
+func TestNotifyChunks(t *testing.T) {
+	payload, err := json.Marshal(Event{Type: TodoCreated, Data: json.RawMessage(`{"title":"` + strings.Repeat("x", 2*maxNotifyChunkSize) + `"}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := splitNotifyPayload(1, payload)
+	second := splitNotifyPayload(2, payload)
+
+	var chunks notifyChunks
+	add := func(message string) (bool, error) {
+		_, ok, err := chunks.add(message)
+		return ok, err
+	}
+
+	// 先頭以外から始まるメッセージは破棄する
+	if _, err := add(first[1]); err == nil {
+		t.Error("add(chunk 2 before chunk 1): want error")
+	}
+
+	// 他のイベントのメッセージが割り込んだ場合は組み立て中のイベントを破棄する
+	if _, err := add(first[0]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := add(second[1]); err == nil {
+		t.Error("add(chunk of another event): want error")
+	}
+	if _, err := add(first[1]); err == nil {
+		t.Error("add(chunk after a discarded event): want error")
+	}
+
+	// 重複したメッセージも順序の誤りとして扱う
+	for _, message := range second[:2] {
+		if _, err := add(message); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := add(second[1]); err == nil {
+		t.Error("add(duplicated chunk): want error")
+	}
+
+	// 破棄した後も次のイベントは組み立てられる
+	for i, message := range second {
+		if ok, err := add(message); err != nil || ok != (i == len(second)-1) {
+			t.Fatalf("add(chunk %d) = %t, %v", i, ok, err)
+		}
+	}
+
+	for _, message := range []string{"", "1:0:1", "x:0:1:{}", "1:1:1:{}", "1:0:0:{}", "1:-1:1:{}", "1:0:1:not json"} {
+		if _, err := add(message); err == nil {
+			t.Errorf("add(%q): want error", message)
+		}
+	}
+}
+
+// nextEvent は購読者に配信された次のイベントを返す
+func nextEvent(t *testing.T, sub *Subscription) Event {
+	t.Helper()
+	select {
+	case e := <-sub.C:
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+		return Event{}
+	}
+}
+
+func TestPostgresPublishFailure(t *testing.T) {
+	db, err := sql.Open("pgx", "postgres://localhost/unused")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	broker := NewBroker(10)
+	sub, _ := broker.Subscribe(0)
+	defer broker.Unsubscribe(sub)
+	before := broker.Publish(Event{Type: TodoCreated})
+	nextEvent(t, sub)
+
+	// 送信待ちが溢れた場合は送信せずに Reset する
+	p := NewPostgres(db, "", broker)
+	for range publishQueueSize {
+		p.Publish(Event{Type: TodoUpdated})
+	}
+	p.Publish(Event{Type: TodoUpdated})
+	if e := nextEvent(t, sub); e.Type != Reset || e.ID != before.ID {
+		t.Errorf("event after queue overflow = %d %s, want %d %s", e.ID, e.Type, before.ID, Reset)
+	}
+
+	// 送信に失敗した場合も Reset し、Publish の呼び出し元は待たせない
+	p = NewPostgres(db, "", broker)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go p.publishQueued(ctx)
+	p.Publish(Event{Type: TodoDeleted})
+	if e := nextEvent(t, sub); e.Type != Reset {
+		t.Errorf("event after publish failure = %s, want %s", e.Type, Reset)
+	}
+}
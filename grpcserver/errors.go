@@ -0,0 +1,85 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorDomain はエラーの詳細（google.rpc.ErrorInfo）の domain
+const ErrorDomain = "todo.v1"
+
+// ToStatus は err を gRPC のステータスのエラーに変換する
+//
+// utils.APIError は HTTP ステータスコードに対応する gRPC のステータスコードに変換し、
+// エラーコード（TODO_NOT_FOUND など）を google.rpc.ErrorInfo の reason として詳細に付加する。
+// 項目ごとの検証エラーは google.rpc.BadRequest の field_violations として付加する。
+// APIError 以外のエラーは REST API と同様にデータベースエラーとして扱い、ログに出力する。
+func ToStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	apiErr := utils.AsAPIError(err)
+	if apiErr == utils.ErrDatabase {
+		log.Printf("Database error: %v", err)
+	}
+
+	st := status.New(codeFromHTTPStatus(apiErr.Status), apiErr.Message)
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason: apiErr.Code,
+		Domain: ErrorDomain,
+	}}
+	if len(apiErr.Details) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, d := range apiErr.Details {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{Field: d.Field, Description: d.Message})
+		}
+		details = append(details, badRequest)
+	}
+	detailed, detailErr := st.WithDetails(details...)
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// codeFromHTTPStatus は HTTP ステータスコードに対応する gRPC のステータスコードを返す
+func codeFromHTTPStatus(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.Aborted
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+	if httpStatus >= 500 {
+		return codes.Internal
+	}
+	return codes.Unknown
+}
@@ -0,0 +1,109 @@
+package grpcserver_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/t-okuji/go-openapi-todo-demo/grpcserver"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		reason  string
+		message string
+	}{
+		{"bad request", utils.ErrInvalidUUID, codes.InvalidArgument, "INVALID_UUID", "Invalid UUID format"},
+		{"unauthorized", utils.ErrInvalidCalendarToken, codes.Unauthenticated, "INVALID_CALENDAR_TOKEN", "Invalid calendar feed token"},
+		{"forbidden", &utils.APIError{Status: http.StatusForbidden, Code: "FORBIDDEN", Message: "forbidden"}, codes.PermissionDenied, "FORBIDDEN", "forbidden"},
+		{"not found", utils.ErrTodoNotFound, codes.NotFound, "TODO_NOT_FOUND", "Specified Todo not found"},
+		{"conflict", utils.ErrVersionConflict, codes.Aborted, "VERSION_CONFLICT", "Todo was modified by another request"},
+		{"precondition failed", &utils.APIError{Status: http.StatusPreconditionFailed, Code: "PRECONDITION_FAILED", Message: "stale"}, codes.FailedPrecondition, "PRECONDITION_FAILED", "stale"},
+		{"too many requests", &utils.APIError{Status: http.StatusTooManyRequests, Code: "RATE_LIMITED", Message: "slow down"}, codes.ResourceExhausted, "RATE_LIMITED", "slow down"},
+		{"not implemented", utils.ErrSyncNotSupported, codes.Unimplemented, "SYNC_NOT_SUPPORTED", "Sync is not supported by the SQLite database"},
+		{"service unavailable", &utils.APIError{Status: http.StatusServiceUnavailable, Code: "UNAVAILABLE", Message: "down"}, codes.Unavailable, "UNAVAILABLE", "down"},
+		{"gateway timeout", &utils.APIError{Status: http.StatusGatewayTimeout, Code: "TIMEOUT", Message: "timeout"}, codes.DeadlineExceeded, "TIMEOUT", "timeout"},
+		{"other server error", &utils.APIError{Status: http.StatusBadGateway, Code: "BAD_GATEWAY", Message: "bad gateway"}, codes.Internal, "BAD_GATEWAY", "bad gateway"},
+		{"other client error", &utils.APIError{Status: http.StatusTeapot, Code: "TEAPOT", Message: "teapot"}, codes.Unknown, "TEAPOT", "teapot"},
+		{"wrapped", fmt.Errorf("update: %w", utils.ErrTodoNotFound), codes.NotFound, "TODO_NOT_FOUND", "Specified Todo not found"},
+		// APIError 以外のエラーは内容を返さず、データベースエラーとして扱う
+		{"database error", errors.New("connection reset"), codes.Internal, "DB_ERROR", "Database error occurred"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(grpcserver.ToStatus(tt.err))
+			if st.Code() != tt.code || st.Message() != tt.message {
+				t.Errorf("status = %s %q, want %s %q", st.Code(), st.Message(), tt.code, tt.message)
+			}
+			details := st.Details()
+			if len(details) != 1 {
+				t.Fatalf("details = %v, want only ErrorInfo", details)
+			}
+			if info, ok := details[0].(*errdetails.ErrorInfo); !ok || info.Reason != tt.reason || info.Domain != grpcserver.ErrorDomain {
+				t.Errorf("ErrorInfo = %v, want reason %s in %s", details[0], tt.reason, grpcserver.ErrorDomain)
+			}
+		})
+	}
+}
+
+func TestToStatusFieldViolations(t *testing.T) {
+	err := utils.NewValidationError([]types.FieldError{
+		{Field: "name", Message: "minimum string length is 1"},
+		{Field: "color", Message: "string doesn't match the regular expression"},
+	})
+	st := status.Convert(grpcserver.ToStatus(err))
+	if st.Code() != codes.InvalidArgument {
+		t.Errorf("code = %s, want InvalidArgument", st.Code())
+	}
+
+	var (
+		info       *errdetails.ErrorInfo
+		badRequest *errdetails.BadRequest
+	)
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.BadRequest:
+			badRequest = d
+		}
+	}
+	if info == nil || info.Reason != "VALIDATION_ERROR" {
+		t.Errorf("ErrorInfo = %v, want VALIDATION_ERROR", info)
+	}
+	if badRequest == nil || len(badRequest.FieldViolations) != 2 {
+		t.Fatalf("BadRequest = %v, want 2 field violations", badRequest)
+	}
+	for i, want := range []string{"name", "color"} {
+		if v := badRequest.FieldViolations[i]; v.Field != want || v.Description != err.Details[i].Message {
+			t.Errorf("violation %d = %v, want %s", i, v, want)
+		}
+	}
+}
+
+func TestToStatusPassThrough(t *testing.T) {
+	// gRPC のステータスのエラーはそのまま返し、コンテキストのエラーは対応するコードに変換する
+	original := status.Error(codes.PermissionDenied, "denied")
+	if got := grpcserver.ToStatus(original); got != original {
+		t.Errorf("ToStatus(status error) = %v, want unchanged", got)
+	}
+	for err, want := range map[error]codes.Code{
+		context.Canceled:                                  codes.Canceled,
+		context.DeadlineExceeded:                          codes.DeadlineExceeded,
+		fmt.Errorf("query: %w", context.DeadlineExceeded): codes.DeadlineExceeded,
+	} {
+		if got := status.Code(grpcserver.ToStatus(err)); got != want {
+			t.Errorf("ToStatus(%v) code = %s, want %s", err, got, want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+// Package grpcserver は REST API と並行して提供する gRPC サーバーを構成する
+//
+// TodoService・CategoryService は REST API と同じ検証・フックを通り、エラーは
+// utils のエラーカタログから gRPC のステータスコードに変換する。
+// 標準のヘルスチェック（grpc.health.v1）とサーバーリフレクションも提供する。
+package grpcserver
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	todov1 "github.com/t-okuji/go-openapi-todo-demo/proto/todo/v1"
+	"github.com/t-okuji/go-openapi-todo-demo/repository"
+	"github.com/t-okuji/go-openapi-todo-demo/service"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// メタデータのキー（REST API のヘッダーに対応する）
+const (
+	ActorMetadataKey     = "x-actor"
+	RequestIDMetadataKey = "x-request-id"
+)
+
+// maxActorLength は操作者名の最大文字数（handlers.Actor と同じ）
+const maxActorLength = 255
+
+// New は TodoService・CategoryService・ヘルスチェック・サーバーリフレクションを登録した gRPC サーバーを作成する
+func New(client *ent.Client) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(logRequests, requestContext, convertErrors),
+	)
+
+	categories := repository.NewEntCategoryRepository(client)
+	todos := service.NewTodoService(repository.NewEntTodoRepository(client), categories)
+	todov1.RegisterTodoServiceServer(server, newTodoServiceServer(todos))
+	todov1.RegisterCategoryServiceServer(server, newCategoryServiceServer(service.NewCategoryService(categories)))
+
+	healthServer := health.NewServer()
+	for _, name := range []string{
+		todov1.TodoService_ServiceDesc.ServiceName,
+		todov1.CategoryService_ServiceDesc.ServiceName,
+	} {
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	reflection.Register(server)
+	return server
+}
+
+// logRequests は呼び出しごとにメソッド・ステータスコード・処理時間をログに出力する
+func logRequests(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	log.Printf("gRPC %s %s in %v", info.FullMethod, status.Code(err), time.Since(start))
+	return resp, err
+}
+
+// requestContext はメタデータの操作者・リクエストIDをコンテキストに設定する
+// リクエストIDが指定されていない場合は生成し、レスポンスのヘッダーで返す
+func requestContext(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	actor := firstValue(md, ActorMetadataKey)
+	if actor == "" {
+		actor = utils.DefaultActor
+	}
+	if len(actor) > maxActorLength {
+		return nil, ToStatus(&utils.APIError{Status: http.StatusBadRequest, Code: "INVALID_ACTOR", Message: "x-actor must be 255 characters or less"})
+	}
+
+	requestID := firstValue(md, RequestIDMetadataKey)
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadataKey, requestID)); err != nil {
+		log.Printf("gRPC header error: %v", err)
+	}
+
+	// 監査ログは chi のリクエストIDと同じキーから読み出す（utils.RequestIDFromContext）
+	ctx = context.WithValue(ctx, middleware.RequestIDKey, requestID)
+	return handler(utils.WithActor(ctx, actor), req)
+}
+
+// convertErrors はサービスが返したエラーを gRPC のステータスに変換する
+func convertErrors(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, ToStatus(err)
+	}
+	return resp, nil
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
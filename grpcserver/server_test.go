@@ -0,0 +1,180 @@
+package grpcserver_test
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/database"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/auditevent"
+	"github.com/t-okuji/go-openapi-todo-demo/grpcserver"
+	"github.com/t-okuji/go-openapi-todo-demo/hooks"
+	todov1 "github.com/t-okuji/go-openapi-todo-demo/proto/todo/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient は SQLite のインメモリデータベースを使う gRPC サーバーを bufconn で起動し、接続を返す
+func newTestClient(t *testing.T) (*grpc.ClientConn, *database.DB) {
+	t.Helper()
+	db, err := database.Open(context.Background(), database.DriverSQLite, "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	hooks.RegisterAudit(db.Client)
+
+	lis := bufconn.Listen(1 << 20)
+	server := grpcserver.New(db.Client)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn, db
+}
+
+// errorReason は gRPC のエラーの詳細（google.rpc.ErrorInfo）の reason を返す
+func errorReason(err error) string {
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+	return ""
+}
+
+func TestServerRoundTrip(t *testing.T) {
+	conn, db := newTestClient(t)
+	todos := todov1.NewTodoServiceClient(conn)
+	categories := todov1.NewCategoryServiceClient(conn)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), grpcserver.ActorMetadataKey, "alice", grpcserver.RequestIDMetadataKey, "req-1")
+
+	health, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: todov1.TodoService_ServiceDesc.ServiceName})
+	if err != nil || health.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("health = %v, %v", health, err)
+	}
+
+	color := "#FF0000"
+	category, err := categories.CreateCategory(ctx, &todov1.CreateCategoryRequest{Category: &todov1.CategoryInput{Name: "Work", Color: &color}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// リクエストIDはレスポンスのヘッダーで返し、操作者とともに監査ログに記録する
+	var header metadata.MD
+	description := "gRPC から作成"
+	created, err := todos.CreateTodo(ctx, &todov1.CreateTodoRequest{Todo: &todov1.TodoInput{Title: "first", Description: &description, CategoryId: &category.Id}}, grpc.Header(&header))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Title != "first" || created.GetDescription() != description || created.GetCategoryId() != category.Id || created.Version != 1 || created.CreatedAt == nil {
+		t.Errorf("created = %v", created)
+	}
+	if got := header.Get(grpcserver.RequestIDMetadataKey); len(got) != 1 || got[0] != "req-1" {
+		t.Errorf("request ID header = %v, want req-1", got)
+	}
+	event := db.Client.AuditEvent.Query().Where(auditevent.EntityIDEQ(uuid.MustParse(created.Id))).OnlyX(ctx)
+	if event.Actor != "alice" || event.RequestID != "req-1" {
+		t.Errorf("audit event actor %q, request ID %v, want alice, req-1", event.Actor, event.RequestID)
+	}
+
+	completed := true
+	updated, err := todos.UpdateTodo(ctx, &todov1.UpdateTodoRequest{Id: created.Id, Todo: &todov1.TodoInput{Title: "first (done)", Completed: &completed}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !updated.Completed || updated.Title != "first (done)" || updated.GetCategoryId() != category.Id || updated.UpdatedAt == nil {
+		t.Errorf("updated = %v", updated)
+	}
+	if _, err := todos.CreateTodo(ctx, &todov1.CreateTodoRequest{Todo: &todov1.TodoInput{Title: "second"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := todos.ListTodos(ctx, &todov1.ListTodosRequest{Sort: todov1.TodoSort_TODO_SORT_TITLE, Order: todov1.SortOrder_SORT_ORDER_ASC, Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.TotalCount != 2 || len(list.Todos) != 1 || list.Todos[0].Title != "first (done)" {
+		t.Errorf("list = %v", list)
+	}
+	list, _ = todos.ListTodos(ctx, &todov1.ListTodosRequest{Completed: &completed})
+	if list.TotalCount != 1 || list.Todos[0].Id != created.Id {
+		t.Errorf("completed list = %v", list)
+	}
+
+	if _, err := todos.DeleteTodo(ctx, &todov1.DeleteTodoRequest{Id: created.Id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := todos.GetTodo(ctx, &todov1.GetTodoRequest{Id: created.Id}); status.Code(err) != codes.NotFound || errorReason(err) != "TODO_NOT_FOUND" {
+		t.Errorf("get deleted todo: %v", err)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	conn, _ := newTestClient(t)
+	todos := todov1.NewTodoServiceClient(conn)
+	categories := todov1.NewCategoryServiceClient(conn)
+	ctx := context.Background()
+
+	invalidColor := "red"
+	for name, tt := range map[string]struct {
+		call   func() error
+		code   codes.Code
+		reason string
+	}{
+		"invalid id": {
+			func() error { _, err := todos.GetTodo(ctx, &todov1.GetTodoRequest{Id: "not-a-uuid"}); return err },
+			codes.InvalidArgument, "INVALID_UUID",
+		},
+		"missing title": {
+			func() error {
+				_, err := todos.CreateTodo(ctx, &todov1.CreateTodoRequest{Todo: &todov1.TodoInput{}})
+				return err
+			},
+			codes.InvalidArgument, "INVALID_REQUEST",
+		},
+		"invalid sort": {
+			func() error { _, err := todos.ListTodos(ctx, &todov1.ListTodosRequest{Sort: 99}); return err },
+			codes.InvalidArgument, "INVALID_PARAMETER",
+		},
+		"invalid category input": {
+			func() error {
+				_, err := categories.CreateCategory(ctx, &todov1.CreateCategoryRequest{Category: &todov1.CategoryInput{Name: "Work", Color: &invalidColor}})
+				return err
+			},
+			codes.InvalidArgument, "VALIDATION_ERROR",
+		},
+		"category not found": {
+			func() error {
+				_, err := categories.GetCategory(ctx, &todov1.GetCategoryRequest{Id: "00000000-0000-0000-0000-000000000000"})
+				return err
+			},
+			codes.NotFound, "NOT_FOUND",
+		},
+	} {
+		if err := tt.call(); status.Code(err) != tt.code || errorReason(err) != tt.reason {
+			t.Errorf("%s: code %s, reason %q, want %s %s (%v)", name, status.Code(err), errorReason(err), tt.code, tt.reason, err)
+		}
+	}
+
+	// 操作者名が長すぎる場合はサービスを呼び出さずに拒否する
+	long := metadata.AppendToOutgoingContext(ctx, grpcserver.ActorMetadataKey, strings.Repeat("a", 256))
+	if _, err := categories.ListCategories(long, &todov1.ListCategoriesRequest{}); status.Code(err) != codes.InvalidArgument || errorReason(err) != "INVALID_ACTOR" {
+		t.Errorf("long actor: %v", err)
+	}
+}
@@ -0,0 +1,306 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	todov1 "github.com/t-okuji/go-openapi-todo-demo/proto/todo/v1"
+	"github.com/t-okuji/go-openapi-todo-demo/repository"
+	"github.com/t-okuji/go-openapi-todo-demo/service"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// todoServiceServer は gRPC の TodoService を実装する
+type todoServiceServer struct {
+	todov1.UnimplementedTodoServiceServer
+	todos *service.TodoService
+}
+
+// newTodoServiceServer は gRPC の TodoService を作成する
+//
+// REST API と同じ TodoService で処理し、エラーは utils.APIError のまま返す。
+// gRPC のステータスへの変換はインターセプター（convertErrors）で行う。
+func newTodoServiceServer(todos *service.TodoService) todov1.TodoServiceServer {
+	return &todoServiceServer{todos: todos}
+}
+
+// ListTodos は絞り込み条件に一致する Todo の一覧を取得する
+func (s *todoServiceServer) ListTodos(ctx context.Context, req *todov1.ListTodosRequest) (*todov1.ListTodosResponse, error) {
+	filter := types.TodoFilter{
+		Completed:  req.Completed,
+		CategoryID: req.CategoryId,
+		Search:     req.Search,
+	}
+	for name, ts := range map[string]struct {
+		src *timestamppb.Timestamp
+		dst **time.Time
+	}{
+		"created_before": {req.CreatedBefore, &filter.CreatedBefore},
+		"created_after":  {req.CreatedAfter, &filter.CreatedAfter},
+		"updated_before": {req.UpdatedBefore, &filter.UpdatedBefore},
+		"updated_after":  {req.UpdatedAfter, &filter.UpdatedAfter},
+	} {
+		if ts.src == nil {
+			continue
+		}
+		if err := ts.src.CheckValid(); err != nil {
+			return nil, utils.NewInvalidParameterError(name + " must be a valid timestamp")
+		}
+		t := ts.src.AsTime()
+		*ts.dst = &t
+	}
+
+	// 並び順（ページングは TodoService が検証する。limit が 0 の場合は全件）
+	opts := repository.TodoListOptions{
+		Filter: filter,
+		Order:  repository.OrderDesc,
+		Limit:  int(req.Limit),
+		Offset: int(req.Offset),
+	}
+	switch req.Sort {
+	case todov1.TodoSort_TODO_SORT_UNSPECIFIED, todov1.TodoSort_TODO_SORT_CREATED_AT:
+		opts.Sort = repository.TodoSortCreatedAt
+	case todov1.TodoSort_TODO_SORT_UPDATED_AT:
+		opts.Sort = repository.TodoSortUpdatedAt
+	case todov1.TodoSort_TODO_SORT_TITLE:
+		opts.Sort = repository.TodoSortTitle
+	default:
+		return nil, utils.NewInvalidParameterError("sort must be one of CREATED_AT, UPDATED_AT, TITLE")
+	}
+	if req.Order == todov1.SortOrder_SORT_ORDER_ASC {
+		opts.Order = repository.OrderAsc
+	}
+
+	todos, total, err := s.todos.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &todov1.ListTodosResponse{
+		Todos:      make([]*todov1.Todo, len(todos)),
+		TotalCount: int32(total),
+	}
+	for i, t := range todos {
+		response.Todos[i] = todoToProto(t)
+	}
+	return response, nil
+}
+
+// GetTodo は Todo を取得する
+func (s *todoServiceServer) GetTodo(ctx context.Context, req *todov1.GetTodoRequest) (*todov1.Todo, error) {
+	todoUUID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, utils.ErrInvalidUUID
+	}
+
+	t, err := s.todos.Get(ctx, todoUUID)
+	if err != nil {
+		return nil, err
+	}
+	return todoToProto(*t), nil
+}
+
+// CreateTodo は Todo を作成する
+func (s *todoServiceServer) CreateTodo(ctx context.Context, req *todov1.CreateTodoRequest) (*todov1.Todo, error) {
+	created, err := s.todos.Create(ctx, todoInputFromProto(req.Todo))
+	if err != nil {
+		return nil, err
+	}
+	return todoToProto(*created), nil
+}
+
+// UpdateTodo は Todo を更新する
+func (s *todoServiceServer) UpdateTodo(ctx context.Context, req *todov1.UpdateTodoRequest) (*todov1.Todo, error) {
+	todoUUID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, utils.ErrInvalidUUID
+	}
+
+	updated, err := s.todos.Update(ctx, todoUUID, todoInputFromProto(req.Todo))
+	if err != nil {
+		return nil, err
+	}
+	return todoToProto(*updated), nil
+}
+
+// DeleteTodo は Todo を削除する
+func (s *todoServiceServer) DeleteTodo(ctx context.Context, req *todov1.DeleteTodoRequest) (*emptypb.Empty, error) {
+	todoUUID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, utils.ErrInvalidUUID
+	}
+
+	if err := s.todos.Delete(ctx, todoUUID); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// categoryServiceServer は gRPC の CategoryService を実装する
+type categoryServiceServer struct {
+	todov1.UnimplementedCategoryServiceServer
+	categories *service.CategoryService
+}
+
+// newCategoryServiceServer は gRPC の CategoryService を作成する
+// 入力は API 仕様の CategoryInput のスキーマで検証する。エラーの扱いは newTodoServiceServer と同じ
+func newCategoryServiceServer(categories *service.CategoryService) todov1.CategoryServiceServer {
+	return &categoryServiceServer{categories: categories}
+}
+
+// ListCategories は全カテゴリの一覧を取得する
+func (s *categoryServiceServer) ListCategories(ctx context.Context, req *todov1.ListCategoriesRequest) (*todov1.ListCategoriesResponse, error) {
+	categories, err := s.categories.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &todov1.ListCategoriesResponse{Categories: make([]*todov1.Category, len(categories))}
+	for i, c := range categories {
+		response.Categories[i] = categoryToProto(c)
+	}
+	return response, nil
+}
+
+// GetCategory はカテゴリを取得する
+func (s *categoryServiceServer) GetCategory(ctx context.Context, req *todov1.GetCategoryRequest) (*todov1.Category, error) {
+	categoryID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, utils.ErrInvalidUUID
+	}
+
+	c, err := s.categories.Get(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	return categoryToProto(*c), nil
+}
+
+// CreateCategory はカテゴリを作成する
+func (s *categoryServiceServer) CreateCategory(ctx context.Context, req *todov1.CreateCategoryRequest) (*todov1.Category, error) {
+	input := categoryInputFromProto(req.Category)
+	if err := service.ValidateCategoryInput(input); err != nil {
+		return nil, err
+	}
+
+	created, err := s.categories.Create(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	return categoryToProto(*created), nil
+}
+
+// UpdateCategory はカテゴリを更新する
+func (s *categoryServiceServer) UpdateCategory(ctx context.Context, req *todov1.UpdateCategoryRequest) (*todov1.Category, error) {
+	categoryID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, utils.ErrInvalidUUID
+	}
+
+	input := categoryInputFromProto(req.Category)
+	if err := service.ValidateCategoryInput(input); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.categories.Update(ctx, categoryID, input)
+	if err != nil {
+		return nil, err
+	}
+	return categoryToProto(*updated), nil
+}
+
+// DeleteCategory はカテゴリを削除する
+func (s *categoryServiceServer) DeleteCategory(ctx context.Context, req *todov1.DeleteCategoryRequest) (*emptypb.Empty, error) {
+	categoryID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, utils.ErrInvalidUUID
+	}
+
+	if err := s.categories.Delete(ctx, categoryID); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// todoInputFromProto は gRPC の Todo の入力を REST API と共通の入力に変換する
+func todoInputFromProto(in *todov1.TodoInput) types.TodoInput {
+	if in == nil {
+		return types.TodoInput{}
+	}
+	return types.TodoInput{
+		Title:       in.Title,
+		Description: in.Description,
+		Completed:   in.Completed,
+		CategoryID:  in.CategoryId,
+		DueAt:       in.DueAt,
+		Recurrence:  in.Recurrence,
+		TimeZone:    in.TimeZone,
+	}
+}
+
+// categoryInputFromProto は gRPC のカテゴリの入力を REST API と共通の入力に変換する
+func categoryInputFromProto(in *todov1.CategoryInput) types.CategoryInput {
+	if in == nil {
+		return types.CategoryInput{}
+	}
+	return types.CategoryInput{
+		Name:        in.Name,
+		Description: in.Description,
+		Color:       in.Color,
+	}
+}
+
+// todoToProto は Todo のレスポンスを gRPC のメッセージに変換する
+func todoToProto(response types.TodoResponse) *todov1.Todo {
+	message := &todov1.Todo{
+		Id:          response.ID.String(),
+		Title:       response.Title,
+		Description: response.Description,
+		Completed:   response.Completed,
+		CategoryId:  uuidStringOrNil(response.CategoryID),
+		Recurrence:  response.Recurrence,
+		TimeZone:    response.TimeZone,
+		SeriesId:    uuidStringOrNil(response.SeriesID),
+		Version:     int32(response.Version),
+		CreatedAt:   timestamppb.New(response.CreatedAt),
+	}
+	if response.DueAt != nil {
+		message.DueAt = timestamppb.New(*response.DueAt)
+	}
+	if response.Occurrence != nil {
+		occurrence := int32(*response.Occurrence)
+		message.Occurrence = &occurrence
+	}
+	if response.UpdatedAt != nil {
+		message.UpdatedAt = timestamppb.New(*response.UpdatedAt)
+	}
+	return message
+}
+
+// categoryToProto はカテゴリのレスポンスを gRPC のメッセージに変換する
+func categoryToProto(response types.CategoryResponse) *todov1.Category {
+	message := &todov1.Category{
+		Id:          response.ID.String(),
+		Name:        response.Name,
+		Description: response.Description,
+		Color:       response.Color,
+		CreatedAt:   timestamppb.New(response.CreatedAt),
+	}
+	if response.UpdatedAt != nil {
+		message.UpdatedAt = timestamppb.New(*response.UpdatedAt)
+	}
+	return message
+}
+
+// uuidStringOrNil は任意の UUID を gRPC のメッセージの任意の文字列に変換する
+func uuidStringOrNil(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
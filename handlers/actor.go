@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// ActorHeader は操作者を識別するリクエストヘッダー名
+const ActorHeader = "X-Actor"
+
+// Actor はリクエストヘッダーから操作者を取得してコンテキストに設定するミドルウェア
+//
+// サーバーは X-Actor を認証せず、クライアントが指定した値をそのまま監査ログなどに記録する。
+// 操作者を信頼できる値にするには、認証を行うリバースプロキシがクライアントの X-Actor を取り除き、
+// 認証した利用者の名前を設定する構成にする。
+func Actor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor := r.Header.Get(ActorHeader)
+		if actor == "" {
+			actor = utils.DefaultActor
+		}
+		if len(actor) > 255 {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_ACTOR", "X-Actor must be 255 characters or less")
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(utils.WithActor(r.Context(), actor)))
+	})
+}
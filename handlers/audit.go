@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/auditevent"
+	"github.com/t-okuji/go-openapi-todo-demo/hooks"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// GetAuditEvents は GET /audit リクエストを処理する
+func (s *Server) GetAuditEvents(w http.ResponseWriter, r *http.Request, params api.GetAuditEventsParams) {
+	ctx := r.Context()
+
+	limit, offset, err := pagination(params.Limit, params.Offset, 50, 200)
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
+
+	// クエリパラメータによる絞り込み
+	query := s.client.AuditEvent.Query()
+	if params.EntityID != nil {
+		query.Where(auditevent.EntityID(*params.EntityID))
+	}
+	if params.EntityType != nil {
+		entityType := string(*params.EntityType)
+		if entityType != hooks.EntityTodo && entityType != hooks.EntityCategory {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "entityType must be todo or category")
+			return
+		}
+		query.Where(auditevent.EntityType(entityType))
+	}
+	if params.Actor != nil && *params.Actor != "" {
+		query.Where(auditevent.Actor(*params.Actor))
+	}
+
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Database error occurred")
+		log.Printf("Audit event count error: %v", err)
+		return
+	}
+
+	// 新しいイベントから順に取得
+	events, err := query.
+		Order(ent.Desc(auditevent.FieldID)).
+		Limit(limit).
+		Offset(offset).
+		All(ctx)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Database error occurred")
+		log.Printf("Audit event fetch error: %v", err)
+		return
+	}
+
+	items := make([]types.AuditEventResponse, len(events))
+	for i, event := range events {
+		items[i] = utils.ConvertToAuditEventResponse(event)
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, types.AuditEventListResponse{
+		Items:  items,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/hooks"
+	"github.com/t-okuji/go-openapi-todo-demo/service"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// 一括処理の実行モード
+const (
+	// BatchModeAtomic はいずれかの操作が失敗した場合に全操作をロールバックする
+	BatchModeAtomic = "atomic"
+	// BatchModeBestEffort は失敗した操作のみをロールバックし、残りの操作を続行する
+	BatchModeBestEffort = "bestEffort"
+)
+
+// 一括処理の操作種別
+const (
+	BatchOpCreate = "create"
+	BatchOpUpdate = "update"
+	BatchOpDelete = "delete"
+)
+
+// 一括処理の操作結果
+const (
+	BatchStatusSucceeded  = "succeeded"
+	BatchStatusFailed     = "failed"
+	BatchStatusRolledBack = "rolledBack"
+	BatchStatusSkipped    = "skipped"
+)
+
+// maxBatchOperations は1リクエストで受け付ける操作数の上限
+const maxBatchOperations = 100
+
+var (
+	errBatchInvalidOp   = &utils.APIError{Status: http.StatusBadRequest, Code: "INVALID_OPERATION", Message: "op must be one of create, update, delete"}
+	errBatchIDRequired  = &utils.APIError{Status: http.StatusBadRequest, Code: "INVALID_REQUEST", Message: "id is required"}
+	errBatchTodoMissing = &utils.APIError{Status: http.StatusBadRequest, Code: "INVALID_REQUEST", Message: "todo is required"}
+)
+
+// BatchTodos は POST /todos:batch リクエストを処理する
+//
+// 全ての操作はひとつのトランザクションで実行される。
+// atomic モードではいずれかの操作が失敗するとトランザクション全体をロールバックし、
+// bestEffort モードでは操作ごとのセーブポイントで失敗した操作のみをロールバックする。
+func (s *Server) BatchTodos(w http.ResponseWriter, r *http.Request, params api.BatchTodosParams) {
+	s.idempotency.serve(w, r, params.IdempotencyKey, s.batchTodos)
+}
+
+func (s *Server) batchTodos(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// リクエストボディをパース
+	var req types.BatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format")
+		return
+	}
+
+	// リクエストの検証
+	mode := BatchModeAtomic
+	if req.Mode != nil {
+		mode = string(*req.Mode)
+	}
+	if mode != BatchModeAtomic && mode != BatchModeBestEffort {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_REQUEST", "mode must be atomic or bestEffort")
+		return
+	}
+	if len(req.Operations) == 0 {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_REQUEST", "operations must not be empty")
+		return
+	}
+	if len(req.Operations) > maxBatchOperations {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "BATCH_TOO_LARGE", fmt.Sprintf("operations must be %d or less", maxBatchOperations))
+		return
+	}
+
+	response := types.BatchResponse{
+		Mode:    api.BatchResponseMode(mode),
+		Results: make([]types.BatchOperationResult, len(req.Operations)),
+	}
+	for i, op := range req.Operations {
+		response.Results[i] = types.BatchOperationResult{Index: i, Op: string(op.Op), Status: BatchStatusSkipped}
+	}
+
+	// 全操作をひとつのトランザクションで実行
+	var failure *utils.APIError
+	err := utils.WithTx(ctx, s.client, func(tx *ent.Tx) error {
+		todos := todoServiceInTx(tx)
+		for i, op := range req.Operations {
+			var todo *types.TodoResponse
+			run := func(ctx context.Context) error {
+				var err error
+				todo, err = runBatchOperation(ctx, todos, op)
+				return err
+			}
+
+			var opErr error
+			if mode == BatchModeBestEffort {
+				var err error
+				if opErr, err = runInSavepoint(ctx, tx, fmt.Sprintf("batch_op_%d", i), run); err != nil {
+					return err
+				}
+			} else {
+				opErr = run(ctx)
+			}
+
+			result := &response.Results[i]
+			if opErr != nil {
+				apiErr := utils.AsAPIError(opErr)
+				if apiErr == utils.ErrDatabase {
+					log.Printf("Batch operation %d error: %v", i, opErr)
+				}
+				result.Status = BatchStatusFailed
+				result.Error = &types.ErrorDetail{Code: apiErr.Code, Message: apiErr.Message}
+				response.Failed++
+
+				if mode == BatchModeAtomic {
+					failure = apiErr
+					return apiErr
+				}
+				continue
+			}
+
+			result.Status = BatchStatusSucceeded
+			result.Todo = todo
+			response.Succeeded++
+		}
+		return nil
+	})
+
+	if failure != nil {
+		// atomic モードの失敗: 成功済みの操作はロールバックされる
+		for i := range response.Results {
+			if response.Results[i].Status == BatchStatusSucceeded {
+				response.Results[i].Status = BatchStatusRolledBack
+				response.Results[i].Todo = nil
+			}
+		}
+		response.Succeeded = 0
+
+		status := http.StatusUnprocessableEntity
+		if failure.Status >= http.StatusInternalServerError {
+			status = failure.Status
+		}
+		utils.SendJSONResponse(w, status, response)
+		return
+	}
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Failed to execute batch")
+		log.Printf("Batch execution error: %v", err)
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response)
+}
+
+// runBatchOperation は一括処理の操作をひとつ実行する。削除操作の場合は nil の Todo を返す
+func runBatchOperation(ctx context.Context, todos *service.TodoService, op types.BatchOperation) (*types.TodoResponse, error) {
+	switch op.Op {
+	case BatchOpCreate:
+		if op.Todo == nil {
+			return nil, errBatchTodoMissing
+		}
+		return todos.Create(ctx, *op.Todo)
+
+	case BatchOpUpdate:
+		todoUUID, err := parseBatchOperationID(op)
+		if err != nil {
+			return nil, err
+		}
+		if op.Todo == nil {
+			return nil, errBatchTodoMissing
+		}
+		return todos.Update(ctx, todoUUID, *op.Todo)
+
+	case BatchOpDelete:
+		todoUUID, err := parseBatchOperationID(op)
+		if err != nil {
+			return nil, err
+		}
+		return nil, todos.Delete(ctx, todoUUID)
+
+	default:
+		return nil, errBatchInvalidOp
+	}
+}
+
+// parseBatchOperationID は更新・削除操作の対象IDを検証する
+func parseBatchOperationID(op types.BatchOperation) (uuid.UUID, error) {
+	if op.ID == nil {
+		return uuid.UUID{}, errBatchIDRequired
+	}
+	return *op.ID, nil
+}
+
+// runInSavepoint は fn をセーブポイント内で実行し、fn が失敗した場合はセーブポイントまでロールバックする
+// fn のエラーは opErr として、セーブポイント操作自体のエラーは err として返す
+// fn には hooks.BeginSavepoint のコンテキストを渡し、ロールバックした変更のイベントを配信しない
+func runInSavepoint(ctx context.Context, tx *ent.Tx, name string, fn func(ctx context.Context) error) (opErr error, err error) {
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return nil, fmt.Errorf("creating savepoint: %w", err)
+	}
+	spCtx, sp := hooks.BeginSavepoint(ctx, tx)
+	if opErr := fn(spCtx); opErr != nil {
+		sp.Rollback()
+		if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); err != nil {
+			return opErr, fmt.Errorf("rolling back to savepoint: %w", err)
+		}
+		return opErr, nil
+	}
+	if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return nil, fmt.Errorf("releasing savepoint: %w", err)
+	}
+	sp.Release()
+	return nil, nil
+}
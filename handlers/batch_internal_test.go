@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/t-okuji/go-openapi-todo-demo/database"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/todo"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+func TestRunInSavepoint(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.Open(ctx, database.DriverSQLite, "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// 失敗した操作の変更のみをロールバックし、前後の操作の変更はコミットする
+	errOp := errors.New("op failed")
+	err = utils.WithTx(ctx, db.Client, func(tx *ent.Tx) error {
+		for i, title := range []string{"kept-1", "rolled-back", "kept-2"} {
+			opErr, err := runInSavepoint(ctx, tx, "op", func(ctx context.Context) error {
+				if _, err := tx.Todo.Create().SetTitle(title).Save(ctx); err != nil {
+					return err
+				}
+				if title == "rolled-back" {
+					return errOp
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if (i == 1) != errors.Is(opErr, errOp) {
+				t.Errorf("op %d: opErr = %v", i, opErr)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	titles := db.Client.Todo.Query().Order(ent.Asc(todo.FieldTitle)).Select(todo.FieldTitle).StringsX(ctx)
+	if len(titles) != 2 || titles[0] != "kept-1" || titles[1] != "kept-2" {
+		t.Errorf("titles = %q, want [kept-1 kept-2]", titles)
+	}
+}
@@ -0,0 +1,108 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/auditevent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/todo"
+	"github.com/t-okuji/go-openapi-todo-demo/handlers"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+func TestBatchTodos(t *testing.T) {
+	ctx := context.Background()
+	srv := newSQLiteServer(t)
+	h := srv.handler
+
+	var existing types.TodoResponse
+	if rec := do(t, h, http.MethodPost, "/todos", types.TodoInput{Title: "existing"}, &existing); rec.Code != http.StatusCreated {
+		t.Fatalf("create todo: status %d", rec.Code)
+	}
+	existingID := existing.ID
+	missingID := uuid.Nil
+	missingCategoryID := missingID.String()
+	completed := true
+	bestEffort := api.BatchRequestMode(handlers.BatchModeBestEffort)
+	invalidMode := api.BatchRequestMode("sometimes")
+
+	// atomic: 1件でも失敗すると成功済みの操作もロールバックする
+	var resp types.BatchResponse
+	rec := do(t, h, http.MethodPost, "/todos:batch", types.BatchRequest{Operations: []types.BatchOperation{
+		{Op: handlers.BatchOpCreate, Todo: &types.TodoInput{Title: "atomic"}},
+		{Op: handlers.BatchOpUpdate, ID: &existingID, Todo: &types.TodoInput{Title: "renamed"}},
+		{Op: handlers.BatchOpDelete, ID: &missingID},
+		{Op: handlers.BatchOpCreate, Todo: &types.TodoInput{Title: "never"}},
+	}}, &resp)
+	if rec.Code != http.StatusUnprocessableEntity || resp.Mode != handlers.BatchModeAtomic || resp.Succeeded != 0 || resp.Failed != 1 {
+		t.Fatalf("atomic: status %d, response %+v", rec.Code, resp)
+	}
+	wantStatuses := []string{handlers.BatchStatusRolledBack, handlers.BatchStatusRolledBack, handlers.BatchStatusFailed, handlers.BatchStatusSkipped}
+	for i, want := range wantStatuses {
+		if r := resp.Results[i]; string(r.Status) != want || r.Todo != nil {
+			t.Errorf("atomic result %d = %s (todo %v), want %s", i, r.Status, r.Todo, want)
+		}
+	}
+	if e := resp.Results[2].Error; e == nil || e.Code != "TODO_NOT_FOUND" {
+		t.Errorf("atomic result 2 error = %+v", e)
+	}
+	if n := srv.db.Client.Todo.Query().Where(todo.TitleIn("atomic", "renamed", "never")).CountX(ctx); n != 0 {
+		t.Errorf("todos written by failed atomic batch = %d, want 0", n)
+	}
+	if n := srv.db.Client.AuditEvent.Query().CountX(ctx); n != 1 {
+		t.Errorf("audit events after failed atomic batch = %d, want 1", n)
+	}
+
+	// bestEffort: 失敗した操作のみをロールバックし、残りの操作を続行する
+	resp = types.BatchResponse{}
+	rec = do(t, h, http.MethodPost, "/todos:batch", types.BatchRequest{Mode: &bestEffort, Operations: []types.BatchOperation{
+		{Op: handlers.BatchOpCreate, Todo: &types.TodoInput{Title: "first"}},
+		{Op: handlers.BatchOpDelete, ID: &missingID},
+		{Op: handlers.BatchOpUpdate, ID: &existingID},
+		{Op: handlers.BatchOpUpdate, ID: &existingID, Todo: &types.TodoInput{Title: "renamed", Completed: &completed}},
+		{Op: handlers.BatchOpCreate, Todo: &types.TodoInput{Title: "orphan", CategoryID: &missingCategoryID}},
+	}}, &resp)
+	if rec.Code != http.StatusOK || resp.Succeeded != 2 || resp.Failed != 3 {
+		t.Fatalf("bestEffort: status %d, response %+v", rec.Code, resp)
+	}
+	wantCodes := []string{"", "TODO_NOT_FOUND", "INVALID_REQUEST", "", "CATEGORY_NOT_FOUND"}
+	for i, want := range wantCodes {
+		r := resp.Results[i]
+		switch {
+		case want == "" && (r.Status != handlers.BatchStatusSucceeded || r.Todo == nil):
+			t.Errorf("bestEffort result %d = %s, want succeeded", i, r.Status)
+		case want != "" && (r.Status != handlers.BatchStatusFailed || r.Error == nil || r.Error.Code != want):
+			t.Errorf("bestEffort result %d = %s %+v, want failed %s", i, r.Status, r.Error, want)
+		}
+	}
+	renamed, err := srv.db.Client.Todo.Get(ctx, existing.ID)
+	if err != nil || renamed.Title != "renamed" || !renamed.Completed {
+		t.Errorf("updated todo = %+v, %v", renamed, err)
+	}
+	if n := srv.db.Client.Todo.Query().CountX(ctx); n != 2 {
+		t.Errorf("todos after bestEffort batch = %d, want 2", n)
+	}
+
+	// リクエスト全体の検証
+	for _, req := range []types.BatchRequest{
+		{Operations: []types.BatchOperation{}},
+		{Mode: &invalidMode, Operations: []types.BatchOperation{{Op: handlers.BatchOpCreate}}},
+	} {
+		if rec := do(t, h, http.MethodPost, "/todos:batch", req, nil); rec.Code != http.StatusBadRequest {
+			t.Errorf("batch %+v: status %d, want 400", req, rec.Code)
+		}
+	}
+	many := make([]types.BatchOperation, 101)
+	for i := range many {
+		many[i] = types.BatchOperation{Op: handlers.BatchOpCreate, Todo: &types.TodoInput{Title: "many"}}
+	}
+	if rec := do(t, h, http.MethodPost, "/todos:batch", types.BatchRequest{Operations: many}, nil); rec.Code != http.StatusBadRequest {
+		t.Errorf("batch of %d operations: status %d, want 400", len(many), rec.Code)
+	}
+	if n := srv.db.Client.AuditEvent.Query().Where(auditevent.OperationEQ(auditevent.OperationCreate)).CountX(ctx); n != 2 {
+		t.Errorf("create audit events = %d, want 2", n)
+	}
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// BulkUpdateTodos は POST /todos:bulkUpdate リクエストを処理する
+func (s *Server) BulkUpdateTodos(w http.ResponseWriter, r *http.Request, params api.BulkUpdateTodosParams) {
+	// リクエストボディをパース
+	var req types.BulkUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format")
+		return
+	}
+
+	// 条件に一致する Todo を一括更新（Ent のリポジトリは監査ログと同一トランザクションで更新する）
+	response, err := s.todos.BulkUpdate(r.Context(), req.Filter, req.Update, isDryRun(params.DryRun))
+	if err != nil {
+		sendServiceError(w, err, "DB_ERROR", "Failed to update Todos")
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response)
+}
+
+// BulkDeleteTodos は POST /todos:bulkDelete リクエストを処理する
+func (s *Server) BulkDeleteTodos(w http.ResponseWriter, r *http.Request, params api.BulkDeleteTodosParams) {
+	// リクエストボディをパース
+	var req types.BulkDeleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format")
+		return
+	}
+
+	// 条件に一致する Todo を一括削除（Ent のリポジトリは監査ログと同一トランザクションで削除する）
+	response, err := s.todos.BulkDelete(r.Context(), req.Filter, isDryRun(params.DryRun))
+	if err != nil {
+		sendServiceError(w, err, "DB_ERROR", "Failed to delete Todos")
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response)
+}
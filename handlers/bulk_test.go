@@ -0,0 +1,100 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/auditevent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/hook"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/todo"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+func TestBulkTodos(t *testing.T) {
+	ctx := context.Background()
+	srv := newSQLiteServer(t)
+	h := srv.handler
+
+	for range 25 {
+		if rec := do(t, h, http.MethodPost, "/todos", types.TodoInput{Title: "task"}, nil); rec.Code != http.StatusCreated {
+			t.Fatalf("create todo: status %d", rec.Code)
+		}
+	}
+	if rec := do(t, h, http.MethodPost, "/todos", types.TodoInput{Title: "other"}, nil); rec.Code != http.StatusCreated {
+		t.Fatalf("create todo: status %d", rec.Code)
+	}
+	search := "task"
+	completed := true
+	filter := types.TodoFilter{Search: &search}
+
+	// 絞り込み条件のない一括操作は全件を対象にしないよう拒否する
+	var errResp types.ErrorResponse
+	if rec := do(t, h, http.MethodPost, "/todos:bulkDelete", types.BulkDeleteRequest{}, &errResp); rec.Code != http.StatusBadRequest || errResp.Error.Code != "INVALID_REQUEST" {
+		t.Errorf("bulk delete without filter: status %d, code %q", rec.Code, errResp.Error.Code)
+	}
+	if rec := do(t, h, http.MethodPost, "/todos:bulkUpdate", types.BulkUpdateRequest{Update: types.BulkTodoUpdate{Completed: &completed}}, nil); rec.Code != http.StatusBadRequest {
+		t.Errorf("bulk update without filter: status %d, want 400", rec.Code)
+	}
+
+	// dryRun は対象件数と先頭のIDのサンプルのみを返し、変更しない
+	var dryRun types.BulkResponse
+	if rec := do(t, h, http.MethodPost, "/todos:bulkDelete?dryRun=true", types.BulkDeleteRequest{Filter: filter}, &dryRun); rec.Code != http.StatusOK {
+		t.Fatalf("dry-run bulk delete: status %d", rec.Code)
+	}
+	if !dryRun.DryRun || dryRun.Affected != 25 || dryRun.SampleIds == nil || len(*dryRun.SampleIds) != 20 {
+		t.Errorf("dry-run = dryRun %t, affected %d, sample IDs %v, want 25 and 20", dryRun.DryRun, dryRun.Affected, dryRun.SampleIds)
+	}
+	if n := srv.db.Client.Todo.Query().CountX(ctx); n != 26 {
+		t.Errorf("todos after dry-run = %d, want 26", n)
+	}
+
+	// 監査ログの書き込みに失敗した場合は一括更新全体をロールバックする
+	failAudit := true
+	srv.db.Client.AuditEvent.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.AuditEventFunc(func(ctx context.Context, m *ent.AuditEventMutation) (ent.Value, error) {
+			if failAudit {
+				return nil, errors.New("audit unavailable")
+			}
+			return next.Mutate(ctx, m)
+		})
+	})
+	update := types.BulkUpdateRequest{Filter: filter, Update: types.BulkTodoUpdate{Completed: &completed}}
+	if rec := do(t, h, http.MethodPost, "/todos:bulkUpdate", update, nil); rec.Code != http.StatusInternalServerError {
+		t.Errorf("bulk update with failing audit: status %d, want 500", rec.Code)
+	}
+	if n := srv.db.Client.Todo.Query().Where(todo.Completed(true)).CountX(ctx); n != 0 {
+		t.Errorf("completed todos after failed bulk update = %d, want 0", n)
+	}
+	failAudit = false
+
+	var resp types.BulkResponse
+	if rec := do(t, h, http.MethodPost, "/todos:bulkUpdate", update, &resp); rec.Code != http.StatusOK || resp.DryRun || resp.Affected != 25 {
+		t.Fatalf("bulk update: status %d, response %+v", rec.Code, resp)
+	}
+	if n := srv.db.Client.Todo.Query().Where(todo.Completed(true)).CountX(ctx); n != 25 {
+		t.Errorf("completed todos = %d, want 25", n)
+	}
+	if n := srv.db.Client.AuditEvent.Query().Where(auditevent.OperationEQ(auditevent.OperationUpdate)).CountX(ctx); n != 25 {
+		t.Errorf("update audit events = %d, want 25", n)
+	}
+
+	// 存在しないカテゴリへの一括更新は拒否する
+	missingID := "00000000-0000-0000-0000-000000000000"
+	errResp = types.ErrorResponse{}
+	update = types.BulkUpdateRequest{Filter: filter, Update: types.BulkTodoUpdate{CategoryID: &missingID}}
+	if rec := do(t, h, http.MethodPost, "/todos:bulkUpdate", update, &errResp); rec.Code != http.StatusBadRequest || errResp.Error.Code != "CATEGORY_NOT_FOUND" {
+		t.Errorf("bulk update to missing category: status %d, code %q", rec.Code, errResp.Error.Code)
+	}
+
+	resp = types.BulkResponse{}
+	filter.Completed = &completed
+	if rec := do(t, h, http.MethodPost, "/todos:bulkDelete", types.BulkDeleteRequest{Filter: filter}, &resp); rec.Code != http.StatusOK || resp.Affected != 25 {
+		t.Fatalf("bulk delete: status %d, response %+v", rec.Code, resp)
+	}
+	if titles := srv.db.Client.Todo.Query().Select(todo.FieldTitle).StringsX(ctx); len(titles) != 1 || titles[0] != "other" {
+		t.Errorf("titles after bulk delete = %q, want [other]", titles)
+	}
+}
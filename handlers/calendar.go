@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/calendartoken"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/predicate"
+	"github.com/t-okuji/go-openapi-todo-demo/ical"
+	"github.com/t-okuji/go-openapi-todo-demo/repository"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// calendarTokenPrefix はカレンダー配信用トークンの接頭辞
+const calendarTokenPrefix = "cal_"
+
+// calendarProductID は配信する iCalendar の PRODID
+const calendarProductID = "-//go-openapi-todo-demo//Todos//JA"
+
+// CreateCalendarToken は POST /calendar/token リクエストを処理する
+//
+// 操作者（X-Actor）ごとにカレンダー配信用のトークンを発行する。発行済みの場合は新しいトークンに置き換え、
+// 以前のトークンは無効になる。データベースにはトークンのハッシュのみを保存するため、トークンはこのレスポンスでのみ返す。
+// トークンには配信の対象（絞り込み条件、または allTodos によるワークスペース全体の明示的な許可）を保存する。
+// X-Actor は認証されないため、API にアクセスできる誰もが任意の操作者のトークンを発行・無効化できる。
+func (s *Server) CreateCalendarToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	actor := utils.ActorFromContext(ctx)
+
+	// リクエストボディをパース
+	var input types.CalendarTokenInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format")
+		return
+	}
+	filter, err := calendarTokenScope(input)
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
+
+	token := generateCalendarToken()
+	var created *ent.CalendarToken
+	err = utils.WithTx(ctx, s.client, func(tx *ent.Tx) error {
+		if _, err := tx.CalendarToken.Delete().Where(calendartoken.Actor(actor)).Exec(ctx); err != nil {
+			return err
+		}
+		create := tx.CalendarToken.Create().
+			SetActor(actor).
+			SetTokenHash(hashCalendarToken(token)).
+			SetAllTodos(filter == nil)
+		if filter != nil {
+			create.SetFilter(filter)
+		}
+		var err error
+		created, err = create.Save(ctx)
+		return err
+	})
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Failed to issue calendar feed token")
+		log.Printf("Calendar token create error: %v", err)
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusCreated, types.CalendarTokenResponse{
+		Token:     token,
+		FeedURL:   calendarFeedURL(r, token),
+		Filter:    created.Filter,
+		AllTodos:  created.AllTodos,
+		CreatedAt: created.CreatedAt,
+	})
+}
+
+// calendarTokenScope はトークンに保存する絞り込み条件を返す（allTodos の場合は nil）
+// 条件を含む filter と allTodos: true のどちらか一方だけを受け付ける
+func calendarTokenScope(input types.CalendarTokenInput) (*types.TodoFilter, error) {
+	allTodos := input.AllTodos != nil && *input.AllTodos
+	var predicates []predicate.Todo
+	if input.Filter != nil {
+		var err error
+		if predicates, err = repository.TodoPredicates(*input.Filter); err != nil {
+			return nil, err
+		}
+	}
+	if allTodos == (len(predicates) > 0) {
+		return nil, utils.ErrInvalidCalendarScope
+	}
+	if allTodos {
+		return nil, nil
+	}
+	return input.Filter, nil
+}
+
+// DeleteCalendarToken は DELETE /calendar/token リクエストを処理する
+// 操作者（X-Actor）のカレンダー配信用トークンを無効にする（X-Actor は認証されない）
+func (s *Server) DeleteCalendarToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	deleted, err := s.client.CalendarToken.Delete().
+		Where(calendartoken.Actor(utils.ActorFromContext(ctx))).
+		Exec(ctx)
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
+	if deleted == 0 {
+		utils.SendAPIError(w, utils.ErrCalendarTokenNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetCalendarFeed は GET /calendar.ics リクエストを処理する
+//
+// カレンダーアプリはリクエストヘッダーを指定できないため、クエリパラメーターの token で認証する。
+// トークンに保存した条件とクエリパラメーターの条件の両方に一致する Todo を VTODO として作成日時順に出力する。
+// Todo には所有者がないため、allTodos のトークンではワークスペースの全ての Todo を配信する。
+func (s *Server) GetCalendarFeed(w http.ResponseWriter, r *http.Request, params api.GetCalendarFeedParams) {
+	ctx := r.Context()
+
+	// トークンの検証
+	if params.Token == "" {
+		utils.SendAPIError(w, utils.ErrInvalidCalendarToken)
+		return
+	}
+	token, err := s.client.CalendarToken.Query().
+		Where(calendartoken.TokenHash(hashCalendarToken(params.Token))).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		utils.SendAPIError(w, utils.ErrInvalidCalendarToken)
+		return
+	}
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
+
+	// クエリパラメーターではトークンの条件を広げられないよう、両方の条件で絞り込む
+	filters := []types.TodoFilter{todoFilter(params.Completed, params.CategoryID, params.Search,
+		params.CreatedBefore, params.CreatedAfter, params.UpdatedBefore, params.UpdatedAfter)}
+	if !token.AllTodos {
+		if token.Filter == nil {
+			utils.SendAPIError(w, utils.ErrInvalidCalendarToken)
+			return
+		}
+		filters = append(filters, *token.Filter)
+	}
+	snapshot, err := s.openTodoSnapshot(ctx, filters...)
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
+	defer snapshot.close()
+
+	w.Header().Set("Content-Type", ical.ContentType)
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeCalendar(ctx, snapshot, w); err != nil {
+		// レスポンスの送信後はステータスコードを変更できないため、出力を打ち切る
+		log.Printf("Calendar feed error: %v", err)
+	}
+}
+
+// writeCalendar はスナップショットの全ての Todo を VTODO として出力する
+func writeCalendar(ctx context.Context, snapshot *todoSnapshot, w http.ResponseWriter) error {
+	cw := ical.NewWriter(w)
+	cw.Begin("VCALENDAR")
+	cw.Property("VERSION", "2.0")
+	cw.Text("PRODID", calendarProductID)
+	cw.Property("CALSCALE", "GREGORIAN")
+	cw.Text("X-WR-CALNAME", "Todos")
+
+	err := snapshot.each(ctx, w, func(chunk []*ent.Todo) error {
+		for _, t := range chunk {
+			writeVTodo(cw, t, snapshot)
+		}
+		return cw.Flush()
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.End("VCALENDAR")
+	return cw.Flush()
+}
+
+// writeVTodo は Todo を VTODO として出力する
+func writeVTodo(cw *ical.Writer, t *ent.Todo, snapshot *todoSnapshot) {
+	modifiedAt := t.CreatedAt
+	if !t.UpdatedAt.IsZero() {
+		modifiedAt = t.UpdatedAt
+	}
+
+	cw.Begin("VTODO")
+	cw.Text("UID", t.ID.String())
+	cw.Time("DTSTAMP", modifiedAt)
+	cw.Time("CREATED", t.CreatedAt)
+	cw.Time("LAST-MODIFIED", modifiedAt)
+	// SEQUENCE は 0 から始まるため、バージョンから1を引く
+	cw.Property("SEQUENCE", strconv.Itoa(t.Version-1))
+	cw.Text("SUMMARY", t.Title)
+	if t.Description != "" {
+		cw.Text("DESCRIPTION", t.Description)
+	}
+	if t.Completed {
+		cw.Property("STATUS", "COMPLETED")
+	} else {
+		cw.Property("STATUS", "NEEDS-ACTION")
+	}
+	if name, ok := snapshot.categoryName(t); ok {
+		cw.TextList("CATEGORIES", name)
+	}
+	if t.DueAt != nil {
+		cw.Time("DUE", *t.DueAt)
+	}
+	cw.End("VTODO")
+}
+
+// generateCalendarToken は推測できないカレンダー配信用トークンを生成する
+func generateCalendarToken() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return calendarTokenPrefix + hex.EncodeToString(b)
+}
+
+// hashCalendarToken はデータベースに保存するトークンのハッシュを返す
+func hashCalendarToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// calendarFeedURL はリクエストのホストからカレンダー配信のURLを組み立てる
+func calendarFeedURL(r *http.Request, token string) string {
+	return requestOrigin(r) + "/calendar.ics?" + url.Values{"token": {token}}.Encode()
+}
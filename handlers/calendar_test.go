@@ -0,0 +1,159 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/t-okuji/go-openapi-todo-demo/handlers"
+	"github.com/t-okuji/go-openapi-todo-demo/ical"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+func TestCalendarFeed(t *testing.T) {
+	h := newSQLiteServer(t).handler
+	as := func(actor string) http.Header {
+		header := http.Header{}
+		header.Set(handlers.ActorHeader, actor)
+		return header
+	}
+
+	var work types.CategoryResponse
+	do(t, h, http.MethodPost, "/categories", types.CategoryInput{Name: "Work, Home"}, &work)
+	categoryID := work.ID.String()
+	description := "line1\nline2; with, punctuation"
+	doWithHeader(t, h, http.MethodPost, "/todos", as("bob"), types.TodoInput{Title: "Bob's todo", Description: &description, CategoryID: &categoryID}, nil)
+
+	all := true
+	allTodos := types.CalendarTokenInput{AllTodos: &all}
+	var alice types.CalendarTokenResponse
+	if rec := doWithHeader(t, h, http.MethodPost, "/calendar/token", as("alice"), allTodos, &alice); rec.Code != http.StatusCreated {
+		t.Fatalf("create token: status %d", rec.Code)
+	}
+	if !alice.AllTodos || alice.Filter != nil {
+		t.Errorf("allTodos = %v, filter = %+v", alice.AllTodos, alice.Filter)
+	}
+	if !strings.HasPrefix(alice.Token, "cal_") || !strings.HasSuffix(alice.FeedURL, "/calendar.ics?token="+url.QueryEscape(alice.Token)) {
+		t.Errorf("token %q, feed URL %q", alice.Token, alice.FeedURL)
+	}
+
+	feed := func(token string) (int, string) {
+		rec := do(t, h, http.MethodGet, "/calendar.ics?token="+url.QueryEscape(token), nil, nil)
+		return rec.Code, rec.Body.String()
+	}
+
+	// allTodos のトークンはワークスペース全体を対象とし、他の操作者が作成した Todo も含む
+	code, body := feed(alice.Token)
+	if code != http.StatusOK {
+		t.Fatalf("feed: status %d", code)
+	}
+	calendars, err := ical.Parse(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("feed is not valid iCalendar: %v\n%s", err, body)
+	}
+	if len(calendars) != 1 || len(calendars[0].Components) != 1 {
+		t.Fatalf("feed = %s", body)
+	}
+	vtodo := calendars[0].Components[0]
+	for name, want := range map[string]string{"SUMMARY": "Bob's todo", "DESCRIPTION": description, "STATUS": "NEEDS-ACTION", "SEQUENCE": "0"} {
+		if p := vtodo.Property(name); p == nil || p.Text() != want {
+			t.Errorf("%s = %+v, want %q", name, p, want)
+		}
+	}
+	if p := vtodo.Property("CATEGORIES"); p == nil || len(p.TextList()) != 1 || p.TextList()[0] != "Work, Home" {
+		t.Errorf("CATEGORIES = %+v, want the escaped category name", p)
+	}
+
+	// 再発行すると以前のトークンは無効になる。他の操作者のトークンには影響しない
+	var bob, reissued types.CalendarTokenResponse
+	doWithHeader(t, h, http.MethodPost, "/calendar/token", as("bob"), allTodos, &bob)
+	doWithHeader(t, h, http.MethodPost, "/calendar/token", as("alice"), allTodos, &reissued)
+	for token, want := range map[string]int{
+		alice.Token:    http.StatusUnauthorized,
+		reissued.Token: http.StatusOK,
+		bob.Token:      http.StatusOK,
+		"cal_unknown":  http.StatusUnauthorized,
+	} {
+		if code, _ := feed(token); code != want {
+			t.Errorf("feed with token %q: status %d, want %d", token, code, want)
+		}
+	}
+
+	// 無効化したトークンは使えず、発行されていない場合は 404 を返す
+	if rec := doWithHeader(t, h, http.MethodDelete, "/calendar/token", as("alice"), nil, nil); rec.Code != http.StatusNoContent {
+		t.Errorf("delete token: status %d", rec.Code)
+	}
+	if code, _ := feed(reissued.Token); code != http.StatusUnauthorized {
+		t.Errorf("feed with deleted token: status %d, want 401", code)
+	}
+	var errResp types.ErrorResponse
+	if rec := doWithHeader(t, h, http.MethodDelete, "/calendar/token", as("alice"), nil, &errResp); rec.Code != http.StatusNotFound || errResp.Error.Code != "CALENDAR_TOKEN_NOT_FOUND" {
+		t.Errorf("delete again: status %d, code %q", rec.Code, errResp.Error.Code)
+	}
+	if code, _ := feed(bob.Token); code != http.StatusOK {
+		t.Errorf("feed with bob's token after alice deleted hers: status %d", code)
+	}
+}
+
+func TestCalendarFeedScope(t *testing.T) {
+	h := newSQLiteServer(t).handler
+
+	var work types.CategoryResponse
+	do(t, h, http.MethodPost, "/categories", types.CategoryInput{Name: "work"}, &work)
+	workID := work.ID.String()
+	completed, all, notAll := true, true, false
+	for _, input := range []types.TodoInput{
+		{Title: "report", CategoryID: &workID},
+		{Title: "meeting", CategoryID: &workID, Completed: &completed},
+		{Title: "groceries"},
+	} {
+		do(t, h, http.MethodPost, "/todos", input, nil)
+	}
+
+	// 配信の対象は条件を含む filter か allTodos のどちらか一方で指定する
+	empty, invalid := "", "work"
+	for name, input := range map[string]types.CalendarTokenInput{
+		"no scope":         {},
+		"empty filter":     {Filter: &types.TodoFilter{Search: &empty}},
+		"allTodos false":   {AllTodos: &notAll},
+		"filter and all":   {Filter: &types.TodoFilter{CategoryID: &workID}, AllTodos: &all},
+		"invalid category": {Filter: &types.TodoFilter{CategoryID: &invalid}},
+	} {
+		if rec := do(t, h, http.MethodPost, "/calendar/token", input, nil); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, body %s, want 400", name, rec.Code, rec.Body)
+		}
+	}
+
+	var token types.CalendarTokenResponse
+	if rec := do(t, h, http.MethodPost, "/calendar/token", types.CalendarTokenInput{Filter: &types.TodoFilter{CategoryID: &workID}}, &token); rec.Code != http.StatusCreated {
+		t.Fatalf("create token: status %d, body %s", rec.Code, rec.Body)
+	}
+	if token.AllTodos || token.Filter == nil || token.Filter.CategoryID == nil || *token.Filter.CategoryID != workID {
+		t.Errorf("allTodos = %v, filter = %+v, want the work category", token.AllTodos, token.Filter)
+	}
+
+	// クエリパラメーターはトークンの条件をさらに絞り込むことのみでき、条件を広げられない
+	for query, want := range map[string][]string{
+		"":                  {"report", "meeting"},
+		"&completed=false":  {"report"},
+		"&categoryId=none":  nil,
+		"&search=groceries": nil,
+	} {
+		rec := do(t, h, http.MethodGet, "/calendar.ics?token="+url.QueryEscape(token.Token)+query, nil, nil)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("feed%s: status %d", query, rec.Code)
+		}
+		calendars, err := ical.Parse(strings.NewReader(rec.Body.String()))
+		if err != nil || len(calendars) != 1 {
+			t.Fatalf("feed%s is not valid iCalendar: %v", query, err)
+		}
+		var got []string
+		for _, vtodo := range calendars[0].Components {
+			got = append(got, vtodo.Property("SUMMARY").Text())
+		}
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("feed%s = %v, want %v", query, got, want)
+		}
+	}
+}
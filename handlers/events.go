@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/events"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+const (
+	// eventsHeartbeatInterval はコネクション維持のためのコメントを送信する間隔
+	eventsHeartbeatInterval = 15 * time.Second
+	// eventsRetryInterval はクライアントに指示する再接続までの待ち時間
+	eventsRetryInterval = 3 * time.Second
+)
+
+// StreamEvents は GET /events リクエストを処理し、Todo・Category の変更を Server-Sent Events で配信する
+//
+// Last-Event-ID ヘッダー（またはクエリパラメータ lastEventId）を指定すると、再送用バッファに残っている
+// 以降のイベントを再送する。categoryId を指定すると、そのカテゴリに関係するイベントのみを配信する。
+func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request, params api.StreamEventsParams) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "STREAMING_UNSUPPORTED", "Streaming is not supported")
+		return
+	}
+
+	// 再開位置の取得
+	lastEventIDStr := ""
+	if params.LastEventIDHeader != nil {
+		lastEventIDStr = *params.LastEventIDHeader
+	} else if params.LastEventID != nil {
+		lastEventIDStr = *params.LastEventID
+	}
+	var lastEventID uint64
+	if lastEventIDStr != "" {
+		id, err := strconv.ParseUint(lastEventIDStr, 10, 64)
+		if err != nil {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "Last-Event-ID must be an event ID")
+			return
+		}
+		lastEventID = id
+	}
+
+	// カテゴリによる絞り込み
+	categoryID := ""
+	if params.CategoryID != nil {
+		categoryID = params.CategoryID.String()
+	}
+	matches := func(e events.Event) bool {
+		return categoryID == "" || e.Type == events.Reset || e.MatchesCategory(categoryID)
+	}
+
+	sub, replay := s.broker.Subscribe(lastEventID)
+	defer s.broker.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", eventsRetryInterval.Milliseconds())
+
+	// 再送対象のイベントを送信
+	for _, e := range replay {
+		if matches(e) {
+			writeSSEEvent(w, e)
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(eventsHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+		case e, ok := <-sub.C:
+			if !ok {
+				// 処理が追いつかず購読が解除された。クライアントは Last-Event-ID で再接続する
+				return
+			}
+			if matches(e) {
+				writeSSEEvent(w, e)
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+// writeSSEEvent はイベントを Server-Sent Events の形式で書き込む
+func writeSSEEvent(w http.ResponseWriter, e events.Event) {
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, e.Data)
+}
@@ -0,0 +1,130 @@
+package handlers_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/t-okuji/go-openapi-todo-demo/events"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+// sseEvent は Server-Sent Events のイベントを表す
+type sseEvent struct {
+	id    string
+	event string
+	data  string
+}
+
+// readSSEEvent はストリームから次のイベントを読み出す（retry・コメントは読み飛ばす）
+func readSSEEvent(t *testing.T, r *bufio.Reader) sseEvent {
+	t.Helper()
+	var e sseEvent
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("reading event stream: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			if e.event != "" {
+				return e
+			}
+		case strings.HasPrefix(line, "id: "):
+			e.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			e.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			e.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestStreamEvents(t *testing.T) {
+	srv := newSQLiteServer(t)
+	ts := httptest.NewServer(srv.handler)
+	defer ts.Close()
+
+	var work, home types.CategoryResponse
+	do(t, srv.handler, http.MethodPost, "/categories", types.CategoryInput{Name: "work"}, &work)
+	do(t, srv.handler, http.MethodPost, "/categories", types.CategoryInput{Name: "home"}, &home)
+	workID, homeID := work.ID.String(), home.ID.String()
+
+	sub, _ := srv.broker.Subscribe(0)
+	defer srv.broker.Unsubscribe(sub)
+	var ids []uint64
+	for _, input := range []types.TodoInput{
+		{Title: "work-1", CategoryID: &workID},
+		{Title: "home-1", CategoryID: &homeID},
+		{Title: "work-2", CategoryID: &workID},
+	} {
+		if rec := do(t, srv.handler, http.MethodPost, "/todos", input, nil); rec.Code != http.StatusCreated {
+			t.Fatalf("create todo: status %d", rec.Code)
+		}
+		ids = append(ids, (<-sub.C).ID)
+	}
+
+	// Last-Event-ID 以降のイベントのうち、指定カテゴリに関係するものを再送する
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/events?categoryId="+workID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Last-Event-ID", strconv.FormatUint(ids[0], 10))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("status %d, content type %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	stream := bufio.NewReader(resp.Body)
+
+	title := func(e sseEvent) string {
+		var todo types.TodoResponse
+		if err := json.Unmarshal([]byte(e.data), &todo); err != nil {
+			t.Fatalf("event data %q: %v", e.data, err)
+		}
+		return todo.Title
+	}
+	e := readSSEEvent(t, stream)
+	if e.id != strconv.FormatUint(ids[2], 10) || e.event != events.TodoCreated || title(e) != "work-2" {
+		t.Errorf("replayed event = %s %s %q, want %d %s work-2", e.id, e.event, e.data, ids[2], events.TodoCreated)
+	}
+
+	// 以降の変更も指定カテゴリに関係するもののみを配信する
+	for _, input := range []types.TodoInput{
+		{Title: "home-2", CategoryID: &homeID},
+		{Title: "work-3", CategoryID: &workID},
+	} {
+		do(t, srv.handler, http.MethodPost, "/todos", input, nil)
+	}
+	if e := readSSEEvent(t, stream); e.event != events.TodoCreated || title(e) != "work-3" {
+		t.Errorf("live event = %s %q, want work-3", e.event, e.data)
+	}
+
+	// カテゴリから外れた Todo の更新は変更前のカテゴリの購読者にも配信する
+	var moved types.TodoResponse
+	do(t, srv.handler, http.MethodPost, "/todos", types.TodoInput{Title: "moving", CategoryID: &workID}, &moved)
+	readSSEEvent(t, stream)
+	do(t, srv.handler, http.MethodPut, "/todos/"+moved.ID.String(), types.TodoInput{Title: "moved", CategoryID: &homeID}, nil)
+	if e := readSSEEvent(t, stream); e.event != events.TodoUpdated || title(e) != "moved" {
+		t.Errorf("moved event = %s %q, want moved", e.event, e.data)
+	}
+
+	var errResp types.ErrorResponse
+	header := http.Header{}
+	header.Set("Last-Event-ID", "not-a-number")
+	if rec := doWithHeader(t, srv.handler, http.MethodGet, "/events", header, nil, &errResp); rec.Code != http.StatusBadRequest || errResp.Error.Code != "INVALID_PARAMETER" {
+		t.Errorf("invalid Last-Event-ID: status %d, code %q", rec.Code, errResp.Error.Code)
+	}
+}
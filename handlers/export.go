@@ -0,0 +1,392 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/category"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/predicate"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/todo"
+	"github.com/t-okuji/go-openapi-todo-demo/repository"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// エクスポートの出力形式
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatJSON   = "json"
+	ExportFormatNDJSON = "ndjson"
+)
+
+// NDJSON の各行の種別
+const (
+	ExportRecordCategory = "category"
+	ExportRecordTodo     = "todo"
+)
+
+// exportChunkSize はエクスポート時に1回のクエリで読み出す Todo の件数
+const exportChunkSize = 500
+
+// utf8BOM は Excel に UTF-8 の CSV であることを認識させるバイト順マーク
+const utf8BOM = "\xef\xbb\xbf"
+
+// csvFormulaPrefixes は表計算ソフトが数式として解釈する値の先頭の文字
+const csvFormulaPrefixes = "=+-@"
+
+// exportCSVHeader は CSV エクスポートの列名
+var exportCSVHeader = []string{"id", "title", "description", "completed", "category_id", "category_name", "due_at", "version", "created_at", "updated_at"}
+
+// ExportTodos は GET /export リクエストを処理する
+//
+// 一覧取得と同じ条件で絞り込んだ Todo を、カテゴリ名を解決したうえで作成日時順に出力する。
+// Todo は exportChunkSize 件ずつ読み出してレスポンスに書き込むため、件数が多くても全件をメモリに保持しない。
+// json・ndjson 形式では全てのカテゴリも出力する。
+func (s *Server) ExportTodos(w http.ResponseWriter, r *http.Request, params api.ExportTodosParams) {
+	ctx := r.Context()
+
+	// 出力形式の取得
+	format := ExportFormatJSON
+	if params.Format != nil {
+		format = string(*params.Format)
+	}
+	if format != ExportFormatCSV && format != ExportFormatJSON && format != ExportFormatNDJSON {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "format must be one of csv, json, ndjson")
+		return
+	}
+	bom := params.Bom != nil && *params.Bom
+
+	// 条件に一致する Todo とカテゴリを同一のスナップショットから読み出す
+	snapshot, err := s.openTodoSnapshot(ctx, todoFilter(params.Completed, params.CategoryID, params.Search,
+		params.CreatedBefore, params.CreatedAfter, params.UpdatedBefore, params.UpdatedAfter))
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
+	defer snapshot.close()
+
+	now := time.Now()
+	var writer exportWriter
+	switch format {
+	case ExportFormatCSV:
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		writer = &csvExportWriter{w: csv.NewWriter(w), bom: bom, out: w}
+	case ExportFormatNDJSON:
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		writer = &ndjsonExportWriter{enc: json.NewEncoder(w)}
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		writer = &jsonExportWriter{w: w, exportedAt: now}
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="todos-%s.%s"`, now.Format("20060102-150405"), format))
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeExport(ctx, snapshot, w, writer); err != nil {
+		// レスポンスの送信後はステータスコードを変更できないため、出力を打ち切る
+		log.Printf("Export error: %v", err)
+	}
+}
+
+// writeExport はスナップショットのカテゴリと全ての Todo を writer で出力する
+func writeExport(ctx context.Context, snapshot *todoSnapshot, w http.ResponseWriter, writer exportWriter) error {
+	if err := writer.begin(snapshot.categories); err != nil {
+		return err
+	}
+	err := snapshot.each(ctx, w, func(chunk []*ent.Todo) error {
+		for _, t := range chunk {
+			record := convertToExportTodo(t)
+			if name, ok := snapshot.categoryName(t); ok {
+				record.CategoryName = &name
+			}
+			if err := writer.todo(record); err != nil {
+				return err
+			}
+		}
+		return writer.flush()
+	})
+	if err != nil {
+		return err
+	}
+	return writer.end()
+}
+
+// convertToExportTodo は Ent の Todo エンティティを ExportTodo に変換する（カテゴリ名は含まない）
+func convertToExportTodo(t *ent.Todo) types.ExportTodo {
+	r := utils.ConvertToTodoResponse(t)
+	return types.ExportTodo{
+		ID:          r.ID,
+		Title:       r.Title,
+		Description: r.Description,
+		Completed:   r.Completed,
+		CategoryID:  r.CategoryID,
+		DueAt:       r.DueAt,
+		Recurrence:  r.Recurrence,
+		TimeZone:    r.TimeZone,
+		SeriesID:    r.SeriesID,
+		Occurrence:  r.Occurrence,
+		Version:     r.Version,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+// todoSnapshot は読み取り専用トランザクションのスナップショットから、条件に一致する Todo を作成日時順に読み出す
+// エクスポートとカレンダー配信で使う。Todo は exportChunkSize 件ずつ読み出すため、件数が多くても全件をメモリに保持しない
+type todoSnapshot struct {
+	tx         *ent.Tx
+	predicates []predicate.Todo
+	// categories は作成日時順の全てのカテゴリ
+	categories    []*ent.Category
+	categoryNames map[uuid.UUID]string
+	// first は最初のチャンク。レスポンスの送信前に読み出し、エラーをステータスコードで返せるようにする
+	first []*ent.Todo
+}
+
+// openTodoSnapshot はトランザクションを開始し、全てのカテゴリと filters の全てに一致する最初のチャンクを読み出す
+// 呼び出し元は close でトランザクションを終了する
+func (s *Server) openTodoSnapshot(ctx context.Context, filters ...types.TodoFilter) (*todoSnapshot, error) {
+	var predicates []predicate.Todo
+	for _, filter := range filters {
+		p, err := repository.TodoPredicates(filter)
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, p...)
+	}
+
+	// 複数回に分けて読み出す Todo とカテゴリを同一のスナップショットから読み出す
+	tx, err := s.client.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &todoSnapshot{tx: tx, predicates: predicates}
+	snapshot.categories, err = tx.Category.Query().
+		Order(ent.Asc(category.FieldCreatedAt), ent.Asc(category.FieldID)).
+		All(ctx)
+	if err == nil {
+		snapshot.first, err = snapshot.next(ctx, nil)
+	}
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	snapshot.categoryNames = make(map[uuid.UUID]string, len(snapshot.categories))
+	for _, c := range snapshot.categories {
+		snapshot.categoryNames[c.ID] = c.Name
+	}
+	return snapshot, nil
+}
+
+// close はスナップショットのトランザクションを終了する
+func (s *todoSnapshot) close() {
+	s.tx.Rollback()
+}
+
+// categoryName は Todo のカテゴリ名を返す
+func (s *todoSnapshot) categoryName(t *ent.Todo) (string, bool) {
+	if t.CategoryID == nil {
+		return "", false
+	}
+	name, ok := s.categoryNames[*t.CategoryID]
+	return name, ok
+}
+
+// each は最初のチャンクから順に全ての Todo をチャンクごとに fn に渡し、fn の後にレスポンスをフラッシュする
+func (s *todoSnapshot) each(ctx context.Context, w http.ResponseWriter, fn func(chunk []*ent.Todo) error) error {
+	flusher, _ := w.(http.Flusher)
+	for chunk := s.first; len(chunk) > 0; {
+		if err := fn(chunk); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		if len(chunk) < exportChunkSize {
+			break
+		}
+		var err error
+		if chunk, err = s.next(ctx, chunk[len(chunk)-1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// next は after の次から exportChunkSize 件の Todo を作成日時順に読み出す
+// after が nil の場合は先頭から読み出す
+func (s *todoSnapshot) next(ctx context.Context, after *ent.Todo) ([]*ent.Todo, error) {
+	query := s.tx.Todo.Query().Where(s.predicates...)
+	if after != nil {
+		// (created_at, id) のキーセットで続きを取得する
+		query.Where(todo.Or(
+			todo.CreatedAtGT(after.CreatedAt),
+			todo.And(todo.CreatedAt(after.CreatedAt), todo.IDGT(after.ID)),
+		))
+	}
+	return query.
+		Order(ent.Asc(todo.FieldCreatedAt), ent.Asc(todo.FieldID)).
+		Limit(exportChunkSize).
+		All(ctx)
+}
+
+// exportWriter はエクスポートの出力形式ごとの書き込み処理
+type exportWriter interface {
+	begin(categories []*ent.Category) error
+	todo(t types.ExportTodo) error
+	// flush はバッファされた出力をレスポンスに書き込む
+	flush() error
+	end() error
+}
+
+// csvExportWriter は Todo を1行ずつ CSV で出力する
+type csvExportWriter struct {
+	w   *csv.Writer
+	out io.Writer
+	bom bool
+}
+
+func (c *csvExportWriter) begin([]*ent.Category) error {
+	if c.bom {
+		if _, err := io.WriteString(c.out, utf8BOM); err != nil {
+			return err
+		}
+	}
+	return c.w.Write(exportCSVHeader)
+}
+
+func (c *csvExportWriter) todo(t types.ExportTodo) error {
+	var dueAt, updatedAt string
+	if t.DueAt != nil {
+		dueAt = t.DueAt.Format(time.RFC3339)
+	}
+	if t.UpdatedAt != nil {
+		updatedAt = t.UpdatedAt.Format(time.RFC3339)
+	}
+	return c.w.Write([]string{
+		t.ID.String(),
+		escapeCSVFormula(t.Title),
+		escapeCSVFormula(stringOrEmpty(t.Description)),
+		strconv.FormatBool(t.Completed),
+		uuidOrEmpty(t.CategoryID),
+		escapeCSVFormula(stringOrEmpty(t.CategoryName)),
+		dueAt,
+		strconv.Itoa(t.Version),
+		t.CreatedAt.Format(time.RFC3339),
+		updatedAt,
+	})
+}
+
+// escapeCSVFormula は表計算ソフトが数式として解釈する文字で始まる値の先頭に ' を付ける（CSV インジェクション対策）
+// importer は取り込み時に先頭の ' を取り除く
+func escapeCSVFormula(s string) string {
+	if s != "" && strings.ContainsRune(csvFormulaPrefixes, rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func (c *csvExportWriter) flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvExportWriter) end() error {
+	return c.flush()
+}
+
+// jsonExportWriter は types.ExportDocument の形式で出力する
+type jsonExportWriter struct {
+	w          io.Writer
+	exportedAt time.Time
+	count      int
+}
+
+func (j *jsonExportWriter) begin(categories []*ent.Category) error {
+	exportedAt, err := json.Marshal(j.exportedAt)
+	if err != nil {
+		return err
+	}
+	responses := make([]types.CategoryResponse, len(categories))
+	for i, c := range categories {
+		responses[i] = utils.ConvertToCategoryResponse(c)
+	}
+	data, err := json.Marshal(responses)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(j.w, `{"exportedAt":%s,"categories":%s,"todos":[`, exportedAt, data)
+	return err
+}
+
+func (j *jsonExportWriter) todo(t types.ExportTodo) error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	if j.count > 0 {
+		if _, err := io.WriteString(j.w, ","); err != nil {
+			return err
+		}
+	}
+	j.count++
+	_, err = j.w.Write(data)
+	return err
+}
+
+func (j *jsonExportWriter) flush() error { return nil }
+
+func (j *jsonExportWriter) end() error {
+	_, err := io.WriteString(j.w, "]}\n")
+	return err
+}
+
+// ndjsonExportWriter はカテゴリと Todo を1行にひとつずつ types.ExportRecord の形式で出力する
+type ndjsonExportWriter struct {
+	enc *json.Encoder
+}
+
+func (n *ndjsonExportWriter) begin(categories []*ent.Category) error {
+	for _, c := range categories {
+		response := utils.ConvertToCategoryResponse(c)
+		if err := n.enc.Encode(types.ExportRecord{Type: ExportRecordCategory, Category: &response}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *ndjsonExportWriter) todo(t types.ExportTodo) error {
+	return n.enc.Encode(types.ExportRecord{Type: ExportRecordTodo, Todo: &t})
+}
+
+func (n *ndjsonExportWriter) flush() error { return nil }
+
+func (n *ndjsonExportWriter) end() error { return nil }
+
+// stringOrEmpty は nil の文字列ポインタを空文字列として返す
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func uuidOrEmpty(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
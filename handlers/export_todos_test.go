@@ -0,0 +1,262 @@
+package handlers_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/t-okuji/go-openapi-todo-demo/database"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/handlers"
+	"github.com/t-okuji/go-openapi-todo-demo/importer"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// exportTodoCount はエクスポートで読み出すチャンク（500件）の境界をまたぐ Todo の件数
+const exportTodoCount = 501
+
+// seedExportTodos は exportTodoCount 件の Todo を作成し、作成順の ID を返す
+// 最初の Todo はタイトル・説明・カテゴリ名が数式として解釈される文字で始まる
+func seedExportTodos(t *testing.T, srv *testServer) []string {
+	t.Helper()
+	ctx := context.Background()
+
+	var ids []string
+	err := utils.WithTx(ctx, srv.db.Client, func(tx *ent.Tx) error {
+		c, err := tx.Category.Create().SetName("@work").Save(ctx)
+		if err != nil {
+			return err
+		}
+		first, err := tx.Todo.Create().SetTitle("=1+1").SetDescription("-2+3").SetCategoryID(c.ID).Save(ctx)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, first.ID.String())
+		for i := 1; i < exportTodoCount; i++ {
+			todo, err := tx.Todo.Create().SetTitle(fmt.Sprintf("todo %03d", i)).Save(ctx)
+			if err != nil {
+				return err
+			}
+			ids = append(ids, todo.ID.String())
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ids
+}
+
+// checkExportIDs はエクスポートした Todo の ID が重複・欠落なく出力されていることを確認する
+func checkExportIDs(t *testing.T, format string, got, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: %d todos, want %d", format, len(got), len(want))
+	}
+	seen := make(map[string]bool, len(got))
+	for _, id := range got {
+		if seen[id] {
+			t.Errorf("%s: duplicate todo %s", format, id)
+		}
+		seen[id] = true
+	}
+	for _, id := range want {
+		if !seen[id] {
+			t.Errorf("%s: missing todo %s", format, id)
+		}
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	srv := newSQLiteServer(t)
+	ids := seedExportTodos(t, srv)
+
+	rec := do(t, srv.handler, http.MethodGet, "/export?format=csv&bom=true", nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/csv; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	body, ok := bytes.CutPrefix(rec.Body.Bytes(), []byte("\xef\xbb\xbf"))
+	if !ok {
+		t.Error("missing UTF-8 BOM")
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) == 0 || records[0][0] != "id" || records[0][1] != "title" {
+		t.Fatalf("header = %v", records[:min(len(records), 1)])
+	}
+	var got []string
+	for _, record := range records[1:] {
+		got = append(got, record[0])
+	}
+	checkExportIDs(t, "csv", got, ids)
+
+	// 数式として解釈される値は先頭に ' を付けて出力する
+	first := records[slices.Index(got, ids[0])+1]
+	if first[1] != "'=1+1" || first[2] != "'-2+3" || first[5] != "'@work" {
+		t.Errorf("title, description, category_name = %q, %q, %q, want escaped formulas", first[1], first[2], first[5])
+	}
+
+	// 取り込み時は ' を取り除いて元の値に戻す
+	rows, err := importer.Parse(importer.FormatCSV, bytes.NewReader(rec.Body.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != exportTodoCount {
+		t.Fatalf("imported %d rows, want %d", len(rows), exportTodoCount)
+	}
+	i := slices.IndexFunc(rows, func(row importer.Row) bool { return strings.Contains(row.Title, "1+1") })
+	if i < 0 || rows[i].Title != "=1+1" || rows[i].Description != "-2+3" || rows[i].Category != "@work" {
+		t.Errorf("imported row = %+v, want the original values", rows[max(i, 0)])
+	}
+}
+
+func TestExportJSON(t *testing.T) {
+	srv := newSQLiteServer(t)
+	ids := seedExportTodos(t, srv)
+
+	var doc types.ExportDocument
+	if rec := do(t, srv.handler, http.MethodGet, "/export?format=json", nil, &doc); rec.Code != http.StatusOK {
+		t.Fatalf("status %d", rec.Code)
+	}
+	if len(doc.Categories) != 1 || doc.ExportedAt.IsZero() {
+		t.Errorf("categories = %+v, exportedAt = %v", doc.Categories, doc.ExportedAt)
+	}
+	var got []string
+	for i, todo := range doc.Todos {
+		got = append(got, todo.ID.String())
+		// 作成日時・ID 順に出力する
+		if i > 0 {
+			prev := doc.Todos[i-1]
+			if todo.CreatedAt.Before(prev.CreatedAt) || (todo.CreatedAt.Equal(prev.CreatedAt) && todo.ID.String() < prev.ID.String()) {
+				t.Errorf("todo %d is out of order", i)
+			}
+		}
+	}
+	checkExportIDs(t, "json", got, ids)
+
+	// JSON の値はエスケープしない
+	i := slices.Index(got, ids[0])
+	if todo := doc.Todos[i]; todo.Title != "=1+1" || todo.CategoryName == nil || *todo.CategoryName != "@work" {
+		t.Errorf("todo = %+v, want raw values with the category name", todo)
+	}
+}
+
+func TestExportNDJSON(t *testing.T) {
+	srv := newSQLiteServer(t)
+	ids := seedExportTodos(t, srv)
+
+	rec := do(t, srv.handler, http.MethodGet, "/export?format=ndjson", nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d", rec.Code)
+	}
+
+	// カテゴリを先に出力し、続けて Todo を1行ずつ出力する
+	var (
+		kinds []string
+		got   []string
+	)
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		var record types.ExportRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("line %q: %v", scanner.Text(), err)
+		}
+		kinds = append(kinds, string(record.Type))
+		if record.Type == handlers.ExportRecordTodo {
+			got = append(got, record.Todo.ID.String())
+		}
+	}
+	if len(kinds) == 0 || kinds[0] != handlers.ExportRecordCategory || slices.Index(kinds[1:], handlers.ExportRecordCategory) >= 0 {
+		t.Errorf("record types start with %v, want a single category first", kinds[:min(len(kinds), 3)])
+	}
+	checkExportIDs(t, "ndjson", got, ids)
+
+	var errResp types.ErrorResponse
+	if rec := do(t, srv.handler, http.MethodGet, "/export?format=xml", nil, &errResp); rec.Code != http.StatusBadRequest {
+		t.Errorf("format=xml: status %d, want 400", rec.Code)
+	}
+}
+
+// blockingWriter は最初の書き込みを started で通知し、release が閉じられるまで書き込みを待たせる ResponseWriter
+type blockingWriter struct {
+	header  http.Header
+	body    bytes.Buffer
+	once    sync.Once
+	started chan struct{}
+	release chan struct{}
+}
+
+func (w *blockingWriter) Header() http.Header { return w.header }
+
+func (w *blockingWriter) WriteHeader(int) {}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	w.once.Do(func() { close(w.started) })
+	<-w.release
+	return w.body.Write(p)
+}
+
+func TestExportDoesNotBlockWrites(t *testing.T) {
+	// インメモリの共有キャッシュはテーブル単位でロックするため、ファイルのデータベース（WAL）で確認する
+	db, err := database.Open(context.Background(), database.DriverSQLite, filepath.Join(t.TempDir(), "todo.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	srv := newTestServer(t, db)
+	ids := seedExportTodos(t, srv)
+
+	w := &blockingWriter{header: http.Header{}, started: make(chan struct{}), release: make(chan struct{})}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		srv.handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/export?format=ndjson", nil))
+	}()
+	select {
+	case <-w.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("export did not start writing")
+	}
+
+	// エクスポートが読み取りのトランザクションを保持している間も、書き込みはロックを待たずに完了する
+	start := time.Now()
+	rec := do(t, srv.handler, http.MethodPost, "/todos", types.TodoInput{Title: "during export"}, nil)
+	if elapsed := time.Since(start); rec.Code != http.StatusCreated || elapsed > time.Second {
+		t.Errorf("create during export: status %d in %s, body %s", rec.Code, elapsed, rec.Body)
+	}
+	close(w.release)
+	<-done
+
+	// エクスポートは開始時点のスナップショットを出力する
+	var exported int
+	for line := range strings.Lines(w.body.String()) {
+		var record types.ExportRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("decode %q: %v", line, err)
+		}
+		if record.Todo != nil {
+			exported++
+		}
+	}
+	if exported != len(ids) {
+		t.Errorf("exported %d todos, want %d from the snapshot", exported, len(ids))
+	}
+}
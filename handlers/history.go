@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/auditevent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/category"
+	"github.com/t-okuji/go-openapi-todo-demo/hooks"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// GetTodoHistory は GET /todos/{todoId}/history リクエストを処理する
+func (s *Server) GetTodoHistory(w http.ResponseWriter, r *http.Request, todoID uuid.UUID) {
+	ctx := r.Context()
+
+	// 監査ログからリビジョンを復元
+	revisions, err := loadTodoRevisions(ctx, s.client, todoID)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Database error occurred")
+		log.Printf("Todo history fetch error: %v", err)
+		return
+	}
+	if len(revisions) == 0 {
+		utils.SendErrorResponse(w, http.StatusNotFound, "TODO_NOT_FOUND", "Specified Todo not found")
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, revisions)
+}
+
+// RevertTodo は POST /todos/{todoId}/revert?revision=N リクエストを処理する
+func (s *Server) RevertTodo(w http.ResponseWriter, r *http.Request, todoUUID uuid.UUID, params api.RevertTodoParams) {
+	ctx := r.Context()
+	client := s.client
+
+	// リビジョン番号の検証
+	revision := params.Revision
+	if revision < 1 {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "revision must be a positive integer")
+		return
+	}
+
+	// 対象Todoの存在確認（削除済みのTodoは復元できない）
+	if _, err := client.Todo.Get(ctx, todoUUID); err != nil {
+		if ent.IsNotFound(err) {
+			utils.SendErrorResponse(w, http.StatusNotFound, "TODO_NOT_FOUND", "Specified Todo not found")
+			return
+		}
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Database error occurred")
+		log.Printf("Todo fetch error: %v", err)
+		return
+	}
+
+	revisions, err := loadTodoRevisions(ctx, client, todoUUID)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Database error occurred")
+		log.Printf("Todo history fetch error: %v", err)
+		return
+	}
+	if revision > len(revisions) {
+		utils.SendErrorResponse(w, http.StatusNotFound, "REVISION_NOT_FOUND", "Specified revision not found")
+		return
+	}
+	state := revisions[revision-1].State
+	if state == nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_REVISION", "Cannot revert to a deletion revision")
+		return
+	}
+
+	dueAt := state.DueAt
+
+	// リビジョン時点のカテゴリが削除されていないか確認
+	categoryUUID := state.CategoryID
+	if categoryUUID != nil {
+		exists, err := client.Category.Query().Where(category.ID(*categoryUUID)).Exist(ctx)
+		if err != nil {
+			utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Database error occurred")
+			log.Printf("Category existence check error: %v", err)
+			return
+		}
+		if !exists {
+			utils.SendErrorResponse(w, http.StatusConflict, "CATEGORY_NOT_FOUND", "Category of the specified revision has been deleted")
+			return
+		}
+	}
+
+	// リビジョン時点の状態を新しい更新として適用（監査ログと同一トランザクション）
+	var todo *ent.Todo
+	err = utils.WithTx(ctx, client, func(tx *ent.Tx) error {
+		updateQuery := tx.Todo.UpdateOneID(todoUUID).
+			SetTitle(state.Title).
+			SetCompleted(state.Completed)
+
+		if state.Description != nil {
+			updateQuery.SetDescription(*state.Description)
+		} else {
+			updateQuery.ClearDescription()
+		}
+
+		if categoryUUID != nil {
+			updateQuery.SetCategoryID(*categoryUUID)
+		} else {
+			updateQuery.ClearCategoryID()
+		}
+
+		if dueAt != nil {
+			updateQuery.SetDueAt(*dueAt)
+		} else {
+			updateQuery.ClearDueAt()
+		}
+
+		if state.Recurrence != nil {
+			updateQuery.SetRecurrence(*state.Recurrence)
+			if state.TimeZone != nil {
+				updateQuery.SetRecurrenceTimeZone(*state.TimeZone)
+			}
+		} else {
+			updateQuery.ClearRecurrence()
+		}
+
+		var err error
+		todo, err = updateQuery.Save(ctx)
+		return err
+	})
+	if err != nil {
+		if ent.IsNotFound(err) {
+			utils.SendErrorResponse(w, http.StatusNotFound, "TODO_NOT_FOUND", "Specified Todo not found")
+			return
+		}
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Failed to revert Todo")
+		log.Printf("Todo revert error: %v", err)
+		return
+	}
+
+	// レスポンスを返却
+	response := utils.ConvertToTodoResponse(todo)
+	utils.SendJSONResponse(w, http.StatusOK, response)
+}
+
+// loadTodoRevisions は監査ログから Todo の各リビジョン時点の状態を復元する
+//
+// 監査ログ導入前に作成された Todo にも対応するため、現在の状態から新しい順に変更を巻き戻して復元する。
+// 削除済みの Todo は削除イベントに記録された変更前の値から復元する。
+func loadTodoRevisions(ctx context.Context, client *ent.Client, todoID uuid.UUID) ([]types.TodoRevisionResponse, error) {
+	events, err := client.AuditEvent.Query().
+		Where(
+			auditevent.EntityType(hooks.EntityTodo),
+			auditevent.EntityID(todoID),
+		).
+		Order(ent.Asc(auditevent.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	// 現在の状態を取得（削除済みの場合は空の状態から巻き戻す）
+	state := hooks.Snapshot{}
+	current, err := client.Todo.Get(ctx, todoID)
+	if err != nil && !ent.IsNotFound(err) {
+		return nil, err
+	}
+	if current != nil {
+		state = hooks.TodoSnapshot(current)
+	}
+
+	revisions := make([]types.TodoRevisionResponse, len(events))
+	for i := len(events) - 1; i >= 0; i-- {
+		event := events[i]
+		revisions[i] = types.TodoRevisionResponse{
+			Revision:  i + 1,
+			Operation: api.TodoRevisionOperation(event.Operation),
+			Actor:     event.Actor,
+			ChangedAt: event.CreatedAt,
+			Changes:   event.Changes,
+		}
+		if event.RequestID != "" {
+			revisions[i].RequestID = &event.RequestID
+		}
+		if event.Operation != auditevent.OperationDelete {
+			revisions[i].State = todoStateFromSnapshot(state)
+		}
+
+		// このイベントの変更を巻き戻し、ひとつ前のリビジョンの状態にする
+		for name, change := range event.Changes {
+			state[name] = change.Old
+		}
+	}
+
+	return revisions, nil
+}
+
+// todoStateFromSnapshot は監査ログの状態を TodoState に変換する
+// 状態は hooks.TodoSnapshot が書き込むため、形式の正しくない値は含まれない（含まれた場合は省略する）
+func todoStateFromSnapshot(s hooks.Snapshot) *types.TodoState {
+	state := &types.TodoState{}
+	if title, ok := s["title"].(string); ok {
+		state.Title = title
+	}
+	if description, ok := s["description"].(string); ok {
+		state.Description = &description
+	}
+	if completed, ok := s["completed"].(bool); ok {
+		state.Completed = completed
+	}
+	if categoryID, ok := s["categoryId"].(string); ok {
+		if id, err := uuid.Parse(categoryID); err == nil {
+			state.CategoryID = &id
+		}
+	}
+	if dueAt, ok := s["dueAt"].(string); ok {
+		if t, err := time.Parse(time.RFC3339, dueAt); err == nil {
+			state.DueAt = &t
+		}
+	}
+	if recurrence, ok := s["recurrence"].(string); ok {
+		state.Recurrence = &recurrence
+	}
+	if timeZone, ok := s["timeZone"].(string); ok {
+		state.TimeZone = &timeZone
+	}
+	return state
+}
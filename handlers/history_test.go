@@ -0,0 +1,86 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+func TestRevertTodo(t *testing.T) {
+	srv := newSQLiteServer(t)
+	h := srv.handler
+
+	var c types.CategoryResponse
+	if rec := do(t, h, http.MethodPost, "/categories", types.CategoryInput{Name: "work"}, &c); rec.Code != http.StatusCreated {
+		t.Fatalf("create category: status %d", rec.Code)
+	}
+	categoryID := c.ID.String()
+
+	// リビジョン1: 作成、リビジョン2: タイトルの変更、リビジョン3: カテゴリの削除による解除
+	var created types.TodoResponse
+	if rec := do(t, h, http.MethodPost, "/todos", types.TodoInput{Title: "a", CategoryID: &categoryID}, &created); rec.Code != http.StatusCreated {
+		t.Fatalf("create todo: status %d", rec.Code)
+	}
+	base := "/todos/" + created.ID.String()
+	if rec := do(t, h, http.MethodPut, base, types.TodoInput{Title: "b", CategoryID: &categoryID}, nil); rec.Code != http.StatusOK {
+		t.Fatalf("update todo: status %d", rec.Code)
+	}
+	if rec := do(t, h, http.MethodDelete, "/categories/"+categoryID, nil, nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete category: status %d", rec.Code)
+	}
+
+	var revisions []types.TodoRevisionResponse
+	if rec := do(t, h, http.MethodGet, base+"/history", nil, &revisions); rec.Code != http.StatusOK || len(revisions) != 3 {
+		t.Fatalf("history: status %d, %d revisions, want 3", rec.Code, len(revisions))
+	}
+	if s := revisions[0].State; s == nil || s.Title != "a" || s.CategoryID == nil || s.CategoryID.String() != categoryID {
+		t.Errorf("revision 1 state = %+v", s)
+	}
+	if s := revisions[2].State; s == nil || s.Title != "b" || s.CategoryID != nil {
+		t.Errorf("revision 3 state = %+v", s)
+	}
+
+	// 削除されたカテゴリを参照するリビジョンには戻せない
+	var errResp types.ErrorResponse
+	if rec := do(t, h, http.MethodPost, base+"/revert?revision=1", nil, &errResp); rec.Code != http.StatusConflict || errResp.Error.Code != "CATEGORY_NOT_FOUND" {
+		t.Errorf("revert to deleted category: status %d, code %q", rec.Code, errResp.Error.Code)
+	}
+
+	// リビジョンの状態を新しい更新として適用する
+	if rec := do(t, h, http.MethodPut, base, types.TodoInput{Title: "c"}, nil); rec.Code != http.StatusOK {
+		t.Fatalf("update todo: status %d", rec.Code)
+	}
+	var reverted types.TodoResponse
+	if rec := do(t, h, http.MethodPost, base+"/revert?revision=3", nil, &reverted); rec.Code != http.StatusOK || reverted.Title != "b" || reverted.CategoryID != nil {
+		t.Errorf("revert: status %d, title %q, category %v", rec.Code, reverted.Title, reverted.CategoryID)
+	}
+	if rec := do(t, h, http.MethodGet, base+"/history", nil, &revisions); rec.Code != http.StatusOK || len(revisions) != 5 || revisions[4].Operation != "update" {
+		t.Errorf("history after revert: status %d, %d revisions", rec.Code, len(revisions))
+	}
+
+	for _, tc := range []struct {
+		target string
+		status int
+		code   string
+	}{
+		{base + "/revert?revision=6", http.StatusNotFound, "REVISION_NOT_FOUND"},
+		{base + "/revert?revision=0", http.StatusBadRequest, "VALIDATION_ERROR"},
+	} {
+		errResp = types.ErrorResponse{}
+		if rec := do(t, h, http.MethodPost, tc.target, nil, &errResp); rec.Code != tc.status || errResp.Error.Code != tc.code {
+			t.Errorf("POST %s: status %d, code %q, want %d %s", tc.target, rec.Code, errResp.Error.Code, tc.status, tc.code)
+		}
+	}
+
+	// 削除済みの Todo は履歴を参照できるが、復元はできない
+	if rec := do(t, h, http.MethodDelete, base, nil, nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete todo: status %d", rec.Code)
+	}
+	if rec := do(t, h, http.MethodGet, base+"/history", nil, &revisions); rec.Code != http.StatusOK || len(revisions) != 6 || revisions[5].State != nil {
+		t.Errorf("history after delete: status %d, %d revisions", rec.Code, len(revisions))
+	}
+	if rec := do(t, h, http.MethodPost, base+"/revert?revision=1", nil, nil); rec.Code != http.StatusNotFound {
+		t.Errorf("revert deleted todo: status %d, want 404", rec.Code)
+	}
+}
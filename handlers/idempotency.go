@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/repository"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+const (
+	// IdempotencyKeyHeader は冪等キーを受け取るリクエストヘッダー名
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader は保存済みレスポンスの再送であることを示すレスポンスヘッダー名
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	maxIdempotentBodySize   = 1 << 20
+)
+
+// idempotencyRecorder はハンドラーのレスポンスを保存用に記録する ResponseWriter
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *idempotencyRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *idempotencyRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
+
+// idempotencyLockTimeout は処理中のキーをロックする期間
+// 期限を過ぎたキーは、サーバーの停止などで初回リクエストが完了しなかったものとして再試行のリクエストが引き継ぐ
+const idempotencyLockTimeout = time.Minute
+
+// idempotencyCompleteAttempts はレスポンスの保存を試みる回数
+const idempotencyCompleteAttempts = 3
+
+// idempotencyKeys は Idempotency-Key ヘッダー付きの POST リクエストを冪等に処理する
+//
+// キーは操作者（X-Actor）ごとに一意で、初回リクエストのレスポンスを ttl の間保存し、同じキーでの再送には
+// 保存済みレスポンスを返す。同じキーが異なるリクエスト内容で再利用された場合は 422、初回リクエストが
+// 処理中の場合は 409 を返す。
+type idempotencyKeys struct {
+	keys repository.IdempotencyKeyRepository
+	ttl  time.Duration
+}
+
+// serve は生成コードが変換した Idempotency-Key（key）に従って next を実行する
+// key が指定されていない場合はそのまま next を実行する
+func (k *idempotencyKeys) serve(w http.ResponseWriter, r *http.Request, key *string, next http.HandlerFunc) {
+	if key == nil || *key == "" {
+		next(w, r)
+		return
+	}
+	if len(*key) > maxIdempotencyKeyLength {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_IDEMPOTENCY_KEY", "Idempotency-Key must be 255 characters or less")
+		return
+	}
+
+	ctx := context.Background()
+	actor := utils.ActorFromContext(r.Context())
+
+	// フィンガープリント計算のためにリクエストボディを読み込み、ハンドラー用に復元する
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxIdempotentBodySize+1))
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_REQUEST", "Failed to read request body")
+		return
+	}
+	if len(body) > maxIdempotentBodySize {
+		utils.SendErrorResponse(w, http.StatusRequestEntityTooLarge, "REQUEST_TOO_LARGE", "Request body is too large")
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	fingerprint := requestFingerprint(r, body)
+
+	id, err := k.acquire(ctx, time.Now(), actor, *key, fingerprint)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Database error occurred")
+		log.Printf("Idempotency key creation error: %v", err)
+		return
+	}
+	if id == uuid.Nil {
+		// 同じキーのリクエストが処理中または完了済み
+		k.replay(w, actor, *key, fingerprint)
+		return
+	}
+
+	// ハンドラーを実行し、レスポンスを記録する
+	rec := &idempotencyRecorder{ResponseWriter: w}
+	defer func() {
+		if v := recover(); v != nil {
+			k.release(ctx, id)
+			panic(v)
+		}
+	}()
+
+	next(rec, r)
+
+	if rec.status == 0 || rec.status >= http.StatusInternalServerError {
+		// サーバーエラー時はキーを解放し、クライアントの再試行を許可する
+		k.release(ctx, id)
+		return
+	}
+	k.complete(ctx, id, rec)
+}
+
+// acquire は時刻 now に処理中としてキーを登録し、そのIDを返す
+// ロックの期限を過ぎた処理中のキーは引き継ぐ。同じキーが処理中または完了済みの場合は uuid.Nil を返す
+func (k *idempotencyKeys) acquire(ctx context.Context, now time.Time, actor, key, fingerprint string) (uuid.UUID, error) {
+	lockedUntil := now.Add(idempotencyLockTimeout)
+	return k.keys.Acquire(ctx, now, repository.IdempotencyKey{
+		Actor:       actor,
+		Key:         key,
+		Fingerprint: fingerprint,
+		LockedUntil: &lockedUntil,
+		ExpiresAt:   now.Add(k.ttl),
+	})
+}
+
+// complete はレスポンスを保存してキーを完了にする
+//
+// 保存に失敗した場合もハンドラーの処理は完了しているため、キーを削除せず、ロックをキーの有効期限まで延ばす。
+// 有効期限までの再送には 409 を返し、ロックの引き継ぎによってハンドラーが再実行されることを防ぐ。
+func (k *idempotencyKeys) complete(ctx context.Context, id uuid.UUID, rec *idempotencyRecorder) {
+	var err error
+	for attempt := range idempotencyCompleteAttempts {
+		if attempt > 0 {
+			time.Sleep(time.Duration(attempt) * 100 * time.Millisecond)
+		}
+		err = k.keys.Complete(ctx, id, rec.status, rec.Header().Get("Content-Type"), rec.body.Bytes())
+		if err == nil {
+			return
+		}
+	}
+	log.Printf("Idempotency key completion error: %v", err)
+
+	// キーが期限切れで削除されるまでロックを保つ
+	if err := k.keys.Lock(ctx, id, time.Now().Add(k.ttl)); err != nil {
+		log.Printf("Idempotency key lock extension error: %v", err)
+	}
+}
+
+// release はサーバーエラーで終わったリクエストのキーを削除する
+func (k *idempotencyKeys) release(ctx context.Context, id uuid.UUID) {
+	if err := k.keys.Release(ctx, id); err != nil {
+		log.Printf("Idempotency key release error: %v", err)
+	}
+}
+
+// replay は既存の冪等キーに対応するレスポンスを返す
+func (k *idempotencyKeys) replay(w http.ResponseWriter, actor, key, fingerprint string) {
+	record, err := k.keys.Get(context.Background(), actor, key)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Database error occurred")
+		log.Printf("Idempotency key fetch error: %v", err)
+		return
+	}
+	if record == nil {
+		// 初回リクエストが失敗してキーが解放された直後
+		w.Header().Set("Retry-After", "1")
+		utils.SendErrorResponse(w, http.StatusConflict, "IDEMPOTENCY_KEY_IN_USE", "A request with this Idempotency-Key is being processed")
+		return
+	}
+
+	if record.Fingerprint != fingerprint {
+		utils.SendErrorResponse(w, http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED", "Idempotency-Key was already used with a different request")
+		return
+	}
+
+	if !record.Completed {
+		w.Header().Set("Retry-After", "1")
+		utils.SendErrorResponse(w, http.StatusConflict, "IDEMPOTENCY_KEY_IN_USE", "A request with this Idempotency-Key is being processed")
+		return
+	}
+
+	// 保存済みレスポンスを再送
+	if record.ResponseContentType != "" {
+		w.Header().Set("Content-Type", record.ResponseContentType)
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(record.ResponseStatus)
+	w.Write(record.ResponseBody)
+}
+
+// requestFingerprint はメソッド・パス・クエリ文字列・ボディから冪等キー照合用のハッシュを計算する
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method)
+	h.Write([]byte{0})
+	io.WriteString(h, r.URL.Path)
+	h.Write([]byte{0})
+	io.WriteString(h, r.URL.RawQuery)
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
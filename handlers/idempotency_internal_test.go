@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/database"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/idempotencykey"
+	"github.com/t-okuji/go-openapi-todo-demo/repository"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// newIdempotencyKeys は SQLite に記録を保存する idempotencyKeys と、記録を直接操作するためのクライアントを返す
+func newIdempotencyKeys(t *testing.T) (*idempotencyKeys, *ent.Client) {
+	t.Helper()
+	db, err := database.Open(context.Background(), database.DriverSQLite, "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return &idempotencyKeys{keys: repository.NewEntIdempotencyKeyRepository(db.Client), ttl: time.Hour}, db.Client
+}
+
+func idempotentRequest(body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/todos", strings.NewReader(body))
+	return r.WithContext(utils.WithActor(r.Context(), "alice"))
+}
+
+// serveKey は key で next を実行し、ステータスコードと next が実行されたかを返す
+func serveKey(k *idempotencyKeys, r *http.Request, key string) (int, bool) {
+	called := false
+	rec := httptest.NewRecorder()
+	k.serve(rec, r, &key, func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusCreated)
+	})
+	return rec.Code, called
+}
+
+func TestIdempotencyKeyLease(t *testing.T) {
+	ctx := context.Background()
+	k, client := newIdempotencyKeys(t)
+
+	// 処理中のキーを登録する（ロックの期限内）
+	r := idempotentRequest(`{"title":"a"}`)
+	record := client.IdempotencyKey.Create().
+		SetActor("alice").
+		SetKey("lease").
+		SetFingerprint(requestFingerprint(r, []byte(`{"title":"a"}`))).
+		SetLockedUntil(time.Now().Add(time.Minute)).
+		SetExpiresAt(time.Now().Add(time.Hour)).
+		SaveX(ctx)
+
+	if code, called := serveKey(k, idempotentRequest(`{"title":"a"}`), "lease"); code != http.StatusConflict || called {
+		t.Fatalf("locked key: status %d, called %t, want 409 without calling the handler", code, called)
+	}
+
+	// ロックの期限を過ぎたキーは再試行のリクエストが引き継ぐ
+	client.IdempotencyKey.UpdateOne(record).SetLockedUntil(time.Now().Add(-time.Second)).ExecX(ctx)
+	if code, called := serveKey(k, idempotentRequest(`{"title":"a"}`), "lease"); code != http.StatusCreated || !called {
+		t.Fatalf("expired lock: status %d, called %t, want the handler to run", code, called)
+	}
+	record = client.IdempotencyKey.GetX(ctx, record.ID)
+	if record.Status != idempotencykey.StatusCompleted || record.LockedUntil != nil || record.ResponseStatus != http.StatusCreated {
+		t.Errorf("record after takeover = %+v, want completed", record)
+	}
+
+	// 異なる内容のリクエストはロックの期限を過ぎていても引き継がない
+	client.IdempotencyKey.Create().
+		SetActor("alice").
+		SetKey("other").
+		SetFingerprint(strings.Repeat("0", 64)).
+		SetLockedUntil(time.Now().Add(-time.Second)).
+		SetExpiresAt(time.Now().Add(time.Hour)).
+		ExecX(ctx)
+	if code, called := serveKey(k, idempotentRequest(`{"title":"a"}`), "other"); code != http.StatusUnprocessableEntity || called {
+		t.Errorf("expired lock with other fingerprint: status %d, called %t, want 422", code, called)
+	}
+}
+
+func TestIdempotencyKeyCompletionFailure(t *testing.T) {
+	ctx := context.Background()
+	k, client := newIdempotencyKeys(t)
+
+	// レスポンスの保存に失敗してもキーを削除せず、再送でハンドラーを再実行しない
+	client.IdempotencyKey.Use(func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if _, ok := m.Field(idempotencykey.FieldResponseStatus); ok {
+				return nil, errors.New("connection lost")
+			}
+			return next.Mutate(ctx, m)
+		})
+	})
+
+	r := idempotentRequest(`{}`)
+	if code, called := serveKey(k, r, "failure"); code != http.StatusCreated || !called {
+		t.Fatalf("first request: status %d, called %t", code, called)
+	}
+	record, err := client.IdempotencyKey.Query().Where(idempotencykey.Key("failure")).Only(ctx)
+	if err != nil {
+		t.Fatalf("key after completion failure: %v", err)
+	}
+	if record.Status != idempotencykey.StatusProcessing || record.LockedUntil == nil || record.LockedUntil.Before(record.ExpiresAt) {
+		t.Errorf("record = %+v, want processing and locked until it expires", record)
+	}
+	if code, called := serveKey(k, idempotentRequest(`{}`), "failure"); code != http.StatusConflict || called {
+		t.Errorf("retry: status %d, called %t, want 409", code, called)
+	}
+
+	// 通常のロックの期間を過ぎた再送もキーを引き継がない。キーの有効期限を過ぎた場合のみ新しいリクエストとして扱う
+	fingerprint := requestFingerprint(r, []byte(`{}`))
+	if id, err := k.acquire(ctx, time.Now().Add(2*idempotencyLockTimeout), "alice", "failure", fingerprint); err != nil || id != uuid.Nil {
+		t.Errorf("acquire after the lock timeout = %s, %v, want the key kept locked", id, err)
+	}
+	if id, err := k.acquire(ctx, record.ExpiresAt.Add(time.Second), "alice", "failure", fingerprint); err != nil || id == uuid.Nil {
+		t.Errorf("acquire after the key expired = %s, %v, want a new key", id, err)
+	}
+}
+
+func TestIdempotencyKeyServerError(t *testing.T) {
+	ctx := context.Background()
+	k, client := newIdempotencyKeys(t)
+
+	// サーバーエラーで終わったリクエストのキーは解放し、再試行を許可する
+	key := "error"
+	k.serve(httptest.NewRecorder(), idempotentRequest(`{}`), &key, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	if n := client.IdempotencyKey.Query().CountX(ctx); n != 0 {
+		t.Errorf("keys after server error = %d, want 0", n)
+	}
+	if code, called := serveKey(k, idempotentRequest(`{}`), key); code != http.StatusCreated || !called {
+		t.Errorf("retry: status %d, called %t", code, called)
+	}
+}
+
+func TestRequestFingerprint(t *testing.T) {
+	fingerprint := func(method, target, body string) string {
+		return requestFingerprint(httptest.NewRequest(method, target, nil), []byte(body))
+	}
+	base := fingerprint(http.MethodPost, "/import?dryRun=true", "a")
+	for _, tt := range []struct{ method, target, body string }{
+		{http.MethodPost, "/import?dryRun=false", "a"},
+		{http.MethodPost, "/import", "a"},
+		{http.MethodPost, "/todos?dryRun=true", "a"},
+		{http.MethodPut, "/import?dryRun=true", "a"},
+		{http.MethodPost, "/import?dryRun=true", "b"},
+	} {
+		if fingerprint(tt.method, tt.target, tt.body) == base {
+			t.Errorf("%s %s %q: same fingerprint as POST /import?dryRun=true", tt.method, tt.target, tt.body)
+		}
+	}
+	if fingerprint(http.MethodPost, "/import?dryRun=true", "a") != base {
+		t.Error("fingerprint is not deterministic")
+	}
+}
@@ -0,0 +1,75 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/t-okuji/go-openapi-todo-demo/handlers"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	srv := newSQLiteServer(t)
+	header := func(key, actor string) http.Header {
+		return http.Header{handlers.IdempotencyKeyHeader: {key}, handlers.ActorHeader: {actor}}
+	}
+
+	var first, replayed types.TodoResponse
+	rec := doWithHeader(t, srv.handler, http.MethodPost, "/todos", header("key-1", "alice"), types.TodoInput{Title: "a"}, &first)
+	if rec.Code != http.StatusCreated || rec.Header().Get(handlers.IdempotentReplayedHeader) != "" {
+		t.Fatalf("first request: status %d, replayed %q", rec.Code, rec.Header().Get(handlers.IdempotentReplayedHeader))
+	}
+
+	// 同じキー・同じ内容の再送には保存済みのレスポンスを返す
+	rec = doWithHeader(t, srv.handler, http.MethodPost, "/todos", header("key-1", "alice"), types.TodoInput{Title: "a"}, &replayed)
+	if rec.Code != http.StatusCreated || rec.Header().Get(handlers.IdempotentReplayedHeader) != "true" || replayed.ID != first.ID {
+		t.Errorf("replay: status %d, replayed %q, id %s, want %s", rec.Code, rec.Header().Get(handlers.IdempotentReplayedHeader), replayed.ID, first.ID)
+	}
+
+	// 同じキーを異なる内容で再利用すると 422
+	var errResp types.ErrorResponse
+	rec = doWithHeader(t, srv.handler, http.MethodPost, "/todos", header("key-1", "alice"), types.TodoInput{Title: "b"}, &errResp)
+	if rec.Code != http.StatusUnprocessableEntity || errResp.Error.Code != "IDEMPOTENCY_KEY_REUSED" {
+		t.Errorf("reused key: status %d, code %q", rec.Code, errResp.Error.Code)
+	}
+
+	// キーは操作者ごとに一意
+	var other types.TodoResponse
+	rec = doWithHeader(t, srv.handler, http.MethodPost, "/todos", header("key-1", "bob"), types.TodoInput{Title: "a"}, &other)
+	if rec.Code != http.StatusCreated || other.ID == first.ID {
+		t.Errorf("other actor: status %d, id %s", rec.Code, other.ID)
+	}
+
+	if n := srv.db.Client.Todo.Query().CountX(context.Background()); n != 2 {
+		t.Errorf("todos = %d, want 2", n)
+	}
+}
+
+func TestIdempotencyKeyConcurrentDuplicates(t *testing.T) {
+	srv := newSQLiteServer(t)
+	header := http.Header{handlers.IdempotencyKeyHeader: {"concurrent"}}
+
+	// 同時に送信された同じキーのリクエストは1つだけが実行され、他は再送または 409 になる
+	const n = 8
+	codes := make([]int, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes[i] = doWithHeader(t, srv.handler, http.MethodPost, "/todos", header, types.TodoInput{Title: "a"}, nil).Code
+		}()
+	}
+	wg.Wait()
+
+	for i, code := range codes {
+		if code != http.StatusCreated && code != http.StatusConflict {
+			t.Errorf("request %d: status %d, want 201 or 409", i, code)
+		}
+	}
+	if count := srv.db.Client.Todo.Query().CountX(context.Background()); count != 1 {
+		t.Errorf("todos = %d, want 1", count)
+	}
+}
@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/category"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/todo"
+	"github.com/t-okuji/go-openapi-todo-demo/importer"
+	"github.com/t-okuji/go-openapi-todo-demo/service"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// 取り込み結果の状態（dryRun の場合は取り込んだ場合の結果）
+const (
+	ImportStatusCreated = "created"
+	ImportStatusSkipped = "skipped"
+)
+
+// 取り込みをスキップした理由
+const (
+	ImportReasonInvalidRow      = "INVALID_ROW"
+	ImportReasonEmptyTitle      = "EMPTY_TITLE"
+	ImportReasonDuplicateTitle  = "DUPLICATE_TITLE"
+	ImportReasonDuplicateInFile = "DUPLICATE_IN_FILE"
+	ImportReasonInvalidCategory = "INVALID_CATEGORY"
+)
+
+const (
+	// maxImportFileSize は取り込むファイルの最大サイズ
+	maxImportFileSize = 10 << 20
+	// maxImportRows は1回で取り込める Todo の件数の上限
+	maxImportRows = 10000
+)
+
+// errImportFileTooLarge はアップロードされたファイルが maxImportFileSize を超えた場合のエラー
+var errImportFileTooLarge = &utils.APIError{
+	Status:  http.StatusRequestEntityTooLarge,
+	Code:    "FILE_TOO_LARGE",
+	Message: fmt.Sprintf("file must be %d bytes or less", maxImportFileSize),
+}
+
+// ImportTodos は POST /import リクエストを処理する
+//
+// multipart/form-data の file フィールドのファイルを format フィールドの形式（省略時は拡張子から推測）で読み出し、
+// Todo を作成する。カテゴリは名前で既存のカテゴリと対応付け、存在しない場合は作成する。
+// 既存の Todo またはファイル内の先の行とタイトルが重複する行はスキップする。
+// 全ての Todo はひとつのトランザクションで作成し、dryRun=true の場合は作成せずに結果のみを返す。
+func (s *Server) ImportTodos(w http.ResponseWriter, r *http.Request, params api.ImportTodosParams) {
+	s.serveImport(w, r, isDryRun(params.DryRun), "")
+}
+
+// ImportICS は POST /import/ics リクエストを処理する
+// iCalendar 形式のファイルの VTODO・VEVENT を POST /import と同じ規則で取り込む
+func (s *Server) ImportICS(w http.ResponseWriter, r *http.Request, params api.ImportICSParams) {
+	s.serveImport(w, r, isDryRun(params.DryRun), importer.FormatICS)
+}
+
+// serveImport はアップロードされたファイルを取り込む
+// format を指定した場合は format フィールドに関わらずその形式として読み出す
+func (s *Server) serveImport(w http.ResponseWriter, r *http.Request, dryRun bool, format string) {
+	ctx := r.Context()
+
+	// アップロードされたファイルの取得
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize)
+	if err := r.ParseMultipartForm(maxImportFileSize); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			utils.SendAPIError(w, errImportFileTooLarge)
+			return
+		}
+		utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_REQUEST", "Request must be multipart/form-data")
+		return
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_REQUEST", "file is required")
+		return
+	}
+	defer file.Close()
+
+	if format == "" {
+		format = r.FormValue("format")
+	}
+	if format == "" {
+		format = importer.DetectFormat(header.Filename)
+	}
+	if !slices.Contains(importer.Formats, format) {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "format must be one of "+strings.Join(importer.Formats, ", "))
+		return
+	}
+
+	// ファイルの読み出し
+	rows, err := importer.Parse(format, file)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_FILE", err.Error())
+		return
+	}
+	if len(rows) > maxImportRows {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "IMPORT_TOO_LARGE", fmt.Sprintf("file must contain %d todos or less", maxImportRows))
+		return
+	}
+
+	var response types.ImportResponse
+	if dryRun {
+		response, err = importRows(ctx, s.client, nil, rows)
+	} else {
+		// 全ての Todo・カテゴリを同一トランザクションで作成（監査ログと同一トランザクション）
+		err = utils.WithTx(ctx, s.client, func(tx *ent.Tx) error {
+			var err error
+			response, err = importRows(ctx, tx.Client(), tx, rows)
+			return err
+		})
+	}
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Failed to import Todos")
+		log.Printf("Todo import error: %v", err)
+		return
+	}
+
+	response.DryRun = dryRun
+	response.Format = api.ImportResponseFormat(format)
+	status := http.StatusCreated
+	if dryRun {
+		status = http.StatusOK
+	}
+	utils.SendJSONResponse(w, status, response)
+}
+
+// importRows は読み出した行ごとに取り込むかどうかを判定し、tx を指定した場合は tx の中で Todo とカテゴリを作成する
+// tx が nil の場合（dryRun）は作成せず、同じ判定の結果のみを返す
+func importRows(ctx context.Context, client *ent.Client, tx *ent.Tx, rows []importer.Row) (types.ImportResponse, error) {
+	create := tx != nil
+	var (
+		todos      *service.TodoService
+		categories *service.CategoryService
+	)
+	if create {
+		todos, categories = todoServiceInTx(tx), categoryServiceInTx(tx)
+	}
+
+	response := types.ImportResponse{
+		CategoriesCreated: []string{},
+		Rows:              make([]types.ImportRowResult, len(rows)),
+	}
+
+	// 重複判定のため、ファイル内のタイトルと一致する既存の Todo を取得
+	var titles, names []string
+	for _, row := range rows {
+		if row.Title != "" {
+			titles = append(titles, row.Title)
+		}
+		if row.Category != "" {
+			names = append(names, row.Category)
+		}
+	}
+	existingTitles, err := client.Todo.Query().
+		Where(todo.TitleIn(titles...)).
+		Select(todo.FieldTitle).
+		Strings(ctx)
+	if err != nil {
+		return response, fmt.Errorf("loading existing titles: %w", err)
+	}
+	existing := make(map[string]bool, len(existingTitles))
+	for _, title := range existingTitles {
+		existing[title] = true
+	}
+
+	// 同名のカテゴリが複数ある場合は最も古いカテゴリに対応付ける
+	existingCategories, err := client.Category.Query().
+		Where(category.NameIn(names...)).
+		Order(ent.Desc(category.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return response, fmt.Errorf("loading categories: %w", err)
+	}
+	categoryIDs := make(map[string]uuid.UUID, len(existingCategories))
+	for _, c := range existingCategories {
+		categoryIDs[c.Name] = c.ID
+	}
+
+	inFile := make(map[string]bool, len(rows))
+	for i, row := range rows {
+		result := &response.Rows[i]
+		*result = types.ImportRowResult{Line: row.Line, Title: row.Title, Status: ImportStatusSkipped}
+		if row.Category != "" {
+			result.Category = &row.Category
+		}
+
+		var reason, message string
+		switch {
+		case row.Invalid != "":
+			reason, message = ImportReasonInvalidRow, row.Invalid
+		case row.Title == "":
+			reason, message = ImportReasonEmptyTitle, "Title is required"
+		case inFile[row.Title]:
+			reason, message = ImportReasonDuplicateInFile, "Todo with the same title appears earlier in the file"
+		case existing[row.Title]:
+			reason, message = ImportReasonDuplicateTitle, "Todo with the same title already exists"
+		}
+		if reason != "" {
+			skipImportRow(result, reason, message)
+			response.Skipped++
+			continue
+		}
+
+		// カテゴリの対応付け（存在しない場合は作成）
+		var categoryUUID *uuid.UUID
+		if row.Category != "" {
+			id, ok := categoryIDs[row.Category]
+			if !ok {
+				if err := service.ValidateCategoryInput(types.CategoryInput{Name: row.Category}); err != nil {
+					skipImportRow(result, ImportReasonInvalidCategory, utils.AsAPIError(err).Message)
+					response.Skipped++
+					continue
+				}
+				if create {
+					c, err := categories.Create(ctx, types.CategoryInput{Name: row.Category})
+					if err != nil {
+						return response, fmt.Errorf("creating category %q: %w", row.Category, err)
+					}
+					id = c.ID
+				}
+				categoryIDs[row.Category] = id
+				response.CategoriesCreated = append(response.CategoriesCreated, row.Category)
+			}
+			categoryUUID = &id
+		}
+
+		inFile[row.Title] = true
+		result.Status = ImportStatusCreated
+		response.Created++
+		if !create {
+			continue
+		}
+
+		input := types.TodoInput{Title: row.Title, Completed: &row.Completed}
+		if row.Description != "" {
+			input.Description = &row.Description
+		}
+		if row.DueAt != nil {
+			dueAt := row.DueAt.Format(time.RFC3339)
+			input.DueAt = &dueAt
+		}
+		if categoryUUID != nil {
+			categoryID := categoryUUID.String()
+			input.CategoryID = &categoryID
+		}
+		t, err := todos.Create(ctx, input)
+		if err != nil {
+			return response, fmt.Errorf("creating todo at line %d: %w", row.Line, err)
+		}
+		result.TodoID = &t.ID
+	}
+
+	return response, nil
+}
+
+// skipImportRow は行をスキップした理由のコードと説明を記録する
+func skipImportRow(result *types.ImportRowResult, reason, message string) {
+	code := api.ImportRowResultReason(reason)
+	result.Reason, result.Message = &code, &message
+}
@@ -0,0 +1,142 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/t-okuji/go-openapi-todo-demo/ent/category"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/todo"
+	"github.com/t-okuji/go-openapi-todo-demo/handlers"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+// upload は file フィールドに content を添付した multipart/form-data のリクエストを送信する
+func upload(t *testing.T, h http.Handler, target, filename, content string, out any) *httptest.ResponseRecorder {
+	t.Helper()
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	fw, err := mw.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw.Write([]byte(content))
+	mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, target, &buf)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("POST %s: decode %q: %v", target, rec.Body.String(), err)
+		}
+	}
+	return rec
+}
+
+func TestImportTodos(t *testing.T) {
+	ctx := context.Background()
+	srv := newSQLiteServer(t)
+	h := srv.handler
+
+	var work types.CategoryResponse
+	do(t, h, http.MethodPost, "/categories", types.CategoryInput{Name: "Work"}, &work)
+	do(t, h, http.MethodPost, "/todos", types.TodoInput{Title: "Existing"}, nil)
+
+	file := "title,category_name,completed\n" +
+		"A,Work,\n" +
+		"B,Home,true\n" +
+		"C,Home,\n" +
+		"Existing,,\n" +
+		"A,,\n" +
+		",Work,\n" +
+		"D,,yes\n" +
+		"E," + strings.Repeat("x", 51) + ",\n"
+
+	// dryRun では作成せずに、取り込んだ場合と同じ結果を返す
+	var preview types.ImportResponse
+	if rec := upload(t, h, "/import?dryRun=true", "todos.csv", file, &preview); rec.Code != http.StatusOK {
+		t.Fatalf("dry run: status %d, body %s", rec.Code, rec.Body)
+	}
+	if n := srv.db.Client.Todo.Query().CountX(ctx); n != 1 {
+		t.Errorf("todos after dry run = %d, want 1", n)
+	}
+	if n := srv.db.Client.Category.Query().CountX(ctx); n != 1 {
+		t.Errorf("categories after dry run = %d, want 1", n)
+	}
+
+	var result types.ImportResponse
+	if rec := upload(t, h, "/import", "todos.csv", file, &result); rec.Code != http.StatusCreated {
+		t.Fatalf("import: status %d, body %s", rec.Code, rec.Body)
+	}
+	if !preview.DryRun || result.DryRun || result.Format != "csv" {
+		t.Errorf("dryRun = %t/%t, format = %q", preview.DryRun, result.DryRun, result.Format)
+	}
+
+	wantReasons := []string{"", "", "", handlers.ImportReasonDuplicateTitle, handlers.ImportReasonDuplicateInFile,
+		handlers.ImportReasonEmptyTitle, handlers.ImportReasonInvalidRow, handlers.ImportReasonInvalidCategory}
+	if len(result.Rows) != len(wantReasons) {
+		t.Fatalf("%d rows, want %d", len(result.Rows), len(wantReasons))
+	}
+	for i, row := range result.Rows {
+		var reason string
+		if row.Reason != nil {
+			reason = string(*row.Reason)
+		}
+		if reason != wantReasons[i] {
+			t.Errorf("row %d: reason %q, want %q", i+1, reason, wantReasons[i])
+		}
+		if created := reason == ""; created != (row.TodoID != nil) {
+			t.Errorf("row %d: todoId %v, status %s", i+1, row.TodoID, row.Status)
+		}
+	}
+	if result.Created != 3 || result.Skipped != 5 || !reflect.DeepEqual(result.CategoriesCreated, []string{"Home"}) {
+		t.Errorf("created %d, skipped %d, categories %v, want 3, 5, [Home]", result.Created, result.Skipped, result.CategoriesCreated)
+	}
+
+	// dryRun の結果は作成した Todo の ID を除いて実際の取り込みと一致する
+	for i := range result.Rows {
+		result.Rows[i].TodoID = nil
+	}
+	preview.DryRun = false
+	if !reflect.DeepEqual(preview, result) {
+		t.Errorf("dry run = %+v\nimport = %+v", preview, result)
+	}
+
+	// カテゴリは名前で既存のカテゴリと対応付け、存在しないカテゴリは1回だけ作成する
+	home := srv.db.Client.Category.Query().Where(category.Name("Home")).AllX(ctx)
+	if len(home) != 1 {
+		t.Fatalf("%d Home categories, want 1", len(home))
+	}
+	for title, want := range map[string]string{"A": work.ID.String(), "B": home[0].ID.String(), "C": home[0].ID.String()} {
+		created := srv.db.Client.Todo.Query().Where(todo.Title(title)).OnlyX(ctx)
+		if created.CategoryID == nil || created.CategoryID.String() != want {
+			t.Errorf("todo %s: category %v, want %s", title, created.CategoryID, want)
+		}
+	}
+	if b := srv.db.Client.Todo.Query().Where(todo.Title("B")).OnlyX(ctx); !b.Completed {
+		t.Error("todo B is not completed")
+	}
+
+	// 同じファイルを再度取り込むと全てスキップする
+	var again types.ImportResponse
+	upload(t, h, "/import", "todos.csv", file, &again)
+	if again.Created != 0 || len(again.CategoriesCreated) != 0 {
+		t.Errorf("second import: created %d, categories %v, want none", again.Created, again.CategoriesCreated)
+	}
+
+	var errResp types.ErrorResponse
+	if rec := upload(t, h, "/import", "todos.xlsx", file, &errResp); rec.Code != http.StatusBadRequest || errResp.Error.Code != "INVALID_PARAMETER" {
+		t.Errorf("unknown extension: status %d, code %q", rec.Code, errResp.Error.Code)
+	}
+	if rec := upload(t, h, "/import", "todos.json", "{", &errResp); rec.Code != http.StatusBadRequest || errResp.Error.Code != "INVALID_FILE" {
+		t.Errorf("invalid file: status %d, code %q", rec.Code, errResp.Error.Code)
+	}
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// API 仕様の形式
+const (
+	OpenAPIFormatJSON = "json"
+	OpenAPIFormatYAML = "yaml"
+)
+
+// OpenAPIHandler は $ref を解決した API 仕様を format（json・yaml）で返すハンドラー
+// servers はリクエストのホストに置き換える（openapi.yml の http://localhost:8080 は使わない）
+func OpenAPIHandler(doc *openapi3.T, format string) (http.HandlerFunc, error) {
+	if format != OpenAPIFormatJSON && format != OpenAPIFormatYAML {
+		return nil, fmt.Errorf("unknown OpenAPI format: %s", format)
+	}
+
+	// 汎用の map に変換しておき、リクエストごとに servers だけを差し替える
+	data, err := doc.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var spec map[string]any
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, err
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		served := maps.Clone(spec)
+		served["servers"] = []map[string]any{{"url": requestOrigin(r)}}
+
+		if format == OpenAPIFormatJSON {
+			utils.SendJSONResponse(w, http.StatusOK, served)
+			return
+		}
+		body, err := yaml.Marshal(served)
+		if err != nil {
+			log.Printf("OpenAPI YAML encoding error: %v", err)
+			utils.SendErrorResponse(w, http.StatusInternalServerError, "ENCODING_ERROR", "Failed to encode OpenAPI document")
+			return
+		}
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Write(body)
+	}, nil
+}
+
+// requestOrigin はリクエストのスキームとホストから https://example.com の形式のオリジンを返す
+func requestOrigin(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// pagination は生成コードが変換した limit・offset を検証する
+// limit を省略した場合は defaultLimit（0 の場合は全件）、offset を省略した場合は 0 を返す
+func pagination(limit, offset *int, defaultLimit, maxLimit int) (int, int, error) {
+	l, o := defaultLimit, 0
+	if limit != nil {
+		if *limit < 1 || *limit > maxLimit {
+			return 0, 0, utils.NewInvalidParameterError(fmt.Sprintf("limit must be an integer between 1 and %d", maxLimit))
+		}
+		l = *limit
+	}
+	if offset != nil {
+		if *offset < 0 {
+			return 0, 0, utils.NewInvalidParameterError("offset must be a non-negative integer")
+		}
+		o = *offset
+	}
+	return l, o, nil
+}
+
+// isDryRun は dryRun パラメーターが true かどうかを返す（省略時は false）
+func isDryRun(dryRun *bool) bool {
+	return dryRun != nil && *dryRun
+}
+
+// todoFilter は一覧・エクスポート・カレンダー配信に共通の絞り込みパラメーターを TodoFilter にまとめる
+// 引数は API 仕様のパラメーターと同じ順序で渡す
+func todoFilter(completed *bool, categoryID, search *string, createdBefore, createdAfter, updatedBefore, updatedAfter *time.Time) types.TodoFilter {
+	return types.TodoFilter{
+		Completed:     completed,
+		CategoryID:    categoryID,
+		Search:        search,
+		CreatedBefore: createdBefore,
+		CreatedAfter:  createdAfter,
+		UpdatedBefore: updatedBefore,
+		UpdatedAfter:  updatedAfter,
+	}
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/hooks"
+	"github.com/t-okuji/go-openapi-todo-demo/recurrence"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// 期限変更の範囲
+const (
+	RescheduleScopeThis      = "this"
+	RescheduleScopeFollowing = "following"
+)
+
+// SkipTodoOccurrence は POST /todos/{todoId}/skip リクエストを処理する
+//
+// 繰り返しの Todo のこの回を完了にせずに飛ばし、同じ Todo を系列の次の回の期限に進める。
+// 系列が終わっている場合は飛ばせない。
+func (s *Server) SkipTodoOccurrence(w http.ResponseWriter, r *http.Request, todoUUID uuid.UUID) {
+	ctx := r.Context()
+
+	var updated *ent.Todo
+	err := utils.WithTx(ctx, s.client, func(tx *ent.Tx) error {
+		t, err := loadOpenRecurringTodo(ctx, tx.Client(), todoUUID)
+		if err != nil {
+			return err
+		}
+		dueAt, ok, err := hooks.NextOccurrence(t)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return utils.ErrRecurrenceEnded
+		}
+
+		updated, err = tx.Todo.UpdateOne(t).
+			SetDueAt(dueAt).
+			SetOccurrence(*t.Occurrence + 1).
+			Save(ctx)
+		return err
+	})
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, utils.ConvertToTodoResponse(updated))
+}
+
+// RescheduleTodo は POST /todos/{todoId}/reschedule リクエストを処理する
+//
+// scope=this の場合はこの回の期限のみを変更し、以降の回は元の系列の日時のまま発生する。
+// scope=following の場合は新しい期限を1回目とする系列を開始し、以降の回を新しい期限に合わせてずらす。
+// COUNT を指定したルールは、元の系列の残りの回数に減らす。
+func (s *Server) RescheduleTodo(w http.ResponseWriter, r *http.Request, todoUUID uuid.UUID) {
+	ctx := r.Context()
+
+	// リクエストボディをパース
+	var req types.RescheduleTodoRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendAPIError(w, utils.ErrInvalidJSON)
+		return
+	}
+	dueAt := req.DueAt
+	scope := RescheduleScopeThis
+	if req.Scope != nil {
+		scope = string(*req.Scope)
+	}
+	if scope != RescheduleScopeThis && scope != RescheduleScopeFollowing {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "VALIDATION_ERROR", "scope must be one of this, following")
+		return
+	}
+
+	var updated *ent.Todo
+	err := utils.WithTx(ctx, s.client, func(tx *ent.Tx) error {
+		t, err := loadOpenRecurringTodo(ctx, tx.Client(), todoUUID)
+		if err != nil {
+			return err
+		}
+
+		updateQuery := tx.Todo.UpdateOne(t).SetDueAt(dueAt)
+		if scope == RescheduleScopeFollowing {
+			rule, err := recurrence.Parse(t.Recurrence)
+			if err != nil {
+				return err
+			}
+			// 元の系列で発生済みの回数を除く
+			if rule.Count > 0 {
+				rule.Count -= *t.Occurrence - 1
+			}
+			updateQuery.
+				SetRecurrence(rule.String()).
+				SetSeriesID(uuid.New()).
+				SetSeriesStart(dueAt).
+				SetOccurrence(1)
+		}
+
+		updated, err = updateQuery.Save(ctx)
+		return err
+	})
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, utils.ConvertToTodoResponse(updated))
+}
+
+// loadOpenRecurringTodo は未完了の繰り返しの Todo を読み込む
+func loadOpenRecurringTodo(ctx context.Context, client *ent.Client, todoUUID uuid.UUID) (*ent.Todo, error) {
+	t, err := client.Todo.Get(ctx, todoUUID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, utils.ErrTodoNotFound
+		}
+		return nil, err
+	}
+	if t.SeriesID == nil || t.Occurrence == nil {
+		return nil, utils.ErrTodoNotRecurring
+	}
+	if t.Completed {
+		return nil, utils.ErrTodoCompleted
+	}
+	return t, nil
+}
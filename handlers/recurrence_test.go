@@ -0,0 +1,114 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/handlers"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+func TestRecurringTodoScopes(t *testing.T) {
+	h := newSQLiteServer(t).handler
+
+	// create は 2026-03-07 09:00（ニューヨーク時間）を1回目として rule で繰り返す Todo を作成する
+	create := func(rule string) types.TodoResponse {
+		t.Helper()
+		dueAt, zone := "2026-03-07T09:00:00-05:00", "America/New_York"
+		var created types.TodoResponse
+		if rec := do(t, h, http.MethodPost, "/todos", types.TodoInput{Title: rule, DueAt: &dueAt, Recurrence: &rule, TimeZone: &zone}, &created); rec.Code != http.StatusCreated {
+			t.Fatalf("create todo: status %d, body %s", rec.Code, rec.Body)
+		}
+		return created
+	}
+	// post は Todo の操作（skip・reschedule）を実行し、更新後の Todo を返す
+	post := func(todo types.TodoResponse, action string, body any) types.TodoResponse {
+		t.Helper()
+		var updated types.TodoResponse
+		if rec := do(t, h, http.MethodPost, "/todos/"+todo.ID.String()+"/"+action, body, &updated); rec.Code != http.StatusOK {
+			t.Fatalf("%s: status %d, body %s", action, rec.Code, rec.Body)
+		}
+		return updated
+	}
+	check := func(step string, todo types.TodoResponse, dueAt string, occurrence int) {
+		t.Helper()
+		if todo.DueAt == nil || todo.DueAt.UTC().Format(time.RFC3339) != dueAt || todo.Occurrence == nil || *todo.Occurrence != occurrence {
+			t.Errorf("%s: dueAt %v, occurrence %v, want %s, %d", step, todo.DueAt, todo.Occurrence, dueAt, occurrence)
+		}
+	}
+	// at は RFC 3339 形式の日時を time.Time に変換する（オフセットを保つ）
+	at := func(s string) time.Time {
+		t.Helper()
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	wantError := func(step string, todo types.TodoResponse, action string, body any, status int, code string) {
+		t.Helper()
+		var errResp types.ErrorResponse
+		if rec := do(t, h, http.MethodPost, "/todos/"+todo.ID.String()+"/"+action, body, &errResp); rec.Code != status || errResp.Error.Code != code {
+			t.Errorf("%s: status %d, code %q, want %d %s", step, rec.Code, errResp.Error.Code, status, code)
+		}
+	}
+
+	t.Run("skip", func(t *testing.T) {
+		// 飛ばすと同じ Todo を次の回に進める。夏時間の開始後も現地時刻の 09:00 になる
+		todo := create("FREQ=DAILY;COUNT=3")
+		todo = post(todo, "skip", nil)
+		check("first skip", todo, "2026-03-08T13:00:00Z", 2)
+		todo = post(todo, "skip", nil)
+		check("second skip", todo, "2026-03-09T13:00:00Z", 3)
+		wantError("skip past COUNT", todo, "skip", nil, http.StatusConflict, "RECURRENCE_ENDED")
+	})
+
+	t.Run("reschedule this", func(t *testing.T) {
+		// この回の期限のみを変更し、以降の回は元の系列の日時で発生する
+		todo := create("FREQ=DAILY")
+		series := *todo.SeriesID
+		todo = post(todo, "reschedule", types.RescheduleTodoRequest{DueAt: at("2026-03-07T15:00:00-05:00")})
+		check("reschedule", todo, "2026-03-07T20:00:00Z", 1)
+		if *todo.SeriesID != series || *todo.Recurrence != "FREQ=DAILY" {
+			t.Errorf("series %s, recurrence %s, want unchanged", todo.SeriesID, *todo.Recurrence)
+		}
+		todo = post(todo, "skip", nil)
+		check("skip after reschedule", todo, "2026-03-08T13:00:00Z", 2)
+	})
+
+	t.Run("reschedule following", func(t *testing.T) {
+		// 新しい期限から系列を開始し、COUNT を残りの回数に減らす
+		todo := create("FREQ=DAILY;COUNT=5")
+		series := *todo.SeriesID
+		todo = post(todo, "skip", nil)
+		following := api.RescheduleTodoRequestScope(handlers.RescheduleScopeFollowing)
+		todo = post(todo, "reschedule", types.RescheduleTodoRequest{DueAt: at("2026-03-10T18:30:00-04:00"), Scope: &following})
+		check("reschedule", todo, "2026-03-10T22:30:00Z", 1)
+		if *todo.SeriesID == series || *todo.Recurrence != "FREQ=DAILY;COUNT=4" {
+			t.Errorf("series %s, recurrence %s, want a new series with COUNT=4", todo.SeriesID, *todo.Recurrence)
+		}
+		for i := 2; i <= 4; i++ {
+			todo = post(todo, "skip", nil)
+		}
+		check("skip after reschedule", todo, "2026-03-13T22:30:00Z", 4)
+		wantError("skip past COUNT", todo, "skip", nil, http.StatusConflict, "RECURRENCE_ENDED")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var plain types.TodoResponse
+		do(t, h, http.MethodPost, "/todos", types.TodoInput{Title: "plain"}, &plain)
+		wantError("skip non-recurring", plain, "skip", nil, http.StatusConflict, "TODO_NOT_RECURRING")
+
+		todo := create("FREQ=WEEKLY")
+		wantError("invalid scope", todo, "reschedule", map[string]string{"dueAt": "2026-03-08T09:00:00Z", "scope": "all"}, http.StatusBadRequest, "VALIDATION_ERROR")
+
+		completed := true
+		dueAt, zone, rule := "2026-03-07T09:00:00-05:00", "America/New_York", "FREQ=WEEKLY"
+		if rec := do(t, h, http.MethodPut, "/todos/"+todo.ID.String(), types.TodoInput{Title: "FREQ=WEEKLY", Completed: &completed, DueAt: &dueAt, Recurrence: &rule, TimeZone: &zone}, nil); rec.Code != http.StatusOK {
+			t.Fatalf("complete: status %d, body %s", rec.Code, rec.Body)
+		}
+		wantError("skip completed", todo, "skip", nil, http.StatusConflict, "TODO_COMPLETED")
+	})
+}
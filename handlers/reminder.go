@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/reminder"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/todo"
+	"github.com/t-okuji/go-openapi-todo-demo/reminders"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// maxReminderOffsetMinutes は期限からの相対時間の絶対値の上限（366日）
+const maxReminderOffsetMinutes = 366 * 24 * 60
+
+// GetReminders は GET /todos/{todoId}/reminders リクエストを処理する
+func (s *Server) GetReminders(w http.ResponseWriter, r *http.Request, todoUUID uuid.UUID) {
+	ctx := r.Context()
+
+	exists, err := s.client.Todo.Query().Where(todo.ID(todoUUID)).Exist(ctx)
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
+	if !exists {
+		utils.SendAPIError(w, utils.ErrTodoNotFound)
+		return
+	}
+
+	list, err := s.client.Reminder.Query().
+		Where(reminder.TodoID(todoUUID)).
+		Order(ent.Asc(reminder.FieldCreatedAt), ent.Asc(reminder.FieldID)).
+		All(ctx)
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
+
+	responses := make([]types.ReminderResponse, len(list))
+	for i, rem := range list {
+		responses[i] = utils.ConvertToReminderResponse(rem)
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, responses)
+}
+
+// CreateReminder は POST /todos/{todoId}/reminders リクエストを処理する
+//
+// remindAt を指定した場合はその日時に、offsetMinutes を指定した場合は Todo の期限からの相対時間に通知する。
+// 相対時間のリマインダーは、Todo に期限が設定されるまで通知されない。
+func (s *Server) CreateReminder(w http.ResponseWriter, r *http.Request, todoUUID uuid.UUID) {
+	ctx := r.Context()
+
+	var input types.ReminderInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.SendAPIError(w, utils.ErrInvalidJSON)
+		return
+	}
+	remindAt, err := validateReminderInput(input)
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
+
+	t, err := s.client.Todo.Get(ctx, todoUUID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			utils.SendAPIError(w, utils.ErrTodoNotFound)
+			return
+		}
+		utils.SendAPIError(w, err)
+		return
+	}
+
+	created, err := s.client.Reminder.Create().
+		SetTodoID(t.ID).
+		SetNillableRemindAt(remindAt).
+		SetNillableOffsetMinutes(input.OffsetMinutes).
+		SetNillableFireAt(reminders.FireAt(remindAt, input.OffsetMinutes, t.DueAt)).
+		Save(ctx)
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusCreated, utils.ConvertToReminderResponse(created))
+}
+
+// DeleteReminder は DELETE /todos/{todoId}/reminders/{reminderId} リクエストを処理する
+func (s *Server) DeleteReminder(w http.ResponseWriter, r *http.Request, todoUUID, reminderUUID uuid.UUID) {
+	deleted, err := s.client.Reminder.Delete().
+		Where(
+			reminder.ID(reminderUUID),
+			reminder.TodoID(todoUUID),
+		).
+		Exec(r.Context())
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
+	if deleted == 0 {
+		utils.SendAPIError(w, utils.ErrReminderNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validateReminderInput はリマインダーの入力を検証し、日時を指定した場合はその日時を返す
+func validateReminderInput(input types.ReminderInput) (*time.Time, error) {
+	if (input.RemindAt == nil) == (input.OffsetMinutes == nil) {
+		return nil, utils.ErrInvalidReminder
+	}
+	if input.OffsetMinutes != nil {
+		if *input.OffsetMinutes < -maxReminderOffsetMinutes || *input.OffsetMinutes > maxReminderOffsetMinutes {
+			return nil, utils.ErrInvalidReminderOffset
+		}
+		return nil, nil
+	}
+	return input.RemindAt, nil
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/database"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/events"
+	"github.com/t-okuji/go-openapi-todo-demo/repository"
+	"github.com/t-okuji/go-openapi-todo-demo/service"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// Server は API 仕様から生成した api.ServerInterface を実装し、各操作を処理する
+//
+// リクエストは ValidateRequests が API 仕様に従って検証してから、生成コードがパスパラメーター・
+// クエリパラメーター・ヘッダーを型付きの値に変換して呼び出す。各操作はリクエストから
+// パラメーターを読み直さないため、仕様のパラメーターを変更すると実装との食い違いがビルドで検出される。
+type Server struct {
+	client      *ent.Client
+	todos       *service.TodoService
+	categories  *service.CategoryService
+	broker      *events.Broker
+	features    database.Features
+	idempotency *idempotencyKeys
+	webSocket   *webSocketHub
+}
+
+var _ api.ServerInterface = (*Server)(nil)
+
+// Config は Server が操作の処理に使うサービス・リポジトリと設定を表す
+type Config struct {
+	// Todos・Categories は Todo・カテゴリの操作（一括更新・一括削除・WebSocket の編集を含む）を処理する
+	Todos      *service.TodoService
+	Categories *service.CategoryService
+	// IdempotencyKeys は Idempotency-Key を受け付ける操作のレスポンスを IdempotencyTTL の間保存する
+	IdempotencyKeys repository.IdempotencyKeyRepository
+	IdempotencyTTL  time.Duration
+	// Broker は変更イベントを SSE・WebSocket のクライアントに配信する
+	Broker *events.Broker
+	// Client は Ent のトランザクション・エンティティを直接扱う操作（一括処理・同期・インポート・エクスポート・
+	// カレンダー・監査ログ・変更履歴・繰り返し・リマインダー・Webhook）に使う
+	// nil の場合、これらの操作は 501 Not Implemented を返す
+	Client *ent.Client
+	// Features はデータベースが対応している機能。対応していない機能の操作は 501 Not Implemented を返す
+	Features database.Features
+}
+
+// New は config のサービス・リポジトリで各操作を処理する api.ServerInterface を作成する
+func New(config Config) api.ServerInterface {
+	server := &Server{
+		client:      config.Client,
+		todos:       config.Todos,
+		categories:  config.Categories,
+		broker:      config.Broker,
+		features:    config.Features,
+		idempotency: &idempotencyKeys{keys: config.IdempotencyKeys, ttl: config.IdempotencyTTL},
+		webSocket:   newWebSocketHub(config.Todos, config.Categories, config.Broker),
+	}
+	if config.Client == nil {
+		return serviceServer{server: server}
+	}
+	return server
+}
+
+// NewServer は client に保存するサービス・リポジトリで全ての操作を処理する Server を作成する
+// Idempotency-Key を受け付ける操作は、idempotencyTTL の間レスポンスを保存して再送に備える
+// データベースが対応していない機能（features）の操作は 501 Not Implemented を返す
+func NewServer(client *ent.Client, broker *events.Broker, idempotencyTTL time.Duration, features database.Features) api.ServerInterface {
+	categories := repository.NewEntCategoryRepository(client)
+	return New(Config{
+		Todos:           service.NewTodoService(repository.NewEntTodoRepository(client), categories),
+		Categories:      service.NewCategoryService(categories),
+		IdempotencyKeys: repository.NewEntIdempotencyKeyRepository(client),
+		IdempotencyTTL:  idempotencyTTL,
+		Broker:          broker,
+		Client:          client,
+		Features:        features,
+	})
+}
+
+// serviceServer は Ent のクライアントなしで作成した Server
+// サービスで処理できる Todo・カテゴリの操作と変更イベントの配信だけを行い、その他の操作は 501 Not Implemented を返す
+type serviceServer struct {
+	api.Unimplemented
+	server *Server
+}
+
+func (s serviceServer) GetCategories(w http.ResponseWriter, r *http.Request) {
+	s.server.GetCategories(w, r)
+}
+
+func (s serviceServer) CreateCategory(w http.ResponseWriter, r *http.Request, params api.CreateCategoryParams) {
+	s.server.CreateCategory(w, r, params)
+}
+
+func (s serviceServer) DeleteCategory(w http.ResponseWriter, r *http.Request, categoryID uuid.UUID) {
+	s.server.DeleteCategory(w, r, categoryID)
+}
+
+func (s serviceServer) GetCategoryByID(w http.ResponseWriter, r *http.Request, categoryID uuid.UUID) {
+	s.server.GetCategoryByID(w, r, categoryID)
+}
+
+func (s serviceServer) UpdateCategory(w http.ResponseWriter, r *http.Request, categoryID uuid.UUID) {
+	s.server.UpdateCategory(w, r, categoryID)
+}
+
+func (s serviceServer) StreamEvents(w http.ResponseWriter, r *http.Request, params api.StreamEventsParams) {
+	s.server.StreamEvents(w, r, params)
+}
+
+func (s serviceServer) GetTodos(w http.ResponseWriter, r *http.Request, params api.GetTodosParams) {
+	s.server.GetTodos(w, r, params)
+}
+
+func (s serviceServer) CreateTodo(w http.ResponseWriter, r *http.Request, params api.CreateTodoParams) {
+	s.server.CreateTodo(w, r, params)
+}
+
+func (s serviceServer) DeleteTodo(w http.ResponseWriter, r *http.Request, todoID uuid.UUID) {
+	s.server.DeleteTodo(w, r, todoID)
+}
+
+func (s serviceServer) GetTodoByID(w http.ResponseWriter, r *http.Request, todoID uuid.UUID) {
+	s.server.GetTodoByID(w, r, todoID)
+}
+
+func (s serviceServer) UpdateTodo(w http.ResponseWriter, r *http.Request, todoID uuid.UUID) {
+	s.server.UpdateTodo(w, r, todoID)
+}
+
+func (s serviceServer) BulkDeleteTodos(w http.ResponseWriter, r *http.Request, params api.BulkDeleteTodosParams) {
+	s.server.BulkDeleteTodos(w, r, params)
+}
+
+func (s serviceServer) BulkUpdateTodos(w http.ResponseWriter, r *http.Request, params api.BulkUpdateTodosParams) {
+	s.server.BulkUpdateTodos(w, r, params)
+}
+
+func (s serviceServer) ConnectWebSocket(w http.ResponseWriter, r *http.Request, params api.ConnectWebSocketParams) {
+	s.server.ConnectWebSocket(w, r, params)
+}
+
+// todoServiceInTx は tx の中で Todo を操作する TodoService を作成する
+// 一括処理・同期・インポートなど、複数の変更をひとつのトランザクションで行う操作で使う
+func todoServiceInTx(tx *ent.Tx) *service.TodoService {
+	return service.NewTodoService(repository.NewEntTodoRepositoryTx(tx), repository.NewEntCategoryRepositoryTx(tx))
+}
+
+// categoryServiceInTx は tx の中でカテゴリを操作する CategoryService を作成する
+func categoryServiceInTx(tx *ent.Tx) *service.CategoryService {
+	return service.NewCategoryService(repository.NewEntCategoryRepositoryTx(tx))
+}
+
+// ParamErrorHandler は生成コードによるパラメーターの変換エラーを VALIDATION_ERROR として返す
+// パラメーターは ValidateRequests が先に API 仕様で検証するため、通常は呼び出されない
+func ParamErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	var (
+		formatErr   *api.InvalidParamFormatError
+		requiredErr *api.RequiredParamError
+		detail      types.FieldError
+	)
+	switch {
+	case errors.As(err, &formatErr):
+		detail = types.FieldError{Field: formatErr.ParamName, Message: formatErr.Err.Error()}
+	case errors.As(err, &requiredErr):
+		detail = types.FieldError{Field: requiredErr.ParamName, Message: "value is required but missing"}
+	default:
+		detail = types.FieldError{Field: "request", Message: err.Error()}
+	}
+	utils.SendAPIError(w, utils.NewValidationError([]types.FieldError{detail}))
+}
@@ -0,0 +1,105 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/database"
+	"github.com/t-okuji/go-openapi-todo-demo/db/migrations"
+	"github.com/t-okuji/go-openapi-todo-demo/events"
+	"github.com/t-okuji/go-openapi-todo-demo/handlers"
+	"github.com/t-okuji/go-openapi-todo-demo/hooks"
+	"github.com/t-okuji/go-openapi-todo-demo/openapi"
+)
+
+// testServer はテスト用のデータベースで処理する API サーバー
+type testServer struct {
+	handler http.Handler
+	db      *database.DB
+	broker  *events.Broker
+}
+
+// newSQLiteServer は SQLite のインメモリデータベースで処理する API サーバーを作成する
+// データベースはテストごとに作成し、テストの終了時に閉じる
+func newSQLiteServer(t *testing.T) *testServer {
+	t.Helper()
+	ctx := context.Background()
+
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	db, err := database.Open(ctx, database.DriverSQLite, "file:"+name+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return newTestServer(t, db)
+}
+
+// newPostgresServer は TEST_POSTGRES_DSN の PostgreSQL データベースで処理する API サーバーを作成する
+// TEST_POSTGRES_DSN が未設定の場合はテストをスキップする。マイグレーションを適用し、全てのテーブルを空にしてから使う
+func newPostgresServer(t *testing.T) *testServer {
+	t.Helper()
+	ctx := context.Background()
+
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+	db, err := database.Open(ctx, database.DriverPostgres, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	all, err := database.LoadMigrations(migrations.FS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := database.NewMigrator(db.SQL, all).Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.SQL.ExecContext(ctx, `TRUNCATE categories, todos, idempotency_keys, audit_events, webhook_subscriptions,
+		webhook_deliveries, calendar_tokens, reminders RESTART IDENTITY CASCADE`); err != nil {
+		t.Fatal(err)
+	}
+	return newTestServer(t, db)
+}
+
+// newTestServer は db に main と同じフック・ミドルウェアを登録した API サーバーを作成する
+func newTestServer(t *testing.T, db *database.DB) *testServer {
+	t.Helper()
+
+	broker := events.NewBroker(100)
+	hooks.RegisterEvents(db.Client, broker)
+	hooks.RegisterWebhooks(db.Client)
+	hooks.RegisterAudit(db.Client)
+	hooks.RegisterVersioning(db.Client)
+	hooks.RegisterRecurrence(db.Client)
+	hooks.RegisterReminders(db.Client)
+
+	spec, err := openapi.Spec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	validator, err := handlers.ValidateRequests(spec, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	r.Use(handlers.Actor)
+	r.Use(validator)
+	api.HandlerWithOptions(handlers.NewServer(db.Client, broker, time.Hour, db.Features), api.ChiServerOptions{
+		BaseRouter:       r,
+		ErrorHandlerFunc: handlers.ParamErrorHandler,
+	})
+
+	return &testServer{handler: r, db: db, broker: broker}
+}
@@ -0,0 +1,942 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/auditevent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/category"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/todo"
+	"github.com/t-okuji/go-openapi-todo-demo/hooks"
+	"github.com/t-okuji/go-openapi-todo-demo/repository"
+	"github.com/t-okuji/go-openapi-todo-demo/service"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// 同期APIの変更の適用結果
+const (
+	SyncStatusApplied  = "applied"
+	SyncStatusConflict = "conflict"
+	SyncStatusFailed   = "failed"
+)
+
+// 同期APIの競合の解決結果
+const (
+	// SyncResolutionClient はクライアントの値を適用したことを表す
+	SyncResolutionClient = "client"
+	// SyncResolutionServer はサーバーの値を維持したことを表す
+	SyncResolutionServer = "server"
+)
+
+const (
+	// maxSyncChanges は1リクエストで受け付ける変更数の上限
+	maxSyncChanges = 500
+	// 全件取得で1ページに返すエンティティ数の既定値と上限
+	defaultSyncPageSize = 500
+	maxSyncPageSize     = 1000
+)
+
+var (
+	errSyncInvalidEntity    = &utils.APIError{Status: http.StatusBadRequest, Code: "INVALID_ENTITY", Message: "entity must be todo or category"}
+	errSyncInvalidOp        = &utils.APIError{Status: http.StatusBadRequest, Code: "INVALID_OPERATION", Message: "op must be one of create, update, delete"}
+	errSyncIDRequired       = &utils.APIError{Status: http.StatusBadRequest, Code: "INVALID_REQUEST", Message: "id is required"}
+	errSyncModifiedAt       = &utils.APIError{Status: http.StatusBadRequest, Code: "INVALID_REQUEST", Message: "modifiedAt is required"}
+	errSyncCategoryNotFound = &utils.APIError{Status: http.StatusNotFound, Code: "NOT_FOUND", Message: "Category not found"}
+)
+
+// syncFieldKind は同期APIで変更できるフィールドの値の種類
+type syncFieldKind int
+
+const (
+	syncFieldString         syncFieldKind = iota // 文字列
+	syncFieldOptionalString                      // 文字列または null（解除）
+	syncFieldBool                                // 真偽値
+)
+
+// syncEntity は同期APIで扱うエンティティ種別ごとのフィールドと操作
+type syncEntity struct {
+	fields map[string]syncFieldKind
+	// load は現在の状態と最終更新日時を返す。存在しない場合は nil の状態を返す
+	load func(ctx context.Context, client *ent.Client, id uuid.UUID) (hooks.Snapshot, time.Time, error)
+	// save は状態を検証し、tx の中でエンティティを作成または更新する
+	save   func(ctx context.Context, tx *ent.Tx, id uuid.UUID, s hooks.Snapshot, create bool) error
+	delete func(ctx context.Context, tx *ent.Tx, id uuid.UUID) error
+	// result は現在のエンティティを適用結果に設定する
+	result   func(ctx context.Context, client *ent.Client, id uuid.UUID, result *types.SyncChangeResult) error
+	notFound error
+}
+
+var syncEntities = map[string]syncEntity{
+	hooks.EntityTodo: {
+		fields: map[string]syncFieldKind{
+			"title":       syncFieldString,
+			"description": syncFieldOptionalString,
+			"completed":   syncFieldBool,
+			"categoryId":  syncFieldOptionalString,
+			"dueAt":       syncFieldOptionalString,
+			"recurrence":  syncFieldOptionalString,
+			"timeZone":    syncFieldOptionalString,
+		},
+		load: loadSyncTodo,
+		save: saveSyncTodo,
+		delete: func(ctx context.Context, tx *ent.Tx, id uuid.UUID) error {
+			return todoServiceInTx(tx).Delete(ctx, id)
+		},
+		result:   syncTodoResult,
+		notFound: utils.ErrTodoNotFound,
+	},
+	hooks.EntityCategory: {
+		fields: map[string]syncFieldKind{
+			"name":        syncFieldString,
+			"description": syncFieldOptionalString,
+			"color":       syncFieldString,
+		},
+		load: loadSyncCategory,
+		save: saveSyncCategory,
+		delete: func(ctx context.Context, tx *ent.Tx, id uuid.UUID) error {
+			return repository.DeleteCategory(ctx, tx.Client(), id)
+		},
+		result:   syncCategoryResult,
+		notFound: errSyncCategoryNotFound,
+	},
+}
+
+// GetSyncChanges は GET /sync リクエストを処理する
+//
+// since を省略した場合は全ての Todo・カテゴリを limit 件ずつのページに分けて返す。since を指定した場合は、
+// その同期トークンの発行以降に作成・更新されたエンティティと、削除されたエンティティ（トゥームストーン）を返す。
+// 変更は監査ログから検出する。
+func (s *Server) GetSyncChanges(w http.ResponseWriter, r *http.Request, params api.GetSyncChangesParams) {
+	ctx := r.Context()
+	if !s.features.Sync {
+		utils.SendAPIError(w, utils.ErrSyncNotSupported)
+		return
+	}
+
+	var since *uint64
+	if params.Since != nil && *params.Since != "" {
+		xmin, err := parseSyncToken(*params.Since)
+		if err != nil {
+			utils.SendAPIError(w, err)
+			return
+		}
+		since = &xmin
+	}
+	var cursor *syncCursor
+	if params.Cursor != nil && *params.Cursor != "" {
+		if since != nil {
+			utils.SendAPIError(w, utils.NewInvalidParameterError("cursor cannot be combined with since"))
+			return
+		}
+		c, err := parseSyncCursor(*params.Cursor)
+		if err != nil {
+			utils.SendAPIError(w, err)
+			return
+		}
+		cursor = &c
+	}
+	limit, _, err := pagination(params.Limit, nil, defaultSyncPageSize, maxSyncPageSize)
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
+
+	// 同期トークンとエンティティを同一のスナップショットから読み出す
+	tx, err := s.client.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
+	defer tx.Rollback()
+
+	var response types.SyncResponse
+	if since == nil {
+		response, err = loadSyncPage(ctx, tx, cursor, limit)
+	} else {
+		response, err = loadSyncChanges(ctx, tx, *since)
+	}
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Database error occurred")
+		log.Printf("Sync fetch error: %v", err)
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response)
+}
+
+// PushSyncChanges は POST /sync リクエストを処理する
+//
+// オフライン中にクライアントで行われた変更をリクエストの順に適用する。フィールドごとに最後に変更された値を採用し
+// （last-writer-wins）、サーバーでの変更がクライアントの変更日時より新しいフィールドはサーバーの値を維持する。
+// since を指定した場合は、クライアントが取得していないサーバーの変更を上書きしたフィールドも競合として報告する。
+// 全ての変更はひとつのトランザクションで実行し、失敗した変更のみをセーブポイントでロールバックする。
+func (s *Server) PushSyncChanges(w http.ResponseWriter, r *http.Request, params api.PushSyncChangesParams) {
+	if !s.features.Sync {
+		utils.SendAPIError(w, utils.ErrSyncNotSupported)
+		return
+	}
+	s.idempotency.serve(w, r, params.IdempotencyKey, s.pushSyncChanges)
+}
+
+func (s *Server) pushSyncChanges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// リクエストボディをパース
+	var req types.SyncPushRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format")
+		return
+	}
+
+	// リクエストの検証
+	if len(req.Changes) == 0 {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_REQUEST", "changes must not be empty")
+		return
+	}
+	if len(req.Changes) > maxSyncChanges {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "SYNC_TOO_LARGE", fmt.Sprintf("changes must be %d or less", maxSyncChanges))
+		return
+	}
+	var since *uint64
+	if req.Since != nil && *req.Since != "" {
+		xmin, err := parseSyncToken(*req.Since)
+		if err != nil {
+			utils.SendAPIError(w, err)
+			return
+		}
+		since = &xmin
+	}
+
+	response := types.SyncPushResponse{
+		Results:   make([]types.SyncChangeResult, len(req.Changes)),
+		Conflicts: []types.SyncConflict{},
+	}
+
+	// 全ての変更をひとつのトランザクションで適用
+	err := utils.WithTx(ctx, s.client, func(tx *ent.Tx) error {
+		for i, change := range req.Changes {
+			var (
+				result    types.SyncChangeResult
+				conflicts []types.SyncConflict
+			)
+			opErr, err := runInSavepoint(ctx, tx, fmt.Sprintf("sync_change_%d", i), func(ctx context.Context) error {
+				var err error
+				result, conflicts, err = applySyncChange(ctx, tx, i, change, since)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+
+			if opErr != nil {
+				apiErr := utils.AsAPIError(opErr)
+				if apiErr == utils.ErrDatabase {
+					log.Printf("Sync change %d error: %v", i, opErr)
+				}
+				result = types.SyncChangeResult{
+					Index:  i,
+					Entity: string(change.Entity),
+					ID:     change.ID,
+					Status: SyncStatusFailed,
+					Error:  &types.ErrorDetail{Code: apiErr.Code, Message: apiErr.Message},
+				}
+				conflicts = nil
+			}
+
+			response.Results[i] = result
+			response.Conflicts = append(response.Conflicts, conflicts...)
+		}
+		return nil
+	})
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Failed to apply sync changes")
+		log.Printf("Sync push error: %v", err)
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response)
+}
+
+// newSyncResponse は xmin を同期トークンとする空のレスポンスを作成する
+func newSyncResponse(xmin uint64, full bool) types.SyncResponse {
+	return types.SyncResponse{
+		Token:             encodeSyncToken(xmin),
+		Full:              full,
+		Todos:             []types.TodoResponse{},
+		Categories:        []types.CategoryResponse{},
+		DeletedTodos:      []types.SyncTombstone{},
+		DeletedCategories: []types.SyncTombstone{},
+	}
+}
+
+// loadSyncPage は全件取得の1ページ分のカテゴリ・Todo を、カテゴリ・Todo の順に作成日時・ID順で limit 件まで読み込む
+//
+// cursor が nil の場合は最初のページとして同期トークンを発行し、続きのページでは最初のページの同期トークンを返す。
+// ページの間に行われた変更は、最初のページの同期トークンからの差分同期で取得できる。
+func loadSyncPage(ctx context.Context, tx *ent.Tx, cursor *syncCursor, limit int) (types.SyncResponse, error) {
+	// スナップショットを確定させるため、最初のクエリで同期トークンを取得する
+	xmin, err := currentSyncXmin(ctx, tx)
+	if err != nil {
+		return types.SyncResponse{}, fmt.Errorf("reading sync token: %w", err)
+	}
+	after := syncCursor{xmin: xmin, entity: hooks.EntityCategory}
+	if cursor != nil {
+		after = *cursor
+	}
+
+	// Todo が参照するカテゴリを先に返す。続きの有無を判定するため1件多く読み込む
+	var (
+		categories []*ent.Category
+		todos      []*ent.Todo
+	)
+	if after.entity == hooks.EntityCategory {
+		query := tx.Category.Query().
+			Order(ent.Asc(category.FieldCreatedAt), ent.Asc(category.FieldID)).
+			Limit(limit + 1)
+		if cursor != nil {
+			query.Where(category.Or(
+				category.CreatedAtGT(after.createdAt),
+				category.And(category.CreatedAt(after.createdAt), category.IDGT(after.id)),
+			))
+		}
+		if categories, err = query.All(ctx); err != nil {
+			return types.SyncResponse{}, err
+		}
+	}
+	if len(categories) <= limit {
+		query := tx.Todo.Query().
+			Order(ent.Asc(todo.FieldCreatedAt), ent.Asc(todo.FieldID)).
+			Limit(limit - len(categories) + 1)
+		if cursor != nil && after.entity == hooks.EntityTodo {
+			query.Where(todo.Or(
+				todo.CreatedAtGT(after.createdAt),
+				todo.And(todo.CreatedAt(after.createdAt), todo.IDGT(after.id)),
+			))
+		}
+		if todos, err = query.All(ctx); err != nil {
+			return types.SyncResponse{}, err
+		}
+	}
+
+	// 1件多く読み込めた場合は続きがあるため、このページの最後のエンティティを次のページのカーソルとする
+	var next *syncCursor
+	switch {
+	case len(categories) > limit:
+		categories = categories[:limit]
+		c := categories[limit-1]
+		next = &syncCursor{xmin: after.xmin, entity: hooks.EntityCategory, createdAt: c.CreatedAt, id: c.ID}
+	case len(categories)+len(todos) > limit:
+		todos = todos[:limit-len(categories)]
+		if len(todos) == 0 {
+			c := categories[len(categories)-1]
+			next = &syncCursor{xmin: after.xmin, entity: hooks.EntityCategory, createdAt: c.CreatedAt, id: c.ID}
+		} else {
+			t := todos[len(todos)-1]
+			next = &syncCursor{xmin: after.xmin, entity: hooks.EntityTodo, createdAt: t.CreatedAt, id: t.ID}
+		}
+	}
+
+	response := newSyncResponse(after.xmin, true)
+	for _, c := range categories {
+		response.Categories = append(response.Categories, utils.ConvertToCategoryResponse(c))
+	}
+	for _, t := range todos {
+		response.Todos = append(response.Todos, utils.ConvertToTodoResponse(t))
+	}
+	if next != nil {
+		token := encodeSyncCursor(*next)
+		response.NextCursor = &token
+	}
+	return response, nil
+}
+
+// loadSyncChanges は since 以降に変更されたエンティティと、削除されたエンティティのトゥームストーンを読み込む
+func loadSyncChanges(ctx context.Context, tx *ent.Tx, since uint64) (types.SyncResponse, error) {
+	// スナップショットを確定させるため、最初のクエリで同期トークンを取得する
+	xmin, err := currentSyncXmin(ctx, tx)
+	if err != nil {
+		return types.SyncResponse{}, fmt.Errorf("reading sync token: %w", err)
+	}
+	response := newSyncResponse(xmin, false)
+
+	changed, err := changedSyncEntities(ctx, tx, since)
+	if err != nil {
+		return types.SyncResponse{}, fmt.Errorf("reading changed entities: %w", err)
+	}
+
+	todos, err := tx.Todo.Query().
+		Where(todo.IDIn(changed[hooks.EntityTodo]...)).
+		Order(ent.Asc(todo.FieldCreatedAt), ent.Asc(todo.FieldID)).
+		All(ctx)
+	if err != nil {
+		return types.SyncResponse{}, err
+	}
+	existing := make(map[uuid.UUID]bool, len(todos))
+	for _, t := range todos {
+		response.Todos = append(response.Todos, utils.ConvertToTodoResponse(t))
+		existing[t.ID] = true
+	}
+
+	categories, err := tx.Category.Query().
+		Where(category.IDIn(changed[hooks.EntityCategory]...)).
+		Order(ent.Asc(category.FieldCreatedAt), ent.Asc(category.FieldID)).
+		All(ctx)
+	if err != nil {
+		return types.SyncResponse{}, err
+	}
+	for _, c := range categories {
+		response.Categories = append(response.Categories, utils.ConvertToCategoryResponse(c))
+		existing[c.ID] = true
+	}
+
+	// 変更されたが現在は存在しないエンティティは削除済み
+	missing := func(ids []uuid.UUID) []uuid.UUID {
+		return slices.DeleteFunc(ids, func(id uuid.UUID) bool { return existing[id] })
+	}
+	if response.DeletedTodos, err = loadSyncTombstones(ctx, tx.Client(), hooks.EntityTodo, missing(changed[hooks.EntityTodo])); err != nil {
+		return types.SyncResponse{}, err
+	}
+	if response.DeletedCategories, err = loadSyncTombstones(ctx, tx.Client(), hooks.EntityCategory, missing(changed[hooks.EntityCategory])); err != nil {
+		return types.SyncResponse{}, err
+	}
+
+	return response, nil
+}
+
+// currentSyncXmin は実行中のトランザクションのスナップショットの xmin を返す
+//
+// xmin より前のトランザクションは全て完了しているため、このスナップショットから読み出した状態に
+// 含まれていない変更は、全て xmin 以降のトランザクションで記録された監査ログから検出できる。
+func currentSyncXmin(ctx context.Context, tx *ent.Tx) (uint64, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT pg_snapshot_xmin(pg_current_snapshot())::text")
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var xmin string
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return 0, err
+		}
+		return 0, sql.ErrNoRows
+	}
+	if err := rows.Scan(&xmin); err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(xmin, 10, 64)
+}
+
+// changedSyncEntities はトランザクション since 以降に監査ログに記録されたエンティティのIDを種別ごとに返す
+func changedSyncEntities(ctx context.Context, tx *ent.Tx, since uint64) (map[string][]uuid.UUID, error) {
+	rows, err := tx.QueryContext(ctx,
+		"SELECT entity_type, entity_id FROM audit_events WHERE transaction_id >= $1::text::xid8 GROUP BY entity_type, entity_id",
+		strconv.FormatUint(since, 10))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changed := make(map[string][]uuid.UUID)
+	for rows.Next() {
+		var (
+			entityType string
+			entityID   uuid.UUID
+		)
+		if err := rows.Scan(&entityType, &entityID); err != nil {
+			return nil, err
+		}
+		changed[entityType] = append(changed[entityType], entityID)
+	}
+	return changed, rows.Err()
+}
+
+// loadSyncTombstones は削除済みのエンティティのトゥームストーンを監査ログの削除イベントから作成する
+func loadSyncTombstones(ctx context.Context, client *ent.Client, entityType string, ids []uuid.UUID) ([]types.SyncTombstone, error) {
+	tombstones := []types.SyncTombstone{}
+	if len(ids) == 0 {
+		return tombstones, nil
+	}
+
+	deletions, err := client.AuditEvent.Query().
+		Where(
+			auditevent.EntityType(entityType),
+			auditevent.EntityIDIn(ids...),
+			auditevent.OperationEQ(auditevent.OperationDelete),
+		).
+		Order(ent.Asc(auditevent.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deletedAt := make(map[uuid.UUID]time.Time, len(deletions))
+	for _, e := range deletions {
+		deletedAt[e.EntityID] = e.CreatedAt
+	}
+
+	for _, id := range ids {
+		if at, ok := deletedAt[id]; ok {
+			tombstones = append(tombstones, types.SyncTombstone{ID: id, DeletedAt: at})
+		}
+	}
+	return tombstones, nil
+}
+
+// encodeSyncToken はスナップショットの xmin を同期トークンに変換する
+func encodeSyncToken(xmin uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(xmin, 10)))
+}
+
+// parseSyncToken は同期トークンからスナップショットの xmin を取り出す
+func parseSyncToken(token string) (uint64, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, utils.ErrInvalidSyncToken
+	}
+	xmin, err := strconv.ParseUint(string(b), 10, 64)
+	if err != nil {
+		return 0, utils.ErrInvalidSyncToken
+	}
+	return xmin, nil
+}
+
+// syncCursor は全件取得で最後に返したエンティティ（次のページはこの次から返す）
+type syncCursor struct {
+	// xmin は最初のページで発行した同期トークンの xmin
+	xmin      uint64
+	entity    string
+	createdAt time.Time
+	id        uuid.UUID
+}
+
+// encodeSyncCursor はカーソルを "xmin:種別:作成日時（Unix ナノ秒）:ID" の形式で符号化する
+func encodeSyncCursor(c syncCursor) string {
+	s := fmt.Sprintf("%d:%s:%d:%s", c.xmin, c.entity, c.createdAt.UnixNano(), c.id)
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+// parseSyncCursor は encodeSyncCursor で符号化したカーソルを取り出す
+func parseSyncCursor(s string) (syncCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return syncCursor{}, utils.ErrInvalidSyncCursor
+	}
+	fields := strings.Split(string(b), ":")
+	if len(fields) != 4 || (fields[1] != hooks.EntityCategory && fields[1] != hooks.EntityTodo) {
+		return syncCursor{}, utils.ErrInvalidSyncCursor
+	}
+	xmin, err1 := strconv.ParseUint(fields[0], 10, 64)
+	createdAt, err2 := strconv.ParseInt(fields[2], 10, 64)
+	id, err3 := uuid.Parse(fields[3])
+	if err1 != nil || err2 != nil || err3 != nil {
+		return syncCursor{}, utils.ErrInvalidSyncCursor
+	}
+	return syncCursor{xmin: xmin, entity: fields[1], createdAt: time.Unix(0, createdAt), id: id}, nil
+}
+
+// applySyncChange はクライアントの変更をひとつ適用し、適用結果と競合を返す
+func applySyncChange(ctx context.Context, tx *ent.Tx, index int, change types.SyncChange, since *uint64) (types.SyncChangeResult, []types.SyncConflict, error) {
+	client := tx.Client()
+	result := types.SyncChangeResult{Index: index, Entity: string(change.Entity), ID: change.ID, Status: SyncStatusApplied}
+
+	// 変更の検証
+	entity, ok := syncEntities[string(change.Entity)]
+	if !ok {
+		return result, nil, errSyncInvalidEntity
+	}
+	if change.Op != BatchOpCreate && change.Op != BatchOpUpdate && change.Op != BatchOpDelete {
+		return result, nil, errSyncInvalidOp
+	}
+	id := change.ID
+	if id == uuid.Nil {
+		return result, nil, errSyncIDRequired
+	}
+	if change.ModifiedAt.IsZero() {
+		return result, nil, errSyncModifiedAt
+	}
+	// 時計のずれたクライアントの変更が常に優先されないよう、未来の日時は現在時刻として扱う
+	modifiedAt := change.ModifiedAt
+	if now := time.Now(); modifiedAt.After(now) {
+		modifiedAt = now
+	}
+	var (
+		fields hooks.Snapshot
+		err    error
+	)
+	if change.Op != BatchOpDelete {
+		if fields, err = decodeSyncFields(entity, change.Fields); err != nil {
+			return result, nil, err
+		}
+	}
+
+	current, lastModified, err := entity.load(ctx, client, id)
+	if err != nil {
+		return result, nil, err
+	}
+	history, err := loadSyncHistory(ctx, client, string(change.Entity), id, since, lastModified)
+	if err != nil {
+		return result, nil, err
+	}
+
+	var conflicts []types.SyncConflict
+	conflict := func(field string, clientValue, serverValue any, at time.Time, resolution string) {
+		c := types.SyncConflict{
+			Index:            index,
+			Entity:           api.SyncConflictEntity(change.Entity),
+			ID:               change.ID,
+			ClientValue:      clientValue,
+			ServerValue:      serverValue,
+			ServerModifiedAt: at,
+			Resolution:       api.SyncConflictResolution(resolution),
+		}
+		if field != "" {
+			c.Field = &field
+		}
+		conflicts = append(conflicts, c)
+	}
+
+	switch {
+	case current == nil && history.deletedAt != nil:
+		// サーバーで削除済みのエンティティへの変更は適用しない
+		if change.Op != BatchOpDelete {
+			result.Status = SyncStatusConflict
+			conflict("", fields, nil, *history.deletedAt, SyncResolutionServer)
+		}
+		return result, conflicts, nil
+
+	case current == nil:
+		switch change.Op {
+		case BatchOpCreate:
+			if err := entity.save(ctx, tx, id, fields, true); err != nil {
+				return result, nil, err
+			}
+		case BatchOpUpdate:
+			return result, nil, entity.notFound
+		default:
+			// 存在しないエンティティの削除は何もしない
+			return result, nil, nil
+		}
+
+	case change.Op == BatchOpDelete:
+		latest := history.latest()
+		if latest.at.After(modifiedAt) {
+			// クライアントでの削除後にサーバーで変更されている場合は削除しない
+			result.Status = SyncStatusConflict
+			conflict("", nil, current, latest.at, SyncResolutionServer)
+			break
+		}
+		if err := entity.delete(ctx, tx, id); err != nil {
+			return result, nil, err
+		}
+		if latest.unseen {
+			conflict("", nil, current, latest.at, SyncResolutionClient)
+		}
+		return result, conflicts, nil
+
+	default:
+		// 作成の再送を含め、既存のエンティティへの変更はフィールドごとに新しい方の値を採用する
+		merged := maps.Clone(current)
+		applied := false
+		for _, name := range slices.Sorted(maps.Keys(fields)) {
+			value := fields[name]
+			if value == current[name] {
+				continue
+			}
+			clock := history.field(name)
+			if clock.at.After(modifiedAt) {
+				result.Status = SyncStatusConflict
+				conflict(name, value, current[name], clock.at, SyncResolutionServer)
+				continue
+			}
+			if clock.unseen {
+				conflict(name, value, current[name], clock.at, SyncResolutionClient)
+			}
+			merged[name] = value
+			applied = true
+		}
+		if applied {
+			if err := entity.save(ctx, tx, id, merged, false); err != nil {
+				return result, nil, err
+			}
+		}
+	}
+
+	if err := entity.result(ctx, client, id, &result); err != nil {
+		return result, nil, err
+	}
+	return result, conflicts, nil
+}
+
+// decodeSyncFields はクライアントが変更したフィールドの値を検証して取り出す
+// 任意項目の空文字列は null（解除）として扱う
+func decodeSyncFields(entity syncEntity, raw map[string]json.RawMessage) (hooks.Snapshot, error) {
+	fields := make(hooks.Snapshot, len(raw))
+	for name, data := range raw {
+		kind, ok := entity.fields[name]
+		if !ok {
+			return nil, syncValidationError("unknown field %q", name)
+		}
+
+		var value any
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &value); err != nil {
+				return nil, utils.ErrInvalidJSON
+			}
+		}
+
+		switch kind {
+		case syncFieldString:
+			if _, ok := value.(string); !ok {
+				return nil, syncValidationError("%s must be a string", name)
+			}
+		case syncFieldOptionalString:
+			if value == "" {
+				value = nil
+			}
+			if _, ok := value.(string); !ok && value != nil {
+				return nil, syncValidationError("%s must be a string or null", name)
+			}
+		case syncFieldBool:
+			if _, ok := value.(bool); !ok {
+				return nil, syncValidationError("%s must be a boolean", name)
+			}
+		}
+		fields[name] = value
+	}
+	return fields, nil
+}
+
+// syncValidationError は同期APIの変更の検証エラーを作成する
+func syncValidationError(format string, args ...any) *utils.APIError {
+	return &utils.APIError{Status: http.StatusBadRequest, Code: "VALIDATION_ERROR", Message: fmt.Sprintf(format, args...)}
+}
+
+// syncClock はフィールドの最終変更日時と、その変更をクライアントが取得していないかどうかを表す
+type syncClock struct {
+	at     time.Time
+	unseen bool
+}
+
+// syncHistory は監査ログから復元したエンティティの変更履歴を表す
+type syncHistory struct {
+	created   syncClock
+	fields    map[string]syncClock
+	deletedAt *time.Time
+}
+
+// field はフィールドの最終変更を返す。作成後に変更されていないフィールドは作成日時となる
+func (h syncHistory) field(name string) syncClock {
+	if c, ok := h.fields[name]; ok && c.at.After(h.created.at) {
+		return c
+	}
+	return h.created
+}
+
+// latest はいずれかのフィールドの最終変更日時と、クライアントが取得していない変更があるかどうかを返す
+func (h syncHistory) latest() syncClock {
+	latest := h.created
+	for _, c := range h.fields {
+		if c.at.After(latest.at) {
+			latest.at = c.at
+		}
+		latest.unseen = latest.unseen || c.unseen
+	}
+	return latest
+}
+
+// loadSyncHistory は監査ログからエンティティの変更履歴を読み込む
+//
+// 実行中のトランザクション（同じリクエストで先に適用した変更）の記録は含めない。
+// 作成が記録されていない（監査ログ導入前に作成された）エンティティは、最初の記録の時点
+// （記録がない場合は lastModified）で全てのフィールドが変更されたものとして扱う。
+func loadSyncHistory(ctx context.Context, client *ent.Client, entityType string, id uuid.UUID, since *uint64, lastModified time.Time) (syncHistory, error) {
+	rows, err := client.QueryContext(ctx,
+		"SELECT operation, changes, created_at, transaction_id::text FROM audit_events "+
+			"WHERE entity_type = $1 AND entity_id = $2 AND transaction_id <> pg_current_xact_id() ORDER BY id",
+		entityType, id)
+	if err != nil {
+		return syncHistory{}, err
+	}
+	defer rows.Close()
+
+	history := syncHistory{created: syncClock{at: lastModified}, fields: make(map[string]syncClock)}
+	first := true
+	for rows.Next() {
+		var (
+			operation     string
+			changes       []byte
+			at            time.Time
+			transactionID string
+		)
+		if err := rows.Scan(&operation, &changes, &at, &transactionID); err != nil {
+			return syncHistory{}, err
+		}
+		xid, err := strconv.ParseUint(transactionID, 10, 64)
+		if err != nil {
+			return syncHistory{}, err
+		}
+		if first {
+			history.created.at = at
+			first = false
+		}
+
+		switch auditevent.Operation(operation) {
+		case auditevent.OperationCreate:
+			history = syncHistory{created: syncClock{at: at}, fields: make(map[string]syncClock)}
+		case auditevent.OperationUpdate:
+			var fieldChanges map[string]types.FieldChange
+			if err := json.Unmarshal(changes, &fieldChanges); err != nil {
+				return syncHistory{}, err
+			}
+			for name := range fieldChanges {
+				history.fields[name] = syncClock{at: at, unseen: since != nil && xid >= *since}
+			}
+		case auditevent.OperationDelete:
+			history.deletedAt = &at
+		}
+	}
+	return history, rows.Err()
+}
+
+// loadSyncTodo は Todo の現在の状態を読み込む
+func loadSyncTodo(ctx context.Context, client *ent.Client, id uuid.UUID) (hooks.Snapshot, time.Time, error) {
+	t, err := client.Todo.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, time.Time{}, nil
+		}
+		return nil, time.Time{}, err
+	}
+	return hooks.TodoSnapshot(t), t.UpdatedAt, nil
+}
+
+// saveSyncTodo は同期APIでマージした状態で Todo を作成または更新する
+func saveSyncTodo(ctx context.Context, tx *ent.Tx, id uuid.UUID, s hooks.Snapshot, create bool) error {
+	title, _ := s["title"].(string)
+	description, _ := s["description"].(string)
+	completed, _ := s["completed"].(bool)
+	categoryID, _ := s["categoryId"].(string)
+	dueAt, _ := s["dueAt"].(string)
+	recurrence, _ := s["recurrence"].(string)
+	timeZone, _ := s["timeZone"].(string)
+
+	input := types.TodoInput{Title: title, Completed: &completed}
+	if create {
+		if description != "" {
+			input.Description = &description
+		}
+		if categoryID != "" {
+			input.CategoryID = &categoryID
+		}
+		if dueAt != "" {
+			input.DueAt = &dueAt
+		}
+		if recurrence != "" {
+			input.Recurrence = &recurrence
+			input.TimeZone = &timeZone
+		}
+	} else {
+		// 空文字列の説明・カテゴリID・期限・繰り返しルールは解除として扱われる
+		input.Description = &description
+		input.CategoryID = &categoryID
+		input.DueAt = &dueAt
+		input.Recurrence = &recurrence
+		if recurrence != "" {
+			input.TimeZone = &timeZone
+		}
+	}
+
+	todos := todoServiceInTx(tx)
+	var err error
+	if create {
+		_, err = todos.CreateWithID(ctx, id, input)
+	} else {
+		_, err = todos.Update(ctx, id, input)
+	}
+	return err
+}
+
+// syncTodoResult は現在の Todo を適用結果に設定する
+func syncTodoResult(ctx context.Context, client *ent.Client, id uuid.UUID, result *types.SyncChangeResult) error {
+	t, err := client.Todo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	response := utils.ConvertToTodoResponse(t)
+	result.Todo = &response
+	return nil
+}
+
+// loadSyncCategory はカテゴリの現在の状態を読み込む
+func loadSyncCategory(ctx context.Context, client *ent.Client, id uuid.UUID) (hooks.Snapshot, time.Time, error) {
+	c, err := client.Category.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, time.Time{}, nil
+		}
+		return nil, time.Time{}, err
+	}
+	return hooks.CategorySnapshot(c), c.UpdatedAt, nil
+}
+
+// saveSyncCategory は同期APIでマージした状態でカテゴリを作成または更新する
+func saveSyncCategory(ctx context.Context, tx *ent.Tx, id uuid.UUID, s hooks.Snapshot, create bool) error {
+	client := tx.Client()
+	name, _ := s["name"].(string)
+	description, _ := s["description"].(string)
+	color, _ := s["color"].(string)
+
+	input := types.CategoryInput{Name: name, Description: &description}
+	if color != "" {
+		input.Color = &color
+	}
+	if err := service.ValidateCategoryInput(input); err != nil {
+		return err
+	}
+
+	if create {
+		createBuilder := client.Category.Create().
+			SetID(id).
+			SetName(name).
+			SetNillableColor(input.Color)
+		if description != "" {
+			createBuilder.SetDescription(description)
+		}
+		return createBuilder.Exec(ctx)
+	}
+
+	updateBuilder := client.Category.UpdateOneID(id).
+		SetName(name).
+		SetNillableColor(input.Color)
+	if description == "" {
+		updateBuilder.ClearDescription()
+	} else {
+		updateBuilder.SetDescription(description)
+	}
+	return updateBuilder.Exec(ctx)
+}
+
+// syncCategoryResult は現在のカテゴリを適用結果に設定する
+func syncCategoryResult(ctx context.Context, client *ent.Client, id uuid.UUID, result *types.SyncChangeResult) error {
+	c, err := client.Category.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	response := utils.ConvertToCategoryResponse(c)
+	result.Category = &response
+	return nil
+}
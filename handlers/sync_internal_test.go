@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/hooks"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+func TestSyncCursor(t *testing.T) {
+	want := syncCursor{
+		xmin:      12345,
+		entity:    hooks.EntityTodo,
+		createdAt: time.Date(2026, 3, 1, 9, 30, 0, 123456000, time.UTC),
+		id:        uuid.New(),
+	}
+	got, err := parseSyncCursor(encodeSyncCursor(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.xmin != want.xmin || got.entity != want.entity || !got.createdAt.Equal(want.createdAt) || got.id != want.id {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	for _, s := range []string{
+		"not base64!",
+		encode("1:todo:0"),
+		encode("1:reminder:0:" + want.id.String()),
+		encode("x:todo:0:" + want.id.String()),
+		encode("1:todo:x:" + want.id.String()),
+		encode("1:todo:0:not-a-uuid"),
+	} {
+		if _, err := parseSyncCursor(s); err != utils.ErrInvalidSyncCursor {
+			t.Errorf("parseSyncCursor(%q) error = %v, want ErrInvalidSyncCursor", s, err)
+		}
+	}
+}
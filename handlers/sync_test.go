@@ -0,0 +1,218 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/t-okuji/go-openapi-todo-demo/handlers"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+func TestSyncNotSupportedOnSQLite(t *testing.T) {
+	h := newSQLiteServer(t).handler
+
+	var errResp types.ErrorResponse
+	if rec := do(t, h, http.MethodGet, "/sync", nil, &errResp); rec.Code != http.StatusNotImplemented {
+		t.Errorf("GET /sync: status %d, code %q, want 501", rec.Code, errResp.Error.Code)
+	}
+}
+
+func TestSyncFullPaging(t *testing.T) {
+	h := newPostgresServer(t).handler
+
+	// カテゴリ3件・Todo 4件を作成し、3件ずつのページで全件を取得する
+	categories := make(map[string]bool)
+	for _, name := range []string{"a", "b", "c"} {
+		var c types.CategoryResponse
+		if rec := do(t, h, http.MethodPost, "/categories", types.CategoryInput{Name: name}, &c); rec.Code != http.StatusCreated {
+			t.Fatalf("create category: status %d", rec.Code)
+		}
+		categories[c.ID.String()] = true
+	}
+	todos := make(map[string]bool)
+	for _, title := range []string{"1", "2", "3", "4"} {
+		var created types.TodoResponse
+		if rec := do(t, h, http.MethodPost, "/todos", types.TodoInput{Title: title}, &created); rec.Code != http.StatusCreated {
+			t.Fatalf("create todo: status %d", rec.Code)
+		}
+		todos[created.ID.String()] = true
+	}
+
+	var (
+		token  string
+		pages  []types.SyncResponse
+		cursor string
+	)
+	for {
+		target := "/sync?limit=3"
+		if cursor != "" {
+			target += "&cursor=" + url.QueryEscape(cursor)
+		}
+		var page types.SyncResponse
+		if rec := do(t, h, http.MethodGet, target, nil, &page); rec.Code != http.StatusOK {
+			t.Fatalf("GET %s: status %d", target, rec.Code)
+		}
+		if !page.Full {
+			t.Errorf("page %d: full = false", len(pages)+1)
+		}
+		if token == "" {
+			token = page.Token
+		} else if page.Token != token {
+			t.Errorf("page %d: token %q, want the first page's token %q", len(pages)+1, page.Token, token)
+		}
+		pages = append(pages, page)
+		if page.NextCursor == nil {
+			break
+		}
+		cursor = *page.NextCursor
+		if len(pages) > 3 {
+			t.Fatal("too many pages")
+		}
+	}
+
+	// カテゴリを先に返し、ページの境界でエンティティが重複・欠落しない
+	wantSizes := [][2]int{{3, 0}, {0, 3}, {0, 1}}
+	if len(pages) != len(wantSizes) {
+		t.Fatalf("%d pages, want %d", len(pages), len(wantSizes))
+	}
+	for i, page := range pages {
+		if got := [2]int{len(page.Categories), len(page.Todos)}; got != wantSizes[i] {
+			t.Errorf("page %d: %d categories, %d todos, want %v", i+1, got[0], got[1], wantSizes[i])
+		}
+		for _, c := range page.Categories {
+			if !categories[c.ID.String()] {
+				t.Errorf("page %d: unexpected or duplicate category %s", i+1, c.ID)
+			}
+			delete(categories, c.ID.String())
+		}
+		for _, todo := range page.Todos {
+			if !todos[todo.ID.String()] {
+				t.Errorf("page %d: unexpected or duplicate todo %s", i+1, todo.ID)
+			}
+			delete(todos, todo.ID.String())
+		}
+	}
+	if len(categories) != 0 || len(todos) != 0 {
+		t.Errorf("missing %d categories, %d todos", len(categories), len(todos))
+	}
+
+	for _, tc := range []struct{ target, code string }{
+		{"/sync?cursor=invalid", "INVALID_SYNC_CURSOR"},
+		{"/sync?cursor=" + url.QueryEscape(*pages[0].NextCursor) + "&since=" + token, "INVALID_PARAMETER"},
+	} {
+		var errResp types.ErrorResponse
+		if rec := do(t, h, http.MethodGet, tc.target, nil, &errResp); rec.Code != http.StatusBadRequest || errResp.Error.Code != tc.code {
+			t.Errorf("GET %s: status %d, code %q, want 400 %s", tc.target, rec.Code, errResp.Error.Code, tc.code)
+		}
+	}
+}
+
+func TestSyncTombstones(t *testing.T) {
+	h := newPostgresServer(t).handler
+
+	var kept, deleted types.TodoResponse
+	do(t, h, http.MethodPost, "/todos", types.TodoInput{Title: "kept"}, &kept)
+	do(t, h, http.MethodPost, "/todos", types.TodoInput{Title: "deleted"}, &deleted)
+	var c types.CategoryResponse
+	do(t, h, http.MethodPost, "/categories", types.CategoryInput{Name: "work"}, &c)
+
+	var full types.SyncResponse
+	if rec := do(t, h, http.MethodGet, "/sync", nil, &full); rec.Code != http.StatusOK {
+		t.Fatalf("full sync: status %d", rec.Code)
+	}
+
+	// 同期トークンの発行後の変更・削除のみを差分として返す
+	if rec := do(t, h, http.MethodPut, "/todos/"+kept.ID.String(), types.TodoInput{Title: "kept 2"}, nil); rec.Code != http.StatusOK {
+		t.Fatalf("update todo: status %d", rec.Code)
+	}
+	if rec := do(t, h, http.MethodDelete, "/todos/"+deleted.ID.String(), nil, nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete todo: status %d", rec.Code)
+	}
+	if rec := do(t, h, http.MethodDelete, "/categories/"+c.ID.String(), nil, nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete category: status %d", rec.Code)
+	}
+
+	var delta types.SyncResponse
+	if rec := do(t, h, http.MethodGet, "/sync?since="+full.Token, nil, &delta); rec.Code != http.StatusOK {
+		t.Fatalf("delta sync: status %d", rec.Code)
+	}
+	if delta.Full {
+		t.Error("delta sync: full = true")
+	}
+	if len(delta.Todos) != 1 || delta.Todos[0].ID != kept.ID || delta.Todos[0].Title != "kept 2" {
+		t.Errorf("delta todos = %+v, want only the updated todo", delta.Todos)
+	}
+	if len(delta.DeletedTodos) != 1 || delta.DeletedTodos[0].ID != deleted.ID || delta.DeletedTodos[0].DeletedAt.IsZero() {
+		t.Errorf("deleted todos = %+v, want a tombstone for %s", delta.DeletedTodos, deleted.ID)
+	}
+	if len(delta.Categories) != 0 || len(delta.DeletedCategories) != 1 || delta.DeletedCategories[0].ID != c.ID {
+		t.Errorf("categories = %+v, deleted = %+v, want a tombstone for %s", delta.Categories, delta.DeletedCategories, c.ID)
+	}
+
+	// 新しい同期トークンからの差分には何も含まれない
+	var empty types.SyncResponse
+	do(t, h, http.MethodGet, "/sync?since="+delta.Token, nil, &empty)
+	if len(empty.Todos)+len(empty.DeletedTodos)+len(empty.Categories)+len(empty.DeletedCategories) != 0 {
+		t.Errorf("delta after the latest token = %+v, want no changes", empty)
+	}
+}
+
+func TestSyncFieldLastWriterWins(t *testing.T) {
+	h := newPostgresServer(t).handler
+
+	description := "original"
+	var created types.TodoResponse
+	if rec := do(t, h, http.MethodPost, "/todos", types.TodoInput{Title: "original", Description: &description}, &created); rec.Code != http.StatusCreated {
+		t.Fatalf("create todo: status %d", rec.Code)
+	}
+	id := created.ID.String()
+
+	// クライアントがオフラインで変更した後に、サーバーでタイトルのみを変更する
+	offline := time.Now()
+	time.Sleep(10 * time.Millisecond)
+	if rec := do(t, h, http.MethodPut, "/todos/"+id, types.TodoInput{Title: "server", Description: &description}, nil); rec.Code != http.StatusOK {
+		t.Fatalf("update todo: status %d", rec.Code)
+	}
+
+	push := func(modifiedAt time.Time, fields map[string]string) types.SyncPushResponse {
+		t.Helper()
+		raw := make(map[string]json.RawMessage, len(fields))
+		for name, value := range fields {
+			raw[name], _ = json.Marshal(value)
+		}
+		var resp types.SyncPushResponse
+		req := types.SyncPushRequest{Changes: []types.SyncChange{
+			{Entity: "todo", Op: handlers.BatchOpUpdate, ID: created.ID, Fields: raw, ModifiedAt: modifiedAt},
+		}}
+		if rec := do(t, h, http.MethodPost, "/sync", req, &resp); rec.Code != http.StatusOK || len(resp.Results) != 1 {
+			t.Fatalf("POST /sync: status %d, body %s", rec.Code, rec.Body)
+		}
+		return resp
+	}
+
+	// サーバーでの変更より古いタイトルの変更は適用せず、変更されていない説明は適用する
+	resp := push(offline, map[string]string{"title": "client", "description": "client"})
+	result := resp.Results[0]
+	if result.Status != handlers.SyncStatusConflict || result.Todo == nil {
+		t.Fatalf("older change: result %+v, want conflict with the todo", result)
+	}
+	if result.Todo.Title != "server" || result.Todo.Description == nil || *result.Todo.Description != "client" {
+		t.Errorf("older change: todo title %q, description %v, want server title and client description", result.Todo.Title, result.Todo.Description)
+	}
+	if len(resp.Conflicts) != 1 || resp.Conflicts[0].Field == nil || *resp.Conflicts[0].Field != "title" || resp.Conflicts[0].Resolution != handlers.SyncResolutionServer {
+		t.Errorf("older change: conflicts %+v, want title resolved to server", resp.Conflicts)
+	}
+
+	// サーバーでの変更より新しい変更は適用する
+	resp = push(time.Now(), map[string]string{"title": "client"})
+	result = resp.Results[0]
+	if result.Status != handlers.SyncStatusApplied || result.Todo == nil || result.Todo.Title != "client" {
+		t.Errorf("newer change: result %+v, want the client title applied", result)
+	}
+	if len(resp.Conflicts) != 0 {
+		t.Errorf("newer change: conflicts %+v, want none", resp.Conflicts)
+	}
+}
@@ -1,263 +1,130 @@
 package handlers
 
 import (
-	"context"
 	"encoding/json"
 	"log"
 	"net/http"
+	"strconv"
 
-	"github.com/go-chi/chi/v5"
-	"github.com/t-okuji/go-openapi-todo-demo/ent"
-	"github.com/t-okuji/go-openapi-todo-demo/ent/category"
-	"github.com/t-okuji/go-openapi-todo-demo/ent/todo"
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/repository"
+	"github.com/t-okuji/go-openapi-todo-demo/service"
 	"github.com/t-okuji/go-openapi-todo-demo/types"
 	"github.com/t-okuji/go-openapi-todo-demo/utils"
 )
 
-// GetTodosHandler は GET /todos リクエストを処理する
-func GetTodosHandler(client *ent.Client) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		ctx := context.Background()
+// TotalCountHeader は一覧取得で条件に一致する総件数を返すレスポンスヘッダー名
+const TotalCountHeader = "X-Total-Count"
 
-		// データベースから全ての Todo を取得
-		todos, err := client.Todo.Query().All(ctx)
-		if err != nil {
-			utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Database error occurred")
-			log.Printf("Todo fetch error: %v", err)
-			return
-		}
-
-		// Ent エンティティをレスポンス形式に変換
-		todoResponses := make([]types.TodoResponse, len(todos))
-		for i, todo := range todos {
-			todoResponses[i] = utils.ConvertToTodoResponse(todo)
-		}
-
-		utils.SendJSONResponse(w, http.StatusOK, todoResponses)
+// GetTodos は GET /todos リクエストを処理する
+func (s *Server) GetTodos(w http.ResponseWriter, r *http.Request, params api.GetTodosParams) {
+	// ページングの検証（limit 未指定の場合は全件）
+	limit, offset, err := pagination(params.Limit, params.Offset, 0, service.MaxTodoListLimit)
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
 	}
-}
-
-// CreateTodoHandler は POST /todos リクエストを処理する
-func CreateTodoHandler(client *ent.Client) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		ctx := context.Background()
-
-		// リクエストボディをパース
-		var input types.TodoInput
-		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-			utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format")
-			return
-		}
-
-		// タイトルの検証
-		if input.Title == "" {
-			utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_REQUEST", "Title is required")
-			return
-		}
-
-		// Todo作成クエリを構築
-		createQuery := client.Todo.Create().
-			SetTitle(input.Title).
-			SetCompleted(false) // デフォルトはfalse
-
-		// オプショナルフィールドの処理
-		if input.Description != nil {
-			createQuery.SetDescription(*input.Description)
-		}
-
-		if input.Completed != nil {
-			createQuery.SetCompleted(*input.Completed)
-		}
-
-		// カテゴリIDの処理
-		if input.CategoryID != nil {
-			categoryUUID, ok := utils.ParseUUID(w, *input.CategoryID)
-			if !ok {
-				return
-			}
 
-			// カテゴリの存在確認
-			exists, err := client.Category.Query().
-				Where(category.ID(categoryUUID)).
-				Exist(ctx)
-			if err != nil {
-				utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Database error occurred")
-				log.Printf("Category existence check error: %v", err)
-				return
-			}
-			if !exists {
-				utils.SendErrorResponse(w, http.StatusBadRequest, "CATEGORY_NOT_FOUND", "Specified category not found")
-				return
-			}
-
-			createQuery.SetCategoryID(categoryUUID)
-		}
-
-		// Todoを作成
-		todo, err := createQuery.Save(ctx)
-		if err != nil {
-			utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Failed to create Todo")
-			log.Printf("Todo creation error: %v", err)
-			return
-		}
-
-		// レスポンスを返却
-		response := utils.ConvertToTodoResponse(todo)
-		utils.SendJSONResponse(w, http.StatusCreated, response)
+	// 条件に一致する Todo を取得（並び順は TodoService が検証する）
+	opts := repository.TodoListOptions{
+		Filter: todoFilter(params.Completed, params.CategoryID, params.Search,
+			params.CreatedBefore, params.CreatedAfter, params.UpdatedBefore, params.UpdatedAfter),
+		Limit:  limit,
+		Offset: offset,
 	}
-}
-
-// GetTodoByIDHandler は GET /todos/{todoId} リクエストを処理する
-func GetTodoByIDHandler(client *ent.Client) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		ctx := context.Background()
-
-		// URLパラメータからtodoIdを取得
-		todoID := chi.URLParam(r, "todoId")
-		todoUUID, ok := utils.ParseUUID(w, todoID)
-		if !ok {
-			return
-		}
-
-		// Todoを取得
-		todo, err := client.Todo.Get(ctx, todoUUID)
-		if err != nil {
-			if ent.IsNotFound(err) {
-				utils.SendErrorResponse(w, http.StatusNotFound, "TODO_NOT_FOUND", "Specified Todo not found")
-				return
-			}
-			utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Database error occurred")
-			log.Printf("Todo fetch error: %v", err)
-			return
-		}
-
-		// レスポンスを返却
-		response := utils.ConvertToTodoResponse(todo)
-		utils.SendJSONResponse(w, http.StatusOK, response)
+	if params.Sort != nil {
+		opts.Sort = string(*params.Sort)
+	}
+	if params.Order != nil {
+		opts.Order = string(*params.Order)
+	}
+	responses, total, err := s.todos.List(r.Context(), opts)
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
 	}
-}
-
-// UpdateTodoHandler は PUT /todos/{todoId} リクエストを処理する
-func UpdateTodoHandler(client *ent.Client) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		ctx := context.Background()
-
-		// URLパラメータからtodoIdを取得
-		todoID := chi.URLParam(r, "todoId")
-		todoUUID, ok := utils.ParseUUID(w, todoID)
-		if !ok {
-			return
-		}
-
-		// リクエストボディをパース
-		var input types.TodoInput
-		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-			utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format")
-			return
-		}
-
-		// タイトルの検証
-		if input.Title == "" {
-			utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_REQUEST", "Title is required")
-			return
-		}
 
-		// 対象Todoの存在確認
-		exists, err := client.Todo.Query().Where(todo.ID(todoUUID)).Exist(ctx)
-		if err != nil {
-			utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Database error occurred")
-			log.Printf("Todo existence check error: %v", err)
-			return
-		}
-		if !exists {
-			utils.SendErrorResponse(w, http.StatusNotFound, "TODO_NOT_FOUND", "指定されたTodoが見つかりません")
-			return
-		}
+	w.Header().Set(TotalCountHeader, strconv.Itoa(total))
+	utils.SendJSONResponse(w, http.StatusOK, responses)
+}
 
-		// Todo更新クエリを構築
-		updateQuery := client.Todo.UpdateOneID(todoUUID).
-			SetTitle(input.Title)
+// CreateTodo は POST /todos リクエストを処理する
+func (s *Server) CreateTodo(w http.ResponseWriter, r *http.Request, params api.CreateTodoParams) {
+	s.idempotency.serve(w, r, params.IdempotencyKey, s.createTodo)
+}
 
-		// オプショナルフィールドの処理
-		if input.Description != nil {
-			if *input.Description == "" {
-				updateQuery.ClearDescription()
-			} else {
-				updateQuery.SetDescription(*input.Description)
-			}
-		}
+func (s *Server) createTodo(w http.ResponseWriter, r *http.Request) {
+	// リクエストボディをパース
+	var input types.TodoInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format")
+		return
+	}
 
-		if input.Completed != nil {
-			updateQuery.SetCompleted(*input.Completed)
-		}
+	// 入力を検証して Todo を作成（タイトル必須・カテゴリの存在確認）
+	response, err := s.todos.Create(r.Context(), input)
+	if err != nil {
+		sendServiceError(w, err, "DB_ERROR", "Failed to create Todo")
+		return
+	}
 
-		// カテゴリIDの処理
-		if input.CategoryID != nil {
-			if *input.CategoryID == "" {
-				updateQuery.ClearCategoryID()
-			} else {
-				categoryUUID, ok := utils.ParseUUID(w, *input.CategoryID)
-				if !ok {
-					return
-				}
+	// レスポンスを返却
+	utils.SendJSONResponse(w, http.StatusCreated, response)
+}
 
-				// カテゴリの存在確認
-				exists, err := client.Category.Query().
-					Where(category.ID(categoryUUID)).
-					Exist(ctx)
-				if err != nil {
-					utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Database error occurred")
-					log.Printf("Category existence check error: %v", err)
-					return
-				}
-				if !exists {
-					utils.SendErrorResponse(w, http.StatusBadRequest, "CATEGORY_NOT_FOUND", "Specified category not found")
-					return
-				}
+// GetTodoByID は GET /todos/{todoId} リクエストを処理する
+func (s *Server) GetTodoByID(w http.ResponseWriter, r *http.Request, todoID uuid.UUID) {
+	// Todoを取得
+	response, err := s.todos.Get(r.Context(), todoID)
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
 
-				updateQuery.SetCategoryID(categoryUUID)
-			}
-		}
+	// レスポンスを返却
+	utils.SendJSONResponse(w, http.StatusOK, response)
+}
 
-		// Todoを更新
-		todo, err := updateQuery.Save(ctx)
-		if err != nil {
-			utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Failed to update Todo")
-			log.Printf("Todo update error: %v", err)
-			return
-		}
+// UpdateTodo は PUT /todos/{todoId} リクエストを処理する
+func (s *Server) UpdateTodo(w http.ResponseWriter, r *http.Request, todoID uuid.UUID) {
+	// リクエストボディをパース
+	var input types.TodoInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format")
+		return
+	}
 
-		// レスポンスを返却
-		response := utils.ConvertToTodoResponse(todo)
-		utils.SendJSONResponse(w, http.StatusOK, response)
+	// 入力を検証して Todo を更新（存在確認・タイトル必須・カテゴリの存在確認）
+	response, err := s.todos.Update(r.Context(), todoID, input)
+	if err != nil {
+		sendServiceError(w, err, "DB_ERROR", "Failed to update Todo")
+		return
 	}
-}
 
-// DeleteTodoHandler は DELETE /todos/{todoId} リクエストを処理する
-func DeleteTodoHandler(client *ent.Client) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		ctx := context.Background()
+	// レスポンスを返却
+	utils.SendJSONResponse(w, http.StatusOK, response)
+}
 
-		// URLパラメータからtodoIdを取得
-		todoID := chi.URLParam(r, "todoId")
-		todoUUID, ok := utils.ParseUUID(w, todoID)
-		if !ok {
-			return
-		}
+// DeleteTodo は DELETE /todos/{todoId} リクエストを処理する
+func (s *Server) DeleteTodo(w http.ResponseWriter, r *http.Request, todoID uuid.UUID) {
+	// Todoを削除
+	if err := s.todos.Delete(r.Context(), todoID); err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
 
-		// Todoを削除
-		err := client.Todo.DeleteOneID(todoUUID).Exec(ctx)
-		if err != nil {
-			if ent.IsNotFound(err) {
-				utils.SendErrorResponse(w, http.StatusNotFound, "TODO_NOT_FOUND", "Specified Todo not found")
-				return
-			}
-			utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Database error occurred")
-			log.Printf("Todo deletion error: %v", err)
-			return
-		}
+	// 204 No Contentを返却
+	w.WriteHeader(http.StatusNoContent)
+}
 
-		// 204 No Contentを返却
-		w.WriteHeader(http.StatusNoContent)
+// sendServiceError はサービスのエラーをエラーレスポンスとして送信する
+// API エラー（検証エラー・404 など）はそのまま返し、それ以外は code・message の 500 エラーとしてログに出力する
+func sendServiceError(w http.ResponseWriter, err error, code, message string) {
+	if apiErr := utils.AsAPIError(err); apiErr != utils.ErrDatabase {
+		utils.SendAPIError(w, apiErr)
+		return
 	}
+	log.Printf("%s: %v", message, err)
+	utils.SendErrorResponse(w, http.StatusInternalServerError, code, message)
 }
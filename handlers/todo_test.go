@@ -0,0 +1,179 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/events"
+	"github.com/t-okuji/go-openapi-todo-demo/handlers"
+	"github.com/t-okuji/go-openapi-todo-demo/repository"
+	"github.com/t-okuji/go-openapi-todo-demo/service"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+// newRouter はインメモリのリポジトリで処理する Server を生成コードのルーターに登録する
+func newRouter() http.Handler {
+	store := repository.NewMemoryStore()
+	server := handlers.New(handlers.Config{
+		Todos:           service.NewTodoService(store.Todos(), store.Categories()),
+		Categories:      service.NewCategoryService(store.Categories()),
+		IdempotencyKeys: store.IdempotencyKeys(),
+		IdempotencyTTL:  time.Hour,
+		Broker:          events.NewBroker(16),
+	})
+	return api.HandlerWithOptions(server, api.ChiServerOptions{ErrorHandlerFunc: handlers.ParamErrorHandler})
+}
+
+// do はリクエストを送信し、レスポンスボディを out にデコードしてレスポンスを返す
+func do(t *testing.T, h http.Handler, method, target string, body, out any) *httptest.ResponseRecorder {
+	t.Helper()
+	return doWithHeader(t, h, method, target, nil, body, out)
+}
+
+// doWithHeader はヘッダーを設定してリクエストを送信する
+// JSON のボディを送信する場合は Content-Type を application/json に設定する
+func doWithHeader(t *testing.T, h http.Handler, method, target string, header http.Header, body, out any) *httptest.ResponseRecorder {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req := httptest.NewRequest(method, target, &buf)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: decode %q: %v", method, target, rec.Body.String(), err)
+		}
+	}
+	return rec
+}
+
+func TestTodoHandlersWithMemoryStore(t *testing.T) {
+	h := newRouter()
+
+	var category types.CategoryResponse
+	if rec := do(t, h, http.MethodPost, "/categories", types.CategoryInput{Name: "仕事"}, &category); rec.Code != http.StatusCreated {
+		t.Fatalf("create category: status %d", rec.Code)
+	}
+	if category.Color != service.DefaultCategoryColor {
+		t.Errorf("category color = %q, want default %q", category.Color, service.DefaultCategoryColor)
+	}
+
+	categoryID := category.ID.String()
+	for _, title := range []string{"b", "a", "c"} {
+		input := types.TodoInput{Title: title}
+		if title != "c" {
+			input.CategoryID = &categoryID
+		}
+		if rec := do(t, h, http.MethodPost, "/todos", input, nil); rec.Code != http.StatusCreated {
+			t.Fatalf("create todo %q: status %d: %s", title, rec.Code, rec.Body)
+		}
+	}
+
+	var todos []types.TodoResponse
+	rec := do(t, h, http.MethodGet, "/todos?categoryId="+categoryID+"&sort=title&order=asc&limit=1", nil, &todos)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list todos: status %d: %s", rec.Code, rec.Body)
+	}
+	if total := rec.Header().Get(handlers.TotalCountHeader); total != "2" {
+		t.Errorf("%s = %q, want 2", handlers.TotalCountHeader, total)
+	}
+	if len(todos) != 1 || todos[0].Title != "a" {
+		t.Fatalf("list todos = %+v, want only a", todos)
+	}
+
+	// カテゴリを削除すると所属していた Todo のカテゴリが解除される
+	if rec := do(t, h, http.MethodDelete, "/categories/"+categoryID, nil, nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete category: status %d", rec.Code)
+	}
+	var todo types.TodoResponse
+	do(t, h, http.MethodGet, "/todos/"+todos[0].ID.String(), nil, &todo)
+	if todo.CategoryID != nil || todo.Version != 2 {
+		t.Errorf("todo after category deletion: categoryId=%v version=%d, want nil and 2", todo.CategoryID, todo.Version)
+	}
+}
+
+func TestMemoryServer(t *testing.T) {
+	h := newRouter()
+
+	// Idempotency-Key の記録もインメモリのリポジトリに保存する
+	header := http.Header{handlers.IdempotencyKeyHeader: {"create-a"}}
+	var first, second types.TodoResponse
+	if rec := doWithHeader(t, h, http.MethodPost, "/todos", header, types.TodoInput{Title: "a"}, &first); rec.Code != http.StatusCreated {
+		t.Fatalf("create todo: status %d: %s", rec.Code, rec.Body)
+	}
+	rec := doWithHeader(t, h, http.MethodPost, "/todos", header, types.TodoInput{Title: "a"}, &second)
+	if rec.Code != http.StatusCreated || rec.Header().Get(handlers.IdempotentReplayedHeader) != "true" || second.ID != first.ID {
+		t.Errorf("replay: status %d, replayed %q, id %s, want the first response", rec.Code, rec.Header().Get(handlers.IdempotentReplayedHeader), second.ID)
+	}
+	if rec := doWithHeader(t, h, http.MethodPost, "/todos", header, types.TodoInput{Title: "b"}, nil); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("reuse with another body: status %d, want 422", rec.Code)
+	}
+
+	// 一括操作はサービスで処理する
+	search := "a"
+	completed := true
+	var bulk types.BulkResponse
+	req := types.BulkUpdateRequest{Filter: types.TodoFilter{Search: &search}, Update: types.BulkTodoUpdate{Completed: &completed}}
+	if rec := do(t, h, http.MethodPost, "/todos:bulkUpdate", req, &bulk); rec.Code != http.StatusOK || bulk.Affected != 1 {
+		t.Errorf("bulk update: status %d, affected %d, want 1", rec.Code, bulk.Affected)
+	}
+	var todo types.TodoResponse
+	if do(t, h, http.MethodGet, "/todos/"+first.ID.String(), nil, &todo); !todo.Completed || todo.Version != 2 {
+		t.Errorf("todo after bulk update: completed %t, version %d", todo.Completed, todo.Version)
+	}
+
+	// Ent のクライアントが必要な操作は 501 を返す
+	for _, target := range []string{"/audit", "/todos/" + first.ID.String() + "/history", "/webhooks"} {
+		if rec := do(t, h, http.MethodGet, target, nil, nil); rec.Code != http.StatusNotImplemented {
+			t.Errorf("GET %s: status %d, want 501", target, rec.Code)
+		}
+	}
+}
+
+func TestTodoHandlerErrors(t *testing.T) {
+	h := newRouter()
+	missing := uuid.NewString()
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   any
+		status int
+		code   string
+	}{
+		{"missing title", http.MethodPost, "/todos", types.TodoInput{}, http.StatusBadRequest, "INVALID_REQUEST"},
+		{"unknown category", http.MethodPost, "/todos", types.TodoInput{Title: "t", CategoryID: &missing}, http.StatusBadRequest, "CATEGORY_NOT_FOUND"},
+		{"invalid sort", http.MethodGet, "/todos?sort=priority", nil, http.StatusBadRequest, "INVALID_PARAMETER"},
+		{"invalid category filter", http.MethodGet, "/todos?categoryId=x", nil, http.StatusBadRequest, "INVALID_UUID"},
+		{"get missing", http.MethodGet, "/todos/" + missing, nil, http.StatusNotFound, "TODO_NOT_FOUND"},
+		// 存在しない Todo の更新は入力の検証より先に 404 を返す
+		{"update missing", http.MethodPut, "/todos/" + missing, types.TodoInput{}, http.StatusNotFound, "TODO_NOT_FOUND"},
+		{"delete missing category", http.MethodDelete, "/categories/" + missing, nil, http.StatusNotFound, "NOT_FOUND"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp types.ErrorResponse
+			rec := do(t, h, tt.method, tt.target, tt.body, &resp)
+			if rec.Code != tt.status || resp.Error.Code != tt.code {
+				t.Errorf("got %d %s, want %d %s", rec.Code, resp.Error.Code, tt.status, tt.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/t-okuji/go-openapi-todo-demo/openapi"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+const (
+	// maxRequestBodySize はファイルのアップロード以外のリクエストボディの上限
+	maxRequestBodySize = 1 << 20
+	// maxValidatedResponseSize は検証のために記録するレスポンスボディの上限（超えた場合はボディを検証しない）
+	maxValidatedResponseSize = 1 << 20
+)
+
+// ValidateRequests は API 仕様に従ってリクエストを検証するミドルウェアを作成する
+//
+// パスパラメーター・クエリパラメーター・ヘッダー・Content-Type・リクエストボディを検証し、
+// 違反がある場合はハンドラーを呼び出さずに項目ごとのエラー（details）を含む 400 を返す。
+// リクエストボディは検証のために全て読み込むため、上限（requestBodyLimit）を超えた場合は 413 を返す。
+// 仕様にないパス（/docs など）はそのまま次のハンドラーに渡す。
+// validateResponses が true の場合はレスポンスも検証し、違反をログに出力する（開発用）。
+func ValidateRequests(doc *openapi3.T, validateResponses bool) (func(http.Handler) http.Handler, error) {
+	// servers のホストに関係なくパスだけで操作を探す
+	spec := *doc
+	spec.Servers = nil
+	router, err := gorillamux.NewRouter(&spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// デフォルト値はハンドラーで補う（更新時に省略した項目をデフォルト値で上書きしない）
+	options := &openapi3filter.Options{
+		MultiError:          true,
+		SkipSettingDefaults: true,
+		AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := router.FindRoute(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			limit, tooLarge := requestBodyLimit(route.Path)
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			}
+			if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					utils.SendAPIError(w, tooLarge)
+					return
+				}
+				utils.SendAPIError(w, requestValidationError(err))
+				return
+			}
+
+			if !validateResponses {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body := &limitedBuffer{limit: maxValidatedResponseSize}
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(body)
+			next.ServeHTTP(ww, r)
+			validateResponse(input, route, ww, body)
+		})
+	}, nil
+}
+
+// requestBodyLimit は path の操作が受け付けるリクエストボディの上限と、超えた場合のエラーを返す
+// ファイルをアップロードするインポートは maxImportFileSize、その他は maxRequestBodySize まで受け付ける
+func requestBodyLimit(path string) (int64, *utils.APIError) {
+	if path == "/import" || strings.HasPrefix(path, "/import/") {
+		return maxImportFileSize, errImportFileTooLarge
+	}
+	return maxRequestBodySize, &utils.APIError{
+		Status:  http.StatusRequestEntityTooLarge,
+		Code:    "REQUEST_TOO_LARGE",
+		Message: fmt.Sprintf("Request body must be %d bytes or less", maxRequestBodySize),
+	}
+}
+
+// validateResponse はレスポンスを API 仕様に従って検証し、違反をログに出力する
+// JSON 以外のレスポンス（CSV・iCalendar・Server-Sent Events など）と上限を超えたボディはステータスコードとヘッダーのみ検証する
+func validateResponse(input *openapi3filter.RequestValidationInput, route *routers.Route, ww middleware.WrapResponseWriter, body *limitedBuffer) {
+	status := ww.Status()
+	if status == 0 {
+		// WebSocket への切り替えなど、レスポンスを書き込まずに終了した場合
+		return
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(ww.Header().Get("Content-Type"))
+	options := &openapi3filter.Options{
+		MultiError:            true,
+		IncludeResponseStatus: true,
+		ExcludeResponseBody:   body.overflow || (mediaType != "" && mediaType != "application/json"),
+	}
+	err := openapi3filter.ValidateResponse(input.Request.Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 status,
+		Header:                 ww.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(body.Bytes())),
+		Options:                options,
+	})
+	if err != nil {
+		log.Printf("OpenAPI response validation failed: %s %s %d: %v", route.Method, route.Path, status, err)
+	}
+}
+
+// requestValidationError はリクエストの検証エラーを API エラーに変換する
+// 不正な JSON は INVALID_JSON、想定外の Content-Type は 415、その他は項目ごとの VALIDATION_ERROR として返す
+func requestValidationError(err error) *utils.APIError {
+	var details []types.FieldError
+	for _, e := range openapi.FlattenErrors(err) {
+		var requestErr *openapi3filter.RequestError
+		if !errors.As(e, &requestErr) {
+			details = append(details, types.FieldError{Field: "request", Message: e.Error()})
+			continue
+		}
+
+		switch {
+		case requestErr.Parameter != nil:
+			field := requestErr.Parameter.In + "." + requestErr.Parameter.Name
+			details = append(details, openapi.FieldErrors(field, requestErr.Err, requestErr.Reason)...)
+		case requestErr.RequestBody != nil:
+			if strings.HasPrefix(requestErr.Reason, "header Content-Type has unexpected value") {
+				return &utils.APIError{Status: http.StatusUnsupportedMediaType, Code: "UNSUPPORTED_MEDIA_TYPE", Message: "Content-Type must be one of " + strings.Join(mediaTypes(requestErr.RequestBody.Content), ", ")}
+			}
+			var parseErr *openapi3filter.ParseError
+			if errors.As(requestErr.Err, &parseErr) {
+				return utils.ErrInvalidJSON
+			}
+			details = append(details, openapi.FieldErrors("body", requestErr.Err, requestErr.Reason)...)
+		default:
+			details = append(details, types.FieldError{Field: "request", Message: requestErr.Error()})
+		}
+	}
+	return utils.NewValidationError(details)
+}
+
+// mediaTypes はリクエストボディが受け付けるメディアタイプを返す
+func mediaTypes(content openapi3.Content) []string {
+	keys := make([]string, 0, len(content))
+	for mediaType := range content {
+		keys = append(keys, mediaType)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// limitedBuffer は limit バイトまでを記録し、超えた分は記録せずに overflow を設定する io.Writer
+type limitedBuffer struct {
+	bytes.Buffer
+	limit    int
+	overflow bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.overflow || b.Len()+len(p) > b.limit {
+		b.overflow = true
+		b.Reset()
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
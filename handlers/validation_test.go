@@ -0,0 +1,33 @@
+package handlers_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+func TestRequestBodyLimit(t *testing.T) {
+	srv := newSQLiteServer(t)
+	h := srv.handler
+
+	// ファイルのアップロード以外は 1MB を超えるボディを検証の前に拒否する
+	var errResp types.ErrorResponse
+	rec := do(t, h, http.MethodPost, "/todos", types.TodoInput{Title: strings.Repeat("a", 1<<20)}, &errResp)
+	if rec.Code != http.StatusRequestEntityTooLarge || errResp.Error.Code != "REQUEST_TOO_LARGE" {
+		t.Errorf("POST /todos with a large body: status %d, body %s, want 413 REQUEST_TOO_LARGE", rec.Code, rec.Body)
+	}
+
+	// インポートは 10MB まで受け付ける
+	file := "title,description\n" + strings.Repeat("a,"+strings.Repeat("b", 500)+"\n", 4000)
+	rec = upload(t, h, "/import?dryRun=true", "todos.csv", file, nil)
+	if rec.Code != http.StatusOK {
+		t.Errorf("POST /import with a 2MB file: status %d, body %.200s, want 200", rec.Code, rec.Body)
+	}
+	errResp = types.ErrorResponse{}
+	rec = upload(t, h, "/import", "todos.csv", strings.Repeat("a", 10<<20), &errResp)
+	if rec.Code != http.StatusRequestEntityTooLarge || errResp.Error.Code != "FILE_TOO_LARGE" {
+		t.Errorf("POST /import with a large file: status %d, body %s, want 413 FILE_TOO_LARGE", rec.Code, rec.Body)
+	}
+}
@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/predicate"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/webhookdelivery"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/webhooksubscription"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+	"github.com/t-okuji/go-openapi-todo-demo/webhooks"
+)
+
+// GetWebhooks は GET /webhooks リクエストを処理する
+func (s *Server) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := s.client.WebhookSubscription.Query().
+		Order(ent.Asc(webhooksubscription.FieldCreatedAt)).
+		All(r.Context())
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Database error occurred")
+		log.Printf("Webhook fetch error: %v", err)
+		return
+	}
+
+	responses := make([]types.WebhookResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		responses[i] = utils.ConvertToWebhookResponse(subscription)
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, responses)
+}
+
+// CreateWebhook は POST /webhooks リクエストを処理する
+//
+// secret を省略した場合は自動生成する。シークレットはこのレスポンスでのみ返す。
+func (s *Server) CreateWebhook(w http.ResponseWriter, r *http.Request, params api.CreateWebhookParams) {
+	s.idempotency.serve(w, r, params.IdempotencyKey, s.createWebhook)
+}
+
+func (s *Server) createWebhook(w http.ResponseWriter, r *http.Request) {
+	var input types.WebhookInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format")
+		return
+	}
+
+	secret := webhooks.GenerateSecret()
+	if input.Secret != nil {
+		secret = *input.Secret
+	}
+	create := s.client.WebhookSubscription.Create().
+		SetURL(input.URL).
+		SetEventTypes(eventTypeNames(input.EventTypes)).
+		SetSecret(secret)
+	if input.Active != nil {
+		create.SetActive(*input.Active)
+	}
+
+	subscription, err := create.Save(r.Context())
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "DB_ERROR", "Failed to create webhook")
+		log.Printf("Webhook creation error: %v", err)
+		return
+	}
+
+	response := utils.ConvertToWebhookResponse(subscription)
+	response.Secret = &subscription.Secret
+	utils.SendJSONResponse(w, http.StatusCreated, response)
+}
+
+// GetWebhookByID は GET /webhooks/{webhookId} リクエストを処理する
+func (s *Server) GetWebhookByID(w http.ResponseWriter, r *http.Request, webhookID uuid.UUID) {
+	subscription, err := s.client.WebhookSubscription.Get(r.Context(), webhookID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			utils.SendAPIError(w, utils.ErrWebhookNotFound)
+			return
+		}
+		utils.SendAPIError(w, err)
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, utils.ConvertToWebhookResponse(subscription))
+}
+
+// UpdateWebhook は PUT /webhooks/{webhookId} リクエストを処理する
+//
+// secret を指定した場合はシークレットを変更し、新しいシークレットをレスポンスで返す。
+// active を省略した場合は現在の状態を維持する。
+func (s *Server) UpdateWebhook(w http.ResponseWriter, r *http.Request, webhookID uuid.UUID) {
+	var input types.WebhookInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_JSON", "Invalid JSON format")
+		return
+	}
+
+	update := s.client.WebhookSubscription.UpdateOneID(webhookID).
+		SetURL(input.URL).
+		SetEventTypes(eventTypeNames(input.EventTypes))
+	if input.Secret != nil {
+		update.SetSecret(*input.Secret)
+	}
+	if input.Active != nil {
+		update.SetActive(*input.Active)
+	}
+
+	subscription, err := update.Save(r.Context())
+	if err != nil {
+		if ent.IsNotFound(err) {
+			utils.SendAPIError(w, utils.ErrWebhookNotFound)
+			return
+		}
+		utils.SendAPIError(w, err)
+		return
+	}
+
+	response := utils.ConvertToWebhookResponse(subscription)
+	if input.Secret != nil {
+		response.Secret = &subscription.Secret
+	}
+	utils.SendJSONResponse(w, http.StatusOK, response)
+}
+
+// DeleteWebhook は DELETE /webhooks/{webhookId} リクエストを処理する
+// 送信待ちを含む配信記録も削除される
+func (s *Server) DeleteWebhook(w http.ResponseWriter, r *http.Request, webhookID uuid.UUID) {
+	err := utils.WithTx(r.Context(), s.client, func(tx *ent.Tx) error {
+		if _, err := tx.WebhookDelivery.Delete().
+			Where(webhookdelivery.SubscriptionID(webhookID)).
+			Exec(r.Context()); err != nil {
+			return err
+		}
+		return tx.WebhookSubscription.DeleteOneID(webhookID).Exec(r.Context())
+	})
+	if err != nil {
+		if ent.IsNotFound(err) {
+			utils.SendAPIError(w, utils.ErrWebhookNotFound)
+			return
+		}
+		utils.SendAPIError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetWebhookDeliveries は GET /webhooks/{webhookId}/deliveries リクエストを処理する
+func (s *Server) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request, webhookID uuid.UUID, params api.GetWebhookDeliveriesParams) {
+	ctx := r.Context()
+
+	limit, offset, err := pagination(params.Limit, params.Offset, 50, 200)
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
+
+	exists, err := s.client.WebhookSubscription.Query().Where(webhooksubscription.ID(webhookID)).Exist(ctx)
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
+	if !exists {
+		utils.SendAPIError(w, utils.ErrWebhookNotFound)
+		return
+	}
+
+	// クエリパラメータによる絞り込み
+	query := s.client.WebhookDelivery.Query().Where(webhookdelivery.SubscriptionID(webhookID))
+	if params.Status != nil {
+		status := webhookdelivery.Status(*params.Status)
+		if err := webhookdelivery.StatusValidator(status); err != nil {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_PARAMETER", "status must be one of pending, succeeded, dead")
+			return
+		}
+		query.Where(webhookdelivery.StatusEQ(status))
+	}
+	if params.EventType != nil {
+		query.Where(webhookdelivery.EventType(string(*params.EventType)))
+	}
+
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
+
+	// 新しい配信記録から順に取得
+	deliveries, err := query.
+		Order(ent.Desc(webhookdelivery.FieldCreatedAt), ent.Desc(webhookdelivery.FieldID)).
+		Limit(limit).
+		Offset(offset).
+		All(ctx)
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
+
+	items := make([]types.WebhookDeliveryResponse, len(deliveries))
+	for i, d := range deliveries {
+		items[i] = utils.ConvertToWebhookDeliveryResponse(d)
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, types.WebhookDeliveryListResponse{
+		Items:  items,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
+// RetryWebhookDelivery は POST /webhooks/{webhookId}/deliveries/{deliveryId}/retry リクエストを処理する
+//
+// dead となった配信（または送信済みの配信）を送信待ちに戻し、送信回数をリセットして再送する。
+func (s *Server) RetryWebhookDelivery(w http.ResponseWriter, r *http.Request, webhookID, deliveryID uuid.UUID) {
+	ctx := r.Context()
+	target := []predicate.WebhookDelivery{webhookdelivery.ID(deliveryID), webhookdelivery.SubscriptionID(webhookID)}
+
+	// 送信待ちでない場合のみ送信待ちに戻す（同時に送信・再送された配信を重ねて送信待ちにしない）
+	updated, err := s.client.WebhookDelivery.Update().
+		Where(target...).
+		Where(webhookdelivery.StatusIn(webhookdelivery.StatusDead, webhookdelivery.StatusSucceeded)).
+		SetStatus(webhookdelivery.StatusPending).
+		SetAttempts(0).
+		SetNextAttemptAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		utils.SendAPIError(w, err)
+		return
+	}
+
+	delivery, err := s.client.WebhookDelivery.Query().Where(target...).Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			utils.SendAPIError(w, utils.ErrDeliveryNotFound)
+			return
+		}
+		utils.SendAPIError(w, err)
+		return
+	}
+	if updated == 0 {
+		utils.SendErrorResponse(w, http.StatusConflict, "DELIVERY_PENDING", "Webhook delivery is already pending")
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusAccepted, utils.ConvertToWebhookDeliveryResponse(delivery))
+}
+
+// eventTypeNames は購読するイベント種別を重複を除いて並べ替えて返す
+func eventTypeNames(eventTypes []api.EventType) []string {
+	names := make([]string, len(eventTypes))
+	for i, eventType := range eventTypes {
+		names[i] = string(eventType)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
@@ -0,0 +1,128 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/webhookdelivery"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+func TestWebhookDeliveries(t *testing.T) {
+	ctx := context.Background()
+	srv := newSQLiteServer(t)
+	h := srv.handler
+
+	var webhook types.WebhookResponse
+	input := types.WebhookInput{URL: "https://example.com/hook", EventTypes: []api.EventType{"todo.created", "todo.updated"}}
+	if rec := do(t, h, http.MethodPost, "/webhooks", input, &webhook); rec.Code != http.StatusCreated || webhook.Secret == nil {
+		t.Fatalf("create webhook: status %d, secret %v", rec.Code, webhook.Secret)
+	}
+	base := "/webhooks/" + webhook.ID.String()
+
+	// 購読しているイベントごとに配信記録を作成する（category.created は購読していない）
+	var created types.TodoResponse
+	do(t, h, http.MethodPost, "/categories", types.CategoryInput{Name: "work"}, nil)
+	do(t, h, http.MethodPost, "/todos", types.TodoInput{Title: "a"}, &created)
+	do(t, h, http.MethodPost, "/todos", types.TodoInput{Title: "b"}, nil)
+	do(t, h, http.MethodPut, "/todos/"+created.ID.String(), types.TodoInput{Title: "a2"}, nil)
+
+	var list types.WebhookDeliveryListResponse
+	if rec := do(t, h, http.MethodGet, base+"/deliveries", nil, &list); rec.Code != http.StatusOK || list.Total != 3 || len(list.Items) != 3 {
+		t.Fatalf("deliveries: status %d, total %d, %d items", rec.Code, list.Total, len(list.Items))
+	}
+	for _, d := range list.Items {
+		if d.Status != "pending" || d.Attempts != 0 || d.WebhookID != webhook.ID {
+			t.Errorf("delivery = %+v", d)
+		}
+	}
+	if rec := do(t, h, http.MethodGet, base+"/deliveries?eventType=todo.created&limit=1&offset=1", nil, &list); rec.Code != http.StatusOK || list.Total != 2 || len(list.Items) != 1 || list.Items[0].EventType != "todo.created" {
+		t.Errorf("filtered deliveries: status %d, total %d, items %+v", rec.Code, list.Total, list.Items)
+	}
+
+	// 送信待ちの配信は再送できない
+	target := base + "/deliveries/" + list.Items[0].ID.String() + "/retry"
+	var errResp types.ErrorResponse
+	if rec := do(t, h, http.MethodPost, target, nil, &errResp); rec.Code != http.StatusConflict || errResp.Error.Code != "DELIVERY_PENDING" {
+		t.Errorf("retry pending: status %d, code %q", rec.Code, errResp.Error.Code)
+	}
+
+	// dead となった配信は送信回数をリセットして送信待ちに戻す
+	deliveryID := list.Items[0].ID
+	srv.db.Client.WebhookDelivery.UpdateOneID(deliveryID).
+		SetStatus(webhookdelivery.StatusDead).
+		SetAttempts(10).
+		ExecX(ctx)
+	if rec := do(t, h, http.MethodGet, base+"/deliveries?status=dead", nil, &list); rec.Code != http.StatusOK || list.Total != 1 {
+		t.Errorf("dead deliveries: status %d, total %d", rec.Code, list.Total)
+	}
+	var retried types.WebhookDeliveryResponse
+	if rec := do(t, h, http.MethodPost, target, nil, &retried); rec.Code != http.StatusAccepted || retried.Status != "pending" || retried.Attempts != 0 {
+		t.Errorf("retry dead: status %d, delivery %+v", rec.Code, retried)
+	}
+	if rec := do(t, h, http.MethodPost, target, nil, nil); rec.Code != http.StatusConflict {
+		t.Errorf("second retry: status %d, want 409", rec.Code)
+	}
+
+	// 他の Webhook の配信・存在しない配信は 404
+	missing := uuid.NewString()
+	for _, target := range []string{
+		"/webhooks/" + missing + "/deliveries/" + deliveryID.String() + "/retry",
+		base + "/deliveries/" + missing + "/retry",
+	} {
+		errResp = types.ErrorResponse{}
+		if rec := do(t, h, http.MethodPost, target, nil, &errResp); rec.Code != http.StatusNotFound || errResp.Error.Code != "DELIVERY_NOT_FOUND" {
+			t.Errorf("POST %s: status %d, code %q", target, rec.Code, errResp.Error.Code)
+		}
+	}
+	if rec := do(t, h, http.MethodGet, "/webhooks/"+missing+"/deliveries", nil, nil); rec.Code != http.StatusNotFound {
+		t.Errorf("deliveries of missing webhook: status %d, want 404", rec.Code)
+	}
+}
+
+func TestWebhookInputValidation(t *testing.T) {
+	h := newSQLiteServer(t).handler
+	var webhook types.WebhookResponse
+	if rec := do(t, h, http.MethodPost, "/webhooks", types.WebhookInput{URL: "http://example.com", EventTypes: []api.EventType{"todo.created"}}, &webhook); rec.Code != http.StatusCreated {
+		t.Fatalf("create webhook: status %d, body %s", rec.Code, rec.Body)
+	}
+
+	// 入力は API 仕様の WebhookInput のスキーマで検証し、作成・更新とも同じ項目のエラーを返す
+	short, long := "0123456789abcde", strings.Repeat("s", 256)
+	for name, tt := range map[string]struct {
+		input types.WebhookInput
+		field string
+	}{
+		"empty url":          {types.WebhookInput{URL: "", EventTypes: []api.EventType{"todo.created"}}, "url"},
+		"relative url":       {types.WebhookInput{URL: "/hooks", EventTypes: []api.EventType{"todo.created"}}, "url"},
+		"unsupported scheme": {types.WebhookInput{URL: "ftp://example.com/hooks", EventTypes: []api.EventType{"todo.created"}}, "url"},
+		"missing host":       {types.WebhookInput{URL: "https:///hooks", EventTypes: []api.EventType{"todo.created"}}, "url"},
+		"url too long":       {types.WebhookInput{URL: "https://example.com/" + strings.Repeat("a", 2029), EventTypes: []api.EventType{"todo.created"}}, "url"},
+		"no event types":     {types.WebhookInput{URL: "https://example.com", EventTypes: []api.EventType{}}, "eventTypes"},
+		"unknown event type": {types.WebhookInput{URL: "https://example.com", EventTypes: []api.EventType{"todo.archived"}}, "eventTypes.0"},
+		"secret too short":   {types.WebhookInput{URL: "https://example.com", EventTypes: []api.EventType{"todo.created"}, Secret: &short}, "secret"},
+		"secret too long":    {types.WebhookInput{URL: "https://example.com", EventTypes: []api.EventType{"todo.created"}, Secret: &long}, "secret"},
+	} {
+		for _, req := range []struct{ method, target string }{
+			{http.MethodPost, "/webhooks"},
+			{http.MethodPut, "/webhooks/" + webhook.ID.String()},
+		} {
+			var errResp types.ErrorResponse
+			rec := do(t, h, req.method, req.target, tt.input, &errResp)
+			if rec.Code != http.StatusBadRequest || errResp.Error.Code != "VALIDATION_ERROR" || errResp.Error.Details == nil || (*errResp.Error.Details)[0].Field != "body."+tt.field {
+				t.Errorf("%s %s (%s): status %d, body %s, want VALIDATION_ERROR for body.%s", req.method, req.target, name, rec.Code, rec.Body, tt.field)
+			}
+		}
+	}
+
+	// 上限ちょうどの URL・シークレットは受け付ける
+	secret := strings.Repeat("s", 255)
+	input := types.WebhookInput{URL: "https://example.com/" + strings.Repeat("a", 2028), EventTypes: []api.EventType{"todo.created"}, Secret: &secret}
+	if rec := do(t, h, http.MethodPut, "/webhooks/"+webhook.ID.String(), input, nil); rec.Code != http.StatusOK {
+		t.Errorf("update with maximum lengths: status %d, body %s", rec.Code, rec.Body)
+	}
+}
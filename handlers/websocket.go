@@ -0,0 +1,409 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/events"
+	"github.com/t-okuji/go-openapi-todo-demo/service"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// WebSocket メッセージの種別
+const (
+	// クライアントから送信するメッセージ
+	WSTypeSubscribe   = "subscribe"
+	WSTypeUnsubscribe = "unsubscribe"
+	WSTypeCreate      = "create"
+	WSTypeUpdate      = "update"
+	WSTypeDelete      = "delete"
+
+	// サーバーから送信するメッセージ
+	WSTypeWelcome  = "welcome"
+	WSTypeAck      = "ack"
+	WSTypeError    = "error"
+	WSTypeEvent    = "event"
+	WSTypePresence = "presence"
+)
+
+const (
+	// wsMaxMessageSize はクライアントから受信するメッセージの最大バイト数
+	wsMaxMessageSize = 64 << 10
+	// wsSendBufferSize は接続ごとの送信待ちメッセージ数の上限。超えた接続は切断する
+	wsSendBufferSize = 64
+
+	wsWriteTimeout = 10 * time.Second
+	wsPongTimeout  = 60 * time.Second
+	wsPingInterval = 30 * time.Second
+)
+
+// wsWorkspace はワークスペース全体を購読していることを表すスコープ
+const wsWorkspace = ""
+
+var wsUpgrader = websocket.Upgrader{
+	// CORS 設定と同様に全てのオリジンからの接続を許可する
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// wsConn は WebSocket の接続ごとの状態を表す
+type wsConn struct {
+	ws    *websocket.Conn
+	id    string
+	actor string
+	since time.Time
+
+	// scope は購読中のスコープ（nil は未購読、wsWorkspace はワークスペース全体、それ以外はカテゴリID）
+	scope atomic.Pointer[string]
+
+	send      chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// webSocketHub は WebSocket の接続で共有する状態を保持する
+// 閲覧者の一覧は全ての接続で共有するため、サーバーごとにひとつだけ作成する
+type webSocketHub struct {
+	todos      *service.TodoService
+	categories *service.CategoryService
+	broker     *events.Broker
+	presence   *wsPresence
+}
+
+func newWebSocketHub(todos *service.TodoService, categories *service.CategoryService, broker *events.Broker) *webSocketHub {
+	return &webSocketHub{
+		todos:      todos,
+		categories: categories,
+		broker:     broker,
+		presence:   &wsPresence{scopes: make(map[string]map[*wsConn]struct{})},
+	}
+}
+
+// ConnectWebSocket は GET /ws リクエストを処理し、Todo の変更の配信と編集を WebSocket で行う
+//
+// クライアントはワークスペース全体またはカテゴリを購読して変更イベントと閲覧者の一覧を受信し、
+// 同じ接続で Todo の作成・更新・削除を送信できる。変更は REST API と同じ検証を経て実行され、
+// サーバーが割り当てたIDとバージョンを含む ack が返される。
+// ブラウザはヘッダーを設定できないため、操作者は actor クエリパラメータでも指定できる。
+func (s *Server) ConnectWebSocket(w http.ResponseWriter, r *http.Request, params api.ConnectWebSocketParams) {
+	s.webSocket.serve(w, r, params.Actor)
+}
+
+// serve は接続をアップグレードし、切断されるまでメッセージを処理する
+func (h *webSocketHub) serve(w http.ResponseWriter, r *http.Request, actorParam *string) {
+	actor := utils.ActorFromContext(r.Context())
+	if actorParam != nil && *actorParam != "" && r.Header.Get(ActorHeader) == "" {
+		if len(*actorParam) > 255 {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "INVALID_ACTOR", "actor must be 255 characters or less")
+			return
+		}
+		actor = *actorParam
+	}
+	ctx := utils.WithActor(r.Context(), actor)
+
+	ws, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade がエラーレスポンスを送信済み
+		return
+	}
+
+	c := &wsConn{
+		ws:    ws,
+		id:    uuid.NewString(),
+		actor: actor,
+		since: time.Now(),
+		send:  make(chan []byte, wsSendBufferSize),
+		done:  make(chan struct{}),
+	}
+
+	sub, _ := h.broker.Subscribe(0)
+	defer h.broker.Unsubscribe(sub)
+
+	go c.writeLoop()
+	go c.forwardEvents(sub)
+
+	c.enqueue(types.WSServerMessage{Type: WSTypeWelcome, ConnectionID: c.id, Actor: c.actor})
+	c.readLoop(ctx, h)
+
+	h.presence.leave(c)
+	c.close(websocket.CloseNormalClosure, "")
+}
+
+// readLoop はクライアントからのメッセージを順に処理する
+// メッセージはひとつずつ処理されるため、処理が追いつかない場合は TCP のフロー制御で送信が抑制される
+func (c *wsConn) readLoop(ctx context.Context, h *webSocketHub) {
+	c.ws.SetReadLimit(wsMaxMessageSize)
+	c.ws.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	c.ws.SetPongHandler(func(string) error {
+		return c.ws.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	})
+
+	for {
+		// 上限を超えるメッセージを受信した場合は 1009 (Message Too Big) で切断される
+		_, data, err := c.ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		c.ws.SetReadDeadline(time.Now().Add(wsPongTimeout))
+
+		var msg types.WSClientMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			c.sendError("", utils.ErrInvalidJSON)
+			continue
+		}
+		c.handleMessage(ctx, h, msg)
+	}
+}
+
+// handleMessage はクライアントからのメッセージをひとつ処理し、ack またはエラーを送信する
+func (c *wsConn) handleMessage(ctx context.Context, h *webSocketHub, msg types.WSClientMessage) {
+	ack := types.WSServerMessage{Type: WSTypeAck, RequestID: msg.RequestID}
+
+	switch msg.Type {
+	case WSTypeSubscribe:
+		scope := wsWorkspace
+		if msg.CategoryID != nil && *msg.CategoryID != "" {
+			categoryUUID, err := uuid.Parse(*msg.CategoryID)
+			if err != nil {
+				c.sendError(msg.RequestID, utils.ErrInvalidUUID)
+				return
+			}
+			exists, err := h.categories.Exists(ctx, categoryUUID)
+			if err != nil {
+				c.sendError(msg.RequestID, err)
+				return
+			}
+			if !exists {
+				c.sendError(msg.RequestID, utils.ErrCategoryNotFound)
+				return
+			}
+			scope = categoryUUID.String()
+			ack.CategoryID = &scope
+		}
+		c.enqueue(ack)
+		h.presence.join(c, scope)
+
+	case WSTypeUnsubscribe:
+		h.presence.leave(c)
+		c.enqueue(ack)
+
+	case WSTypeCreate:
+		if msg.Todo == nil {
+			c.sendError(msg.RequestID, errBatchTodoMissing)
+			return
+		}
+		created, err := h.todos.Create(ctx, *msg.Todo)
+		if err != nil {
+			c.sendError(msg.RequestID, err)
+			return
+		}
+		ack.Todo = created
+		c.enqueue(ack)
+
+	case WSTypeUpdate:
+		todoUUID, err := uuid.Parse(msg.TodoID)
+		if err != nil {
+			c.sendError(msg.RequestID, utils.ErrInvalidUUID)
+			return
+		}
+		if msg.Todo == nil {
+			c.sendError(msg.RequestID, errBatchTodoMissing)
+			return
+		}
+		updated, err := h.todos.UpdateIfVersion(ctx, todoUUID, *msg.Todo, msg.Version)
+		if err != nil {
+			c.sendError(msg.RequestID, err)
+			return
+		}
+		ack.Todo = updated
+		c.enqueue(ack)
+
+	case WSTypeDelete:
+		todoUUID, err := uuid.Parse(msg.TodoID)
+		if err != nil {
+			c.sendError(msg.RequestID, utils.ErrInvalidUUID)
+			return
+		}
+
+		if err := h.todos.DeleteIfVersion(ctx, todoUUID, msg.Version); err != nil {
+			c.sendError(msg.RequestID, err)
+			return
+		}
+		c.enqueue(ack)
+
+	default:
+		c.sendError(msg.RequestID, &utils.APIError{Status: http.StatusBadRequest, Code: "INVALID_MESSAGE_TYPE", Message: "Unknown message type"})
+	}
+}
+
+// forwardEvents は購読中のスコープに関係する変更イベントをクライアントに送信する
+func (c *wsConn) forwardEvents(sub *events.Subscription) {
+	for {
+		select {
+		case <-c.done:
+			return
+		case e, ok := <-sub.C:
+			if !ok {
+				// 処理が追いつかず購読が解除された。クライアントは再接続して最新状態を取得する
+				c.close(websocket.CloseTryAgainLater, "event stream overflowed")
+				return
+			}
+			scope := c.scope.Load()
+			if scope == nil {
+				continue
+			}
+			if *scope != wsWorkspace && e.Type != events.Reset && !e.MatchesCategory(*scope) {
+				continue
+			}
+			c.enqueue(types.WSServerMessage{Type: WSTypeEvent, EventID: e.ID, Event: e.Type, Data: e.Data})
+		}
+	}
+}
+
+// writeLoop は送信待ちのメッセージを書き込み、定期的に ping を送信する
+func (c *wsConn) writeLoop() {
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case data := <-c.send:
+			c.ws.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := c.ws.WriteMessage(websocket.TextMessage, data); err != nil {
+				c.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		case <-ticker.C:
+			if err := c.ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+				c.close(websocket.CloseAbnormalClosure, "")
+				return
+			}
+		}
+	}
+}
+
+// enqueue はメッセージを送信待ちに追加する
+// 送信待ちが上限に達した接続は、受信が追いついていないものとして切断する
+func (c *wsConn) enqueue(msg types.WSServerMessage) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("WebSocket message encoding error: %v", err)
+		return
+	}
+
+	select {
+	case <-c.done:
+		return
+	default:
+	}
+
+	select {
+	case c.send <- data:
+	default:
+		c.close(websocket.CloseTryAgainLater, "send buffer full")
+	}
+}
+
+// sendError はエラーメッセージを送信する。APIError 以外のエラーはログに出力する
+func (c *wsConn) sendError(requestID string, err error) {
+	apiErr := utils.AsAPIError(err)
+	if apiErr == utils.ErrDatabase {
+		log.Printf("WebSocket request error: %v", err)
+	}
+	c.enqueue(types.WSServerMessage{
+		Type:      WSTypeError,
+		RequestID: requestID,
+		Error:     &types.ErrorDetail{Code: apiErr.Code, Message: apiErr.Message},
+	})
+}
+
+// close はクローズフレームを送信して接続を閉じる
+func (c *wsConn) close(code int, reason string) {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		if code != websocket.CloseAbnormalClosure {
+			c.ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(wsWriteTimeout))
+		}
+		c.ws.Close()
+	})
+}
+
+// wsPresence はスコープごとの接続を管理し、閲覧者の一覧を配信する
+// 閲覧者の一覧はこのサーバーに接続しているクライアントのみを含む
+type wsPresence struct {
+	mu     sync.Mutex
+	scopes map[string]map[*wsConn]struct{}
+}
+
+// join は接続のスコープを変更し、変更前後のスコープの閲覧者に一覧を送信する
+func (p *wsPresence) join(c *wsConn, scope string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	previous := c.scope.Load()
+	if previous != nil {
+		p.removeLocked(c, *previous)
+	}
+	if p.scopes[scope] == nil {
+		p.scopes[scope] = make(map[*wsConn]struct{})
+	}
+	p.scopes[scope][c] = struct{}{}
+	c.scope.Store(&scope)
+
+	if previous != nil && *previous != scope {
+		p.broadcastLocked(*previous)
+	}
+	p.broadcastLocked(scope)
+}
+
+// leave は接続の購読を解除し、スコープの閲覧者に一覧を送信する
+func (p *wsPresence) leave(c *wsConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	previous := c.scope.Swap(nil)
+	if previous == nil {
+		return
+	}
+	p.removeLocked(c, *previous)
+	p.broadcastLocked(*previous)
+}
+
+func (p *wsPresence) removeLocked(c *wsConn, scope string) {
+	delete(p.scopes[scope], c)
+	if len(p.scopes[scope]) == 0 {
+		delete(p.scopes, scope)
+	}
+}
+
+func (p *wsPresence) broadcastLocked(scope string) {
+	conns := p.scopes[scope]
+	if len(conns) == 0 {
+		return
+	}
+
+	viewers := make([]types.WSViewer, 0, len(conns))
+	for c := range conns {
+		viewers = append(viewers, types.WSViewer{ConnectionID: c.id, Actor: c.actor, Since: c.since})
+	}
+	sort.Slice(viewers, func(i, j int) bool { return viewers[i].Since.Before(viewers[j].Since) })
+
+	msg := types.WSServerMessage{Type: WSTypePresence, Viewers: viewers}
+	if scope != wsWorkspace {
+		msg.CategoryID = &scope
+	}
+	for c := range conns {
+		c.enqueue(msg)
+	}
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+func TestWebSocketSendBufferFull(t *testing.T) {
+	conns := make(chan *wsConn, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conns <- &wsConn{ws: ws, send: make(chan []byte, wsSendBufferSize), done: make(chan struct{})}
+	}))
+	defer ts.Close()
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	c := <-conns
+
+	// writeLoop を起動せずに送信待ちを上限まで溜める。上限を超えたメッセージで接続を切断する
+	for range wsSendBufferSize {
+		c.enqueue(types.WSServerMessage{Type: WSTypeEvent})
+	}
+	select {
+	case <-c.done:
+		t.Fatal("connection closed before the send buffer was full")
+	default:
+	}
+	c.enqueue(types.WSServerMessage{Type: WSTypeEvent})
+	select {
+	case <-c.done:
+	default:
+		t.Fatal("connection not closed after the send buffer overflowed")
+	}
+
+	client.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, _, err = client.ReadMessage()
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != websocket.CloseTryAgainLater {
+		t.Fatalf("read error = %v, want close %d", err, websocket.CloseTryAgainLater)
+	}
+}
@@ -0,0 +1,152 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/t-okuji/go-openapi-todo-demo/handlers"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// startWebSocketServer は srv を HTTP サーバーとして起動し、/ws の URL を返す
+func startWebSocketServer(t *testing.T, srv *testServer) string {
+	t.Helper()
+	ts := httptest.NewServer(srv.handler)
+	t.Cleanup(ts.Close)
+	return "ws" + strings.TrimPrefix(ts.URL, "http") + "/ws"
+}
+
+// dialWebSocket は /ws に接続し、welcome メッセージを読み出す
+func dialWebSocket(t *testing.T, url string) (*websocket.Conn, types.WSServerMessage) {
+	t.Helper()
+	ws, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ws.Close() })
+
+	var welcome types.WSServerMessage
+	if err := ws.ReadJSON(&welcome); err != nil {
+		t.Fatal(err)
+	}
+	if welcome.Type != handlers.WSTypeWelcome || welcome.ConnectionID == "" {
+		t.Fatalf("first message = %+v, want welcome", welcome)
+	}
+	return ws, welcome
+}
+
+// request はメッセージを送信し、同じ requestId の ack またはエラーを返す
+// 変更イベント・閲覧者の一覧は読み飛ばす
+func request(t *testing.T, ws *websocket.Conn, msg types.WSClientMessage) types.WSServerMessage {
+	t.Helper()
+	if err := ws.WriteJSON(msg); err != nil {
+		t.Fatal(err)
+	}
+	ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		var reply types.WSServerMessage
+		if err := ws.ReadJSON(&reply); err != nil {
+			t.Fatalf("%s %s: %v", msg.Type, msg.RequestID, err)
+		}
+		if reply.RequestID == msg.RequestID && (reply.Type == handlers.WSTypeAck || reply.Type == handlers.WSTypeError) {
+			return reply
+		}
+	}
+}
+
+func TestWebSocketAcks(t *testing.T) {
+	ws, welcome := dialWebSocket(t, startWebSocketServer(t, newSQLiteServer(t))+"?actor=alice")
+	if welcome.Actor != "alice" {
+		t.Errorf("welcome actor = %q, want alice", welcome.Actor)
+	}
+
+	if reply := request(t, ws, types.WSClientMessage{Type: handlers.WSTypeSubscribe, RequestID: "sub"}); reply.Type != handlers.WSTypeAck {
+		t.Fatalf("subscribe: %+v", reply)
+	}
+
+	created := request(t, ws, types.WSClientMessage{Type: handlers.WSTypeCreate, RequestID: "create", Todo: &types.TodoInput{Title: "牛乳を買う"}})
+	if created.Type != handlers.WSTypeAck || created.Todo == nil || created.Todo.Version != 1 {
+		t.Fatalf("create: %+v", created)
+	}
+	todoID := created.Todo.ID.String()
+
+	version := 1
+	updated := request(t, ws, types.WSClientMessage{Type: handlers.WSTypeUpdate, RequestID: "update", TodoID: todoID, Version: &version, Todo: &types.TodoInput{Title: "卵を買う"}})
+	if updated.Type != handlers.WSTypeAck || updated.Todo == nil || updated.Todo.Version != 2 || updated.Todo.Title != "卵を買う" {
+		t.Fatalf("update: %+v", updated)
+	}
+
+	// 操作の失敗は同じ requestId のエラーとして返し、接続は維持する
+	for _, tt := range []struct {
+		name string
+		msg  types.WSClientMessage
+		want *utils.APIError
+	}{
+		{"stale version", types.WSClientMessage{Type: handlers.WSTypeUpdate, TodoID: todoID, Version: &version, Todo: &types.TodoInput{Title: "x"}}, utils.ErrVersionConflict},
+		{"empty title", types.WSClientMessage{Type: handlers.WSTypeCreate, Todo: &types.TodoInput{}}, utils.ErrTitleRequired},
+		{"invalid id", types.WSClientMessage{Type: handlers.WSTypeDelete, TodoID: "x"}, utils.ErrInvalidUUID},
+	} {
+		tt.msg.RequestID = tt.name
+		reply := request(t, ws, tt.msg)
+		if reply.Type != handlers.WSTypeError || reply.Error == nil || reply.Error.Code != tt.want.Code {
+			t.Errorf("%s: reply = %+v, want error %s", tt.name, reply, tt.want.Code)
+		}
+	}
+
+	version = 2
+	if reply := request(t, ws, types.WSClientMessage{Type: handlers.WSTypeDelete, RequestID: "delete", TodoID: todoID, Version: &version}); reply.Type != handlers.WSTypeAck {
+		t.Fatalf("delete: %+v", reply)
+	}
+}
+
+func TestWebSocketMaxMessageSize(t *testing.T) {
+	ws, _ := dialWebSocket(t, startWebSocketServer(t, newSQLiteServer(t)))
+
+	// 上限（64 KiB）を超えるメッセージを受信した接続は 1009 (Message Too Big) で切断する
+	title := strings.Repeat("a", 64<<10)
+	if err := ws.WriteJSON(types.WSClientMessage{Type: handlers.WSTypeCreate, Todo: &types.TodoInput{Title: title}}); err != nil {
+		t.Fatal(err)
+	}
+	ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		_, _, err := ws.ReadMessage()
+		if err == nil {
+			continue
+		}
+		var closeErr *websocket.CloseError
+		if !errors.As(err, &closeErr) || closeErr.Code != websocket.CloseMessageTooBig {
+			t.Fatalf("read error = %v, want close %d", err, websocket.CloseMessageTooBig)
+		}
+		return
+	}
+}
+
+func TestWebSocketPresence(t *testing.T) {
+	url := startWebSocketServer(t, newSQLiteServer(t))
+	alice, _ := dialWebSocket(t, url+"?actor=alice")
+	bob, _ := dialWebSocket(t, url+"?actor=bob")
+
+	// 閲覧者の一覧は同じサーバーの全ての接続で共有する
+	request(t, alice, types.WSClientMessage{Type: handlers.WSTypeSubscribe, RequestID: "1"})
+	request(t, bob, types.WSClientMessage{Type: handlers.WSTypeSubscribe, RequestID: "1"})
+
+	alice.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		var msg types.WSServerMessage
+		if err := alice.ReadJSON(&msg); err != nil {
+			t.Fatal(err)
+		}
+		if msg.Type != handlers.WSTypePresence || len(msg.Viewers) < 2 {
+			continue
+		}
+		if msg.Viewers[0].Actor != "alice" || msg.Viewers[1].Actor != "bob" {
+			t.Errorf("viewers = %+v, want alice and bob", msg.Viewers)
+		}
+		return
+	}
+}
@@ -0,0 +1,227 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/auditevent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/category"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/hook"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/todo"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// 監査対象のエンティティ種別
+const (
+	EntityTodo     = "todo"
+	EntityCategory = "category"
+)
+
+// Snapshot は監査ログ用に記録するエンティティの状態（API のフィールド名をキーとする）
+type Snapshot map[string]any
+
+// entityMutation は Todo・Category のミューテーションが共通して持つメソッド
+type entityMutation interface {
+	ent.Mutation
+	ID() (uuid.UUID, bool)
+	IDs(ctx context.Context) ([]uuid.UUID, error)
+	Client() *ent.Client
+	Tx() (*ent.Tx, error)
+}
+
+// snapshotLoader は指定IDのエンティティの状態を読み込む関数
+type snapshotLoader func(ctx context.Context, client *ent.Client, ids []uuid.UUID) (map[uuid.UUID]Snapshot, error)
+
+// ErrAuditRequiresTx はトランザクション外で Todo・Category を変更した場合のエラー
+var ErrAuditRequiresTx = errors.New("audit: todo and category mutations must run in a transaction")
+
+// RegisterAudit は Todo・Category の全ミューテーションを監査ログに記録するグローバルフックを登録する
+//
+// 監査イベントはミューテーションと同じトランザクションで書き込み、変更と同時にコミットされる。
+// トランザクション外のミューテーションは、変更と監査イベントの一方だけが保存されることを防ぐため
+// ErrAuditRequiresTx で拒否する（utils.WithTx やリポジトリを通して変更する）。
+func RegisterAudit(client *ent.Client) {
+	client.Todo.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.TodoFunc(func(ctx context.Context, m *ent.TodoMutation) (ent.Value, error) {
+			return audit(ctx, m, next, EntityTodo, LoadTodoSnapshots)
+		})
+	})
+	client.Category.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.CategoryFunc(func(ctx context.Context, m *ent.CategoryMutation) (ent.Value, error) {
+			return audit(ctx, m, next, EntityCategory, LoadCategorySnapshots)
+		})
+	})
+
+	// 監査ログは追記のみ許可する
+	client.AuditEvent.Use(hook.Reject(ent.OpUpdate | ent.OpUpdateOne | ent.OpDelete | ent.OpDeleteOne))
+}
+
+// audit はミューテーション前後の状態を比較し、対象エンティティごとに監査イベントを書き込む
+func audit(ctx context.Context, m entityMutation, next ent.Mutator, entityType string, load snapshotLoader) (ent.Value, error) {
+	if _, err := m.Tx(); err != nil {
+		return nil, ErrAuditRequiresTx
+	}
+	client := m.Client()
+
+	// 更新・削除の場合は変更前の状態を取得
+	var (
+		ids    []uuid.UUID
+		before map[uuid.UUID]Snapshot
+	)
+	if !m.Op().Is(ent.OpCreate) {
+		var err error
+		if ids, err = m.IDs(ctx); err != nil {
+			return nil, fmt.Errorf("audit: loading ids: %w", err)
+		}
+		if before, err = load(ctx, client, ids); err != nil {
+			return nil, fmt.Errorf("audit: loading snapshots: %w", err)
+		}
+	}
+
+	v, err := next.Mutate(ctx, m)
+	if err != nil {
+		return v, err
+	}
+
+	var (
+		operation auditevent.Operation
+		after     map[uuid.UUID]Snapshot
+	)
+	switch {
+	case m.Op().Is(ent.OpCreate):
+		operation = auditevent.OperationCreate
+		if id, ok := m.ID(); ok {
+			ids = []uuid.UUID{id}
+		}
+	case m.Op().Is(ent.OpUpdate | ent.OpUpdateOne):
+		operation = auditevent.OperationUpdate
+	default:
+		operation = auditevent.OperationDelete
+	}
+	if operation != auditevent.OperationDelete {
+		if after, err = load(ctx, client, ids); err != nil {
+			return nil, fmt.Errorf("audit: loading snapshots: %w", err)
+		}
+	}
+
+	actor := utils.ActorFromContext(ctx)
+	requestID := utils.RequestIDFromContext(ctx)
+
+	builders := make([]*ent.AuditEventCreate, 0, len(ids))
+	for _, id := range ids {
+		old, existed := before[id]
+		if operation != auditevent.OperationCreate && !existed {
+			continue
+		}
+		builders = append(builders, client.AuditEvent.Create().
+			SetActor(actor).
+			SetOperation(operation).
+			SetEntityType(entityType).
+			SetEntityID(id).
+			SetChanges(diffSnapshots(old, after[id])).
+			SetNillableRequestID(nilIfEmpty(requestID)))
+	}
+	if len(builders) > 0 {
+		if err := client.AuditEvent.CreateBulk(builders...).Exec(ctx); err != nil {
+			return nil, fmt.Errorf("audit: writing events: %w", err)
+		}
+	}
+
+	return v, nil
+}
+
+// diffSnapshots は変更前後の状態から変更されたフィールドを抽出する
+func diffSnapshots(old, new Snapshot) map[string]types.FieldChange {
+	changes := make(map[string]types.FieldChange)
+	for name, newValue := range new {
+		if oldValue := old[name]; oldValue != newValue {
+			changes[name] = types.FieldChange{Old: oldValue, New: newValue}
+		}
+	}
+	for name, oldValue := range old {
+		if _, ok := new[name]; !ok && oldValue != nil {
+			changes[name] = types.FieldChange{Old: oldValue, New: nil}
+		}
+	}
+	return changes
+}
+
+// LoadTodoSnapshots は指定IDの Todo の状態を読み込む
+func LoadTodoSnapshots(ctx context.Context, client *ent.Client, ids []uuid.UUID) (map[uuid.UUID]Snapshot, error) {
+	todos, err := client.Todo.Query().Where(todo.IDIn(ids...)).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make(map[uuid.UUID]Snapshot, len(todos))
+	for _, t := range todos {
+		snapshots[t.ID] = TodoSnapshot(t)
+	}
+	return snapshots, nil
+}
+
+// LoadCategorySnapshots は指定IDの Category の状態を読み込む
+func LoadCategorySnapshots(ctx context.Context, client *ent.Client, ids []uuid.UUID) (map[uuid.UUID]Snapshot, error) {
+	categories, err := client.Category.Query().Where(category.IDIn(ids...)).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make(map[uuid.UUID]Snapshot, len(categories))
+	for _, c := range categories {
+		snapshots[c.ID] = CategorySnapshot(c)
+	}
+	return snapshots, nil
+}
+
+// TodoSnapshot は Todo エンティティを監査ログ用の状態に変換する
+func TodoSnapshot(t *ent.Todo) Snapshot {
+	s := Snapshot{
+		"title":       t.Title,
+		"description": optionalString(t.Description),
+		"completed":   t.Completed,
+		"categoryId":  nil,
+		"dueAt":       nil,
+		"recurrence":  nil,
+		"timeZone":    nil,
+	}
+	if t.CategoryID != nil {
+		s["categoryId"] = t.CategoryID.String()
+	}
+	if t.DueAt != nil {
+		s["dueAt"] = t.DueAt.UTC().Format(time.RFC3339)
+	}
+	if t.Recurrence != "" {
+		s["recurrence"] = t.Recurrence
+		s["timeZone"] = t.RecurrenceTimeZone
+	}
+	return s
+}
+
+// CategorySnapshot は Category エンティティを監査ログ用の状態に変換する
+func CategorySnapshot(c *ent.Category) Snapshot {
+	return Snapshot{
+		"name":        c.Name,
+		"description": optionalString(c.Description),
+		"color":       c.Color,
+	}
+}
+
+// optionalString は任意項目の文字列を、未設定（空文字列）の場合は nil として返す
+func optionalString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// nilIfEmpty は空文字列を nil ポインタに変換する
+func nilIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,110 @@
+package hooks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/t-okuji/go-openapi-todo-demo/database"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/auditevent"
+	"github.com/t-okuji/go-openapi-todo-demo/hooks"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// openDB はテストごとに SQLite のインメモリデータベースを作成し、テストの終了時に閉じる
+func openDB(t *testing.T) *database.DB {
+	t.Helper()
+	db, err := database.Open(context.Background(), database.DriverSQLite, "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestAudit(t *testing.T) {
+	db := openDB(t)
+	hooks.RegisterAudit(db.Client)
+	hooks.RegisterVersioning(db.Client)
+
+	ctx := utils.WithActor(context.Background(), "alice")
+	ctx = context.WithValue(ctx, middleware.RequestIDKey, "req-1")
+
+	// トランザクション外の変更は拒否し、何も書き込まない
+	if _, err := db.Client.Todo.Create().SetTitle("outside").Save(ctx); !errors.Is(err, hooks.ErrAuditRequiresTx) {
+		t.Fatalf("Create() outside tx: err = %v, want ErrAuditRequiresTx", err)
+	}
+	if n := db.Client.Todo.Query().CountX(ctx); n != 0 {
+		t.Fatalf("todos after rejected create = %d, want 0", n)
+	}
+
+	var created *ent.Todo
+	err := utils.WithTx(ctx, db.Client, func(tx *ent.Tx) error {
+		var err error
+		if created, err = tx.Todo.Create().SetTitle("a").Save(ctx); err != nil {
+			return err
+		}
+		if _, err = tx.Todo.UpdateOne(created).SetTitle("b").SetCompleted(true).Save(ctx); err != nil {
+			return err
+		}
+		return tx.Todo.DeleteOne(created).Exec(ctx)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := db.Client.AuditEvent.Query().Order(ent.Asc(auditevent.FieldID)).AllX(ctx)
+	if len(events) != 3 {
+		t.Fatalf("audit events = %d, want 3", len(events))
+	}
+	want := []struct {
+		operation auditevent.Operation
+		changes   map[string]types.FieldChange
+	}{
+		{auditevent.OperationCreate, map[string]types.FieldChange{
+			"title":     {Old: nil, New: "a"},
+			"completed": {Old: nil, New: false},
+		}},
+		{auditevent.OperationUpdate, map[string]types.FieldChange{
+			"title":     {Old: "a", New: "b"},
+			"completed": {Old: false, New: true},
+		}},
+		{auditevent.OperationDelete, map[string]types.FieldChange{
+			"title":     {Old: "b", New: nil},
+			"completed": {Old: true, New: nil},
+		}},
+	}
+	for i, e := range events {
+		if e.Actor != "alice" || e.RequestID != "req-1" || e.EntityType != hooks.EntityTodo || e.EntityID != created.ID {
+			t.Errorf("event %d = actor %q, request %q, entity %s/%s", i, e.Actor, e.RequestID, e.EntityType, e.EntityID)
+		}
+		if e.Operation != want[i].operation {
+			t.Errorf("event %d operation = %s, want %s", i, e.Operation, want[i].operation)
+		}
+		if len(e.Changes) != len(want[i].changes) {
+			t.Errorf("event %d changes = %v, want %v", i, e.Changes, want[i].changes)
+		}
+		for name, change := range want[i].changes {
+			if got := e.Changes[name]; got != change {
+				t.Errorf("event %d changes[%s] = %v, want %v", i, name, got, change)
+			}
+		}
+	}
+
+	// ロールバックしたトランザクションの監査イベントは残らない
+	err = utils.WithTx(ctx, db.Client, func(tx *ent.Tx) error {
+		if _, err := tx.Category.Create().SetName("work").Save(ctx); err != nil {
+			return err
+		}
+		return errors.New("abort")
+	})
+	if err == nil {
+		t.Fatal("WithTx() = nil, want error")
+	}
+	if n := db.Client.AuditEvent.Query().Where(auditevent.EntityType(hooks.EntityCategory)).CountX(ctx); n != 0 {
+		t.Errorf("category audit events after rollback = %d, want 0", n)
+	}
+}
@@ -0,0 +1,285 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/category"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/hook"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/todo"
+	"github.com/t-okuji/go-openapi-todo-demo/events"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// Publisher は変更イベントの配信先
+type Publisher interface {
+	Publish(e events.Event) events.Event
+}
+
+// eventLoader は指定IDのエンティティを変更イベントに変換する関数
+type eventLoader func(ctx context.Context, client *ent.Client, ids []uuid.UUID, eventType string) (map[uuid.UUID]events.Event, error)
+
+// RegisterEvents は Todo・Category の全ミューテーションを変更イベントとして配信するグローバルフックを登録する
+//
+// トランザクション内のミューテーションはコミット後に配信される。
+// セーブポイント内のミューテーションは BeginSavepoint のコンテキストで実行すると、
+// セーブポイントまでロールバックした変更のイベントを配信しない。
+func RegisterEvents(client *ent.Client, publisher Publisher) {
+	client.Todo.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.TodoFunc(func(ctx context.Context, m *ent.TodoMutation) (ent.Value, error) {
+			return publishEvents(ctx, m, next, publisher, todoEventTypes, loadTodoEvents)
+		})
+	})
+	client.Category.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.CategoryFunc(func(ctx context.Context, m *ent.CategoryMutation) (ent.Value, error) {
+			return publishEvents(ctx, m, next, publisher, categoryEventTypes, loadCategoryEvents)
+		})
+	})
+}
+
+// eventTypes は操作種別ごとのイベント種別
+type eventTypes struct {
+	created, updated, deleted string
+}
+
+var (
+	todoEventTypes     = eventTypes{events.TodoCreated, events.TodoUpdated, events.TodoDeleted}
+	categoryEventTypes = eventTypes{events.CategoryCreated, events.CategoryUpdated, events.CategoryDeleted}
+)
+
+// publishEvents はミューテーションの対象エンティティごとに変更イベントを配信する
+func publishEvents(ctx context.Context, m entityMutation, next ent.Mutator, publisher Publisher, types eventTypes, load eventLoader) (ent.Value, error) {
+	v, published, err := collectEvents(ctx, m, next, types, load)
+	if err != nil {
+		return v, err
+	}
+
+	publish := func() {
+		for _, e := range published {
+			publisher.Publish(e)
+		}
+	}
+
+	// トランザクション内であればコミット後に、セーブポイント内であればセーブポイントの解放後に配信する
+	tx, err := m.Tx()
+	switch {
+	case err != nil:
+		publish()
+	case savepointFromContext(ctx) != nil:
+		sp := savepointFromContext(ctx)
+		sp.pending = append(sp.pending, publish)
+	default:
+		afterCommit(tx, publish)
+	}
+
+	return v, nil
+}
+
+type afterCommitContextKey struct{}
+
+// afterCommit は tx のコミットに成功した後に publish を実行する
+// 同じトランザクションで登録した publish は登録順に実行する
+func afterCommit(tx *ent.Tx, publish func()) {
+	tx.OnCommit(func(next ent.Committer) ent.Committer {
+		return ent.CommitFunc(func(ctx context.Context, tx *ent.Tx) error {
+			queue, nested := ctx.Value(afterCommitContextKey{}).(*[]func())
+			if !nested {
+				queue = new([]func())
+				ctx = context.WithValue(ctx, afterCommitContextKey{}, queue)
+			}
+			if err := next.Commit(ctx, tx); err != nil {
+				return err
+			}
+
+			// コミットフックは最初に登録したものが最も外側になり、後に登録したものから順に戻るため、
+			// 先頭に追加して登録順に並べ、最も外側のフックでまとめて実行する
+			*queue = append([]func(){publish}, *queue...)
+			if !nested {
+				for _, publish := range *queue {
+					publish()
+				}
+			}
+			return nil
+		})
+	})
+}
+
+type savepointContextKey struct{}
+
+// Savepoint はセーブポイント内のミューテーションの変更イベントを、セーブポイントの解放まで保留する
+type Savepoint struct {
+	tx      *ent.Tx
+	parent  *Savepoint
+	pending []func()
+}
+
+// BeginSavepoint は tx のセーブポイント内でミューテーションを実行するためのコンテキストを返す
+//
+// SQL の SAVEPOINT の作成・解放・ロールバックは呼び出し側が行い、その結果に合わせて
+// Release または Rollback を呼び出す。セーブポイントは入れ子にできる。
+func BeginSavepoint(ctx context.Context, tx *ent.Tx) (context.Context, *Savepoint) {
+	sp := &Savepoint{tx: tx, parent: savepointFromContext(ctx)}
+	return context.WithValue(ctx, savepointContextKey{}, sp), sp
+}
+
+// Release はセーブポイントの解放後に呼び出し、保留したイベントを外側のセーブポイントに引き継ぐ
+// 外側にセーブポイントがない場合はトランザクションのコミット後に配信する
+func (sp *Savepoint) Release() {
+	pending := sp.pending
+	sp.pending = nil
+	if len(pending) == 0 {
+		return
+	}
+	if sp.parent != nil {
+		sp.parent.pending = append(sp.parent.pending, pending...)
+		return
+	}
+	afterCommit(sp.tx, func() {
+		for _, publish := range pending {
+			publish()
+		}
+	})
+}
+
+// Rollback はセーブポイントまでのロールバック後に呼び出し、保留したイベントを破棄する
+func (sp *Savepoint) Rollback() {
+	sp.pending = nil
+}
+
+// savepointFromContext はコンテキストのセーブポイントを返す（セーブポイント外の場合は nil）
+func savepointFromContext(ctx context.Context) *Savepoint {
+	sp, _ := ctx.Value(savepointContextKey{}).(*Savepoint)
+	return sp
+}
+
+// collectEvents はミューテーションを実行し、対象エンティティごとの変更イベントを返す
+func collectEvents(ctx context.Context, m entityMutation, next ent.Mutator, types eventTypes, load eventLoader) (ent.Value, []events.Event, error) {
+	client := m.Client()
+
+	var ids []uuid.UUID
+	if !m.Op().Is(ent.OpCreate) {
+		var err error
+		if ids, err = m.IDs(ctx); err != nil {
+			return nil, nil, fmt.Errorf("events: loading ids: %w", err)
+		}
+	}
+
+	// 削除の場合は削除前の状態をイベントの内容とする
+	var deleted map[uuid.UUID]events.Event
+	if m.Op().Is(ent.OpDelete | ent.OpDeleteOne) {
+		var err error
+		if deleted, err = load(ctx, client, ids, types.deleted); err != nil {
+			return nil, nil, fmt.Errorf("events: loading entities: %w", err)
+		}
+	}
+
+	// 更新前の所属カテゴリ（Todo がカテゴリ間を移動した場合の絞り込み用）
+	var previousCategories map[uuid.UUID]string
+	if _, ok := m.(*ent.TodoMutation); ok && m.Op().Is(ent.OpUpdate|ent.OpUpdateOne) {
+		var err error
+		if previousCategories, err = loadTodoCategories(ctx, client, ids); err != nil {
+			return nil, nil, fmt.Errorf("events: loading categories: %w", err)
+		}
+	}
+
+	v, err := next.Mutate(ctx, m)
+	if err != nil {
+		return v, nil, err
+	}
+
+	var published []events.Event
+	switch {
+	case m.Op().Is(ent.OpCreate):
+		if id, ok := m.ID(); ok {
+			created, err := load(ctx, client, []uuid.UUID{id}, types.created)
+			if err != nil {
+				return nil, nil, fmt.Errorf("events: loading entities: %w", err)
+			}
+			published = append(published, created[id])
+		}
+	case m.Op().Is(ent.OpUpdate | ent.OpUpdateOne):
+		updated, err := load(ctx, client, ids, types.updated)
+		if err != nil {
+			return nil, nil, fmt.Errorf("events: loading entities: %w", err)
+		}
+		for _, id := range ids {
+			e, ok := updated[id]
+			if !ok {
+				continue
+			}
+			if previous, ok := previousCategories[id]; ok && !e.MatchesCategory(previous) {
+				e.CategoryIDs = append(e.CategoryIDs, previous)
+			}
+			published = append(published, e)
+		}
+	default:
+		for _, id := range ids {
+			if e, ok := deleted[id]; ok {
+				published = append(published, e)
+			}
+		}
+	}
+
+	return v, published, nil
+}
+
+// loadTodoCategories は指定IDの Todo の所属カテゴリIDを読み込む
+func loadTodoCategories(ctx context.Context, client *ent.Client, ids []uuid.UUID) (map[uuid.UUID]string, error) {
+	todos, err := client.Todo.Query().
+		Where(todo.IDIn(ids...), todo.CategoryIDNotNil()).
+		Select(todo.FieldID, todo.FieldCategoryID).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	categories := make(map[uuid.UUID]string, len(todos))
+	for _, t := range todos {
+		categories[t.ID] = t.CategoryID.String()
+	}
+	return categories, nil
+}
+
+// loadTodoEvents は指定IDの Todo を types.TodoResponse を内容とするイベントに変換する
+func loadTodoEvents(ctx context.Context, client *ent.Client, ids []uuid.UUID, eventType string) (map[uuid.UUID]events.Event, error) {
+	todos, err := client.Todo.Query().Where(todo.IDIn(ids...)).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[uuid.UUID]events.Event, len(todos))
+	for _, t := range todos {
+		data, err := json.Marshal(utils.ConvertToTodoResponse(t))
+		if err != nil {
+			return nil, err
+		}
+		e := events.Event{Type: eventType, Data: data}
+		if t.CategoryID != nil {
+			e.CategoryIDs = []string{t.CategoryID.String()}
+		}
+		result[t.ID] = e
+	}
+	return result, nil
+}
+
+// loadCategoryEvents は指定IDの Category を types.CategoryResponse を内容とするイベントに変換する
+func loadCategoryEvents(ctx context.Context, client *ent.Client, ids []uuid.UUID, eventType string) (map[uuid.UUID]events.Event, error) {
+	categories, err := client.Category.Query().Where(category.IDIn(ids...)).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[uuid.UUID]events.Event, len(categories))
+	for _, c := range categories {
+		data, err := json.Marshal(utils.ConvertToCategoryResponse(c))
+		if err != nil {
+			return nil, err
+		}
+		result[c.ID] = events.Event{
+			Type:        eventType,
+			CategoryIDs: []string{c.ID.String()},
+			Data:        data,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,119 @@
+package hooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/events"
+	"github.com/t-okuji/go-openapi-todo-demo/hooks"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// publishedTitles は購読中に配信された Todo のイベントのタイトルを配信順に返す
+func publishedTitles(t *testing.T, sub *events.Subscription) []string {
+	t.Helper()
+	var titles []string
+	for {
+		select {
+		case e := <-sub.C:
+			var todo types.TodoResponse
+			if err := json.Unmarshal(e.Data, &todo); err != nil {
+				t.Fatal(err)
+			}
+			titles = append(titles, e.Type+":"+todo.Title)
+		default:
+			return titles
+		}
+	}
+}
+
+func TestEventsInSavepoint(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+	broker := events.NewBroker(100)
+	hooks.RegisterEvents(db.Client, broker)
+	sub, _ := broker.Subscribe(0)
+	defer broker.Unsubscribe(sub)
+
+	// savepoint は SQL のセーブポイント内で fn を実行し、fn が失敗した場合はセーブポイントまでロールバックする
+	savepoint := func(ctx context.Context, tx *ent.Tx, name string, fn func(ctx context.Context) error) {
+		t.Helper()
+		tx.ExecContext(ctx, "SAVEPOINT "+name)
+		spCtx, sp := hooks.BeginSavepoint(ctx, tx)
+		if err := fn(spCtx); err != nil {
+			sp.Rollback()
+			tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+			return
+		}
+		tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+		sp.Release()
+	}
+	create := func(tx *ent.Tx, title string, fail bool) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			if _, err := tx.Todo.Create().SetTitle(title).Save(ctx); err != nil {
+				return err
+			}
+			if fail {
+				return errors.New("op failed")
+			}
+			return nil
+		}
+	}
+
+	err := utils.WithTx(ctx, db.Client, func(tx *ent.Tx) error {
+		if _, err := tx.Todo.Create().SetTitle("outside").Save(ctx); err != nil {
+			return err
+		}
+		savepoint(ctx, tx, "sp1", create(tx, "released", false))
+		savepoint(ctx, tx, "sp2", create(tx, "rolled-back", true))
+
+		// 入れ子のセーブポイントは外側のセーブポイントのロールバックで破棄される
+		savepoint(ctx, tx, "sp3", func(ctx context.Context) error {
+			savepoint(ctx, tx, "sp3_inner", create(tx, "nested", false))
+			return errors.New("outer failed")
+		})
+		savepoint(ctx, tx, "sp4", func(ctx context.Context) error {
+			savepoint(ctx, tx, "sp4_inner", create(tx, "nested-released", false))
+			return nil
+		})
+
+		// コミットまでは配信しない
+		if titles := publishedTitles(t, sub); len(titles) != 0 {
+			t.Errorf("published before commit: %q", titles)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"todo.created:outside", "todo.created:released", "todo.created:nested-released"}
+	titles := publishedTitles(t, sub)
+	if len(titles) != len(want) {
+		t.Fatalf("published = %q, want %q", titles, want)
+	}
+	for i := range want {
+		if titles[i] != want[i] {
+			t.Errorf("published = %q, want %q", titles, want)
+			break
+		}
+	}
+
+	// ロールバックしたトランザクションの変更は配信しない
+	err = utils.WithTx(ctx, db.Client, func(tx *ent.Tx) error {
+		if _, err := tx.Todo.Create().SetTitle("aborted").Save(ctx); err != nil {
+			return err
+		}
+		return errors.New("abort")
+	})
+	if err == nil {
+		t.Fatal("WithTx() = nil, want error")
+	}
+	if titles := publishedTitles(t, sub); len(titles) != 0 {
+		t.Errorf("published after rollback: %q", titles)
+	}
+}
@@ -0,0 +1,237 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/hook"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/todo"
+	"github.com/t-okuji/go-openapi-todo-demo/recurrence"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// RegisterRecurrence は繰り返しの系列を管理するグローバルフックを登録する
+//
+// 繰り返しルールを設定した Todo は期限を1回目とする系列を開始し、ルール・タイムゾーンを変更した場合は
+// その時点の期限から新しい系列を開始する。繰り返しの Todo が完了になった場合は、
+// 同じカテゴリで系列の次の回の Todo を同一トランザクションで作成する。
+// 次の回の Todo が作成済みの場合（完了を取り消して再度完了にした場合など）は作成しない。
+func RegisterRecurrence(client *ent.Client) {
+	client.Todo.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.TodoFunc(func(ctx context.Context, m *ent.TodoMutation) (ent.Value, error) {
+			switch {
+			case m.Op().Is(ent.OpCreate):
+				if err := startSeriesOnCreate(m); err != nil {
+					return nil, err
+				}
+			case m.Op().Is(ent.OpUpdateOne):
+				if err := updateSeries(ctx, m); err != nil {
+					return nil, err
+				}
+			}
+			if !m.Op().Is(ent.OpUpdate | ent.OpUpdateOne) {
+				return next.Mutate(ctx, m)
+			}
+			return completeRecurring(ctx, m, next)
+		})
+	})
+}
+
+// startSeriesOnCreate は繰り返しルールを設定して作成する Todo の系列を開始する
+// 系列を引き継いで作成する場合（次の回の作成）は設定済みの系列の項目をそのまま使う
+func startSeriesOnCreate(m *ent.TodoMutation) error {
+	rule, _ := m.Recurrence()
+	if rule == "" {
+		m.ClearRecurrenceTimeZone()
+		return nil
+	}
+	dueAt, ok := m.DueAt()
+	if !ok {
+		return utils.ErrRecurrenceRequiresDueAt
+	}
+	if tz, _ := m.RecurrenceTimeZone(); tz == "" {
+		m.SetRecurrenceTimeZone(time.UTC.String())
+	}
+	if _, ok := m.SeriesID(); !ok {
+		id, _ := m.ID()
+		m.SetSeriesID(id)
+	}
+	if _, ok := m.SeriesStart(); !ok {
+		m.SetSeriesStart(dueAt)
+	}
+	if _, ok := m.Occurrence(); !ok {
+		m.SetOccurrence(1)
+	}
+	return nil
+}
+
+// updateSeries は更新後の繰り返しルール・タイムゾーン・期限に合わせて系列の項目を更新する
+func updateSeries(ctx context.Context, m *ent.TodoMutation) error {
+	_, ruleSet := m.Recurrence()
+	_, tzSet := m.RecurrenceTimeZone()
+	_, dueSet := m.DueAt()
+	if !ruleSet && !tzSet && !dueSet && !m.RecurrenceCleared() && !m.DueAtCleared() {
+		return nil
+	}
+
+	id, ok := m.ID()
+	if !ok {
+		return nil
+	}
+	old, err := m.Client().Todo.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			// 対象が存在しない場合はミューテーションに任せる
+			return nil
+		}
+		return fmt.Errorf("recurrence: loading todo: %w", err)
+	}
+
+	// 更新後の値
+	rule := old.Recurrence
+	if v, ok := m.Recurrence(); ok {
+		rule = v
+	} else if m.RecurrenceCleared() {
+		rule = ""
+	}
+	tz := old.RecurrenceTimeZone
+	if v, ok := m.RecurrenceTimeZone(); ok {
+		tz = v
+	}
+	if tz == "" {
+		tz = time.UTC.String()
+	}
+	dueAt := old.DueAt
+	if v, ok := m.DueAt(); ok {
+		dueAt = &v
+	} else if m.DueAtCleared() {
+		dueAt = nil
+	}
+
+	if rule == "" {
+		m.ClearRecurrence()
+		m.ClearRecurrenceTimeZone()
+		m.ClearSeriesID()
+		m.ClearSeriesStart()
+		m.ClearOccurrence()
+		return nil
+	}
+	if dueAt == nil {
+		return utils.ErrRecurrenceRequiresDueAt
+	}
+	m.SetRecurrenceTimeZone(tz)
+
+	// ルール・タイムゾーンを変更した場合は新しい系列を開始する（系列の項目を明示的に設定した場合を除く）
+	if _, ok := m.SeriesStart(); ok {
+		return nil
+	}
+	if rule != old.Recurrence || tz != old.RecurrenceTimeZone || old.SeriesID == nil {
+		m.SetSeriesID(uuid.New())
+		m.SetSeriesStart(*dueAt)
+		m.SetOccurrence(1)
+	}
+	return nil
+}
+
+// completeRecurring は更新で完了になった繰り返しの Todo について、系列の次の回の Todo を作成する
+func completeRecurring(ctx context.Context, m *ent.TodoMutation, next ent.Mutator) (ent.Value, error) {
+	completed, ok := m.Completed()
+	if !ok || !completed {
+		return next.Mutate(ctx, m)
+	}
+
+	// 更新前に未完了だった繰り返しの Todo
+	ids, err := m.IDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("recurrence: loading ids: %w", err)
+	}
+	client := m.Client()
+	completing, err := client.Todo.Query().
+		Where(
+			todo.IDIn(ids...),
+			todo.Completed(false),
+			todo.SeriesIDNotNil(),
+		).
+		IDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("recurrence: loading recurring todos: %w", err)
+	}
+
+	v, err := next.Mutate(ctx, m)
+	if err != nil || len(completing) == 0 {
+		return v, err
+	}
+
+	// 更新後の状態から次の回を作成する
+	todos, err := client.Todo.Query().Where(todo.IDIn(completing...)).All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("recurrence: loading completed todos: %w", err)
+	}
+	for _, t := range todos {
+		if err := createNextOccurrence(ctx, client, t); err != nil {
+			return nil, fmt.Errorf("recurrence: creating next occurrence of %s: %w", t.ID, err)
+		}
+	}
+	return v, nil
+}
+
+// createNextOccurrence は t の系列の次の回の Todo を作成する
+// 系列が終わっている場合、または次の回以降の Todo が作成済みの場合は何もしない
+func createNextOccurrence(ctx context.Context, client *ent.Client, t *ent.Todo) error {
+	if t.Recurrence == "" || t.SeriesID == nil || t.SeriesStart == nil || t.Occurrence == nil {
+		return nil
+	}
+	dueAt, ok, err := NextOccurrence(t)
+	if err != nil || !ok {
+		return err
+	}
+
+	exists, err := client.Todo.Query().
+		Where(
+			todo.SeriesID(*t.SeriesID),
+			todo.OccurrenceGT(*t.Occurrence),
+		).
+		Exist(ctx)
+	if err != nil || exists {
+		return err
+	}
+
+	create := client.Todo.Create().
+		SetTitle(t.Title).
+		SetNillableCategoryID(t.CategoryID).
+		SetRecurrence(t.Recurrence).
+		SetRecurrenceTimeZone(t.RecurrenceTimeZone).
+		SetSeriesID(*t.SeriesID).
+		SetSeriesStart(*t.SeriesStart).
+		SetOccurrence(*t.Occurrence + 1).
+		SetDueAt(dueAt)
+	if t.Description != "" {
+		create.SetDescription(t.Description)
+	}
+	created, err := create.Save(ctx)
+	if err != nil {
+		return err
+	}
+	// 期限からの相対時間で指定したリマインダーは次の回にも引き継ぐ
+	return copyRelativeReminders(ctx, client, t.ID, created.ID, created.DueAt)
+}
+
+// NextOccurrence は繰り返しの Todo の系列の次の回の日時を返す。系列が終わっている場合は false を返す
+func NextOccurrence(t *ent.Todo) (time.Time, bool, error) {
+	if t.Recurrence == "" || t.SeriesStart == nil || t.Occurrence == nil {
+		return time.Time{}, false, nil
+	}
+	rule, err := recurrence.Parse(t.Recurrence)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	loc, err := recurrence.LoadLocation(t.RecurrenceTimeZone)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	next, ok := rule.Nth(*t.SeriesStart, loc, *t.Occurrence+1)
+	return next, ok, nil
+}
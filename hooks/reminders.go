@@ -0,0 +1,110 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/hook"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/reminder"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/todo"
+	"github.com/t-okuji/go-openapi-todo-demo/reminders"
+)
+
+// RegisterReminders は Todo の期限の変更をリマインダーに反映するグローバルフックを登録する
+//
+// 期限からの相対時間で指定したリマインダーは、期限を変更すると新しい期限から通知予定日時を求め直し、
+// 送信済みの場合も再び送信待ちにする（繰り返しの Todo を次の回に進めた場合など）。
+// 日時を指定したリマインダーは変更しない。
+func RegisterReminders(client *ent.Client) {
+	client.Todo.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.TodoFunc(func(ctx context.Context, m *ent.TodoMutation) (ent.Value, error) {
+			_, dueSet := m.DueAt()
+			if !m.Op().Is(ent.OpUpdate|ent.OpUpdateOne) || (!dueSet && !m.DueAtCleared()) {
+				return next.Mutate(ctx, m)
+			}
+
+			ids, err := m.IDs(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("reminders: loading ids: %w", err)
+			}
+			v, err := next.Mutate(ctx, m)
+			if err != nil || len(ids) == 0 {
+				return v, err
+			}
+			if err := rescheduleReminders(ctx, m.Client(), ids); err != nil {
+				return nil, fmt.Errorf("reminders: %w", err)
+			}
+			return v, nil
+		})
+	})
+}
+
+// rescheduleReminders は Todo の現在の期限から相対リマインダーの通知予定日時を求め直す
+func rescheduleReminders(ctx context.Context, client *ent.Client, todoIDs []uuid.UUID) error {
+	relative, err := client.Reminder.Query().
+		Where(
+			reminder.TodoIDIn(todoIDs...),
+			reminder.OffsetMinutesNotNil(),
+		).
+		WithTodo(func(q *ent.TodoQuery) {
+			q.Select(todo.FieldDueAt)
+		}).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("loading reminders: %w", err)
+	}
+
+	for _, r := range relative {
+		fireAt := reminders.FireAt(nil, r.OffsetMinutes, r.Edges.Todo.DueAt)
+		if sameTime(fireAt, r.FireAt) {
+			continue
+		}
+		update := client.Reminder.UpdateOne(r).
+			SetStatus(reminder.StatusPending).
+			SetAttempts(0).
+			ClearLastError().
+			ClearSentAt()
+		if fireAt != nil {
+			update.SetFireAt(*fireAt)
+		} else {
+			update.ClearFireAt()
+		}
+		if err := update.Exec(ctx); err != nil {
+			return fmt.Errorf("updating reminder %s: %w", r.ID, err)
+		}
+	}
+	return nil
+}
+
+// copyRelativeReminders は from の相対リマインダーを、期限が dueAt の Todo（to）に複製する
+func copyRelativeReminders(ctx context.Context, client *ent.Client, from, to uuid.UUID, dueAt *time.Time) error {
+	relative, err := client.Reminder.Query().
+		Where(
+			reminder.TodoID(from),
+			reminder.OffsetMinutesNotNil(),
+		).
+		Order(ent.Asc(reminder.FieldCreatedAt)).
+		All(ctx)
+	if err != nil || len(relative) == 0 {
+		return err
+	}
+
+	builders := make([]*ent.ReminderCreate, len(relative))
+	for i, r := range relative {
+		builders[i] = client.Reminder.Create().
+			SetTodoID(to).
+			SetOffsetMinutes(*r.OffsetMinutes).
+			SetNillableFireAt(reminders.FireAt(nil, r.OffsetMinutes, dueAt))
+	}
+	return client.Reminder.CreateBulk(builders...).Exec(ctx)
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,21 @@
+package hooks
+
+import (
+	"context"
+
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/hook"
+)
+
+// RegisterVersioning は Todo の更新ごとにバージョンを1つ進めるグローバルフックを登録する
+//
+// バージョンは楽観的排他制御に使用するため、一括更新やカテゴリ削除に伴う更新を含む
+// 全ての更新で進める。
+func RegisterVersioning(client *ent.Client) {
+	client.Todo.Use(hook.On(func(next ent.Mutator) ent.Mutator {
+		return hook.TodoFunc(func(ctx context.Context, m *ent.TodoMutation) (ent.Value, error) {
+			m.AddVersion(1)
+			return next.Mutate(ctx, m)
+		})
+	}, ent.OpUpdate|ent.OpUpdateOne))
+}
@@ -0,0 +1,87 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/hook"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/webhooksubscription"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+// RegisterWebhooks は Todo・Category の全ミューテーションを Webhook の送信待ちとして記録するグローバルフックを登録する
+//
+// 送信待ちの配信記録（アウトボックス）はミューテーションと同一トランザクションで書き込まれるため、
+// ロールバックされた変更が送信されることはない。実際の送信は webhooks.Dispatcher が行う。
+func RegisterWebhooks(client *ent.Client) {
+	client.Todo.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.TodoFunc(func(ctx context.Context, m *ent.TodoMutation) (ent.Value, error) {
+			return enqueueWebhooks(ctx, m, next, todoEventTypes, loadTodoEvents)
+		})
+	})
+	client.Category.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.CategoryFunc(func(ctx context.Context, m *ent.CategoryMutation) (ent.Value, error) {
+			return enqueueWebhooks(ctx, m, next, categoryEventTypes, loadCategoryEvents)
+		})
+	})
+}
+
+// enqueueWebhooks はミューテーションの変更イベントを購読している Webhook ごとに配信記録を作成する
+func enqueueWebhooks(ctx context.Context, m entityMutation, next ent.Mutator, kinds eventTypes, load eventLoader) (ent.Value, error) {
+	v, collected, err := collectEvents(ctx, m, next, kinds, load)
+	if err != nil || len(collected) == 0 {
+		return v, err
+	}
+
+	client := m.Client()
+	subscriptions, err := client.WebhookSubscription.Query().
+		Where(webhooksubscription.Active(true)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("webhooks: loading subscriptions: %w", err)
+	}
+	if len(subscriptions) == 0 {
+		return v, nil
+	}
+
+	now := time.Now()
+	var builders []*ent.WebhookDeliveryCreate
+	for _, e := range collected {
+		eventID := uuid.New()
+		payload, err := json.Marshal(types.WebhookPayload{
+			ID:        eventID,
+			Type:      api.EventType(e.Type),
+			CreatedAt: now,
+			Data:      e.Data,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("webhooks: encoding payload: %w", err)
+		}
+
+		for _, s := range subscriptions {
+			if !slices.Contains(s.EventTypes, e.Type) {
+				continue
+			}
+			builders = append(builders, client.WebhookDelivery.Create().
+				SetSubscriptionID(s.ID).
+				SetEventID(eventID).
+				SetEventType(e.Type).
+				SetPayload(payload).
+				SetNextAttemptAt(now))
+		}
+	}
+
+	if len(builders) > 0 {
+		if err := client.WebhookDelivery.CreateBulk(builders...).Exec(ctx); err != nil {
+			return nil, fmt.Errorf("webhooks: enqueueing deliveries: %w", err)
+		}
+	}
+
+	return v, nil
+}
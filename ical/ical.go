@@ -0,0 +1,360 @@
+// Package ical は iCalendar（RFC 5545）形式の読み書きを行う
+//
+// Todo の配信と取り込みに必要な範囲として、コンポーネントとプロパティの構造、
+// 行の折り返し、TEXT 型のエスケープ、DATE・DATE-TIME 型の値のみを扱う。
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	_ "time/tzdata" // TZID のタイムゾーンを実行環境に依存せず解決するため
+)
+
+// ContentType は iCalendar 形式のメディアタイプ
+const ContentType = "text/calendar; charset=utf-8"
+
+// maxLineOctets は折り返し前の1行の最大オクテット数（改行を除く）
+const maxLineOctets = 75
+
+// dateTimeUTC・dateTimeLocal・date は DATE-TIME 型（UTC・ローカル時刻）と DATE 型の書式
+const (
+	dateTimeUTC   = "20060102T150405Z"
+	dateTimeLocal = "20060102T150405"
+	date          = "20060102"
+)
+
+// Writer は iCalendar 形式のコンテンツ行を書き込む
+// 書き込みエラーは Flush で返す
+type Writer struct {
+	w   *bufio.Writer
+	err error
+}
+
+// NewWriter は w に書き込む Writer を返す
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+// Begin はコンポーネントの開始行を書き込む
+func (w *Writer) Begin(name string) {
+	w.Property("BEGIN", name)
+}
+
+// End はコンポーネントの終了行を書き込む
+func (w *Writer) End(name string) {
+	w.Property("END", name)
+}
+
+// Property はエンコード済みの値のプロパティを書き込む
+func (w *Writer) Property(name, value string) {
+	w.writeLine(name + ":" + value)
+}
+
+// Text は TEXT 型の値のプロパティを書き込む
+func (w *Writer) Text(name, value string) {
+	w.Property(name, EscapeText(value))
+}
+
+// TextList は TEXT 型の値のリスト（CATEGORIES など）のプロパティを書き込む
+func (w *Writer) TextList(name string, values ...string) {
+	escaped := make([]string, len(values))
+	for i, v := range values {
+		escaped[i] = EscapeText(v)
+	}
+	w.Property(name, strings.Join(escaped, ","))
+}
+
+// Time は DATE-TIME 型の値（UTC）のプロパティを書き込む
+func (w *Writer) Time(name string, t time.Time) {
+	w.Property(name, t.UTC().Format(dateTimeUTC))
+}
+
+// Flush はバッファされた内容を書き込み、それまでに発生したエラーを返す
+func (w *Writer) Flush() error {
+	if w.err != nil {
+		return w.err
+	}
+	w.err = w.w.Flush()
+	return w.err
+}
+
+// writeLine は75オクテットを超える行を UTF-8 の文字の途中で分割しないように折り返して書き込む
+func (w *Writer) writeLine(line string) {
+	if w.err != nil {
+		return
+	}
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > maxLineOctets {
+			// 折り返した行は先頭の空白1文字を含めて数える
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+	_, w.err = w.w.WriteString(b.String())
+}
+
+// EscapeText は TEXT 型の値をエスケープする
+func EscapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", "")
+
+// Component は iCalendar のコンポーネント（VCALENDAR・VTODO・VEVENT など）を表す
+type Component struct {
+	Name string
+	// Line は BEGIN 行の行番号
+	Line       int
+	Properties []Property
+	Components []*Component
+}
+
+// Property は指定した名前の最初のプロパティを返す。存在しない場合は nil を返す
+func (c *Component) Property(name string) *Property {
+	for i := range c.Properties {
+		if c.Properties[i].Name == name {
+			return &c.Properties[i]
+		}
+	}
+	return nil
+}
+
+// Property は iCalendar のプロパティを表す
+type Property struct {
+	Name string
+	// Params はパラメーター名（大文字）から値を引くマップ
+	Params map[string]string
+	// Value はエンコードされたままの値
+	Value string
+}
+
+// Text は TEXT 型の値をエスケープを解除して返す
+func (p *Property) Text() string {
+	return unescapeText(p.Value)
+}
+
+// TextList はカンマ区切りの TEXT 型の値をエスケープを解除して返す
+func (p *Property) TextList() []string {
+	var (
+		values []string
+		start  int
+	)
+	for i := 0; i < len(p.Value); i++ {
+		switch p.Value[i] {
+		case '\\':
+			i++
+		case ',':
+			values = append(values, unescapeText(p.Value[start:i]))
+			start = i + 1
+		}
+	}
+	return append(values, unescapeText(p.Value[start:]))
+}
+
+// Time は DATE 型または DATE-TIME 型の値を返す
+//
+// TZID パラメーターが指定された場合はそのタイムゾーン、UTC（末尾が Z）でもなく TZID もない
+// ローカル時刻と DATE 型の値は UTC として解釈する。
+func (p *Property) Time() (time.Time, error) {
+	loc := time.UTC
+	if tzid := p.Params["TZID"]; tzid != "" {
+		var err error
+		if loc, err = time.LoadLocation(strings.TrimPrefix(tzid, "/")); err != nil {
+			return time.Time{}, fmt.Errorf("%s: unknown time zone %q", p.Name, tzid)
+		}
+	}
+
+	var (
+		t   time.Time
+		err error
+	)
+	switch {
+	case strings.EqualFold(p.Params["VALUE"], "DATE") || len(p.Value) == len(date):
+		t, err = time.ParseInLocation(date, p.Value, loc)
+	case strings.HasSuffix(p.Value, "Z"):
+		t, err = time.Parse(dateTimeUTC, p.Value)
+	default:
+		t, err = time.ParseInLocation(dateTimeLocal, p.Value, loc)
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: invalid date-time %q", p.Name, p.Value)
+	}
+	return t, nil
+}
+
+// unescapeText は TEXT 型の値のエスケープを解除する
+func unescapeText(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// Parse は iCalendar 形式のデータを読み出し、最上位のコンポーネント（通常は VCALENDAR）の一覧を返す
+func Parse(r io.Reader) ([]*Component, error) {
+	var (
+		roots []*Component
+		stack []*Component
+	)
+	err := scanLines(r, func(line int, content string) error {
+		prop, err := parseProperty(content)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		switch prop.Name {
+		case "BEGIN":
+			c := &Component{Name: strings.ToUpper(prop.Value), Line: line}
+			if len(stack) == 0 {
+				roots = append(roots, c)
+			} else {
+				parent := stack[len(stack)-1]
+				parent.Components = append(parent.Components, c)
+			}
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(prop.Value) {
+				return fmt.Errorf("line %d: unexpected END:%s", line, prop.Value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return fmt.Errorf("line %d: property %s outside of a component", line, prop.Name)
+			}
+			c := stack[len(stack)-1]
+			c.Properties = append(c.Properties, prop)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("missing END:%s", stack[len(stack)-1].Name)
+	}
+	return roots, nil
+}
+
+// scanLines は折り返しを解除したコンテンツ行ごとに、開始行の行番号とともに fn を呼び出す
+func scanLines(r io.Reader, fn func(line int, content string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+
+	var (
+		current   strings.Builder
+		startLine int
+		line      int
+	)
+	flush := func() error {
+		if current.Len() == 0 {
+			return nil
+		}
+		content := current.String()
+		current.Reset()
+		return fn(startLine, content)
+	}
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\xef\xbb\xbf")
+		}
+		// 空白1文字で始まる行は前の行の続き
+		if len(text) > 0 && (text[0] == ' ' || text[0] == '\t') && current.Len() > 0 {
+			current.WriteString(text[1:])
+			continue
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		current.WriteString(text)
+		startLine = line
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+// parseProperty は "NAME;PARAM=value:value" 形式のコンテンツ行を読み出す
+func parseProperty(content string) (Property, error) {
+	prop := Property{Params: map[string]string{}}
+
+	// 名前とパラメーターは、引用符の外にある最初のコロンまで
+	inQuote := false
+	sep := -1
+	for i := 0; i < len(content) && sep < 0; i++ {
+		switch content[i] {
+		case '"':
+			inQuote = !inQuote
+		case ':':
+			if !inQuote {
+				sep = i
+			}
+		}
+	}
+	if sep < 0 {
+		return prop, fmt.Errorf("missing ':' in %q", content)
+	}
+	prop.Value = content[sep+1:]
+
+	parts := splitParams(content[:sep])
+	prop.Name = strings.ToUpper(parts[0])
+	if prop.Name == "" {
+		return prop, fmt.Errorf("missing property name in %q", content)
+	}
+	for _, param := range parts[1:] {
+		name, value, ok := strings.Cut(param, "=")
+		if !ok {
+			return prop, fmt.Errorf("invalid parameter %q", param)
+		}
+		prop.Params[strings.ToUpper(name)] = strings.Trim(value, `"`)
+	}
+	return prop, nil
+}
+
+// splitParams は名前とパラメーターを引用符の外のセミコロンで分割する
+func splitParams(s string) []string {
+	var (
+		parts   []string
+		start   int
+		inQuote bool
+	)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			inQuote = !inQuote
+		case ';':
+			if !inQuote {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
@@ -0,0 +1,195 @@
+package ical_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/t-okuji/go-openapi-todo-demo/ical"
+)
+
+func TestWriterFolding(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"ascii", strings.Repeat("a", 200)},
+		{"multibyte", strings.Repeat("あ", 60)},
+		{"mixed", "x" + strings.Repeat("日本語のタイトル", 10)},
+		{"exactly 75 octets", strings.Repeat("b", 75-len("SUMMARY:"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := ical.NewWriter(&buf)
+			w.Text("SUMMARY", tt.value)
+			if err := w.Flush(); err != nil {
+				t.Fatal(err)
+			}
+
+			out := buf.String()
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatalf("output %q does not end with CRLF", out)
+			}
+			lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+			for i, line := range lines {
+				// 1行は改行を除いて75オクテット以内で、UTF-8 の文字の途中で分割しない
+				if len(line) > 75 {
+					t.Errorf("line %d is %d octets", i+1, len(line))
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d splits a UTF-8 character: %q", i+1, line)
+				}
+				if i > 0 && line[0] != ' ' {
+					t.Errorf("continuation line %d does not start with a space: %q", i+1, line)
+				}
+			}
+			if len("SUMMARY:"+tt.value) <= 75 && len(lines) != 1 {
+				t.Errorf("%d lines, want 1", len(lines))
+			}
+
+			// 読み出すと折り返しを解除した元の値に戻る
+			got := parseProperty(t, out)
+			if got.Text() != tt.value {
+				t.Errorf("unfolded value = %q, want %q", got.Text(), tt.value)
+			}
+		})
+	}
+}
+
+func TestTextEscaping(t *testing.T) {
+	tests := []struct {
+		value   string
+		escaped string
+		// want は読み出した値（CR は出力しない）
+		want string
+	}{
+		{`a\b`, `a\\b`, `a\b`},
+		{"a;b,c", `a\;b\,c`, "a;b,c"},
+		{"line1\nline2", `line1\nline2`, "line1\nline2"},
+		{"line1\r\nline2", `line1\nline2`, "line1\nline2"},
+		{"a\rb", "ab", "ab"},
+		{`trailing\`, `trailing\\`, `trailing\`},
+	}
+	for _, tt := range tests {
+		if got := ical.EscapeText(tt.value); got != tt.escaped {
+			t.Errorf("EscapeText(%q) = %q, want %q", tt.value, got, tt.escaped)
+		}
+
+		var buf bytes.Buffer
+		w := ical.NewWriter(&buf)
+		w.Text("DESCRIPTION", tt.value)
+		w.Flush()
+		if got := parseProperty(t, buf.String()).Text(); got != tt.want {
+			t.Errorf("round trip of %q = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+
+	// リストの区切りのカンマとエスケープされたカンマを区別する
+	var buf bytes.Buffer
+	w := ical.NewWriter(&buf)
+	w.TextList("CATEGORIES", "a,b", `c\`, "d")
+	w.Flush()
+	got := parseProperty(t, buf.String()).TextList()
+	if want := []string{"a,b", `c\`, "d"}; strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("TextList round trip = %q, want %q", got, want)
+	}
+	// 大文字の \N も改行として扱う
+	if got := parseProperty(t, "DESCRIPTION:a\\Nb\r\n").Text(); got != "a\nb" {
+		t.Errorf("\\N = %q, want a newline", got)
+	}
+}
+
+func TestPropertyTime(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		line string
+		want time.Time
+	}{
+		{"DUE:20260301T090000Z", time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)},
+		{"DUE;TZID=Asia/Tokyo:20260301T090000", time.Date(2026, 3, 1, 9, 0, 0, 0, tokyo)},
+		{"DUE;TZID=\"/Asia/Tokyo\":20260301T090000", time.Date(2026, 3, 1, 9, 0, 0, 0, tokyo)},
+		{"DUE:20260301T090000", time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)},
+		{"DUE;VALUE=DATE:20260301", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"DUE:20260301", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parseProperty(t, tt.line+"\r\n").Time()
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("%s: Time() = %v, %v, want %v", tt.line, got, err, tt.want)
+		}
+	}
+	for _, line := range []string{"DUE;TZID=Nowhere/City:20260301T090000", "DUE:2026-03-01", "DUE:20260301T0900"} {
+		if _, err := parseProperty(t, line+"\r\n").Time(); err == nil {
+			t.Errorf("%s: Time() error = nil", line)
+		}
+	}
+
+	var buf bytes.Buffer
+	w := ical.NewWriter(&buf)
+	w.Time("DUE", time.Date(2026, 3, 1, 9, 0, 0, 0, tokyo))
+	w.Flush()
+	if got := buf.String(); got != "DUE:20260301T000000Z\r\n" {
+		t.Errorf("Time() wrote %q, want UTC", got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	input := "\xef\xbb\xbfBEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"SUMMARY:folded\r\n" +
+		" \ttitle\r\n" +
+		"X-PARAM;ALTREP=\"http://example.com/a;b:c\";LANGUAGE=ja:value:with:colons\r\n" +
+		"END:VTODO\r\n" +
+		"END:VCALENDAR\r\n"
+	calendars, err := ical.Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calendars) != 1 || calendars[0].Name != "VCALENDAR" || len(calendars[0].Components) != 1 {
+		t.Fatalf("calendars = %+v", calendars)
+	}
+	todo := calendars[0].Components[0]
+	if todo.Name != "VTODO" || todo.Line != 4 {
+		t.Errorf("component %s at line %d, want VTODO at line 4", todo.Name, todo.Line)
+	}
+	if p := todo.Property("SUMMARY"); p == nil || p.Text() != "folded\ttitle" {
+		t.Errorf("SUMMARY = %+v", p)
+	}
+	p := todo.Property("X-PARAM")
+	if p == nil || p.Value != "value:with:colons" || p.Params["ALTREP"] != "http://example.com/a;b:c" || p.Params["LANGUAGE"] != "ja" {
+		t.Errorf("X-PARAM = %+v", p)
+	}
+	if todo.Property("DUE") != nil {
+		t.Error("missing property is not nil")
+	}
+
+	for _, input := range []string{
+		"BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nEND:VCALENDAR\r\n",
+		"BEGIN:VCALENDAR\r\n",
+		"SUMMARY:outside\r\n",
+		"BEGIN:VCALENDAR\r\nNO COLON\r\nEND:VCALENDAR\r\n",
+		"BEGIN:VCALENDAR\r\nX;BAD:v\r\nEND:VCALENDAR\r\n",
+	} {
+		if _, err := ical.Parse(strings.NewReader(input)); err == nil {
+			t.Errorf("Parse(%q) error = nil", input)
+		}
+	}
+}
+
+// parseProperty は VTODO の中に content を置いて読み出し、最初のプロパティを返す
+func parseProperty(t *testing.T, content string) *ical.Property {
+	t.Helper()
+	calendars, err := ical.Parse(strings.NewReader("BEGIN:VTODO\r\n" + content + "END:VTODO\r\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &calendars[0].Properties[0]
+}
@@ -0,0 +1,166 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// todoTxtDate は todo.txt の完了日・作成日（YYYY-MM-DD）
+var todoTxtDate = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// todoTxtPriority は todo.txt の優先度（(A)〜(Z)）
+var todoTxtPriority = regexp.MustCompile(`^\([A-Z]\)$`)
+
+// parseTodoTxt は todo.txt 形式のテキストを読み出す
+//
+// "x " で始まる行は完了済みとする。優先度と完了日・作成日は取り込まず、
+// 最初の +プロジェクト をカテゴリ、due:YYYY-MM-DD を期限（UTC の0時）としてタイトルから取り除く。
+func parseTodoTxt(r io.Reader) ([]Row, error) {
+	var (
+		rows []Row
+		line int
+	)
+	scanner := bufio.NewScanner(skipBOM(r))
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		line++
+		tokens := strings.Fields(scanner.Text())
+		if len(tokens) == 0 {
+			continue
+		}
+
+		row := Row{Line: line}
+		if tokens[0] == "x" {
+			row.Completed = true
+			tokens = tokens[1:]
+		} else if todoTxtPriority.MatchString(tokens[0]) {
+			tokens = tokens[1:]
+		}
+		// 完了日・作成日
+		for range 2 {
+			if len(tokens) > 0 && todoTxtDate.MatchString(tokens[0]) {
+				tokens = tokens[1:]
+			}
+		}
+
+		title := make([]string, 0, len(tokens))
+		for _, token := range tokens {
+			if row.Category == "" && len(token) > 1 && token[0] == '+' {
+				row.Category = token[1:]
+				continue
+			}
+			if value, ok := strings.CutPrefix(token, "due:"); ok && row.DueAt == nil {
+				if dueAt, err := time.Parse(time.DateOnly, value); err == nil {
+					row.DueAt = &dueAt
+					continue
+				}
+			}
+			title = append(title, token)
+		}
+		row.Title = strings.Join(title, " ")
+		rows = append(rows, row)
+	}
+	return rows, scanner.Err()
+}
+
+// parseTodoist は Todoist のプロジェクトのテンプレート（CSV）を読み出す
+//
+// TYPE が task の行を Todo として取り込み、直前の section の行の名前をカテゴリとする。
+// テンプレートには未完了のタスクのみが含まれる。
+func parseTodoist(r io.Reader) ([]Row, error) {
+	records, lines, err := csvRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) > 0 {
+		_, hasType := records[0]["type"]
+		_, hasContent := records[0]["content"]
+		if !hasType || !hasContent {
+			return nil, errors.New("todoist: TYPE and CONTENT columns are required")
+		}
+	}
+
+	var (
+		rows    []Row
+		section string
+	)
+	for i, record := range records {
+		switch strings.ToLower(strings.TrimSpace(record["type"])) {
+		case "section":
+			section = record["content"]
+		case "task":
+			rows = append(rows, Row{
+				Line:        lines[i],
+				Title:       record["content"],
+				Description: record["description"],
+				Category:    section,
+			})
+		}
+	}
+	return rows, nil
+}
+
+// msToDoList は Microsoft Graph の todoTaskList（tasks を展開したもの）
+type msToDoList struct {
+	DisplayName       string       `json:"displayName"`
+	WellknownListName string       `json:"wellknownListName"`
+	Tasks             []msToDoTask `json:"tasks"`
+}
+
+// msToDoTask は Microsoft Graph の todoTask
+type msToDoTask struct {
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	Body   *struct {
+		Content     string `json:"content"`
+		ContentType string `json:"contentType"`
+	} `json:"body"`
+}
+
+// parseMSToDo は Microsoft To Do のリストとタスクを読み出す
+//
+// リストの配列、または {"value": [...]} 形式のレスポンスを受け付ける。
+// リスト名をカテゴリとし、既定のリスト（タスク）のタスクはカテゴリなしとする。
+// 本文はテキスト形式の場合のみ説明として取り込む。
+func parseMSToDo(r io.Reader) ([]Row, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var lists []msToDoList
+	if err := json.Unmarshal(data, &lists); err != nil {
+		var response struct {
+			Value []msToDoList `json:"value"`
+		}
+		if err := json.Unmarshal(data, &response); err != nil {
+			return nil, err
+		}
+		lists = response.Value
+	}
+
+	var rows []Row
+	for _, list := range lists {
+		category := list.DisplayName
+		if list.WellknownListName == "defaultList" {
+			category = ""
+		}
+		for _, task := range list.Tasks {
+			row := Row{
+				Line:      len(rows) + 1,
+				Title:     task.Title,
+				Completed: task.Status == "completed",
+				Category:  category,
+			}
+			if task.Body != nil && strings.EqualFold(task.Body.ContentType, "text") {
+				row.Description = strings.TrimSpace(task.Body.Content)
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
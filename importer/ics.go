@@ -0,0 +1,72 @@
+package importer
+
+import (
+	"io"
+	"strings"
+
+	"github.com/t-okuji/go-openapi-todo-demo/ical"
+)
+
+// parseICS は iCalendar 形式のファイルから VTODO・VEVENT を読み出す
+//
+// SUMMARY をタイトル、DESCRIPTION を説明、CATEGORIES の最初の値をカテゴリとする。
+// VTODO は DUE を期限とし、STATUS が COMPLETED または COMPLETED プロパティがある場合は完了済みとする。
+// VEVENT は DTSTART を期限とする。繰り返し（RRULE）は取り込まず、最初の回のみを取り込む。
+// STATUS が CANCELLED のものは取り込めない行とする。
+func parseICS(r io.Reader) ([]Row, error) {
+	calendars, err := ical.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []Row
+	for _, calendar := range calendars {
+		for _, c := range calendar.Components {
+			var dueProperty string
+			switch c.Name {
+			case "VTODO":
+				dueProperty = "DUE"
+			case "VEVENT":
+				dueProperty = "DTSTART"
+			default:
+				continue
+			}
+			rows = append(rows, icsRow(c, dueProperty))
+		}
+	}
+	return rows, nil
+}
+
+// icsRow は VTODO・VEVENT を取り込む行に変換する
+func icsRow(c *ical.Component, dueProperty string) Row {
+	row := Row{Line: c.Line}
+	if p := c.Property("SUMMARY"); p != nil {
+		row.Title = p.Text()
+	}
+	if p := c.Property("DESCRIPTION"); p != nil {
+		row.Description = strings.TrimSpace(p.Text())
+	}
+	if p := c.Property("CATEGORIES"); p != nil {
+		row.Category = p.TextList()[0]
+	}
+
+	var status string
+	if p := c.Property("STATUS"); p != nil {
+		status = strings.ToUpper(p.Value)
+	}
+	row.Completed = status == "COMPLETED" || c.Property("COMPLETED") != nil
+	if status == "CANCELLED" {
+		row.Invalid = c.Name + " is cancelled"
+		return row
+	}
+
+	if p := c.Property(dueProperty); p != nil {
+		dueAt, err := p.Time()
+		if err != nil {
+			row.Invalid = err.Error()
+			return row
+		}
+		row.DueAt = &dueAt
+	}
+	return row
+}
@@ -0,0 +1,281 @@
+// Package importer は各種形式のファイルから取り込む Todo を読み出す
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+// 取り込みに対応するファイル形式
+const (
+	// FormatCSV は GET /export?format=csv と同じ列名の CSV
+	FormatCSV = "csv"
+	// FormatJSON は GET /export?format=json のドキュメント
+	FormatJSON = "json"
+	// FormatNDJSON は GET /export?format=ndjson の出力
+	FormatNDJSON = "ndjson"
+	// FormatTodoTxt は todo.txt 形式のテキスト
+	FormatTodoTxt = "todotxt"
+	// FormatTodoist は Todoist のプロジェクトのテンプレート（CSV）
+	FormatTodoist = "todoist"
+	// FormatMSToDo は Microsoft To Do のリストとタスク（Microsoft Graph の todoTaskList・todoTask 形式の JSON）
+	FormatMSToDo = "mstodo"
+	// FormatICS は iCalendar 形式（VTODO・VEVENT）
+	FormatICS = "ics"
+)
+
+// Formats は対応するファイル形式の一覧
+var Formats = []string{FormatCSV, FormatJSON, FormatNDJSON, FormatTodoTxt, FormatTodoist, FormatMSToDo, FormatICS}
+
+// ErrInvalidFile はファイルの内容が指定された形式として読み出せないことを表す
+var ErrInvalidFile = errors.New("invalid file")
+
+// Row はファイルから読み出した Todo の1件を表す
+type Row struct {
+	// Line はファイル内の位置（行番号、JSON の場合は何件目か）
+	Line        int
+	Title       string
+	Description string
+	Completed   bool
+	// Category はカテゴリ名（未設定の場合は空文字列）
+	Category string
+	// DueAt は期限（未設定の場合は nil）
+	DueAt *time.Time
+	// Invalid は取り込めない行の理由（取り込める場合は空文字列）
+	Invalid string
+}
+
+// Parse は format 形式のファイルから Todo を読み出す
+func Parse(format string, r io.Reader) ([]Row, error) {
+	var (
+		rows []Row
+		err  error
+	)
+	switch format {
+	case FormatCSV:
+		rows, err = parseCSV(r)
+	case FormatJSON:
+		rows, err = parseJSON(r)
+	case FormatNDJSON:
+		rows, err = parseNDJSON(r)
+	case FormatTodoTxt:
+		rows, err = parseTodoTxt(r)
+	case FormatTodoist:
+		rows, err = parseTodoist(r)
+	case FormatMSToDo:
+		rows, err = parseMSToDo(r)
+	case FormatICS:
+		rows, err = parseICS(r)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+
+	for i := range rows {
+		rows[i].Title = strings.TrimSpace(rows[i].Title)
+		rows[i].Category = strings.TrimSpace(rows[i].Category)
+	}
+	return rows, nil
+}
+
+// DetectFormat はファイル名の拡張子から形式を推測する。推測できない場合は空文字列を返す
+// Todoist・Microsoft To Do の形式は推測しない
+func DetectFormat(filename string) string {
+	i := strings.LastIndex(filename, ".")
+	if i < 0 {
+		return ""
+	}
+	switch strings.ToLower(filename[i+1:]) {
+	case "csv":
+		return FormatCSV
+	case "json":
+		return FormatJSON
+	case "ndjson", "jsonl":
+		return FormatNDJSON
+	case "txt":
+		return FormatTodoTxt
+	case "ics":
+		return FormatICS
+	default:
+		return ""
+	}
+}
+
+// csvRecords はヘッダー行付きの CSV を読み出し、列名（小文字）から値を引ける行の一覧を返す
+func csvRecords(r io.Reader) ([]map[string]string, []int, error) {
+	reader := csv.NewReader(skipBOM(r))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, name := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	var (
+		records []map[string]string
+		lines   []int
+	)
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		record := make(map[string]string, len(header))
+		for i, value := range fields {
+			if i < len(header) {
+				record[header[i]] = value
+			}
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, record)
+		lines = append(lines, line)
+	}
+	return records, lines, nil
+}
+
+// parseCSV は GET /export?format=csv と同じ列名の CSV を読み出す
+// title 列は必須。カテゴリは category_name 列（または category 列）から読み出す
+func parseCSV(r io.Reader) ([]Row, error) {
+	records, lines, err := csvRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) > 0 {
+		if _, ok := records[0]["title"]; !ok {
+			return nil, errors.New("csv: title column is required")
+		}
+	}
+
+	rows := make([]Row, len(records))
+	for i, record := range records {
+		row := Row{
+			Line:        lines[i],
+			Title:       unescapeCSVFormula(record["title"]),
+			Description: unescapeCSVFormula(record["description"]),
+			Category:    unescapeCSVFormula(record["category_name"]),
+		}
+		if row.Category == "" {
+			row.Category = unescapeCSVFormula(record["category"])
+		}
+		if v := strings.TrimSpace(record["completed"]); v != "" {
+			completed, err := strconv.ParseBool(v)
+			if err != nil {
+				row.Invalid = "completed must be true or false"
+			}
+			row.Completed = completed
+		}
+		if v := strings.TrimSpace(record["due_at"]); v != "" {
+			dueAt, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				row.Invalid = "due_at must be an RFC 3339 date-time"
+			} else {
+				row.DueAt = &dueAt
+			}
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// unescapeCSVFormula は GET /export?format=csv が数式の先頭に付けた ' を取り除く
+func unescapeCSVFormula(s string) string {
+	if len(s) > 1 && s[0] == '\'' && strings.ContainsRune("=+-@", rune(s[1])) {
+		return s[1:]
+	}
+	return s
+}
+
+// exportTodoRow は JSON・NDJSON エクスポートの Todo を取り込む行に変換する
+// カテゴリ名が含まれていない場合は、同じファイルのカテゴリから名前を解決する
+func exportTodoRow(line int, t types.ExportTodo, categoryNames map[uuid.UUID]string) Row {
+	row := Row{Line: line, Title: t.Title, Completed: t.Completed, DueAt: t.DueAt}
+	if t.Description != nil {
+		row.Description = *t.Description
+	}
+	switch {
+	case t.CategoryName != nil:
+		row.Category = *t.CategoryName
+	case t.CategoryID != nil:
+		row.Category = categoryNames[*t.CategoryID]
+	}
+	return row
+}
+
+// parseJSON は GET /export?format=json のドキュメントを読み出す
+func parseJSON(r io.Reader) ([]Row, error) {
+	var doc types.ExportDocument
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	categoryNames := make(map[uuid.UUID]string, len(doc.Categories))
+	for _, c := range doc.Categories {
+		categoryNames[c.ID] = c.Name
+	}
+	rows := make([]Row, len(doc.Todos))
+	for i, t := range doc.Todos {
+		rows[i] = exportTodoRow(i+1, t, categoryNames)
+	}
+	return rows, nil
+}
+
+// parseNDJSON は GET /export?format=ndjson の出力を読み出す
+func parseNDJSON(r io.Reader) ([]Row, error) {
+	var (
+		rows          []Row
+		categoryNames = make(map[uuid.UUID]string)
+		line          int
+	)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		var record types.ExportRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		switch {
+		case record.Category != nil:
+			categoryNames[record.Category.ID] = record.Category.Name
+		case record.Todo != nil:
+			rows = append(rows, exportTodoRow(line, *record.Todo, categoryNames))
+		}
+	}
+	return rows, scanner.Err()
+}
+
+// skipBOM は先頭の UTF-8 BOM を読み飛ばす Reader を返す
+func skipBOM(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	if b, err := br.Peek(3); err == nil && string(b) == "\xef\xbb\xbf" {
+		br.Discard(3)
+	}
+	return br
+}
@@ -0,0 +1,239 @@
+package importer_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/t-okuji/go-openapi-todo-demo/importer"
+)
+
+// row は比較用の Row（期限は RFC 3339 の文字列、未設定の場合は空文字列）
+type row struct {
+	line        int
+	title       string
+	description string
+	completed   bool
+	category    string
+	dueAt       string
+	invalid     bool
+}
+
+func toRow(r importer.Row) row {
+	got := row{
+		line:        r.Line,
+		title:       r.Title,
+		description: r.Description,
+		completed:   r.Completed,
+		category:    r.Category,
+		invalid:     r.Invalid != "",
+	}
+	if r.DueAt != nil {
+		got.dueAt = r.DueAt.UTC().Format(time.RFC3339)
+	}
+	return got
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		input  string
+		want   []row
+	}{
+		{
+			name:   "csv",
+			format: importer.FormatCSV,
+			input: "\xef\xbb\xbfid,Title,description,completed,category_name,due_at\r\n" +
+				"1, Buy milk ,2 liters,true, Home ,2026-03-01T09:00:00+09:00\r\n" +
+				"2,\"Multi\nline\",,,,\r\n" +
+				"3,Bad,,yes,,\r\n" +
+				"4,Bad due,,,,tomorrow\r\n" +
+				"5,'=SUM(A1),'@desc,false,'+cat,\r\n",
+			want: []row{
+				{line: 2, title: "Buy milk", description: "2 liters", completed: true, category: "Home", dueAt: "2026-03-01T00:00:00Z"},
+				{line: 3, title: "Multi\nline"},
+				{line: 5, title: "Bad", invalid: true},
+				{line: 6, title: "Bad due", invalid: true},
+				{line: 7, title: "=SUM(A1)", description: "@desc", category: "+cat"},
+			},
+		},
+		{
+			name:   "csv with category column",
+			format: importer.FormatCSV,
+			input:  "title,category\nA,Work\nB,'not escaped\n",
+			want: []row{
+				{line: 2, title: "A", category: "Work"},
+				{line: 3, title: "B", category: "'not escaped"},
+			},
+		},
+		{
+			name:   "json",
+			format: importer.FormatJSON,
+			input: `{"exportedAt":"2026-01-01T00:00:00Z",
+				"categories":[{"id":"11111111-1111-1111-1111-111111111111","name":"Work","color":"#000000","createdAt":"2026-01-01T00:00:00Z"}],
+				"todos":[
+					{"id":"22222222-2222-2222-2222-222222222222","title":"A","description":"d","completed":true,"categoryId":"11111111-1111-1111-1111-111111111111","dueAt":"2026-02-01T00:00:00Z","version":1,"createdAt":"2026-01-01T00:00:00Z"},
+					{"id":"33333333-3333-3333-3333-333333333333","title":"B","completed":false,"categoryName":"Named","version":1,"createdAt":"2026-01-01T00:00:00Z"}
+				]}`,
+			want: []row{
+				{line: 1, title: "A", description: "d", completed: true, category: "Work", dueAt: "2026-02-01T00:00:00Z"},
+				{line: 2, title: "B", category: "Named"},
+			},
+		},
+		{
+			name:   "ndjson",
+			format: importer.FormatNDJSON,
+			input: `{"type":"category","category":{"id":"11111111-1111-1111-1111-111111111111","name":"Work","color":"#000000","createdAt":"2026-01-01T00:00:00Z"}}
+
+{"type":"todo","todo":{"id":"22222222-2222-2222-2222-222222222222","title":"A","completed":false,"categoryId":"11111111-1111-1111-1111-111111111111","version":1,"createdAt":"2026-01-01T00:00:00Z"}}
+{"type":"todo","todo":{"id":"33333333-3333-3333-3333-333333333333","title":"B","completed":true,"version":1,"createdAt":"2026-01-01T00:00:00Z"}}
+`,
+			want: []row{
+				{line: 3, title: "A", category: "Work"},
+				{line: 4, title: "B", completed: true},
+			},
+		},
+		{
+			name:   "todo.txt",
+			format: importer.FormatTodoTxt,
+			input: "(A) 2026-01-01 Call mom +Family due:2026-01-05 @phone\n" +
+				"\n" +
+				"x 2026-01-03 2026-01-01 Pay bills +Home +Money\n" +
+				"Plan trip due:someday\n",
+			want: []row{
+				{line: 1, title: "Call mom @phone", category: "Family", dueAt: "2026-01-05T00:00:00Z"},
+				{line: 3, title: "Pay bills +Money", completed: true, category: "Home"},
+				{line: 4, title: "Plan trip due:someday"},
+			},
+		},
+		{
+			name:   "todoist",
+			format: importer.FormatTodoist,
+			input: "TYPE,CONTENT,DESCRIPTION,PRIORITY\n" +
+				"task,Inbox task,,4\n" +
+				"section,Errands,,\n" +
+				"task,Buy milk,2 liters,1\n" +
+				"note,A comment,,\n",
+			want: []row{
+				{line: 2, title: "Inbox task"},
+				{line: 4, title: "Buy milk", description: "2 liters", category: "Errands"},
+			},
+		},
+		{
+			name:   "mstodo",
+			format: importer.FormatMSToDo,
+			input: `{"value":[
+				{"displayName":"Tasks","wellknownListName":"defaultList","tasks":[{"title":"A","status":"completed"}]},
+				{"displayName":"Work","wellknownListName":"none","tasks":[
+					{"title":"B","status":"notStarted","body":{"content":" text body ","contentType":"text"}},
+					{"title":"C","status":"inProgress","body":{"content":"<p>html</p>","contentType":"html"}}
+				]}
+			]}`,
+			want: []row{
+				{line: 1, title: "A", completed: true},
+				{line: 2, title: "B", description: "text body", category: "Work"},
+				{line: 3, title: "C", category: "Work"},
+			},
+		},
+		{
+			name:   "mstodo array",
+			format: importer.FormatMSToDo,
+			input:  `[{"displayName":"Work","tasks":[{"title":"A","status":"notStarted"}]}]`,
+			want:   []row{{line: 1, title: "A", category: "Work"}},
+		},
+		{
+			name:   "ics",
+			format: importer.FormatICS,
+			input: "BEGIN:VCALENDAR\r\n" +
+				"VERSION:2.0\r\n" +
+				"BEGIN:VTODO\r\n" +
+				"SUMMARY:Write\\, review\r\n" +
+				"DESCRIPTION:line1\\nline2\r\n" +
+				"CATEGORIES:Work,Home\r\n" +
+				"DUE;TZID=Asia/Tokyo:20260301T090000\r\n" +
+				"STATUS:COMPLETED\r\n" +
+				"END:VTODO\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"SUMMARY:Meeting\r\n" +
+				"DTSTART:20260302T010000Z\r\n" +
+				"RRULE:FREQ=DAILY\r\n" +
+				"END:VEVENT\r\n" +
+				"BEGIN:VTODO\r\n" +
+				"SUMMARY:Cancelled\r\n" +
+				"STATUS:CANCELLED\r\n" +
+				"END:VTODO\r\n" +
+				"BEGIN:VTODO\r\n" +
+				"SUMMARY:Bad zone\r\n" +
+				"DUE;TZID=Nowhere/City:20260301T090000\r\n" +
+				"END:VTODO\r\n" +
+				"BEGIN:VJOURNAL\r\n" +
+				"SUMMARY:Ignored\r\n" +
+				"END:VJOURNAL\r\n" +
+				"END:VCALENDAR\r\n",
+			want: []row{
+				{line: 3, title: "Write, review", description: "line1\nline2", completed: true, category: "Work", dueAt: "2026-03-01T00:00:00Z"},
+				{line: 10, title: "Meeting", dueAt: "2026-03-02T01:00:00Z"},
+				{line: 15, title: "Cancelled", invalid: true},
+				{line: 19, title: "Bad zone", invalid: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := importer.Parse(tt.format, strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rows) != len(tt.want) {
+				t.Fatalf("%d rows, want %d: %+v", len(rows), len(tt.want), rows)
+			}
+			for i, r := range rows {
+				if got := toRow(r); got != tt.want[i] {
+					t.Errorf("row %d = %+v, want %+v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseInvalidFile(t *testing.T) {
+	tests := []struct {
+		format string
+		input  string
+	}{
+		{importer.FormatCSV, "id,name\n1,a\n"},
+		{importer.FormatCSV, "title\n\"unterminated\n"},
+		{importer.FormatJSON, "[1,2]"},
+		{importer.FormatNDJSON, "{\"type\":\"todo\"}\nnot json\n"},
+		{importer.FormatTodoist, "TYPE,NAME\ntask,a\n"},
+		{importer.FormatMSToDo, `{"value":1}`},
+		{importer.FormatICS, "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\n"},
+	}
+	for _, tt := range tests {
+		if _, err := importer.Parse(tt.format, strings.NewReader(tt.input)); !errors.Is(err, importer.ErrInvalidFile) {
+			t.Errorf("Parse(%s, %q) error = %v, want ErrInvalidFile", tt.format, tt.input, err)
+		}
+	}
+	if _, err := importer.Parse("xml", strings.NewReader("")); err == nil || errors.Is(err, importer.ErrInvalidFile) {
+		t.Errorf("unsupported format error = %v", err)
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	for filename, want := range map[string]string{
+		"todos.CSV":   importer.FormatCSV,
+		"todos.json":  importer.FormatJSON,
+		"todos.jsonl": importer.FormatNDJSON,
+		"todo.txt":    importer.FormatTodoTxt,
+		"cal.ics":     importer.FormatICS,
+		"todos.xlsx":  "",
+		"todos":       "",
+	} {
+		if got := importer.DetectFormat(filename); got != want {
+			t.Errorf("DetectFormat(%q) = %q, want %q", filename, got, want)
+		}
+	}
+}
@@ -1,33 +1,77 @@
 package main
 
 import (
-	"database/sql"
-	"fmt"
+	"context"
+	_ "embed"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 
-	"entgo.io/ent/dialect"
-	entsql "entgo.io/ent/dialect/sql"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
-	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/joho/godotenv"
-	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+	"github.com/t-okuji/go-openapi-todo-demo/database"
+	"github.com/t-okuji/go-openapi-todo-demo/events"
+	"github.com/t-okuji/go-openapi-todo-demo/grpcserver"
 	"github.com/t-okuji/go-openapi-todo-demo/handlers"
+	"github.com/t-okuji/go-openapi-todo-demo/hooks"
+	"github.com/t-okuji/go-openapi-todo-demo/openapi"
+	"github.com/t-okuji/go-openapi-todo-demo/reminders"
+	"github.com/t-okuji/go-openapi-todo-demo/webhooks"
 )
 
-// Open は新しいデータベース接続を開く
-func Open(databaseUrl string) *ent.Client {
-	db, err := sql.Open("pgx", databaseUrl)
-	if err != nil {
-		log.Fatal(err)
-	}
+// docsHTML は /docs で提供する API ドキュメントの UI（Stoplight Elements）
+//
+//go:embed openapi-ui.html
+var docsHTML []byte
+
+// idempotencyKeyTTL は Idempotency-Key とレスポンスを保持する期間
+const idempotencyKeyTTL = 24 * time.Hour
 
-	// `db` から ent.Driver を作成
-	drv := entsql.OpenDB(dialect.Postgres, db)
-	return ent.NewClient(ent.Driver(drv))
+// eventReplayBufferSize は Last-Event-ID による再送のために保持する変更イベント数
+const eventReplayBufferSize = 1000
+
+// newReminderNotifier は環境変数 REMINDER_NOTIFIER（stdout・smtp・webhook、省略時は stdout）で指定した Notifier を作成する
+func newReminderNotifier() reminders.Notifier {
+	switch kind := os.Getenv("REMINDER_NOTIFIER"); kind {
+	case "", "stdout":
+		return &reminders.StdoutNotifier{}
+	case "smtp":
+		port := os.Getenv("SMTP_PORT")
+		if port == "" {
+			port = "25"
+		}
+		var to []string
+		for _, addr := range strings.Split(os.Getenv("REMINDER_EMAIL_TO"), ",") {
+			if addr = strings.TrimSpace(addr); addr != "" {
+				to = append(to, addr)
+			}
+		}
+		if len(to) == 0 {
+			log.Fatal("REMINDER_EMAIL_TO is required for the smtp reminder notifier")
+		}
+		return &reminders.SMTPNotifier{
+			Addr:     net.JoinHostPort(os.Getenv("SMTP_HOST"), port),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("SMTP_FROM"),
+			To:       to,
+		}
+	case "webhook":
+		url := os.Getenv("REMINDER_WEBHOOK_URL")
+		if url == "" {
+			log.Fatal("REMINDER_WEBHOOK_URL is required for the webhook reminder notifier")
+		}
+		return &reminders.WebhookNotifier{URL: url, Secret: os.Getenv("REMINDER_WEBHOOK_SECRET")}
+	default:
+		log.Fatalf("Unknown REMINDER_NOTIFIER: %s", kind)
+		return nil
+	}
 }
 
 func main() {
@@ -36,55 +80,121 @@ func main() {
 		log.Printf("Failed to load .env file: %v", err)
 	}
 
-	// データベース接続情報を環境変数から取得
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%s/%s",
-		os.Getenv("POSTGRES_USER"),
-		os.Getenv("POSTGRES_PASSWORD"),
-		os.Getenv("POSTGRES_HOST"),
-		os.Getenv("POSTGRES_PORT"),
-		os.Getenv("POSTGRES_DB"),
-	)
+	// server migrate ... はマイグレーションを実行して終了する
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// データベース接続情報を環境変数から取得（DB_DRIVER=sqlite の場合はスキーマを自動作成する）
+	driver, dsn, err := database.Config()
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Connecting to database (%s): %s", driver, dsn)
+	db, err := database.Open(context.Background(), driver, dsn)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	client := db.Client
+
+	// 変更イベントは PostgreSQL の LISTEN/NOTIFY を介して全てのレプリカの購読者に配信する
+	// LISTEN/NOTIFY に対応していない場合（SQLite）は同じプロセスの購読者にのみ配信する
+	broker := events.NewBroker(eventReplayBufferSize)
+	var fanout hooks.Publisher = broker
+	if db.Features.Notify {
+		postgres := events.NewPostgres(db.SQL, dsn, broker)
+		go postgres.Run(context.Background())
+		fanout = postgres
+	}
+
+	// Todo・Category の変更を配信・記録するフックを登録
+	// 変更イベントはロールバックされた変更を配信しないよう、最も外側のフックとして先に登録する
+	hooks.RegisterEvents(client, fanout)
+	hooks.RegisterWebhooks(client)
+	hooks.RegisterAudit(client)
+	hooks.RegisterVersioning(client)
+	hooks.RegisterRecurrence(client)
+	hooks.RegisterReminders(client)
+
+	// 送信待ちの Webhook を送信する
+	go webhooks.NewDispatcher(client).Run(context.Background())
 
-	log.Printf("Connecting to database: %s", dsn)
-	client := Open(dsn)
+	// 通知予定日時を過ぎたリマインダーを送信する
+	go reminders.NewScheduler(client, newReminderNotifier(), db.Features).Run(context.Background())
+
+	// gRPC サーバー（TodoService・CategoryService）を REST API とは別のポートで起動する
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9090"
+	}
+	lis, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatalf("Failed to listen for gRPC: %v", err)
+	}
+	go func() {
+		log.Printf("Starting gRPC server: localhost:%s", grpcPort)
+		if err := grpcserver.New(client).Serve(lis); err != nil {
+			log.Printf("gRPC server error: %v", err)
+		}
+	}()
 
 	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
 	r.Use(middleware.Logger)
+	r.Use(handlers.Actor)
 
 	// CORS設定
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", handlers.IdempotencyKeyHeader, handlers.ActorHeader, middleware.RequestIDHeader, "Last-Event-ID"},
+		ExposedHeaders:   []string{"Link", handlers.IdempotentReplayedHeader, handlers.TotalCountHeader},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
+
+	// API 仕様に従ってリクエストを検証する（APP_ENV=development の場合はレスポンスも検証してログに出力する）
+	spec, err := openapi.Spec()
+	if err != nil {
+		log.Fatalf("Failed to load OpenAPI spec: %v", err)
+	}
+	validator, err := handlers.ValidateRequests(spec, os.Getenv("APP_ENV") == "development")
+	if err != nil {
+		log.Fatalf("Failed to create OpenAPI validator: %v", err)
+	}
+	r.Use(validator)
+
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("welcome"))
 	})
 
 	// OpenAPI ドキュメント用エンドポイント
 	r.Get("/docs", func(w http.ResponseWriter, r *http.Request) {
-		http.ServeFile(w, r, "openapi-ui.html")
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(docsHTML)
 	})
 
-	// OpenAPI仕様ファイルを提供するエンドポイント
-	r.Handle("/openapi/*", http.StripPrefix("/openapi/", http.FileServer(http.Dir("openapi"))))
-
-	// Todo API エンドポイント
-	r.Get("/todos", handlers.GetTodosHandler(client))
-	r.Post("/todos", handlers.CreateTodoHandler(client))
-	r.Get("/todos/{todoId}", handlers.GetTodoByIDHandler(client))
-	r.Put("/todos/{todoId}", handlers.UpdateTodoHandler(client))
-	r.Delete("/todos/{todoId}", handlers.DeleteTodoHandler(client))
-
-	// Category API エンドポイント
-	r.Get("/categories", handlers.GetCategories(client))
-	r.Post("/categories", handlers.CreateCategory(client))
-	r.Get("/categories/{categoryId}", handlers.GetCategoryByID(client))
-	r.Put("/categories/{categoryId}", handlers.UpdateCategory(client))
-	r.Delete("/categories/{categoryId}", handlers.DeleteCategory(client))
+	// $ref を解決した OpenAPI 仕様を提供するエンドポイント
+	for path, format := range map[string]string{
+		"/openapi.json": handlers.OpenAPIFormatJSON,
+		"/openapi.yaml": handlers.OpenAPIFormatYAML,
+	} {
+		specHandler, err := handlers.OpenAPIHandler(spec, format)
+		if err != nil {
+			log.Fatalf("Failed to create OpenAPI handler: %v", err)
+		}
+		r.Get(path, specHandler)
+	}
+
+	// API 仕様（openapi.yml）から生成したルーティングに各操作のハンドラーを登録する
+	// POST /todos などの Idempotency-Key を受け付ける操作は冪等性を保証するミドルウェアを通る
+	api.HandlerWithOptions(handlers.NewServer(client, broker, idempotencyKeyTTL, db.Features), api.ChiServerOptions{
+		BaseRouter:       r,
+		ErrorHandlerFunc: handlers.ParamErrorHandler,
+	})
 
 	log.Printf("Starting server: http://localhost:8080")
 	http.ListenAndServe(":8080", r)
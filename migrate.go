@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/t-okuji/go-openapi-todo-demo/database"
+	"github.com/t-okuji/go-openapi-todo-demo/db/migrations"
+)
+
+const migrateUsage = `Usage: server migrate <command> [flags]
+
+Commands:
+  up [-dry-run]               未適用のマイグレーションを全て適用する
+  down [-dry-run] [-steps N]  適用済みのマイグレーションを新しいものから N 件（省略時は1件）取り消す
+  baseline [-dry-run] <version>
+                              version 以下のマイグレーションを実行せずに適用済みとして記録する
+                              （db/schema.sql で作成した既存のデータベースを移行する場合に使う）
+  status                      マイグレーションの適用状況を表示する
+  check                       未適用・変更されたマイグレーションと、Ent のスキーマとの違いを確認する
+  create [-dir DIR] <name>    次のバージョンの空のマイグレーションファイルを作成する（省略時は db/migrations）
+  unlock                      異常終了で残ったマイグレーションのロックを解除する
+`
+
+// errSchemaCheckFailed は migrate check で問題が見つかったことを表す
+var errSchemaCheckFailed = errors.New("schema check failed")
+
+// runMigrate は server migrate のサブコマンドを実行する
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return fmt.Errorf("missing migrate command")
+	}
+	command, args := args[0], args[1:]
+
+	flags := flag.NewFlagSet("migrate "+command, flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprint(flags.Output(), migrateUsage) }
+	var dryRun bool
+	var steps int
+	var dir string
+	var baselineVersion int64
+	switch command {
+	case "up", "down", "baseline":
+		flags.BoolVar(&dryRun, "dry-run", false, "実行せずに実行する SQL を表示する")
+		if command == "down" {
+			flags.IntVar(&steps, "steps", 1, "取り消すマイグレーションの数")
+		}
+	case "create":
+		flags.StringVar(&dir, "dir", "db/migrations", "マイグレーションファイルを作成するディレクトリ")
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	switch command {
+	case "create":
+		if flags.NArg() != 1 {
+			return fmt.Errorf("usage: server migrate create <name>")
+		}
+		up, down, err := database.CreateMigration(dir, flags.Arg(0))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Created %s\nCreated %s\n", up, down)
+		return nil
+	case "baseline":
+		if flags.NArg() != 1 {
+			return fmt.Errorf("usage: server migrate baseline <version>")
+		}
+		var err error
+		if baselineVersion, err = strconv.ParseInt(flags.Arg(0), 10, 64); err != nil {
+			return fmt.Errorf("invalid version %q", flags.Arg(0))
+		}
+	case "up", "down", "status", "check", "unlock":
+		if flags.NArg() != 0 {
+			return fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+		}
+	default:
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return fmt.Errorf("unknown migrate command: %s", command)
+	}
+
+	// マイグレーションは PostgreSQL のスキーマを管理する（SQLite は起動時に Ent のスキーマから作成する）
+	driver, dsn, err := database.Config()
+	if err != nil {
+		return err
+	}
+	if driver != database.DriverPostgres {
+		return fmt.Errorf("migrations require DB_DRIVER=%s; the %s schema is created from the Ent schema on startup", database.DriverPostgres, driver)
+	}
+	ctx := context.Background()
+	db, err := database.Open(ctx, driver, dsn)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	all, err := database.LoadMigrations(migrations.FS)
+	if err != nil {
+		return err
+	}
+	migrator := database.NewMigrator(db.SQL, all)
+	migrator.Log = os.Stdout
+	migrator.DryRun = dryRun
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("No pending migrations")
+		}
+	case "down":
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		if len(reverted) == 0 {
+			fmt.Println("No applied migrations")
+		}
+	case "baseline":
+		recorded, err := migrator.Baseline(ctx, baselineVersion)
+		if err != nil {
+			return err
+		}
+		if len(recorded) == 0 {
+			fmt.Println("No migrations to record")
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+		for _, s := range statuses {
+			appliedAt := "-"
+			if !s.AppliedAt.IsZero() {
+				appliedAt = s.AppliedAt.Local().Format(time.DateTime)
+			}
+			fmt.Fprintf(w, "%03d\t%s\t%s\t%s\n", s.Version, s.Name, s.State, appliedAt)
+		}
+		return w.Flush()
+	case "check":
+		return checkSchema(ctx, db, migrator)
+	case "unlock":
+		if err := migrator.Unlock(ctx); err != nil {
+			return err
+		}
+		fmt.Println("Released migration lock")
+	}
+	return nil
+}
+
+// checkSchema は全てのマイグレーションが変更されずに適用され、Ent のスキーマとデータベースに違いがないことを確認する
+func checkSchema(ctx context.Context, db *database.DB, migrator *database.Migrator) error {
+	var problems []string
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	for _, s := range statuses {
+		switch s.State {
+		case database.MigrationPending:
+			problems = append(problems, fmt.Sprintf("migration %03d_%s is not applied", s.Version, s.Name))
+		case database.MigrationModified:
+			problems = append(problems, fmt.Sprintf("migration %03d_%s was modified after it was applied", s.Version, s.Name))
+		case database.MigrationMissing:
+			problems = append(problems, fmt.Sprintf("applied migration %03d_%s has no file", s.Version, s.Name))
+		}
+	}
+
+	drift, err := database.Drift(ctx, db.Client)
+	if err != nil {
+		return fmt.Errorf("comparing with the Ent schema: %w", err)
+	}
+	for _, d := range drift {
+		problems = append(problems, "drift: "+d)
+	}
+
+	if len(problems) > 0 {
+		for _, p := range problems {
+			fmt.Println(p)
+		}
+		return errSchemaCheckFailed
+	}
+	fmt.Println("Schema is up to date")
+	return nil
+}
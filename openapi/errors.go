@@ -0,0 +1,67 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+// FieldErrors はスキーマの検証エラーを項目ごとのエラーに変換する
+// 項目名は prefix にリクエストボディ内のパスを「.」区切りで続けたもの（body.operations.0.op など）
+// 項目ごとのエラーに変換できない場合は reason（空の場合は "is invalid"）を prefix のエラーとする
+func FieldErrors(prefix string, err error, reason string) []types.FieldError {
+	var details []types.FieldError
+	for _, e := range FlattenErrors(err) {
+		var schemaErr *openapi3.SchemaError
+		if !errors.As(e, &schemaErr) {
+			details = append(details, types.FieldError{Field: prefix, Message: e.Error()})
+			continue
+		}
+		field := prefix
+		if pointer := schemaErr.JSONPointer(); len(pointer) > 0 {
+			field = strings.Trim(prefix+"."+strings.Join(pointer, "."), ".")
+		}
+		details = append(details, types.FieldError{Field: field, Message: schemaErrorMessage(schemaErr)})
+	}
+	if len(details) == 0 {
+		if reason == "" {
+			reason = "is invalid"
+		}
+		details = append(details, types.FieldError{Field: prefix, Message: reason})
+	}
+	return details
+}
+
+// schemaErrorMessage はスキーマの検証エラーの内容（スキーマ全体を含まない短いメッセージ）を返す
+func schemaErrorMessage(err *openapi3.SchemaError) string {
+	switch {
+	case err.SchemaField == "format":
+		return fmt.Sprintf("string doesn't match the format %q", err.Schema.Format)
+	case err.Reason != "":
+		return err.Reason
+	case err.Origin != nil:
+		return err.Origin.Error()
+	default:
+		return "doesn't match schema " + err.SchemaField
+	}
+}
+
+// FlattenErrors は openapi3.MultiError を個々のエラーに展開する
+// RequestError などがラップした MultiError は展開しない（パラメーター・ボディの区別を保つため）
+func FlattenErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	multi, ok := err.(openapi3.MultiError)
+	if !ok {
+		return []error{err}
+	}
+	var errs []error
+	for _, e := range multi {
+		errs = append(errs, FlattenErrors(e)...)
+	}
+	return errs
+}
@@ -0,0 +1,92 @@
+// Package openapi は API 仕様（openapi.yml と paths・components の分割ファイル）を提供する
+//
+// 分割ファイルはバイナリに埋め込み、Load で $ref を解決したひとつのドキュメントにまとめる。
+// まとめたドキュメントはサーバーのインターフェース・モデルのコード生成（api パッケージ）と、
+// 実行時のリクエスト・レスポンスの検証に使う。
+package openapi
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// formatOfUUID は format: uuid の文字列の形式（バージョンを問わない 8-4-4-4-12 の16進数）
+const formatOfUUID = `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`
+
+func init() {
+	// kin-openapi は uuid の形式を既定では検証しないため登録する
+	openapi3.DefineStringFormat("uuid", formatOfUUID)
+}
+
+// files は API 仕様の分割ファイル
+//
+//go:embed openapi.yml paths components
+var files embed.FS
+
+// Load は API 仕様を読み込み、分割ファイルへの $ref を components への参照に置き換えたドキュメントを返す
+//
+// components の名前は分割ファイル内の名前（todo.yml#/Todo の場合は Todo）をそのまま使うため、
+// 分割ファイルをまたいで同じ名前を付けてはならない。
+func Load(ctx context.Context) (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+	loader.Context = ctx
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(_ *openapi3.Loader, location *url.URL) ([]byte, error) {
+		return files.ReadFile(path.Clean(location.Path))
+	}
+
+	doc, err := loader.LoadFromFile("openapi.yml")
+	if err != nil {
+		return nil, fmt.Errorf("openapi: loading spec: %w", err)
+	}
+	doc.InternalizeRefs(ctx, componentName)
+	if err := doc.Validate(ctx); err != nil {
+		return nil, fmt.Errorf("openapi: invalid spec: %w", err)
+	}
+	return doc, nil
+}
+
+// loadSpec は Spec が返す API 仕様を一度だけ読み込む
+var loadSpec = sync.OnceValues(func() (*openapi3.T, error) {
+	return Load(context.Background())
+})
+
+// Spec は初回の呼び出しで読み込んだ API 仕様を返す。呼び出し元で共有するため、返したドキュメントを変更してはならない
+func Spec() (*openapi3.T, error) {
+	return loadSpec()
+}
+
+// ValidateSchema は value を JSON に変換し、components の name のスキーマ（CategoryInput など）で検証する
+// 全ての違反を openapi3.MultiError（要素は *openapi3.SchemaError）で返す
+func ValidateSchema(name string, value any) error {
+	doc, err := Spec()
+	if err != nil {
+		return err
+	}
+	schema := doc.Components.Schemas[name]
+	if schema == nil {
+		return fmt.Errorf("openapi: unknown schema %q", name)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	return schema.Value.VisitJSON(v, openapi3.VisitAsRequest(), openapi3.MultiErrors())
+}
+
+// componentName は分割ファイルの $ref を components に移すときの名前を返す
+func componentName(_ *openapi3.T, ref openapi3.ComponentRef) string {
+	return path.Base(ref.RefPath().Fragment)
+}
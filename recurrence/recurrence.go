@@ -0,0 +1,358 @@
+// Package recurrence は Todo の繰り返しルール（RFC 5545 の RRULE のサブセット）を扱う
+//
+// FREQ（DAILY・WEEKLY・MONTHLY）、INTERVAL、BYDAY、UNTIL、COUNT に対応する。
+// 発生日時は系列の開始日時（DTSTART）のタイムゾーンでの壁時計の時刻を保って求めるため、
+// 夏時間の切り替えをまたいでも同じ時刻に発生する。
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // タイムゾーンを実行環境に依存せず解決するため
+)
+
+// Frequency は繰り返しの単位
+type Frequency string
+
+// 対応する繰り返しの単位
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// ErrInvalidRule は繰り返しルールが不正または未対応であることを表す
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+// maxPeriods は発生日時を求める際に走査する期間（日・週・月）の上限
+const maxPeriods = 100000
+
+// untilDateTime・untilDate は UNTIL の書式（UTC の日時・日付）
+const (
+	untilDateTime = "20060102T150405Z"
+	untilDate     = "20060102"
+)
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// WeekdayNum は BYDAY の曜日を表す
+type WeekdayNum struct {
+	// Ordinal は月内の何番目か（1〜5、末尾からは -1〜-5）。0 の場合は全ての該当する曜日
+	Ordinal int
+	Weekday time.Weekday
+}
+
+// String は "MO"・"1MO"・"-1FR" 形式の文字列を返す
+func (d WeekdayNum) String() string {
+	code := strings.ToUpper(d.Weekday.String()[:2])
+	if d.Ordinal == 0 {
+		return code
+	}
+	return strconv.Itoa(d.Ordinal) + code
+}
+
+// Rule は繰り返しルールを表す
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	// ByDay は DAILY では発生する曜日の絞り込み、WEEKLY では週内の曜日、MONTHLY では月内の曜日
+	ByDay []WeekdayNum
+	// Until は最後の発生日時の上限。UntilDate が true の場合は系列のタイムゾーンでのその日の終わりまで
+	Until     *time.Time
+	UntilDate bool
+	// Count は系列の発生回数（0 の場合は無制限）
+	Count int
+}
+
+// Parse は "FREQ=WEEKLY;BYDAY=MO,WE" 形式の繰り返しルールを読み出す
+// 先頭の "RRULE:" は省略できる
+func Parse(s string) (Rule, error) {
+	rule := Rule{Interval: 1}
+	s = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "RRULE:")
+	if s == "" {
+		return rule, fmt.Errorf("%w: rule is empty", ErrInvalidRule)
+	}
+
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return rule, fmt.Errorf("%w: %q must be NAME=VALUE", ErrInvalidRule, part)
+		}
+		if seen[name] {
+			return rule, fmt.Errorf("%w: %s is specified more than once", ErrInvalidRule, name)
+		}
+		seen[name] = true
+
+		switch name {
+		case "FREQ":
+			switch f := Frequency(value); f {
+			case Daily, Weekly, Monthly:
+				rule.Freq = f
+			default:
+				return rule, fmt.Errorf("%w: FREQ must be one of DAILY, WEEKLY, MONTHLY", ErrInvalidRule)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 {
+				return rule, fmt.Errorf("%w: INTERVAL must be a positive integer", ErrInvalidRule)
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 1 {
+				return rule, fmt.Errorf("%w: COUNT must be a positive integer", ErrInvalidRule)
+			}
+			rule.Count = count
+		case "UNTIL":
+			if t, err := time.Parse(untilDateTime, value); err == nil {
+				rule.Until = &t
+			} else if t, err := time.Parse(untilDate, value); err == nil {
+				rule.Until, rule.UntilDate = &t, true
+			} else {
+				return rule, fmt.Errorf("%w: UNTIL must be YYYYMMDD or YYYYMMDDTHHMMSSZ", ErrInvalidRule)
+			}
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				day, err := parseWeekdayNum(code)
+				if err != nil {
+					return rule, err
+				}
+				if !slices.Contains(rule.ByDay, day) {
+					rule.ByDay = append(rule.ByDay, day)
+				}
+			}
+		default:
+			return rule, fmt.Errorf("%w: %s is not supported", ErrInvalidRule, name)
+		}
+	}
+
+	if rule.Freq == "" {
+		return rule, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return rule, fmt.Errorf("%w: COUNT and UNTIL cannot be specified together", ErrInvalidRule)
+	}
+	if rule.Freq != Monthly {
+		for _, day := range rule.ByDay {
+			if day.Ordinal != 0 {
+				return rule, fmt.Errorf("%w: BYDAY with an ordinal is only supported with FREQ=MONTHLY", ErrInvalidRule)
+			}
+		}
+	}
+	return rule, nil
+}
+
+// parseWeekdayNum は "MO"・"1MO"・"-1FR" 形式の曜日を読み出す
+func parseWeekdayNum(s string) (WeekdayNum, error) {
+	if len(s) < 2 {
+		return WeekdayNum{}, fmt.Errorf("%w: invalid BYDAY %q", ErrInvalidRule, s)
+	}
+	weekday, ok := weekdayCodes[s[len(s)-2:]]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("%w: invalid BYDAY %q", ErrInvalidRule, s)
+	}
+	day := WeekdayNum{Weekday: weekday}
+	if prefix := s[:len(s)-2]; prefix != "" {
+		ordinal, err := strconv.Atoi(prefix)
+		if err != nil || ordinal == 0 || ordinal < -5 || ordinal > 5 {
+			return WeekdayNum{}, fmt.Errorf("%w: BYDAY ordinal must be between -5 and 5 in %q", ErrInvalidRule, s)
+		}
+		day.Ordinal = ordinal
+	}
+	return day, nil
+}
+
+// String は正規化した繰り返しルールを返す
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = day.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Until != nil {
+		if r.UntilDate {
+			parts = append(parts, "UNTIL="+r.Until.Format(untilDate))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilDateTime))
+		}
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+// LoadLocation は系列のタイムゾーン（IANA のタイムゾーン名）を読み込む
+// 空文字列は UTC とする。実行環境に依存する "Local" は受け付けない
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	if name == "Local" {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	return time.LoadLocation(name)
+}
+
+// Nth は start を1回目とする系列の n 回目の発生日時を返す
+//
+// RFC 5545 と同様に start はルールに一致しない場合も1回目とし、2回目以降は start より後のルールに一致する日時とする。
+// 系列が n 回目より前に終わる場合は false を返す。
+func (r Rule) Nth(start time.Time, loc *time.Location, n int) (time.Time, bool) {
+	if n < 1 || (r.Count > 0 && n > r.Count) {
+		return time.Time{}, false
+	}
+	if n == 1 {
+		return start, !r.after(start, loc)
+	}
+
+	var (
+		result time.Time
+		found  bool
+		count  = 1
+	)
+	r.each(start, loc, func(t time.Time) bool {
+		if r.after(t, loc) {
+			return false
+		}
+		count++
+		if count == n {
+			result, found = t, true
+			return false
+		}
+		return true
+	})
+	return result, found
+}
+
+// after は t が UNTIL より後かどうかを返す
+func (r Rule) after(t time.Time, loc *time.Location) bool {
+	if r.Until == nil {
+		return false
+	}
+	if r.UntilDate {
+		y, m, d := t.In(loc).Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).After(*r.Until)
+	}
+	return t.After(*r.Until)
+}
+
+// each は start より後のルールに一致する日時を古い順に yield に渡す。yield が false を返すと終了する
+func (r Rule) each(start time.Time, loc *time.Location, yield func(time.Time) bool) {
+	local := start.In(loc)
+	year, month, day := local.Date()
+	hour, minute, sec := local.Clock()
+	at := func(y int, m time.Month, d int) time.Time {
+		// 壁時計の時刻で組み立てるため、夏時間の切り替え後も同じ時刻になる
+		return time.Date(y, m, d, hour, minute, sec, local.Nanosecond(), loc)
+	}
+	emit := func(t time.Time) bool {
+		return !t.After(start) || yield(t)
+	}
+
+	switch r.Freq {
+	case Daily:
+		for i := 1; i <= maxPeriods; i++ {
+			t := at(year, month, day+i*r.Interval)
+			if len(r.ByDay) > 0 && !r.hasWeekday(t.Weekday()) {
+				continue
+			}
+			if !emit(t) {
+				return
+			}
+		}
+	case Weekly:
+		weekdays := []time.Weekday{local.Weekday()}
+		if len(r.ByDay) > 0 {
+			weekdays = weekdays[:0]
+			for _, d := range r.ByDay {
+				weekdays = append(weekdays, d.Weekday)
+			}
+		}
+		// 週は月曜日から始まる（WKST=MO）
+		slices.SortFunc(weekdays, func(a, b time.Weekday) int { return mondayOffset(a) - mondayOffset(b) })
+		monday := day - mondayOffset(local.Weekday())
+		for week := 0; week <= maxPeriods; week += r.Interval {
+			for _, wd := range weekdays {
+				if !emit(at(year, month, monday+week*7+mondayOffset(wd))) {
+					return
+				}
+			}
+		}
+	case Monthly:
+		for i := 0; i <= maxPeriods; i += r.Interval {
+			first := time.Date(year, month+time.Month(i), 1, 0, 0, 0, 0, time.UTC)
+			for _, d := range r.monthDays(first, day) {
+				if !emit(at(first.Year(), first.Month(), d)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// monthDays は first の月で発生する日の一覧を昇順で返す
+// BYDAY がない場合は startDay 日（その月に存在しない場合は発生しない）
+func (r Rule) monthDays(first time.Time, startDay int) []int {
+	daysInMonth := first.AddDate(0, 1, -1).Day()
+	if len(r.ByDay) == 0 {
+		if startDay > daysInMonth {
+			return nil
+		}
+		return []int{startDay}
+	}
+
+	var days []int
+	for _, byDay := range r.ByDay {
+		// その月の最初の該当する曜日
+		firstDay := 1 + (int(byDay.Weekday)-int(first.Weekday())+7)%7
+		var candidates []int
+		for d := firstDay; d <= daysInMonth; d += 7 {
+			candidates = append(candidates, d)
+		}
+		switch {
+		case byDay.Ordinal == 0:
+			days = append(days, candidates...)
+		case byDay.Ordinal > 0 && byDay.Ordinal <= len(candidates):
+			days = append(days, candidates[byDay.Ordinal-1])
+		case byDay.Ordinal < 0 && -byDay.Ordinal <= len(candidates):
+			days = append(days, candidates[len(candidates)+byDay.Ordinal])
+		}
+	}
+	slices.Sort(days)
+	return slices.Compact(days)
+}
+
+// hasWeekday は BYDAY に weekday が含まれるかどうかを返す
+func (r Rule) hasWeekday(weekday time.Weekday) bool {
+	for _, d := range r.ByDay {
+		if d.Weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// mondayOffset は月曜日から数えた曜日の位置（月曜日が 0、日曜日が 6）を返す
+func mondayOffset(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
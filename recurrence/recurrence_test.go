@@ -0,0 +1,209 @@
+package recurrence_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/t-okuji/go-openapi-todo-demo/recurrence"
+)
+
+func TestNth(t *testing.T) {
+	newYork, err := recurrence.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	utc := func(s string) time.Time {
+		t.Helper()
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		loc   *time.Location
+		// want は1回目からの発生日時（UTC）。系列がその次の回で終わる場合は end を true にする
+		want []string
+		end  bool
+	}{
+		{
+			// 2026-03-08 に EST から EDT に切り替わっても、現地時刻の 09:00 に発生する
+			name:  "daily across DST start",
+			rule:  "FREQ=DAILY",
+			start: time.Date(2026, 3, 7, 9, 0, 0, 0, newYork),
+			loc:   newYork,
+			want:  []string{"2026-03-07T14:00:00Z", "2026-03-08T13:00:00Z", "2026-03-09T13:00:00Z"},
+		},
+		{
+			name:  "weekly across DST end",
+			rule:  "FREQ=WEEKLY",
+			start: time.Date(2026, 10, 26, 9, 0, 0, 0, newYork),
+			loc:   newYork,
+			want:  []string{"2026-10-26T13:00:00Z", "2026-11-02T14:00:00Z"},
+		},
+		{
+			name:  "weekly BYDAY with COUNT",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4",
+			start: utc("2026-01-05T09:00:00Z"), // 月曜日
+			loc:   time.UTC,
+			want:  []string{"2026-01-05T09:00:00Z", "2026-01-07T09:00:00Z", "2026-01-12T09:00:00Z", "2026-01-14T09:00:00Z"},
+			end:   true,
+		},
+		{
+			// 開始日がルールに一致しない場合も1回目とする
+			name:  "start not matching BYDAY",
+			rule:  "FREQ=WEEKLY;BYDAY=MO",
+			start: utc("2026-01-04T09:00:00Z"), // 日曜日
+			loc:   time.UTC,
+			want:  []string{"2026-01-04T09:00:00Z", "2026-01-05T09:00:00Z", "2026-01-12T09:00:00Z"},
+		},
+		{
+			// 31日がない月は発生しない
+			name:  "monthly on the 31st",
+			rule:  "FREQ=MONTHLY",
+			start: utc("2026-01-31T09:00:00Z"),
+			loc:   time.UTC,
+			want:  []string{"2026-01-31T09:00:00Z", "2026-03-31T09:00:00Z", "2026-05-31T09:00:00Z", "2026-07-31T09:00:00Z"},
+		},
+		{
+			name:  "last Friday until a date-time",
+			rule:  "FREQ=MONTHLY;BYDAY=-1FR;UNTIL=20260424T090000Z",
+			start: utc("2026-01-30T09:00:00Z"),
+			loc:   time.UTC,
+			want:  []string{"2026-01-30T09:00:00Z", "2026-02-27T09:00:00Z", "2026-03-27T09:00:00Z", "2026-04-24T09:00:00Z"},
+			end:   true,
+		},
+		{
+			// 日付の UNTIL は系列のタイムゾーンでのその日の終わりまで
+			name:  "last Friday until a date",
+			rule:  "FREQ=MONTHLY;BYDAY=-1FR;UNTIL=20260327",
+			start: time.Date(2026, 1, 30, 22, 0, 0, 0, newYork),
+			loc:   newYork,
+			want:  []string{"2026-01-31T03:00:00Z", "2026-02-28T03:00:00Z", "2026-03-28T02:00:00Z"},
+			end:   true,
+		},
+		{
+			name:  "second Tuesday and last Friday",
+			rule:  "FREQ=MONTHLY;BYDAY=2TU,-1FR",
+			start: utc("2026-01-13T09:00:00Z"),
+			loc:   time.UTC,
+			want:  []string{"2026-01-13T09:00:00Z", "2026-01-30T09:00:00Z", "2026-02-10T09:00:00Z", "2026-02-27T09:00:00Z"},
+		},
+		{
+			name:  "daily INTERVAL=2",
+			rule:  "FREQ=DAILY;INTERVAL=2",
+			start: utc("2026-02-27T09:00:00Z"),
+			loc:   time.UTC,
+			want:  []string{"2026-02-27T09:00:00Z", "2026-03-01T09:00:00Z", "2026-03-03T09:00:00Z"},
+		},
+		{
+			name:  "weekly INTERVAL=2 with BYDAY",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH",
+			start: utc("2026-01-06T09:00:00Z"), // 火曜日
+			loc:   time.UTC,
+			want:  []string{"2026-01-06T09:00:00Z", "2026-01-08T09:00:00Z", "2026-01-20T09:00:00Z", "2026-01-22T09:00:00Z"},
+		},
+		{
+			name:  "monthly INTERVAL=2",
+			rule:  "FREQ=MONTHLY;INTERVAL=2;COUNT=3",
+			start: utc("2026-01-15T09:00:00Z"),
+			loc:   time.UTC,
+			want:  []string{"2026-01-15T09:00:00Z", "2026-03-15T09:00:00Z", "2026-05-15T09:00:00Z"},
+			end:   true,
+		},
+		{
+			name:  "daily BYDAY weekdays",
+			rule:  "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR",
+			start: utc("2026-01-09T09:00:00Z"), // 金曜日
+			loc:   time.UTC,
+			want:  []string{"2026-01-09T09:00:00Z", "2026-01-12T09:00:00Z", "2026-01-13T09:00:00Z"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := recurrence.Parse(tt.rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, want := range tt.want {
+				got, ok := rule.Nth(tt.start, tt.loc, i+1)
+				if !ok || !got.Equal(utc(want)) {
+					t.Errorf("occurrence %d = %s, %t, want %s", i+1, got.UTC().Format(time.RFC3339), ok, want)
+				}
+			}
+			if got, ok := rule.Nth(tt.start, tt.loc, len(tt.want)+1); ok == tt.end {
+				t.Errorf("occurrence %d = %s, %t, want series end %t", len(tt.want)+1, got.UTC().Format(time.RFC3339), ok, tt.end)
+			}
+		})
+	}
+
+	rule, _ := recurrence.Parse("FREQ=DAILY")
+	if _, ok := rule.Nth(utc("2026-01-01T00:00:00Z"), time.UTC, 0); ok {
+		t.Error("occurrence 0 exists")
+	}
+	// UNTIL より後に開始した系列は1回目も発生しない
+	rule, _ = recurrence.Parse("FREQ=DAILY;UNTIL=20251231")
+	if _, ok := rule.Nth(utc("2026-01-01T00:00:00Z"), time.UTC, 1); ok {
+		t.Error("occurrence 1 after UNTIL exists")
+	}
+}
+
+func TestParse(t *testing.T) {
+	for input, want := range map[string]string{
+		"FREQ=DAILY": "FREQ=DAILY",
+		"rrule:freq=weekly;byday=we,mo,we;interval=1":    "FREQ=WEEKLY;BYDAY=WE,MO",
+		"FREQ=MONTHLY;BYDAY=-1FR;UNTIL=20260424T090000Z": "FREQ=MONTHLY;BYDAY=-1FR;UNTIL=20260424T090000Z",
+		"FREQ=MONTHLY;UNTIL=20260424;INTERVAL=3":         "FREQ=MONTHLY;INTERVAL=3;UNTIL=20260424",
+		"COUNT=4;FREQ=WEEKLY;BYDAY=MO,WE":                "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4",
+	} {
+		rule, err := recurrence.Parse(input)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", input, err)
+			continue
+		}
+		if got := rule.String(); got != want {
+			t.Errorf("Parse(%q).String() = %q, want %q", input, got, want)
+		}
+	}
+
+	for _, input := range []string{
+		"",
+		"FREQ=YEARLY",
+		"INTERVAL=2",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=-1",
+		"FREQ=DAILY;COUNT=2;UNTIL=20260101",
+		"FREQ=DAILY;UNTIL=2026-01-01",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;BYMONTH=1",
+		"FREQ=DAILY;BYDAY",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=MONTHLY;BYDAY=6MO",
+		"FREQ=MONTHLY;BYDAY=0MO",
+		"FREQ=MONTHLY;BYDAY=XX",
+	} {
+		if _, err := recurrence.Parse(input); !errors.Is(err, recurrence.ErrInvalidRule) {
+			t.Errorf("Parse(%q) error = %v, want ErrInvalidRule", input, err)
+		}
+	}
+}
+
+func TestLoadLocation(t *testing.T) {
+	if loc, err := recurrence.LoadLocation(""); err != nil || loc != time.UTC {
+		t.Errorf("LoadLocation(\"\") = %v, %v, want UTC", loc, err)
+	}
+	if loc, err := recurrence.LoadLocation("Asia/Tokyo"); err != nil || loc.String() != "Asia/Tokyo" {
+		t.Errorf("LoadLocation(Asia/Tokyo) = %v, %v", loc, err)
+	}
+	for _, name := range []string{"Local", "Nowhere/City"} {
+		if _, err := recurrence.LoadLocation(name); err == nil {
+			t.Errorf("LoadLocation(%q) error = nil", name)
+		}
+	}
+}
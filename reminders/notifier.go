@@ -0,0 +1,62 @@
+// Package reminders は Todo のリマインダーを通知予定日時に送信する
+//
+// リマインダーはデータベースに保存し、Scheduler が通知予定日時を過ぎたものを
+// SELECT ... FOR UPDATE SKIP LOCKED で確保して Notifier で送信する。
+// プロセスが再起動しても送信待ちのリマインダーは失われず、複数のレプリカで実行しても
+// 各リマインダーは同時にひとつのレプリカだけが送信する。
+package reminders
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Notification は送信するリマインダーの内容を表す
+type Notification struct {
+	ReminderID  uuid.UUID  `json:"reminderId"`
+	TodoID      uuid.UUID  `json:"todoId"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	DueAt       *time.Time `json:"dueAt,omitempty"`
+	// FireAt はリマインダーの通知予定日時
+	FireAt time.Time `json:"fireAt"`
+}
+
+// Notifier はリマインダーを送信する
+// Notify がエラーを返した場合、Scheduler は時間をおいて再送する
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// StdoutNotifier はリマインダーを1行のテキストとして書き出す
+// 開発環境での確認用で、Out が nil の場合は標準出力に書き出す
+type StdoutNotifier struct {
+	Out io.Writer
+
+	mu sync.Mutex
+}
+
+// Notify はリマインダーを書き出す
+func (s *StdoutNotifier) Notify(ctx context.Context, n Notification) error {
+	out := s.Out
+	if out == nil {
+		out = os.Stdout
+	}
+
+	line := fmt.Sprintf("[reminder] %s %q (todo %s)", n.FireAt.UTC().Format(time.RFC3339), n.Title, n.TodoID)
+	if n.DueAt != nil {
+		line += " due " + n.DueAt.UTC().Format(time.RFC3339)
+	}
+
+	// 並行して送信した行が混ざらないようにする
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := fmt.Fprintln(out, line)
+	return err
+}
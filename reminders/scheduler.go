@@ -0,0 +1,181 @@
+package reminders
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/database"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/reminder"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+	"github.com/t-okuji/go-openapi-todo-demo/worker"
+)
+
+const (
+	// MaxAttempts は送信を諦めて dead とするまでの送信回数
+	MaxAttempts = 5
+
+	pollInterval  = 1 * time.Second
+	batchSize     = 20
+	concurrency   = 8
+	notifyTimeout = 1 * time.Minute
+
+	// claimLease は送信中のリマインダーを他のレプリカが取得しないよう通知予定日時を延ばす期間
+	// 送信中にプロセスが停止した場合は、この期間の経過後に再送される
+	claimLease = 2 * time.Minute
+)
+
+// retryBackoff は送信に失敗したリマインダーを再送するまでの待ち時間
+var retryBackoff = worker.Backoff{Base: 10 * time.Second, Max: time.Hour}
+
+// Scheduler は通知予定日時を過ぎたリマインダーを Notifier で送信する
+//
+// 送信時に Todo が完了している場合は送信せずに skipped とする。
+// 送信に失敗したリマインダーは指数バックオフで再送し、MaxAttempts 回失敗すると dead とする。
+type Scheduler struct {
+	client     *ent.Client
+	notifier   Notifier
+	skipLocked bool
+}
+
+// NewScheduler は client のリマインダーを notifier で送信する Scheduler を作成する
+// データベースが SKIP LOCKED に対応していない場合（SQLite）は行ロックを使わずに取得する
+func NewScheduler(client *ent.Client, notifier Notifier, features database.Features) *Scheduler {
+	return &Scheduler{client: client, notifier: notifier, skipLocked: features.SkipLocked}
+}
+
+// Run は ctx が終了するまで通知予定日時を過ぎたリマインダーを定期的に送信する
+func (s *Scheduler) Run(ctx context.Context) {
+	worker.Poll(ctx, "Reminder scheduler", pollInterval, batchSize, s.fireDue)
+}
+
+// fireDue は通知予定日時を過ぎたリマインダーを確保して送信し、確保した件数を返す
+func (s *Scheduler) fireDue(ctx context.Context) (int, error) {
+	due, lease, err := s.claim(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	worker.Each(due, concurrency, func(r *ent.Reminder) {
+		if err := s.fire(ctx, r, lease); err != nil {
+			log.Printf("Reminder %s update error: %v", r.ID, err)
+		}
+	})
+
+	return len(due), nil
+}
+
+// claim は通知予定日時を過ぎたリマインダーを行ロックで確保し、通知予定日時を確保の期限（claimLease 後）に延ばす
+//
+// 他のレプリカがロックしている行は待たずに読み飛ばす（SKIP LOCKED）。
+// SQLite では書き込みのトランザクションがデータベース全体をロックするため、行ロックは使わない。
+// 返すリマインダーの FireAt は延ばす前の通知予定日時のまま。確保の期限も返す。
+func (s *Scheduler) claim(ctx context.Context, now time.Time) ([]*ent.Reminder, time.Time, error) {
+	// データベースに保存した値と比較できるよう、PostgreSQL の精度（マイクロ秒）に揃える
+	lease := now.Add(claimLease).Truncate(time.Microsecond)
+
+	var due []*ent.Reminder
+	err := utils.WithTx(ctx, s.client, func(tx *ent.Tx) error {
+		query := tx.Reminder.Query().
+			Where(
+				reminder.StatusEQ(reminder.StatusPending),
+				reminder.FireAtLTE(now),
+			).
+			Order(ent.Asc(reminder.FieldFireAt)).
+			Limit(batchSize)
+		if s.skipLocked {
+			query.ForUpdate(sql.WithLockAction(sql.SkipLocked))
+		}
+
+		var err error
+		due, err = query.All(ctx)
+		if err != nil || len(due) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(due))
+		for i, r := range due {
+			ids[i] = r.ID
+		}
+		return tx.Reminder.Update().
+			Where(reminder.IDIn(ids...)).
+			SetFireAt(lease).
+			Exec(ctx)
+	})
+	return due, lease, err
+}
+
+// fire は確保の期限が lease のリマインダーをひとつ送信し、結果をリマインダーに書き込む
+//
+// 送信中に Todo の期限の変更などで通知予定日時が変わった場合は、新しい通知予定日時を優先して結果を書き込まない。
+func (s *Scheduler) fire(ctx context.Context, r *ent.Reminder, lease time.Time) error {
+	// 送信処理の終了時に ctx がキャンセルされていても結果は記録する
+	recordCtx := context.WithoutCancel(ctx)
+
+	t, err := s.client.Todo.Get(ctx, r.TodoID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			// Todo と同時に削除された
+			return nil
+		}
+		return err
+	}
+	// 確保のために延ばした通知予定日時を戻す（再送する場合は次の送信予定日時）
+	update := s.client.Reminder.Update().
+		Where(
+			reminder.ID(r.ID),
+			reminder.StatusEQ(reminder.StatusPending),
+			reminder.FireAt(lease),
+		).
+		SetFireAt(*r.FireAt)
+	if t.Completed {
+		return update.SetStatus(reminder.StatusSkipped).Exec(recordCtx)
+	}
+
+	notifyCtx, cancel := context.WithTimeout(ctx, notifyTimeout)
+	defer cancel()
+	sendErr := s.notifier.Notify(notifyCtx, Notification{
+		ReminderID:  r.ID,
+		TodoID:      t.ID,
+		Title:       t.Title,
+		Description: t.Description,
+		DueAt:       t.DueAt,
+		FireAt:      *r.FireAt,
+	})
+
+	now := time.Now()
+	attempts := r.Attempts + 1
+	update.SetAttempts(attempts)
+	switch {
+	case sendErr == nil:
+		update.
+			SetStatus(reminder.StatusSent).
+			SetSentAt(now).
+			ClearLastError()
+	case attempts >= MaxAttempts:
+		update.
+			SetStatus(reminder.StatusDead).
+			SetLastError(worker.ErrorMessage(sendErr))
+	default:
+		update.
+			SetFireAt(now.Add(retryBackoff.Delay(attempts))).
+			SetLastError(worker.ErrorMessage(sendErr))
+	}
+	return update.Exec(recordCtx)
+}
+
+// FireAt はリマインダーの通知予定日時を返す
+// 期限からの相対時間で指定したリマインダーは、Todo に期限がない場合は nil を返す
+func FireAt(remindAt *time.Time, offsetMinutes *int, dueAt *time.Time) *time.Time {
+	switch {
+	case remindAt != nil:
+		return remindAt
+	case offsetMinutes != nil && dueAt != nil:
+		t := dueAt.Add(time.Duration(*offsetMinutes) * time.Minute)
+		return &t
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package reminders
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/database"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/reminder"
+	"github.com/t-okuji/go-openapi-todo-demo/worker"
+)
+
+// fakeNotifier は送信したリマインダーを記録し、err を返す
+type fakeNotifier struct {
+	mu   sync.Mutex
+	sent []Notification
+	err  error
+}
+
+func (f *fakeNotifier) Notify(ctx context.Context, n Notification) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, n)
+	return f.err
+}
+
+func newTestScheduler(t *testing.T) (*Scheduler, *fakeNotifier) {
+	t.Helper()
+	db, err := database.Open(context.Background(), database.DriverSQLite, "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	notifier := &fakeNotifier{}
+	return NewScheduler(db.Client, notifier, db.Features), notifier
+}
+
+// createReminder は fireAt に通知する Todo のリマインダーを作成する
+func createReminder(t *testing.T, s *Scheduler, completed bool, fireAt time.Time) *ent.Reminder {
+	t.Helper()
+	ctx := context.Background()
+	todo := s.client.Todo.Create().SetTitle("todo").SetCompleted(completed).SaveX(ctx)
+	return s.client.Reminder.Create().
+		SetTodoID(todo.ID).
+		SetRemindAt(fireAt).
+		SetFireAt(fireAt).
+		SaveX(ctx)
+}
+
+func reminderIDs(rs []*ent.Reminder) map[uuid.UUID]bool {
+	ids := make(map[uuid.UUID]bool, len(rs))
+	for _, r := range rs {
+		ids[r.ID] = true
+	}
+	return ids
+}
+
+func TestSchedulerClaim(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestScheduler(t)
+	now := time.Now().Truncate(time.Second)
+
+	due := createReminder(t, s, false, now.Add(-time.Minute))
+	createReminder(t, s, false, now.Add(time.Hour))
+	sent := createReminder(t, s, false, now.Add(-time.Minute))
+	s.client.Reminder.UpdateOne(sent).SetStatus(reminder.StatusSent).ExecX(ctx)
+
+	// 通知予定日時を過ぎた送信待ちのリマインダーのみを確保する
+	claimed, lease, err := s.claim(ctx, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := reminderIDs(claimed); len(ids) != 1 || !ids[due.ID] {
+		t.Fatalf("claimed %v, want only %s", ids, due.ID)
+	}
+	// 返すリマインダーは確保前の通知予定日時を保ち、データベースでは claimLease だけ延ばす
+	if !claimed[0].FireAt.Equal(*due.FireAt) {
+		t.Errorf("claimed fireAt = %v, want %v", claimed[0].FireAt, due.FireAt)
+	}
+	if got := s.client.Reminder.GetX(ctx, due.ID).FireAt; !lease.Equal(now.Add(claimLease)) || !got.Equal(lease) {
+		t.Errorf("stored fireAt = %v, lease %v, want %v", got, lease, now.Add(claimLease))
+	}
+
+	// 確保中のリマインダーはリースの期間内には再度確保しない
+	if claimed, _, _ := s.claim(ctx, now.Add(claimLease-time.Second)); len(claimed) != 0 {
+		t.Errorf("claimed %d reminders during the lease, want 0", len(claimed))
+	}
+	// 送信中にプロセスが停止した場合は、リースの経過後に再度確保する
+	claimed, _, _ = s.claim(ctx, now.Add(claimLease))
+	if ids := reminderIDs(claimed); len(ids) != 1 || !ids[due.ID] {
+		t.Errorf("claimed %v after the lease, want only %s", ids, due.ID)
+	}
+}
+
+func TestSchedulerFire(t *testing.T) {
+	ctx := context.Background()
+	fireAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	// fire は確保したリマインダーを送信し、保存された結果を返す
+	fire := func(t *testing.T, s *Scheduler, r *ent.Reminder) *ent.Reminder {
+		t.Helper()
+		claimed, lease, err := s.claim(ctx, time.Now())
+		if err != nil || len(claimed) != 1 || claimed[0].ID != r.ID {
+			t.Fatalf("claim = %v, %v", claimed, err)
+		}
+		if err := s.fire(ctx, claimed[0], lease); err != nil {
+			t.Fatal(err)
+		}
+		return s.client.Reminder.GetX(ctx, r.ID)
+	}
+
+	t.Run("sent", func(t *testing.T) {
+		s, notifier := newTestScheduler(t)
+		r := createReminder(t, s, false, fireAt)
+		got := fire(t, s, r)
+		if got.Status != reminder.StatusSent || got.Attempts != 1 || got.SentAt == nil || got.LastError != nil || !got.FireAt.Equal(fireAt) {
+			t.Errorf("reminder = %+v, want sent at the original fireAt", got)
+		}
+		if len(notifier.sent) != 1 || notifier.sent[0].ReminderID != r.ID || notifier.sent[0].TodoID != r.TodoID || !notifier.sent[0].FireAt.Equal(fireAt) {
+			t.Errorf("notifications = %+v", notifier.sent)
+		}
+	})
+
+	t.Run("skipped", func(t *testing.T) {
+		// 完了した Todo のリマインダーは送信しない
+		s, notifier := newTestScheduler(t)
+		got := fire(t, s, createReminder(t, s, true, fireAt))
+		if got.Status != reminder.StatusSkipped || got.Attempts != 0 || !got.FireAt.Equal(fireAt) {
+			t.Errorf("reminder = %+v, want skipped", got)
+		}
+		if len(notifier.sent) != 0 {
+			t.Errorf("%d notifications sent, want 0", len(notifier.sent))
+		}
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		s, notifier := newTestScheduler(t)
+		notifier.err = errors.New("connection refused")
+		before := time.Now()
+		got := fire(t, s, createReminder(t, s, false, fireAt))
+		if got.Status != reminder.StatusPending || got.Attempts != 1 || got.LastError == nil || *got.LastError != "connection refused" {
+			t.Errorf("reminder = %+v, want pending with the error", got)
+		}
+		// 次の送信予定日時は1回目の再送の待ち時間の後（最大 10% のゆらぎ）
+		if earliest, latest := before.Add(10*time.Second), time.Now().Add(12*time.Second); got.FireAt.Before(earliest) || got.FireAt.After(latest) {
+			t.Errorf("retry fireAt = %v, want between %v and %v", got.FireAt, earliest, latest)
+		}
+		if claimed, _, _ := s.claim(ctx, time.Now()); len(claimed) != 0 {
+			t.Errorf("claimed %d reminders before the retry delay, want 0", len(claimed))
+		}
+	})
+
+	t.Run("dead", func(t *testing.T) {
+		s, notifier := newTestScheduler(t)
+		notifier.err = errors.New(strings.Repeat("x", worker.MaxErrorMessage+100))
+		r := createReminder(t, s, false, fireAt)
+		s.client.Reminder.UpdateOne(r).SetAttempts(MaxAttempts - 1).ExecX(ctx)
+		got := fire(t, s, r)
+		if got.Status != reminder.StatusDead || got.Attempts != MaxAttempts || got.LastError == nil || len(*got.LastError) != worker.MaxErrorMessage {
+			t.Errorf("reminder status %s, attempts %d, want dead after %d attempts with a truncated error", got.Status, got.Attempts, MaxAttempts)
+		}
+	})
+
+	t.Run("rescheduled while sending", func(t *testing.T) {
+		// 送信中に通知予定日時が変わった場合は、送信の結果で新しい通知予定日時を上書きしない
+		s, notifier := newTestScheduler(t)
+		r := createReminder(t, s, false, fireAt)
+		claimed, lease, err := s.claim(ctx, time.Now())
+		if err != nil || len(claimed) != 1 {
+			t.Fatalf("claim = %v, %v", claimed, err)
+		}
+		rescheduled := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+		s.client.Reminder.UpdateOne(r).SetFireAt(rescheduled).ExecX(ctx)
+		if err := s.fire(ctx, claimed[0], lease); err != nil {
+			t.Fatal(err)
+		}
+		got := s.client.Reminder.GetX(ctx, r.ID)
+		if got.Status != reminder.StatusPending || got.Attempts != 0 || !got.FireAt.Equal(rescheduled) {
+			t.Errorf("reminder = %+v, want pending at the rescheduled fireAt", got)
+		}
+		if len(notifier.sent) != 1 {
+			t.Errorf("%d notifications sent, want 1", len(notifier.sent))
+		}
+	})
+
+	t.Run("fireDue", func(t *testing.T) {
+		s, notifier := newTestScheduler(t)
+		for range 3 {
+			createReminder(t, s, false, fireAt)
+		}
+		createReminder(t, s, false, time.Now().Add(time.Hour))
+		n, err := s.fireDue(ctx)
+		if err != nil || n != 3 || len(notifier.sent) != 3 {
+			t.Errorf("fireDue = %d, %v with %d notifications, want 3", n, err, len(notifier.sent))
+		}
+		if pending := s.client.Reminder.Query().Where(reminder.StatusEQ(reminder.StatusPending)).CountX(ctx); pending != 1 {
+			t.Errorf("%d pending reminders, want 1", pending)
+		}
+	})
+}
@@ -0,0 +1,117 @@
+package reminders
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// smtpTimeout は SMTP サーバーとの1回のやり取り全体の制限時間
+const smtpTimeout = 30 * time.Second
+
+// SMTPNotifier はリマインダーをメールで送信する
+//
+// Username を指定した場合は PLAIN 認証を行う。net/smtp の仕様により、認証情報は
+// STARTTLS に対応したサーバーまたは localhost のサーバーにのみ送信する。
+type SMTPNotifier struct {
+	// Addr は "host:port" 形式の SMTP サーバーのアドレス
+	Addr     string
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// Notify はリマインダーをメールで送信する
+func (s *SMTPNotifier) Notify(ctx context.Context, n Notification) error {
+	if len(s.To) == 0 {
+		return errors.New("smtp: no recipients")
+	}
+	host, _, err := net.SplitHostPort(s.Addr)
+	if err != nil {
+		return fmt.Errorf("smtp: invalid address %q: %w", s.Addr, err)
+	}
+
+	deadline := time.Now().Add(smtpTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	dialer := net.Dialer{Deadline: deadline}
+	conn, err := dialer.DialContext(ctx, "tcp", s.Addr)
+	if err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	// サーバーが応答しない場合もプロセスを止めないよう、やり取り全体に期限を設ける
+	conn.SetDeadline(deadline)
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp: %w", err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return fmt.Errorf("smtp: starttls: %w", err)
+		}
+	}
+	if s.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.Username, s.Password, host)); err != nil {
+			return fmt.Errorf("smtp: auth: %w", err)
+		}
+	}
+	if err := c.Mail(s.From); err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	for _, to := range s.To {
+		if err := c.Rcpt(to); err != nil {
+			return fmt.Errorf("smtp: %w", err)
+		}
+	}
+	wc, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	if _, err := wc.Write(s.message(n)); err != nil {
+		wc.Close()
+		return fmt.Errorf("smtp: %w", err)
+	}
+	if err := wc.Close(); err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	return c.Quit()
+}
+
+// message はリマインダーのメール（ヘッダーと本文）を組み立てる
+func (s *SMTPNotifier) message(n Notification) []byte {
+	var body strings.Builder
+	body.WriteString(n.Title + "\r\n")
+	if n.DueAt != nil {
+		body.WriteString("\r\n期限: " + n.DueAt.UTC().Format(time.RFC3339) + "\r\n")
+	}
+	if n.Description != "" {
+		body.WriteString("\r\n" + strings.ReplaceAll(n.Description, "\n", "\r\n") + "\r\n")
+	}
+	body.WriteString("\r\nTodo ID: " + n.TodoID.String() + "\r\n")
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", s.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(s.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", "リマインダー: "+n.Title))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "Message-ID: <%s@reminders.go-openapi-todo-demo>\r\n", n.ReminderID)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(body.String())
+	return b.Bytes()
+}
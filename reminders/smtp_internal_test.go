@@ -0,0 +1,223 @@
+package reminders
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"mime"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeSMTP は受け取ったコマンドとメッセージを記録する SMTP サーバー
+// reject に含まれるコマンド（"RCPT" など）には 550 を返す
+type fakeSMTP struct {
+	addr     string
+	reject   map[string]bool
+	commands chan string
+	messages chan string
+}
+
+func newFakeSMTP(t *testing.T, reject ...string) *fakeSMTP {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	s := &fakeSMTP{
+		addr:     ln.Addr().String(),
+		reject:   make(map[string]bool),
+		commands: make(chan string, 100),
+		messages: make(chan string, 10),
+	}
+	for _, cmd := range reject {
+		s.reject[cmd] = true
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTP) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost ESMTP fake")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		s.commands <- line
+		verb := strings.ToUpper(strings.Fields(line + " ")[0])
+		if s.reject[verb] {
+			reply("550 rejected")
+			continue
+		}
+
+		switch verb {
+		case "EHLO":
+			reply("250-localhost")
+			reply("250 AUTH PLAIN")
+		case "AUTH":
+			reply("235 authenticated")
+		case "DATA":
+			reply("354 end with .")
+			var msg strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				msg.WriteString(strings.TrimPrefix(l, "."))
+			}
+			s.messages <- msg.String()
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+// drain は記録したコマンドを全て返す
+func (s *fakeSMTP) drain() []string {
+	var commands []string
+	for {
+		select {
+		case c := <-s.commands:
+			commands = append(commands, c)
+		default:
+			return commands
+		}
+	}
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	server := newFakeSMTP(t)
+	notifier := &SMTPNotifier{
+		Addr:     server.addr,
+		Username: "user",
+		Password: "secret",
+		From:     "reminders@example.com",
+		To:       []string{"alice@example.com", "bob@example.com"},
+	}
+	dueAt := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	n := Notification{
+		ReminderID:  uuid.New(),
+		TodoID:      uuid.New(),
+		Title:       "請求書を送る",
+		Description: "line1\n.line2",
+		DueAt:       &dueAt,
+		FireAt:      dueAt.Add(-time.Hour),
+	}
+	if err := notifier.Notify(context.Background(), n); err != nil {
+		t.Fatal(err)
+	}
+
+	// localhost のサーバーには TLS なしで PLAIN 認証を行う
+	commands := server.drain()
+	wantAuth := "AUTH PLAIN " + base64.StdEncoding.EncodeToString([]byte("\x00user\x00secret"))
+	for _, want := range []string{wantAuth, "MAIL FROM:<reminders@example.com>", "RCPT TO:<alice@example.com>", "RCPT TO:<bob@example.com>", "QUIT"} {
+		found := false
+		for _, c := range commands {
+			found = found || strings.HasPrefix(c, want)
+		}
+		if !found {
+			t.Errorf("command %q not sent; commands: %q", want, commands)
+		}
+	}
+
+	var raw string
+	select {
+	case raw = <-server.messages:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message received")
+	}
+	msg, err := mail.ReadMessage(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("message %q: %v", raw, err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != "リマインダー: 請求書を送る" {
+		t.Errorf("Subject = %q, %v", subject, err)
+	}
+	if got := msg.Header.Get("To"); got != "alice@example.com, bob@example.com" {
+		t.Errorf("To = %q", got)
+	}
+	if got := msg.Header.Get("Message-ID"); !strings.Contains(got, n.ReminderID.String()) {
+		t.Errorf("Message-ID = %q, want the reminder ID", got)
+	}
+	body := new(strings.Builder)
+	bufio.NewReader(msg.Body).WriteTo(body)
+	for _, want := range []string{"請求書を送る\r\n", "期限: 2026-03-01T09:00:00Z", "line1\r\n.line2\r\n", "Todo ID: " + n.TodoID.String()} {
+		if !strings.Contains(body.String(), want) {
+			t.Errorf("body %q does not contain %q", body, want)
+		}
+	}
+}
+
+func TestSMTPNotifierErrors(t *testing.T) {
+	n := Notification{ReminderID: uuid.New(), TodoID: uuid.New(), Title: "a"}
+
+	// 受信者を拒否された場合は送信せずにエラーを返す
+	server := newFakeSMTP(t, "RCPT")
+	notifier := &SMTPNotifier{Addr: server.addr, From: "from@example.com", To: []string{"to@example.com"}}
+	if err := notifier.Notify(context.Background(), n); err == nil || !strings.Contains(err.Error(), "550") {
+		t.Errorf("rejected recipient: error = %v, want 550", err)
+	}
+	select {
+	case msg := <-server.messages:
+		t.Errorf("message sent despite rejected recipient: %q", msg)
+	default:
+	}
+
+	if err := (&SMTPNotifier{Addr: server.addr}).Notify(context.Background(), n); err == nil {
+		t.Error("no recipients: error = nil")
+	}
+	if err := (&SMTPNotifier{Addr: "no-port", To: []string{"to@example.com"}}).Notify(context.Background(), n); err == nil {
+		t.Error("invalid address: error = nil")
+	}
+
+	// 応答しないサーバーは ctx の期限で打ち切る
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err == nil {
+			defer conn.Close()
+			time.Sleep(5 * time.Second)
+		}
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	silent := &SMTPNotifier{Addr: ln.Addr().String(), To: []string{"to@example.com"}}
+	if err := silent.Notify(ctx, n); err == nil || time.Since(start) > 3*time.Second {
+		t.Errorf("silent server: error = %v after %s, want a timeout", err, time.Since(start))
+	}
+}
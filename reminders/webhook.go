@@ -0,0 +1,70 @@
+package reminders
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/t-okuji/go-openapi-todo-demo/webhooks"
+)
+
+// EventReminder はリマインダーの Webhook の X-Webhook-Event ヘッダーの値
+const EventReminder = "todo.reminder"
+
+// webhookTimeout はリマインダーの Webhook の HTTP リクエストの制限時間
+const webhookTimeout = 10 * time.Second
+
+// WebhookNotifier はリマインダーを JSON の HTTP POST で送信する
+//
+// Secret を指定した場合は、変更イベントの Webhook と同じ形式（webhooks.Sign）で署名する。
+// X-Webhook-Delivery ヘッダーにはリマインダーのIDを設定するため、受信側は再送を判別できる。
+type WebhookNotifier struct {
+	URL    string
+	Secret string
+	// Client は送信に使う HTTP クライアント。nil の場合は webhookTimeout の制限時間で送信する
+	Client *http.Client
+}
+
+// Notify はリマインダーを送信する。2xx 以外のレスポンスはエラーとする
+func (s *WebhookNotifier) Notify(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "go-openapi-todo-demo-reminders/1.0")
+	req.Header.Set(webhooks.DeliveryHeader, n.ReminderID.String())
+	req.Header.Set(webhooks.EventHeader, EventReminder)
+	req.Header.Set(webhooks.TimestampHeader, strconv.FormatInt(timestamp, 10))
+	if s.Secret != "" {
+		req.Header.Set(webhooks.SignatureHeader, webhooks.Sign(s.Secret, timestamp, body))
+	}
+
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: webhookTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// コネクションを再利用できるようレスポンスボディを読み捨てる
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return nil
+}
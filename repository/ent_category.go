@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/category"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/todo"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// EntCategoryRepository は Ent でデータベースにカテゴリを保存する CategoryRepository
+//
+// 変更は監査ログなどのフック（hooks パッケージ）と同一のトランザクションで行う。
+type EntCategoryRepository struct {
+	client *ent.Client
+	tx     *ent.Tx
+}
+
+var _ CategoryRepository = (*EntCategoryRepository)(nil)
+
+// NewEntCategoryRepository は client にカテゴリを保存する EntCategoryRepository を作成する
+func NewEntCategoryRepository(client *ent.Client) *EntCategoryRepository {
+	return &EntCategoryRepository{client: client}
+}
+
+// NewEntCategoryRepositoryTx は tx の中でカテゴリを保存する EntCategoryRepository を作成する
+func NewEntCategoryRepositoryTx(tx *ent.Tx) *EntCategoryRepository {
+	return &EntCategoryRepository{client: tx.Client(), tx: tx}
+}
+
+// List は全てのカテゴリを返す
+func (r *EntCategoryRepository) List(ctx context.Context) ([]types.CategoryResponse, error) {
+	categories, err := r.client.Category.
+		Query().
+		Order(ent.Asc(category.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("category fetch: %w", err)
+	}
+
+	responses := make([]types.CategoryResponse, len(categories))
+	for i, c := range categories {
+		responses[i] = utils.ConvertToCategoryResponse(c)
+	}
+	return responses, nil
+}
+
+// Get はカテゴリを返す
+func (r *EntCategoryRepository) Get(ctx context.Context, id uuid.UUID) (*types.CategoryResponse, error) {
+	c, err := r.client.Category.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, utils.ErrCategoryNotFoundByID
+		}
+		return nil, err
+	}
+	response := utils.ConvertToCategoryResponse(c)
+	return &response, nil
+}
+
+// Exists はカテゴリが存在するかを返す
+func (r *EntCategoryRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	exists, err := r.client.Category.Query().Where(category.ID(id)).Exist(ctx)
+	if err != nil {
+		return false, fmt.Errorf("category existence check: %w", err)
+	}
+	return exists, nil
+}
+
+// Create はカテゴリを作成する
+func (r *EntCategoryRepository) Create(ctx context.Context, input types.CategoryInput) (*types.CategoryResponse, error) {
+	var created *ent.Category
+	err := runInTx(ctx, r.client, r.tx, func(client *ent.Client) error {
+		var err error
+		created, err = CreateCategory(ctx, client, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	response := utils.ConvertToCategoryResponse(created)
+	return &response, nil
+}
+
+// Update はカテゴリを更新する
+func (r *EntCategoryRepository) Update(ctx context.Context, id uuid.UUID, input types.CategoryInput) (*types.CategoryResponse, error) {
+	var updated *ent.Category
+	err := runInTx(ctx, r.client, r.tx, func(client *ent.Client) error {
+		var err error
+		updated, err = UpdateCategory(ctx, client, id, input)
+		return err
+	})
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, utils.ErrCategoryNotFoundByID
+		}
+		return nil, err
+	}
+	response := utils.ConvertToCategoryResponse(updated)
+	return &response, nil
+}
+
+// Delete はカテゴリを削除する
+func (r *EntCategoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	err := runInTx(ctx, r.client, r.tx, func(client *ent.Client) error {
+		return DeleteCategory(ctx, client, id)
+	})
+	if ent.IsNotFound(err) {
+		return utils.ErrCategoryNotFoundByID
+	}
+	return err
+}
+
+// CreateCategory は検証済みの入力からカテゴリを作成する
+// 同期・インポートなど、複数の変更を同じトランザクションで行う場合は client にトランザクションのクライアントを渡す
+func CreateCategory(ctx context.Context, client *ent.Client, input types.CategoryInput) (*ent.Category, error) {
+	createBuilder := client.Category.Create().
+		SetName(input.Name).
+		SetColor(*input.Color)
+
+	if input.Description != nil && *input.Description != "" {
+		createBuilder.SetDescription(*input.Description)
+	}
+
+	return createBuilder.Save(ctx)
+}
+
+// UpdateCategory は検証済みの入力でカテゴリを更新する
+// 空文字列の説明は解除として扱い、カラーコードは指定された場合のみ更新する
+func UpdateCategory(ctx context.Context, client *ent.Client, categoryID uuid.UUID, input types.CategoryInput) (*ent.Category, error) {
+	updateBuilder := client.Category.UpdateOneID(categoryID).SetName(input.Name)
+
+	if input.Description != nil {
+		if *input.Description == "" {
+			updateBuilder.ClearDescription()
+		} else {
+			updateBuilder.SetDescription(*input.Description)
+		}
+	}
+
+	if input.Color != nil {
+		updateBuilder.SetColor(*input.Color)
+	}
+
+	return updateBuilder.Save(ctx)
+}
+
+// DeleteCategory はカテゴリを削除する
+// 関連するTodoのcategory_idは外部キー制約でもNULLになるが、変更を監査ログに残すため明示的に解除する
+func DeleteCategory(ctx context.Context, client *ent.Client, categoryID uuid.UUID) error {
+	if _, err := client.Todo.Update().
+		Where(todo.CategoryID(categoryID)).
+		ClearCategoryID().
+		Save(ctx); err != nil {
+		return err
+	}
+	return client.Category.DeleteOneID(categoryID).Exec(ctx)
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/idempotencykey"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/predicate"
+)
+
+// EntIdempotencyKeyRepository は Ent でデータベースに Idempotency-Key の記録を保存する IdempotencyKeyRepository
+//
+// キーの一意性はテーブルの一意制約で保証し、ロックの引き継ぎは条件付きの更新で1つのリクエストだけが行う。
+type EntIdempotencyKeyRepository struct {
+	client *ent.Client
+}
+
+var _ IdempotencyKeyRepository = (*EntIdempotencyKeyRepository)(nil)
+
+// NewEntIdempotencyKeyRepository は client に Idempotency-Key の記録を保存する EntIdempotencyKeyRepository を作成する
+func NewEntIdempotencyKeyRepository(client *ent.Client) *EntIdempotencyKeyRepository {
+	return &EntIdempotencyKeyRepository{client: client}
+}
+
+// Acquire はキーを処理中として登録する
+func (r *EntIdempotencyKeyRepository) Acquire(ctx context.Context, now time.Time, key IdempotencyKey) (uuid.UUID, error) {
+	// 期限切れのキーを削除してから、処理中としてキーを登録する
+	if _, err := r.client.IdempotencyKey.Delete().
+		Where(idempotencykey.ExpiresAtLT(now)).
+		Exec(ctx); err != nil {
+		log.Printf("Idempotency key purge error: %v", err)
+	}
+
+	record, err := r.client.IdempotencyKey.Create().
+		SetActor(key.Actor).
+		SetKey(key.Key).
+		SetFingerprint(key.Fingerprint).
+		SetNillableLockedUntil(key.LockedUntil).
+		SetExpiresAt(key.ExpiresAt).
+		Save(ctx)
+	if err == nil {
+		return record.ID, nil
+	}
+	if !ent.IsConstraintError(err) {
+		return uuid.Nil, err
+	}
+
+	// 一意制約違反は同じキーのリクエストが既に存在することを意味する
+	// ロックの期限を過ぎた同じ内容のリクエストは、条件付きの更新で1つのリクエストだけが引き継ぐ
+	existing := []predicate.IdempotencyKey{
+		idempotencykey.Actor(key.Actor),
+		idempotencykey.Key(key.Key),
+		idempotencykey.Fingerprint(key.Fingerprint),
+		idempotencykey.StatusEQ(idempotencykey.StatusProcessing),
+		idempotencykey.Or(idempotencykey.LockedUntilIsNil(), idempotencykey.LockedUntilLT(now)),
+	}
+	id, err := r.client.IdempotencyKey.Query().Where(existing...).OnlyID(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return uuid.Nil, nil
+		}
+		return uuid.Nil, err
+	}
+	taken, err := r.client.IdempotencyKey.Update().
+		Where(append(existing, idempotencykey.ID(id))...).
+		SetNillableLockedUntil(key.LockedUntil).
+		Save(ctx)
+	if err != nil || taken == 0 {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+// Get はキーの記録を返す
+func (r *EntIdempotencyKeyRepository) Get(ctx context.Context, actor, key string) (*IdempotencyKey, error) {
+	record, err := r.client.IdempotencyKey.Query().
+		Where(idempotencykey.Actor(actor), idempotencykey.Key(key)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &IdempotencyKey{
+		ID:                  record.ID,
+		Actor:               record.Actor,
+		Key:                 record.Key,
+		Fingerprint:         record.Fingerprint,
+		Completed:           record.Status == idempotencykey.StatusCompleted,
+		LockedUntil:         record.LockedUntil,
+		ExpiresAt:           record.ExpiresAt,
+		ResponseStatus:      record.ResponseStatus,
+		ResponseContentType: record.ResponseContentType,
+		ResponseBody:        record.ResponseBody,
+	}, nil
+}
+
+// Complete はレスポンスを保存してキーを完了にする
+func (r *EntIdempotencyKeyRepository) Complete(ctx context.Context, id uuid.UUID, status int, contentType string, body []byte) error {
+	return r.client.IdempotencyKey.UpdateOneID(id).
+		SetStatus(idempotencykey.StatusCompleted).
+		ClearLockedUntil().
+		SetResponseStatus(status).
+		SetResponseContentType(contentType).
+		SetResponseBody(body).
+		Exec(ctx)
+}
+
+// Lock はキーのロックを延ばす
+// レスポンスを含まない小さな更新のため、Complete に失敗した後でも成功しやすい
+func (r *EntIdempotencyKeyRepository) Lock(ctx context.Context, id uuid.UUID, until time.Time) error {
+	return r.client.IdempotencyKey.UpdateOneID(id).
+		SetLockedUntil(until).
+		Exec(ctx)
+}
+
+// Release はキーを削除する
+func (r *EntIdempotencyKeyRepository) Release(ctx context.Context, id uuid.UUID) error {
+	if err := r.client.IdempotencyKey.DeleteOneID(id).Exec(ctx); err != nil && !ent.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,352 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/predicate"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/todo"
+	"github.com/t-okuji/go-openapi-todo-demo/recurrence"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// EntTodoRepository は Ent でデータベースに Todo を保存する TodoRepository
+//
+// 変更は監査ログなどのフック（hooks パッケージ）と同一のトランザクションで行う。
+type EntTodoRepository struct {
+	client *ent.Client
+	tx     *ent.Tx
+}
+
+var _ TodoRepository = (*EntTodoRepository)(nil)
+
+// NewEntTodoRepository は client に Todo を保存する EntTodoRepository を作成する
+func NewEntTodoRepository(client *ent.Client) *EntTodoRepository {
+	return &EntTodoRepository{client: client}
+}
+
+// NewEntTodoRepositoryTx は tx の中で Todo を保存する EntTodoRepository を作成する
+// 一括処理・同期など、複数の変更をひとつのトランザクションで行う場合に使う。変更は tx のコミットで確定する
+func NewEntTodoRepositoryTx(tx *ent.Tx) *EntTodoRepository {
+	return &EntTodoRepository{client: tx.Client(), tx: tx}
+}
+
+// List は条件に一致する Todo のページと総件数を返す
+func (r *EntTodoRepository) List(ctx context.Context, opts TodoListOptions) ([]types.TodoResponse, int, error) {
+	predicates, err := TodoPredicates(opts.Filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := r.client.Todo.Query().Where(predicates...)
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("todo count: %w", err)
+	}
+
+	query.Order(todoOrder(opts.Sort, opts.Order), ent.Asc(todo.FieldID)).Offset(opts.Offset)
+	if opts.Limit > 0 {
+		query.Limit(opts.Limit)
+	}
+	todos, err := query.All(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("todo fetch: %w", err)
+	}
+
+	responses := make([]types.TodoResponse, len(todos))
+	for i, t := range todos {
+		responses[i] = utils.ConvertToTodoResponse(t)
+	}
+	return responses, total, nil
+}
+
+// Get は Todo を返す
+func (r *EntTodoRepository) Get(ctx context.Context, id uuid.UUID) (*types.TodoResponse, error) {
+	t, err := r.client.Todo.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, utils.ErrTodoNotFound
+		}
+		return nil, err
+	}
+	response := utils.ConvertToTodoResponse(t)
+	return &response, nil
+}
+
+// Create は Todo を作成する
+func (r *EntTodoRepository) Create(ctx context.Context, id uuid.UUID, input types.TodoInput, categoryID *uuid.UUID) (*types.TodoResponse, error) {
+	var created *ent.Todo
+	err := runInTx(ctx, r.client, r.tx, func(client *ent.Client) error {
+		create := NewTodoCreate(client, input, categoryID)
+		if id != uuid.Nil {
+			create.SetID(id)
+		}
+		var err error
+		created, err = create.Save(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	response := utils.ConvertToTodoResponse(created)
+	return &response, nil
+}
+
+// Update は Todo を更新する
+func (r *EntTodoRepository) Update(ctx context.Context, id uuid.UUID, input types.TodoInput, categoryID *uuid.UUID, expectedVersion *int) (*types.TodoResponse, error) {
+	var updated *ent.Todo
+	err := runInTx(ctx, r.client, r.tx, func(client *ent.Client) error {
+		var err error
+		updated, err = UpdateTodo(ctx, client, id, input, categoryID, expectedVersion)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	response := utils.ConvertToTodoResponse(updated)
+	return &response, nil
+}
+
+// Delete は Todo を削除する
+func (r *EntTodoRepository) Delete(ctx context.Context, id uuid.UUID, expectedVersion *int) error {
+	return runInTx(ctx, r.client, r.tx, func(client *ent.Client) error {
+		return DeleteTodo(ctx, client, id, expectedVersion)
+	})
+}
+
+// UpdateMatching は条件に一致する Todo を一括更新する
+func (r *EntTodoRepository) UpdateMatching(ctx context.Context, filter types.TodoFilter, update TodoBulkUpdate) (int, error) {
+	predicates, err := TodoPredicates(filter)
+	if err != nil {
+		return 0, err
+	}
+	var affected int
+	err = runInTx(ctx, r.client, r.tx, func(client *ent.Client) error {
+		query := client.Todo.Update().Where(predicates...)
+		if update.Completed != nil {
+			query.SetCompleted(*update.Completed)
+		}
+		if update.CategoryID != nil {
+			query.SetCategoryID(*update.CategoryID)
+		} else if update.ClearCategory {
+			query.ClearCategoryID()
+		}
+		var err error
+		affected, err = query.Save(ctx)
+		return err
+	})
+	return affected, err
+}
+
+// DeleteMatching は条件に一致する Todo を一括削除する
+func (r *EntTodoRepository) DeleteMatching(ctx context.Context, filter types.TodoFilter) (int, error) {
+	predicates, err := TodoPredicates(filter)
+	if err != nil {
+		return 0, err
+	}
+	var affected int
+	err = runInTx(ctx, r.client, r.tx, func(client *ent.Client) error {
+		var err error
+		affected, err = client.Todo.Delete().Where(predicates...).Exec(ctx)
+		return err
+	})
+	return affected, err
+}
+
+// runInTx は fn をトランザクションのクライアントで実行する
+// トランザクション内のリポジトリ（tx が nil でない場合）は、新しいトランザクションを開始せずに tx で実行する
+func runInTx(ctx context.Context, client *ent.Client, tx *ent.Tx, fn func(client *ent.Client) error) error {
+	if tx != nil {
+		return fn(tx.Client())
+	}
+	return utils.WithTx(ctx, client, func(tx *ent.Tx) error {
+		return fn(tx.Client())
+	})
+}
+
+// todoOrder は並び替えの項目・並び順を Ent の並び順に変換する
+func todoOrder(sort, order string) todo.OrderOption {
+	field := todo.FieldCreatedAt
+	switch sort {
+	case TodoSortUpdatedAt:
+		field = todo.FieldUpdatedAt
+	case TodoSortTitle:
+		field = todo.FieldTitle
+	}
+	if order == OrderAsc {
+		return ent.Asc(field)
+	}
+	return ent.Desc(field)
+}
+
+// TodoPredicates は絞り込み条件を Ent の述語に変換する
+// 一覧のほか、一括操作・エクスポート・カレンダー配信でも使う
+func TodoPredicates(filter types.TodoFilter) ([]predicate.Todo, error) {
+	var predicates []predicate.Todo
+
+	if filter.Completed != nil {
+		predicates = append(predicates, todo.Completed(*filter.Completed))
+	}
+	if filter.CategoryID != nil {
+		if *filter.CategoryID == CategoryIDNone {
+			predicates = append(predicates, todo.CategoryIDIsNil())
+		} else {
+			categoryUUID, err := uuid.Parse(*filter.CategoryID)
+			if err != nil {
+				return nil, utils.ErrInvalidUUID
+			}
+			predicates = append(predicates, todo.CategoryID(categoryUUID))
+		}
+	}
+	if filter.Search != nil && *filter.Search != "" {
+		predicates = append(predicates, todo.Or(
+			todo.TitleContainsFold(*filter.Search),
+			todo.DescriptionContainsFold(*filter.Search),
+		))
+	}
+	if filter.CreatedBefore != nil {
+		predicates = append(predicates, todo.CreatedAtLT(*filter.CreatedBefore))
+	}
+	if filter.CreatedAfter != nil {
+		predicates = append(predicates, todo.CreatedAtGTE(*filter.CreatedAfter))
+	}
+	if filter.UpdatedBefore != nil {
+		predicates = append(predicates, todo.UpdatedAtLT(*filter.UpdatedBefore))
+	}
+	if filter.UpdatedAfter != nil {
+		predicates = append(predicates, todo.UpdatedAtGTE(*filter.UpdatedAfter))
+	}
+
+	return predicates, nil
+}
+
+// NewTodoCreate は検証済みの入力から Todo の作成クエリを組み立てる
+// 一括処理・同期など、複数の変更を同じトランザクションで行う場合は client にトランザクションのクライアントを渡す
+func NewTodoCreate(client *ent.Client, input types.TodoInput, categoryUUID *uuid.UUID) *ent.TodoCreate {
+	createQuery := client.Todo.Create().
+		SetTitle(input.Title).
+		SetCompleted(false). // デフォルトはfalse
+		SetNillableCategoryID(categoryUUID)
+
+	// オプショナルフィールドの処理
+	if input.Description != nil {
+		createQuery.SetDescription(*input.Description)
+	}
+
+	if input.Completed != nil {
+		createQuery.SetCompleted(*input.Completed)
+	}
+
+	if input.DueAt != nil && *input.DueAt != "" {
+		if dueAt, err := time.Parse(time.RFC3339, *input.DueAt); err == nil {
+			createQuery.SetDueAt(dueAt)
+		}
+	}
+
+	// 系列の開始は hooks.RegisterRecurrence で設定する
+	if input.Recurrence != nil && *input.Recurrence != "" {
+		if rule, err := recurrence.Parse(*input.Recurrence); err == nil {
+			createQuery.SetRecurrence(rule.String())
+		}
+	}
+
+	if input.TimeZone != nil {
+		createQuery.SetRecurrenceTimeZone(*input.TimeZone)
+	}
+
+	return createQuery
+}
+
+// UpdateTodo は検証済みの入力で Todo を更新する
+// 空文字列の説明・カテゴリID・期限・繰り返しルールはそれぞれの解除として扱う
+// expectedVersion を指定した場合、Todo のバージョンが一致しなければ ErrVersionConflict を返す
+func UpdateTodo(ctx context.Context, client *ent.Client, todoUUID uuid.UUID, input types.TodoInput, categoryUUID *uuid.UUID, expectedVersion *int) (*ent.Todo, error) {
+	updateQuery := client.Todo.UpdateOneID(todoUUID).
+		SetTitle(input.Title)
+	if expectedVersion != nil {
+		updateQuery.Where(todo.Version(*expectedVersion))
+	}
+
+	// オプショナルフィールドの処理
+	if input.Description != nil {
+		if *input.Description == "" {
+			updateQuery.ClearDescription()
+		} else {
+			updateQuery.SetDescription(*input.Description)
+		}
+	}
+
+	if input.Completed != nil {
+		updateQuery.SetCompleted(*input.Completed)
+	}
+
+	if input.DueAt != nil {
+		if *input.DueAt == "" {
+			updateQuery.ClearDueAt()
+		} else if dueAt, err := time.Parse(time.RFC3339, *input.DueAt); err == nil {
+			updateQuery.SetDueAt(dueAt)
+		}
+	}
+
+	// ルール・タイムゾーンを変更した場合の系列の再開始は hooks.RegisterRecurrence で行う
+	if input.Recurrence != nil {
+		if *input.Recurrence == "" {
+			updateQuery.ClearRecurrence()
+		} else if rule, err := recurrence.Parse(*input.Recurrence); err == nil {
+			updateQuery.SetRecurrence(rule.String())
+		}
+	}
+
+	if input.TimeZone != nil {
+		updateQuery.SetRecurrenceTimeZone(*input.TimeZone)
+	}
+
+	if categoryUUID != nil {
+		updateQuery.SetCategoryID(*categoryUUID)
+	} else if input.CategoryID != nil {
+		updateQuery.ClearCategoryID()
+	}
+
+	updated, err := updateQuery.Save(ctx)
+	if ent.IsNotFound(err) {
+		return nil, todoNotFoundOrConflict(ctx, client, todoUUID, expectedVersion)
+	}
+	return updated, err
+}
+
+// DeleteTodo は Todo を削除する
+// expectedVersion を指定した場合、Todo のバージョンが一致しなければ ErrVersionConflict を返す
+func DeleteTodo(ctx context.Context, client *ent.Client, todoUUID uuid.UUID, expectedVersion *int) error {
+	deleteQuery := client.Todo.Delete().Where(todo.ID(todoUUID))
+	if expectedVersion != nil {
+		deleteQuery.Where(todo.Version(*expectedVersion))
+	}
+
+	deleted, err := deleteQuery.Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return todoNotFoundOrConflict(ctx, client, todoUUID, expectedVersion)
+	}
+	return nil
+}
+
+// todoNotFoundOrConflict は条件付きの更新・削除の対象がなかった原因に応じたエラーを返す
+func todoNotFoundOrConflict(ctx context.Context, client *ent.Client, todoUUID uuid.UUID, expectedVersion *int) error {
+	if expectedVersion == nil {
+		return utils.ErrTodoNotFound
+	}
+	exists, err := client.Todo.Query().Where(todo.ID(todoUUID)).Exist(ctx)
+	if err != nil {
+		return fmt.Errorf("todo existence check: %w", err)
+	}
+	if !exists {
+		return utils.ErrTodoNotFound
+	}
+	return utils.ErrVersionConflict
+}
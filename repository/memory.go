@@ -0,0 +1,587 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/recurrence"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// MemoryStore は Todo・カテゴリ・Idempotency-Key の記録をメモリに保存する
+//
+// テストやデモのためのもので、プロセスを終了するとデータは失われる。
+// データベースのフック（監査ログ・繰り返しの系列・イベントの配信）は実行しないため、
+// 系列の ID・回数は設定されない。
+type MemoryStore struct {
+	mu         sync.RWMutex
+	todos      map[uuid.UUID]*memoryTodo
+	categories map[uuid.UUID]*memoryCategory
+	// idempotencyKeys は操作者とキーの組（memoryIdempotencyKey）ごとの記録
+	idempotencyKeys map[[2]string]*IdempotencyKey
+}
+
+// memoryTodo はメモリに保存する Todo（Ent の Todo エンティティと同じ項目）
+type memoryTodo struct {
+	id                 uuid.UUID
+	title              string
+	description        string
+	completed          bool
+	categoryID         *uuid.UUID
+	dueAt              *time.Time
+	recurrence         string
+	recurrenceTimeZone string
+	version            int
+	createdAt          time.Time
+	updatedAt          time.Time
+}
+
+// memoryCategory はメモリに保存するカテゴリ
+type memoryCategory struct {
+	id          uuid.UUID
+	name        string
+	description string
+	color       string
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// NewMemoryStore は空の MemoryStore を作成する
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		todos:           make(map[uuid.UUID]*memoryTodo),
+		categories:      make(map[uuid.UUID]*memoryCategory),
+		idempotencyKeys: make(map[[2]string]*IdempotencyKey),
+	}
+}
+
+// Todos は MemoryStore に Todo を保存する TodoRepository を返す
+func (s *MemoryStore) Todos() TodoRepository {
+	return memoryTodoRepository{s}
+}
+
+// Categories は MemoryStore にカテゴリを保存する CategoryRepository を返す
+func (s *MemoryStore) Categories() CategoryRepository {
+	return memoryCategoryRepository{s}
+}
+
+// IdempotencyKeys は MemoryStore に Idempotency-Key の記録を保存する IdempotencyKeyRepository を返す
+func (s *MemoryStore) IdempotencyKeys() IdempotencyKeyRepository {
+	return memoryIdempotencyKeyRepository{s}
+}
+
+type memoryTodoRepository struct {
+	store *MemoryStore
+}
+
+// List は条件に一致する Todo のページと総件数を返す
+func (r memoryTodoRepository) List(ctx context.Context, opts TodoListOptions) ([]types.TodoResponse, int, error) {
+	match, err := memoryTodoMatcher(opts.Filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var todos []*memoryTodo
+	for _, t := range r.store.todos {
+		if match(t) {
+			todos = append(todos, t)
+		}
+	}
+	slices.SortFunc(todos, func(a, b *memoryTodo) int {
+		var c int
+		switch opts.Sort {
+		case TodoSortUpdatedAt:
+			c = a.updatedAt.Compare(b.updatedAt)
+		case TodoSortTitle:
+			c = strings.Compare(a.title, b.title)
+		default:
+			c = a.createdAt.Compare(b.createdAt)
+		}
+		if opts.Order != OrderAsc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+		return cmp.Compare(a.id.String(), b.id.String())
+	})
+
+	total := len(todos)
+	todos = todos[min(opts.Offset, total):]
+	if opts.Limit > 0 && opts.Limit < len(todos) {
+		todos = todos[:opts.Limit]
+	}
+
+	responses := make([]types.TodoResponse, len(todos))
+	for i, t := range todos {
+		responses[i] = t.response()
+	}
+	return responses, total, nil
+}
+
+// Get は Todo を返す
+func (r memoryTodoRepository) Get(ctx context.Context, id uuid.UUID) (*types.TodoResponse, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	t, ok := r.store.todos[id]
+	if !ok {
+		return nil, utils.ErrTodoNotFound
+	}
+	response := t.response()
+	return &response, nil
+}
+
+// Create は Todo を作成する
+func (r memoryTodoRepository) Create(ctx context.Context, id uuid.UUID, input types.TodoInput, categoryID *uuid.UUID) (*types.TodoResponse, error) {
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+	now := time.Now()
+	t := &memoryTodo{
+		id:                 id,
+		recurrenceTimeZone: "UTC",
+		version:            1,
+		createdAt:          now,
+	}
+	t.apply(input, categoryID, now)
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.todos[t.id] = t
+	response := t.response()
+	return &response, nil
+}
+
+// Update は Todo を更新する
+func (r memoryTodoRepository) Update(ctx context.Context, id uuid.UUID, input types.TodoInput, categoryID *uuid.UUID, expectedVersion *int) (*types.TodoResponse, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	t, ok := r.store.todos[id]
+	if !ok {
+		return nil, utils.ErrTodoNotFound
+	}
+	if expectedVersion != nil && t.version != *expectedVersion {
+		return nil, utils.ErrVersionConflict
+	}
+	t.apply(input, categoryID, time.Now())
+	if categoryID == nil && input.CategoryID != nil {
+		t.categoryID = nil
+	}
+	t.version++
+	response := t.response()
+	return &response, nil
+}
+
+// Delete は Todo を削除する
+func (r memoryTodoRepository) Delete(ctx context.Context, id uuid.UUID, expectedVersion *int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	t, ok := r.store.todos[id]
+	if !ok {
+		return utils.ErrTodoNotFound
+	}
+	if expectedVersion != nil && t.version != *expectedVersion {
+		return utils.ErrVersionConflict
+	}
+	delete(r.store.todos, id)
+	return nil
+}
+
+// UpdateMatching は条件に一致する Todo を一括更新する
+func (r memoryTodoRepository) UpdateMatching(ctx context.Context, filter types.TodoFilter, update TodoBulkUpdate) (int, error) {
+	match, err := memoryTodoMatcher(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now()
+	var affected int
+	for _, t := range r.store.todos {
+		if !match(t) {
+			continue
+		}
+		if update.Completed != nil {
+			t.completed = *update.Completed
+		}
+		if update.CategoryID != nil || update.ClearCategory {
+			t.categoryID = cloneUUID(update.CategoryID)
+		}
+		t.version++
+		t.updatedAt = now
+		affected++
+	}
+	return affected, nil
+}
+
+// DeleteMatching は条件に一致する Todo を一括削除する
+func (r memoryTodoRepository) DeleteMatching(ctx context.Context, filter types.TodoFilter) (int, error) {
+	match, err := memoryTodoMatcher(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var affected int
+	for id, t := range r.store.todos {
+		if match(t) {
+			delete(r.store.todos, id)
+			affected++
+		}
+	}
+	return affected, nil
+}
+
+// apply は検証済みの入力を Todo に反映する（NewTodoCreate・UpdateTodo と同じ規則）
+func (t *memoryTodo) apply(input types.TodoInput, categoryID *uuid.UUID, now time.Time) {
+	t.title = input.Title
+	if input.Description != nil {
+		t.description = *input.Description
+	}
+	if input.Completed != nil {
+		t.completed = *input.Completed
+	}
+	if input.DueAt != nil {
+		if *input.DueAt == "" {
+			t.dueAt = nil
+		} else if dueAt, err := time.Parse(time.RFC3339, *input.DueAt); err == nil {
+			t.dueAt = &dueAt
+		}
+	}
+	if input.Recurrence != nil {
+		if *input.Recurrence == "" {
+			t.recurrence = ""
+		} else if rule, err := recurrence.Parse(*input.Recurrence); err == nil {
+			t.recurrence = rule.String()
+		}
+	}
+	if input.TimeZone != nil {
+		t.recurrenceTimeZone = *input.TimeZone
+	}
+	if categoryID != nil {
+		t.categoryID = cloneUUID(categoryID)
+	}
+	t.updatedAt = now
+}
+
+// response は Todo をレスポンス形式に変換する（utils.ConvertToTodoResponse と同じ規則）
+func (t *memoryTodo) response() types.TodoResponse {
+	response := types.TodoResponse{
+		ID:         t.id,
+		Title:      t.title,
+		Completed:  t.completed,
+		CategoryID: cloneUUID(t.categoryID),
+		Version:    t.version,
+		CreatedAt:  t.createdAt,
+	}
+	if t.description != "" {
+		description := t.description
+		response.Description = &description
+	}
+	if t.dueAt != nil {
+		dueAt := *t.dueAt
+		response.DueAt = &dueAt
+	}
+	if t.recurrence != "" {
+		rule, timeZone := t.recurrence, t.recurrenceTimeZone
+		response.Recurrence = &rule
+		response.TimeZone = &timeZone
+	}
+	updatedAt := t.updatedAt
+	response.UpdatedAt = &updatedAt
+	return response
+}
+
+// memoryTodoMatcher は絞り込み条件を Todo の判定関数に変換する（TodoPredicates と同じ規則）
+func memoryTodoMatcher(filter types.TodoFilter) (func(*memoryTodo) bool, error) {
+	var categoryID *uuid.UUID
+	if filter.CategoryID != nil && *filter.CategoryID != CategoryIDNone {
+		id, err := uuid.Parse(*filter.CategoryID)
+		if err != nil {
+			return nil, utils.ErrInvalidUUID
+		}
+		categoryID = &id
+	}
+	var search string
+	if filter.Search != nil {
+		search = strings.ToLower(*filter.Search)
+	}
+
+	return func(t *memoryTodo) bool {
+		if filter.Completed != nil && t.completed != *filter.Completed {
+			return false
+		}
+		if filter.CategoryID != nil {
+			if categoryID == nil && t.categoryID != nil {
+				return false
+			}
+			if categoryID != nil && (t.categoryID == nil || *t.categoryID != *categoryID) {
+				return false
+			}
+		}
+		if search != "" &&
+			!strings.Contains(strings.ToLower(t.title), search) &&
+			!strings.Contains(strings.ToLower(t.description), search) {
+			return false
+		}
+		if filter.CreatedBefore != nil && !t.createdAt.Before(*filter.CreatedBefore) {
+			return false
+		}
+		if filter.CreatedAfter != nil && t.createdAt.Before(*filter.CreatedAfter) {
+			return false
+		}
+		if filter.UpdatedBefore != nil && !t.updatedAt.Before(*filter.UpdatedBefore) {
+			return false
+		}
+		if filter.UpdatedAfter != nil && t.updatedAt.Before(*filter.UpdatedAfter) {
+			return false
+		}
+		return true
+	}, nil
+}
+
+type memoryCategoryRepository struct {
+	store *MemoryStore
+}
+
+// List は全てのカテゴリを作成日時の昇順で返す
+func (r memoryCategoryRepository) List(ctx context.Context) ([]types.CategoryResponse, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	categories := make([]*memoryCategory, 0, len(r.store.categories))
+	for _, c := range r.store.categories {
+		categories = append(categories, c)
+	}
+	slices.SortFunc(categories, func(a, b *memoryCategory) int {
+		return a.createdAt.Compare(b.createdAt)
+	})
+
+	responses := make([]types.CategoryResponse, len(categories))
+	for i, c := range categories {
+		responses[i] = c.response()
+	}
+	return responses, nil
+}
+
+// Get はカテゴリを返す
+func (r memoryCategoryRepository) Get(ctx context.Context, id uuid.UUID) (*types.CategoryResponse, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	c, ok := r.store.categories[id]
+	if !ok {
+		return nil, utils.ErrCategoryNotFoundByID
+	}
+	response := c.response()
+	return &response, nil
+}
+
+// Exists はカテゴリが存在するかを返す
+func (r memoryCategoryRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	_, ok := r.store.categories[id]
+	return ok, nil
+}
+
+// Create はカテゴリを作成する
+func (r memoryCategoryRepository) Create(ctx context.Context, input types.CategoryInput) (*types.CategoryResponse, error) {
+	now := time.Now()
+	c := &memoryCategory{
+		id:        uuid.New(),
+		name:      input.Name,
+		color:     *input.Color,
+		createdAt: now,
+		updatedAt: now,
+	}
+	if input.Description != nil {
+		c.description = *input.Description
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.categories[c.id] = c
+	response := c.response()
+	return &response, nil
+}
+
+// Update はカテゴリを更新する
+func (r memoryCategoryRepository) Update(ctx context.Context, id uuid.UUID, input types.CategoryInput) (*types.CategoryResponse, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	c, ok := r.store.categories[id]
+	if !ok {
+		return nil, utils.ErrCategoryNotFoundByID
+	}
+	c.name = input.Name
+	if input.Description != nil {
+		c.description = *input.Description
+	}
+	if input.Color != nil {
+		c.color = *input.Color
+	}
+	c.updatedAt = time.Now()
+	response := c.response()
+	return &response, nil
+}
+
+// Delete はカテゴリを削除し、所属していた Todo のカテゴリを解除する
+func (r memoryCategoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.categories[id]; !ok {
+		return utils.ErrCategoryNotFoundByID
+	}
+	now := time.Now()
+	for _, t := range r.store.todos {
+		if t.categoryID != nil && *t.categoryID == id {
+			t.categoryID = nil
+			t.version++
+			t.updatedAt = now
+		}
+	}
+	delete(r.store.categories, id)
+	return nil
+}
+
+// response はカテゴリをレスポンス形式に変換する（utils.ConvertToCategoryResponse と同じ規則）
+func (c *memoryCategory) response() types.CategoryResponse {
+	response := types.CategoryResponse{
+		ID:        c.id,
+		Name:      c.name,
+		Color:     c.color,
+		CreatedAt: c.createdAt,
+	}
+	if c.description != "" {
+		description := c.description
+		response.Description = &description
+	}
+	updatedAt := c.updatedAt
+	response.UpdatedAt = &updatedAt
+	return response
+}
+
+// cloneUUID は任意の UUID の複製を返す
+func cloneUUID(id *uuid.UUID) *uuid.UUID {
+	if id == nil {
+		return nil
+	}
+	clone := *id
+	return &clone
+}
+
+type memoryIdempotencyKeyRepository struct {
+	store *MemoryStore
+}
+
+// memoryIdempotencyKey は記録を保存する map のキーを返す
+func memoryIdempotencyKey(actor, key string) [2]string {
+	return [2]string{actor, key}
+}
+
+// Acquire はキーを処理中として登録する
+func (r memoryIdempotencyKeyRepository) Acquire(ctx context.Context, now time.Time, key IdempotencyKey) (uuid.UUID, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for k, record := range r.store.idempotencyKeys {
+		if record.ExpiresAt.Before(now) {
+			delete(r.store.idempotencyKeys, k)
+		}
+	}
+
+	existing, ok := r.store.idempotencyKeys[memoryIdempotencyKey(key.Actor, key.Key)]
+	if !ok {
+		key.ID = uuid.New()
+		key.Completed = false
+		r.store.idempotencyKeys[memoryIdempotencyKey(key.Actor, key.Key)] = &key
+		return key.ID, nil
+	}
+	if existing.Fingerprint != key.Fingerprint || existing.Completed ||
+		(existing.LockedUntil != nil && !existing.LockedUntil.Before(now)) {
+		return uuid.Nil, nil
+	}
+	existing.LockedUntil = key.LockedUntil
+	return existing.ID, nil
+}
+
+// Get はキーの記録を返す
+func (r memoryIdempotencyKeyRepository) Get(ctx context.Context, actor, key string) (*IdempotencyKey, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	record, ok := r.store.idempotencyKeys[memoryIdempotencyKey(actor, key)]
+	if !ok {
+		return nil, nil
+	}
+	copied := *record
+	return &copied, nil
+}
+
+// Complete はレスポンスを保存してキーを完了にする
+func (r memoryIdempotencyKeyRepository) Complete(ctx context.Context, id uuid.UUID, status int, contentType string, body []byte) error {
+	return r.update(id, func(record *IdempotencyKey) {
+		record.Completed = true
+		record.LockedUntil = nil
+		record.ResponseStatus = status
+		record.ResponseContentType = contentType
+		record.ResponseBody = slices.Clone(body)
+	})
+}
+
+// Lock はキーのロックを延ばす
+func (r memoryIdempotencyKeyRepository) Lock(ctx context.Context, id uuid.UUID, until time.Time) error {
+	return r.update(id, func(record *IdempotencyKey) {
+		record.LockedUntil = &until
+	})
+}
+
+// Release はキーを削除する
+func (r memoryIdempotencyKeyRepository) Release(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for k, record := range r.store.idempotencyKeys {
+		if record.ID == id {
+			delete(r.store.idempotencyKeys, k)
+		}
+	}
+	return nil
+}
+
+// update は id の記録を fn で更新する
+func (r memoryIdempotencyKeyRepository) update(id uuid.UUID, fn func(record *IdempotencyKey)) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, record := range r.store.idempotencyKeys {
+		if record.ID == id {
+			fn(record)
+			return nil
+		}
+	}
+	return fmt.Errorf("idempotency key %s not found", id)
+}
@@ -0,0 +1,128 @@
+// Package repository は Todo・カテゴリ・Idempotency-Key の記録の永続化を抽象化する
+//
+// サービス（service パッケージ）はインターフェースを通して永続化を行うため、
+// データベースを使う Ent の実装と、テスト・デモ用のインメモリの実装を切り替えられる。
+// 対象が存在しない場合は utils の API エラー（utils.ErrTodoNotFound など）を返す。
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+// Todo の並び替えの項目
+const (
+	TodoSortCreatedAt = "createdAt"
+	TodoSortUpdatedAt = "updatedAt"
+	TodoSortTitle     = "title"
+)
+
+// Todo の並び順
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+// CategoryIDNone は絞り込み条件でカテゴリ未設定の Todo を指定する値
+const CategoryIDNone = "none"
+
+// TodoListOptions は Todo の一覧の絞り込み・並び替え・ページングの条件を表す
+type TodoListOptions struct {
+	Filter types.TodoFilter
+	// Sort は並び替えの項目（空の場合は TodoSortCreatedAt）
+	Sort string
+	// Order は並び順（空の場合は OrderDesc）。同じ値の Todo は ID の昇順に並べる
+	Order string
+	// Limit は取得件数（0 の場合は全件）
+	Limit  int
+	Offset int
+}
+
+// TodoBulkUpdate は一括更新で条件に一致する Todo に適用する変更を表す
+type TodoBulkUpdate struct {
+	Completed *bool
+	// CategoryID は設定するカテゴリ。nil で ClearCategory が true の場合はカテゴリを解除する
+	CategoryID    *uuid.UUID
+	ClearCategory bool
+}
+
+// TodoRepository は Todo の永続化を行う
+//
+// 入力は service.TodoService が検証してから渡す。
+type TodoRepository interface {
+	// List は条件に一致する Todo のページと、ページングを除いた総件数を返す
+	List(ctx context.Context, opts TodoListOptions) ([]types.TodoResponse, int, error)
+	// Get は Todo を返す
+	Get(ctx context.Context, id uuid.UUID) (*types.TodoResponse, error)
+	// Create は入力から Todo を作成する（categoryID は input.CategoryID を解析したもの）
+	// id が uuid.Nil の場合は新しいIDを割り当てる
+	Create(ctx context.Context, id uuid.UUID, input types.TodoInput, categoryID *uuid.UUID) (*types.TodoResponse, error)
+	// Update は入力で Todo を更新する
+	// 省略した項目は変更せず、空文字列の説明・カテゴリID・期限・繰り返しルールはそれぞれの解除として扱う
+	// expectedVersion を指定した場合、Todo のバージョンが一致しなければ utils.ErrVersionConflict を返す
+	Update(ctx context.Context, id uuid.UUID, input types.TodoInput, categoryID *uuid.UUID, expectedVersion *int) (*types.TodoResponse, error)
+	// Delete は Todo を削除する
+	// expectedVersion を指定した場合、Todo のバージョンが一致しなければ utils.ErrVersionConflict を返す
+	Delete(ctx context.Context, id uuid.UUID, expectedVersion *int) error
+	// UpdateMatching は条件に一致する全ての Todo に update を適用し、更新した件数を返す
+	UpdateMatching(ctx context.Context, filter types.TodoFilter, update TodoBulkUpdate) (int, error)
+	// DeleteMatching は条件に一致する全ての Todo を削除し、削除した件数を返す
+	DeleteMatching(ctx context.Context, filter types.TodoFilter) (int, error)
+}
+
+// CategoryRepository はカテゴリの永続化を行う
+//
+// 入力は service.CategoryService が検証し、デフォルト値を補ってから渡す。
+type CategoryRepository interface {
+	// List は全てのカテゴリを作成日時の昇順で返す
+	List(ctx context.Context) ([]types.CategoryResponse, error)
+	// Get はカテゴリを返す
+	Get(ctx context.Context, id uuid.UUID) (*types.CategoryResponse, error)
+	// Exists はカテゴリが存在するかを返す
+	Exists(ctx context.Context, id uuid.UUID) (bool, error)
+	// Create は入力からカテゴリを作成する（input.Color は必須）
+	Create(ctx context.Context, input types.CategoryInput) (*types.CategoryResponse, error)
+	// Update は入力でカテゴリを更新する
+	// 空文字列の説明は解除として扱い、カラーコードは指定された場合のみ更新する
+	Update(ctx context.Context, id uuid.UUID, input types.CategoryInput) (*types.CategoryResponse, error)
+	// Delete はカテゴリを削除し、所属していた Todo のカテゴリを解除する
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// IdempotencyKey は Idempotency-Key で受け付けたリクエストの記録を表す
+type IdempotencyKey struct {
+	ID          uuid.UUID
+	Actor       string
+	Key         string
+	Fingerprint string
+	// Completed はレスポンスを保存済みかを表す。false の場合は処理中
+	Completed bool
+	// LockedUntil は処理中のキーのロックの期限
+	LockedUntil *time.Time
+	ExpiresAt   time.Time
+
+	ResponseStatus      int
+	ResponseContentType string
+	ResponseBody        []byte
+}
+
+// IdempotencyKeyRepository は Idempotency-Key の記録の永続化を行う
+//
+// キーは操作者（Actor）ごとに一意で、複数のレプリカが同じキーを同時に登録しても1つだけが成功する。
+type IdempotencyKeyRepository interface {
+	// Acquire は時刻 now に key を処理中として登録し、そのIDを返す
+	// 有効期限を過ぎたキーは登録の前に削除する。同じキーが既に存在する場合、ロックの期限を過ぎた同じ内容
+	// （Fingerprint）の処理中のキーは key.LockedUntil までロックして引き継ぎ、それ以外は uuid.Nil を返す
+	Acquire(ctx context.Context, now time.Time, key IdempotencyKey) (uuid.UUID, error)
+	// Get は actor の key の記録を返す。存在しない場合は nil を返す
+	Get(ctx context.Context, actor, key string) (*IdempotencyKey, error)
+	// Complete はレスポンスを保存してキーを完了にする
+	Complete(ctx context.Context, id uuid.UUID, status int, contentType string, body []byte) error
+	// Lock は処理中のキーのロックを until まで延ばす
+	Lock(ctx context.Context, id uuid.UUID, until time.Time) error
+	// Release はキーを削除する。キーが存在しない場合は何もしない
+	Release(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/openapi"
+	"github.com/t-okuji/go-openapi-todo-demo/repository"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// DefaultCategoryColor はカラーコードを省略して作成したカテゴリの色
+const DefaultCategoryColor = "#6c757d"
+
+// CategoryService はカテゴリの業務ルールを実装する
+//
+// 名前・カラーコードの形式は API 仕様のスキーマで検証済みの入力を受け取る。
+type CategoryService struct {
+	categories repository.CategoryRepository
+}
+
+// NewCategoryService は CategoryService を作成する
+func NewCategoryService(categories repository.CategoryRepository) *CategoryService {
+	return &CategoryService{categories: categories}
+}
+
+// List は全てのカテゴリを返す
+func (s *CategoryService) List(ctx context.Context) ([]types.CategoryResponse, error) {
+	return s.categories.List(ctx)
+}
+
+// Get はカテゴリを返す
+func (s *CategoryService) Get(ctx context.Context, id uuid.UUID) (*types.CategoryResponse, error) {
+	return s.categories.Get(ctx, id)
+}
+
+// Exists はカテゴリが存在するかを返す
+func (s *CategoryService) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
+	return s.categories.Exists(ctx, id)
+}
+
+// Create はカテゴリを作成する
+// カラーコードを省略した場合は DefaultCategoryColor を使う
+func (s *CategoryService) Create(ctx context.Context, input types.CategoryInput) (*types.CategoryResponse, error) {
+	if input.Color == nil {
+		defaultColor := DefaultCategoryColor
+		input.Color = &defaultColor
+	}
+	return s.categories.Create(ctx, input)
+}
+
+// Update はカテゴリを更新する
+func (s *CategoryService) Update(ctx context.Context, id uuid.UUID, input types.CategoryInput) (*types.CategoryResponse, error) {
+	exists, err := s.categories.Exists(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, utils.ErrCategoryNotFoundByID
+	}
+	return s.categories.Update(ctx, id, input)
+}
+
+// Delete はカテゴリを削除し、所属していた Todo のカテゴリを解除する
+func (s *CategoryService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.categories.Delete(ctx, id)
+}
+
+// ValidateCategoryInput はカテゴリの作成・更新入力を API 仕様の CategoryInput のスキーマで検証する
+// REST API のリクエストは handlers.ValidateRequests が検証するため、gRPC・同期・インポートの入力に使う
+func ValidateCategoryInput(input types.CategoryInput) error {
+	return validateSchema("CategoryInput", input)
+}
+
+// validateSchema は value を API 仕様の components の name のスキーマで検証する
+// 違反は REST API と同じ項目ごとのエラー（VALIDATION_ERROR）で返す
+func validateSchema(name string, value any) error {
+	err := openapi.ValidateSchema(name, value)
+	if err == nil {
+		return nil
+	}
+	var (
+		schemaErr *openapi3.SchemaError
+		multi     openapi3.MultiError
+	)
+	if !errors.As(err, &schemaErr) && !errors.As(err, &multi) {
+		return err
+	}
+	return utils.NewValidationError(openapi.FieldErrors("", err, ""))
+}
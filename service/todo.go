@@ -0,0 +1,262 @@
+// Package service は Todo・カテゴリの業務ルールを実装する
+//
+// 入力の検証・カテゴリの存在確認・デフォルト値の設定を行い、永続化は repository のインターフェースに任せる。
+// REST API・gRPC のハンドラーはサービスを通して Todo・カテゴリを操作する。
+// エラーは utils の API エラー（utils.APIError）で返す。
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/recurrence"
+	"github.com/t-okuji/go-openapi-todo-demo/repository"
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+	"github.com/t-okuji/go-openapi-todo-demo/utils"
+)
+
+// MaxTodoListLimit は Todo の一覧で一度に取得できる最大件数
+const MaxTodoListLimit = 1000
+
+// BulkSampleSize は一括操作の dryRun で返す対象IDのサンプル数
+const BulkSampleSize = 20
+
+var (
+	errBulkFilterRequired = &utils.APIError{Status: http.StatusBadRequest, Code: "INVALID_REQUEST", Message: "filter must have at least one condition"}
+	errBulkUpdateRequired = &utils.APIError{Status: http.StatusBadRequest, Code: "INVALID_REQUEST", Message: "update must have at least one field"}
+)
+
+// TodoService は Todo の業務ルールを実装する
+type TodoService struct {
+	todos      repository.TodoRepository
+	categories repository.CategoryRepository
+}
+
+// NewTodoService は TodoService を作成する
+// categories は入力で指定されたカテゴリの存在確認に使う
+func NewTodoService(todos repository.TodoRepository, categories repository.CategoryRepository) *TodoService {
+	return &TodoService{todos: todos, categories: categories}
+}
+
+// List は条件に一致する Todo のページと総件数を返す
+func (s *TodoService) List(ctx context.Context, opts repository.TodoListOptions) ([]types.TodoResponse, int, error) {
+	switch opts.Sort {
+	case "", repository.TodoSortCreatedAt, repository.TodoSortUpdatedAt, repository.TodoSortTitle:
+	default:
+		return nil, 0, utils.NewInvalidParameterError("sort must be one of createdAt, updatedAt, title")
+	}
+	switch opts.Order {
+	case "", repository.OrderAsc, repository.OrderDesc:
+	default:
+		return nil, 0, utils.NewInvalidParameterError("order must be asc or desc")
+	}
+	if opts.Limit < 0 || opts.Limit > MaxTodoListLimit {
+		return nil, 0, utils.NewInvalidParameterError(fmt.Sprintf("limit must be an integer between 0 and %d", MaxTodoListLimit))
+	}
+	if opts.Offset < 0 {
+		return nil, 0, utils.NewInvalidParameterError("offset must be a non-negative integer")
+	}
+	if err := validateTodoFilter(opts.Filter); err != nil {
+		return nil, 0, err
+	}
+
+	return s.todos.List(ctx, opts)
+}
+
+// Get は Todo を返す
+func (s *TodoService) Get(ctx context.Context, id uuid.UUID) (*types.TodoResponse, error) {
+	return s.todos.Get(ctx, id)
+}
+
+// Create は入力を検証して Todo を作成する
+func (s *TodoService) Create(ctx context.Context, input types.TodoInput) (*types.TodoResponse, error) {
+	return s.CreateWithID(ctx, uuid.Nil, input)
+}
+
+// CreateWithID は入力を検証し、id の Todo を作成する
+// 同期APIのようにクライアントがIDを割り当てる場合に使う。id が uuid.Nil の場合は Create と同じ
+func (s *TodoService) CreateWithID(ctx context.Context, id uuid.UUID, input types.TodoInput) (*types.TodoResponse, error) {
+	categoryID, err := ValidateTodoInput(ctx, s.categories, input)
+	if err != nil {
+		return nil, err
+	}
+	return s.todos.Create(ctx, id, input, categoryID)
+}
+
+// Update は入力を検証して Todo を更新する
+// Todo が存在しない場合は、入力の検証より先に utils.ErrTodoNotFound を返す
+func (s *TodoService) Update(ctx context.Context, id uuid.UUID, input types.TodoInput) (*types.TodoResponse, error) {
+	return s.UpdateIfVersion(ctx, id, input, nil)
+}
+
+// UpdateIfVersion は Todo のバージョンが expectedVersion の場合のみ更新する
+// バージョンが一致しない場合は utils.ErrVersionConflict を返す。expectedVersion が nil の場合は Update と同じ
+func (s *TodoService) UpdateIfVersion(ctx context.Context, id uuid.UUID, input types.TodoInput, expectedVersion *int) (*types.TodoResponse, error) {
+	if _, err := s.todos.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	categoryID, err := ValidateTodoInput(ctx, s.categories, input)
+	if err != nil {
+		return nil, err
+	}
+	return s.todos.Update(ctx, id, input, categoryID, expectedVersion)
+}
+
+// Delete は Todo を削除する
+func (s *TodoService) Delete(ctx context.Context, id uuid.UUID) error {
+	return s.DeleteIfVersion(ctx, id, nil)
+}
+
+// DeleteIfVersion は Todo のバージョンが expectedVersion の場合のみ削除する
+// バージョンが一致しない場合は utils.ErrVersionConflict を返す。expectedVersion が nil の場合は Delete と同じ
+func (s *TodoService) DeleteIfVersion(ctx context.Context, id uuid.UUID, expectedVersion *int) error {
+	return s.todos.Delete(ctx, id, expectedVersion)
+}
+
+// BulkUpdate は条件に一致する全ての Todo に update を適用し、更新した件数を返す
+// dryRun の場合は更新せず、対象の件数と作成日時の古い順に BulkSampleSize 件の対象IDを返す
+// 誤って全件を対象にしないよう、絞り込み条件がひとつもない場合はエラーとする
+func (s *TodoService) BulkUpdate(ctx context.Context, filter types.TodoFilter, update types.BulkTodoUpdate, dryRun bool) (*types.BulkResponse, error) {
+	if err := validateBulkFilter(filter); err != nil {
+		return nil, err
+	}
+	if update.Completed == nil && update.CategoryID == nil {
+		return nil, errBulkUpdateRequired
+	}
+	change := repository.TodoBulkUpdate{Completed: update.Completed}
+	if update.CategoryID != nil {
+		categoryID, err := validateCategoryID(ctx, s.categories, update.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		change.CategoryID = categoryID
+		change.ClearCategory = categoryID == nil
+	}
+
+	if dryRun {
+		return s.bulkPreview(ctx, filter)
+	}
+	affected, err := s.todos.UpdateMatching(ctx, filter, change)
+	if err != nil {
+		return nil, err
+	}
+	return &types.BulkResponse{Affected: affected}, nil
+}
+
+// BulkDelete は条件に一致する全ての Todo を削除し、削除した件数を返す
+// dryRun・絞り込み条件の扱いは BulkUpdate と同じ
+func (s *TodoService) BulkDelete(ctx context.Context, filter types.TodoFilter, dryRun bool) (*types.BulkResponse, error) {
+	if err := validateBulkFilter(filter); err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return s.bulkPreview(ctx, filter)
+	}
+	affected, err := s.todos.DeleteMatching(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &types.BulkResponse{Affected: affected}, nil
+}
+
+// bulkPreview は一括操作の対象件数と対象IDのサンプルを返す
+func (s *TodoService) bulkPreview(ctx context.Context, filter types.TodoFilter) (*types.BulkResponse, error) {
+	sample, affected, err := s.todos.List(ctx, repository.TodoListOptions{
+		Filter: filter,
+		Sort:   repository.TodoSortCreatedAt,
+		Order:  repository.OrderAsc,
+		Limit:  BulkSampleSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	response := &types.BulkResponse{DryRun: true, Affected: affected}
+	if len(sample) > 0 {
+		ids := make([]uuid.UUID, len(sample))
+		for i, t := range sample {
+			ids[i] = t.ID
+		}
+		response.SampleIds = &ids
+	}
+	return response, nil
+}
+
+// validateTodoFilter は絞り込み条件のカテゴリIDを検証する
+func validateTodoFilter(filter types.TodoFilter) error {
+	if id := filter.CategoryID; id != nil && *id != repository.CategoryIDNone {
+		if _, err := uuid.Parse(*id); err != nil {
+			return utils.ErrInvalidUUID
+		}
+	}
+	return nil
+}
+
+// validateBulkFilter は一括操作の絞り込み条件を検証する
+// 空文字列のキーワードは条件として扱わない（repository.TodoPredicates と同じ）
+func validateBulkFilter(filter types.TodoFilter) error {
+	if err := validateTodoFilter(filter); err != nil {
+		return err
+	}
+	if filter.Completed == nil && filter.CategoryID == nil && (filter.Search == nil || *filter.Search == "") &&
+		filter.CreatedBefore == nil && filter.CreatedAfter == nil && filter.UpdatedBefore == nil && filter.UpdatedAfter == nil {
+		return errBulkFilterRequired
+	}
+	return nil
+}
+
+// ValidateTodoInput は Todo の作成・更新入力を検証し、指定されたカテゴリのIDを返す
+// カテゴリIDが未指定または空文字列の場合は nil を返す
+func ValidateTodoInput(ctx context.Context, categories repository.CategoryRepository, input types.TodoInput) (*uuid.UUID, error) {
+	// タイトルの検証
+	if input.Title == "" {
+		return nil, utils.ErrTitleRequired
+	}
+
+	// 期限の検証
+	if input.DueAt != nil && *input.DueAt != "" {
+		if _, err := time.Parse(time.RFC3339, *input.DueAt); err != nil {
+			return nil, utils.ErrInvalidDueAt
+		}
+	}
+
+	// 繰り返しルール・タイムゾーンの検証
+	if input.Recurrence != nil && *input.Recurrence != "" {
+		if _, err := recurrence.Parse(*input.Recurrence); err != nil {
+			return nil, &utils.APIError{Status: http.StatusBadRequest, Code: "VALIDATION_ERROR", Message: err.Error()}
+		}
+	}
+	if input.TimeZone != nil {
+		if _, err := recurrence.LoadLocation(*input.TimeZone); err != nil {
+			return nil, utils.ErrInvalidTimeZone
+		}
+	}
+
+	return validateCategoryID(ctx, categories, input.CategoryID)
+}
+
+// validateCategoryID は入力で指定されたカテゴリIDを検証し、存在するカテゴリのIDを返す
+// 未指定または空文字列の場合は nil を返す
+func validateCategoryID(ctx context.Context, categories repository.CategoryRepository, id *string) (*uuid.UUID, error) {
+	if id == nil || *id == "" {
+		return nil, nil
+	}
+
+	categoryID, err := uuid.Parse(*id)
+	if err != nil {
+		return nil, utils.ErrInvalidUUID
+	}
+
+	// カテゴリの存在確認
+	exists, err := categories.Exists(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, utils.ErrCategoryNotFound
+	}
+
+	return &categoryID, nil
+}
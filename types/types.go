@@ -1,47 +1,173 @@
 package types
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/t-okuji/go-openapi-todo-demo/api"
+)
 
 // TodoResponse は API レスポンス用の Todo エンティティを表す
-type TodoResponse struct {
-	ID          string     `json:"id"`
-	Title       string     `json:"title"`
-	Description *string    `json:"description,omitempty"`
-	Completed   bool       `json:"completed"`
-	CategoryID  *string    `json:"categoryId,omitempty"`
-	CreatedAt   time.Time  `json:"createdAt"`
-	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
-}
+type TodoResponse = api.Todo
 
 // TodoInput は API リクエスト用の Todo 入力データを表す
-type TodoInput struct {
-	Title       string  `json:"title"`
-	Description *string `json:"description,omitempty"`
-	Completed   *bool   `json:"completed,omitempty"`
-	CategoryID  *string `json:"categoryId,omitempty"`
-}
+type TodoInput = api.TodoInput
 
 // CategoryResponse は API レスポンス用の Category エンティティを表す
-type CategoryResponse struct {
-	ID          string     `json:"id"`
-	Name        string     `json:"name"`
-	Description *string    `json:"description,omitempty"`
-	Color       string     `json:"color"`
-	CreatedAt   time.Time  `json:"createdAt"`
-	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
-}
+type CategoryResponse = api.Category
 
 // CategoryInput は API リクエスト用の Category 入力データを表す
-type CategoryInput struct {
-	Name        string  `json:"name"`
-	Description *string `json:"description,omitempty"`
-	Color       *string `json:"color,omitempty"`
-}
+type CategoryInput = api.CategoryInput
+
+// ErrorDetail は API エラーのコードとメッセージを表す
+type ErrorDetail = api.ErrorDetail
 
 // ErrorResponse は API エラーレスポンスを表す
-type ErrorResponse struct {
-	Error struct {
-		Code    string `json:"code"`
-		Message string `json:"message"`
-	} `json:"error"`
+type ErrorResponse = api.Error
+
+// FieldError は項目ごとの検証エラーを表す
+type FieldError = api.FieldError
+
+// FieldChange は監査ログに記録されるフィールド単位の変更前後の値を表す
+type FieldChange = api.FieldChange
+
+// AuditEventResponse は API レスポンス用の監査イベントを表す
+type AuditEventResponse = api.AuditEvent
+
+// AuditEventListResponse は監査イベント一覧のページングされたレスポンスを表す
+type AuditEventListResponse = api.AuditEventList
+
+// RescheduleTodoRequest は繰り返しの Todo の期限変更リクエストを表す
+type RescheduleTodoRequest = api.RescheduleTodoRequest
+
+// TodoState はリビジョン時点の Todo の状態を表す
+type TodoState = api.TodoState
+
+// TodoRevisionResponse は API レスポンス用の Todo のリビジョンを表す
+type TodoRevisionResponse = api.TodoRevision
+
+// BatchOperation は一括処理APIの個々の操作を表す
+type BatchOperation = api.BatchOperation
+
+// BatchRequest は POST /todos:batch のリクエストを表す
+type BatchRequest = api.BatchRequest
+
+// BatchOperationResult は一括処理APIの個々の操作結果を表す
+type BatchOperationResult = api.BatchOperationResult
+
+// BatchResponse は POST /todos:batch のレスポンスを表す
+type BatchResponse = api.BatchResponse
+
+// TodoFilter は Todo 一覧取得・一括操作で共通して使用する絞り込み条件を表す
+type TodoFilter = api.TodoFilter
+
+// BulkTodoUpdate は一括更新で適用する変更内容を表す
+type BulkTodoUpdate = api.BulkTodoUpdate
+
+// BulkUpdateRequest は POST /todos:bulkUpdate のリクエストを表す
+type BulkUpdateRequest = api.BulkUpdateRequest
+
+// BulkDeleteRequest は POST /todos:bulkDelete のリクエストを表す
+type BulkDeleteRequest = api.BulkDeleteRequest
+
+// BulkResponse は一括更新・一括削除のレスポンスを表す
+type BulkResponse = api.BulkResponse
+
+// ReminderInput は API リクエスト用のリマインダーの入力データを表す
+type ReminderInput = api.ReminderInput
+
+// ReminderResponse は API レスポンス用のリマインダーを表す
+type ReminderResponse = api.Reminder
+
+// WebhookInput は API リクエスト用の Webhook 購読の入力データを表す
+type WebhookInput = api.WebhookInput
+
+// WebhookResponse は API レスポンス用の Webhook 購読を表す
+type WebhookResponse = api.Webhook
+
+// WebhookPayload は Webhook で送信するリクエストボディを表す
+type WebhookPayload = api.WebhookPayload
+
+// WebhookDeliveryResponse は API レスポンス用の Webhook の配信記録を表す
+type WebhookDeliveryResponse = api.WebhookDelivery
+
+// WebhookDeliveryListResponse は Webhook の配信記録一覧のページングされたレスポンスを表す
+type WebhookDeliveryListResponse = api.WebhookDeliveryList
+
+// WSClientMessage は WebSocket でクライアントから受信するメッセージを表す
+type WSClientMessage struct {
+	Type      string `json:"type"`
+	RequestID string `json:"requestId,omitempty"`
+	// CategoryID は subscribe で購読するカテゴリ（省略時はワークスペース全体）
+	CategoryID *string `json:"categoryId,omitempty"`
+	// TodoID は update・delete の対象
+	TodoID string `json:"todoId,omitempty"`
+	// Version は update・delete で期待する Todo のバージョン（省略時は確認しない）
+	Version *int       `json:"version,omitempty"`
+	Todo    *TodoInput `json:"todo,omitempty"`
 }
+
+// WSViewer は WebSocket で同じ範囲を閲覧している接続を表す
+type WSViewer struct {
+	ConnectionID string    `json:"connectionId"`
+	Actor        string    `json:"actor"`
+	Since        time.Time `json:"since"`
+}
+
+// WSServerMessage は WebSocket でクライアントに送信するメッセージを表す
+type WSServerMessage struct {
+	Type         string        `json:"type"`
+	RequestID    string        `json:"requestId,omitempty"`
+	ConnectionID string        `json:"connectionId,omitempty"`
+	Actor        string        `json:"actor,omitempty"`
+	CategoryID   *string       `json:"categoryId,omitempty"`
+	Todo         *TodoResponse `json:"todo,omitempty"`
+	// EventID・Event・Data は変更イベントの ID・種別・内容
+	EventID uint64          `json:"eventId,omitempty"`
+	Event   string          `json:"event,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	Viewers []WSViewer      `json:"viewers,omitempty"`
+	Error   *ErrorDetail    `json:"error,omitempty"`
+}
+
+// SyncTombstone は同期APIで削除されたエンティティを表す
+type SyncTombstone = api.SyncTombstone
+
+// SyncResponse は GET /sync のレスポンスを表す
+type SyncResponse = api.SyncResponse
+
+// SyncChange はオフライン中にクライアントで行われた変更を表す
+type SyncChange = api.SyncChange
+
+// SyncPushRequest は POST /sync のリクエストを表す
+type SyncPushRequest = api.SyncPushRequest
+
+// SyncConflict は同期APIで競合したフィールドと解決結果を表す
+type SyncConflict = api.SyncConflict
+
+// SyncChangeResult は同期APIで送信された個々の変更の適用結果を表す
+type SyncChangeResult = api.SyncChangeResult
+
+// SyncPushResponse は POST /sync のレスポンスを表す
+type SyncPushResponse = api.SyncPushResponse
+
+// ExportTodo はエクスポートする Todo を表す（カテゴリ名を含む）
+type ExportTodo = api.ExportTodo
+
+// ExportDocument は GET /export?format=json で出力するドキュメントを表す
+type ExportDocument = api.ExportDocument
+
+// ExportRecord は GET /export?format=ndjson で出力する1行を表す
+type ExportRecord = api.ExportRecord
+
+// ImportRowResult は取り込みファイルの1件ごとの結果を表す
+type ImportRowResult = api.ImportRowResult
+
+// ImportResponse は POST /import のレスポンスを表す
+type ImportResponse = api.ImportResponse
+
+// CalendarTokenInput はカレンダー配信用トークンの発行リクエスト（配信の対象）を表す
+type CalendarTokenInput = api.CalendarTokenInput
+
+// CalendarTokenResponse は発行したカレンダー配信用トークンを表す
+type CalendarTokenResponse = api.CalendarToken
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// DefaultActor は操作者が特定できないリクエストに割り当てる操作者名
+const DefaultActor = "anonymous"
+
+type actorContextKey struct{}
+
+// WithActor は操作者名をコンテキストに設定する
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext はコンテキストから操作者名を取得する
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorContextKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return DefaultActor
+}
+
+// RequestIDFromContext はコンテキストからリクエストIDを取得する
+func RequestIDFromContext(ctx context.Context) string {
+	return middleware.GetReqID(ctx)
+}
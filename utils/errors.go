@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/t-okuji/go-openapi-todo-demo/types"
+)
+
+// APIError は HTTP ステータスコードとエラーコードを持つ API エラーを表す
+type APIError struct {
+	Status  int
+	Code    string
+	Message string
+	// Details は項目ごとの検証エラー（VALIDATION_ERROR の場合のみ）
+	Details []types.FieldError
+}
+
+// Error は error インターフェースを実装する
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// エラーカタログ
+var (
+	ErrInvalidJSON             = &APIError{Status: http.StatusBadRequest, Code: "INVALID_JSON", Message: "Invalid JSON format"}
+	ErrInvalidUUID             = &APIError{Status: http.StatusBadRequest, Code: "INVALID_UUID", Message: "Invalid UUID format"}
+	ErrTitleRequired           = &APIError{Status: http.StatusBadRequest, Code: "INVALID_REQUEST", Message: "Title is required"}
+	ErrInvalidDueAt            = &APIError{Status: http.StatusBadRequest, Code: "VALIDATION_ERROR", Message: "dueAt must be an RFC 3339 date-time"}
+	ErrInvalidTimeZone         = &APIError{Status: http.StatusBadRequest, Code: "VALIDATION_ERROR", Message: "timeZone must be an IANA time zone name"}
+	ErrRecurrenceRequiresDueAt = &APIError{Status: http.StatusBadRequest, Code: "VALIDATION_ERROR", Message: "dueAt is required for a recurring Todo"}
+	ErrCategoryNotFound        = &APIError{Status: http.StatusBadRequest, Code: "CATEGORY_NOT_FOUND", Message: "Specified category not found"}
+	ErrCategoryNotFoundByID    = &APIError{Status: http.StatusNotFound, Code: "NOT_FOUND", Message: "Category not found"}
+	ErrTodoNotFound            = &APIError{Status: http.StatusNotFound, Code: "TODO_NOT_FOUND", Message: "Specified Todo not found"}
+	ErrVersionConflict         = &APIError{Status: http.StatusConflict, Code: "VERSION_CONFLICT", Message: "Todo was modified by another request"}
+	ErrTodoNotRecurring        = &APIError{Status: http.StatusConflict, Code: "TODO_NOT_RECURRING", Message: "Todo does not recur"}
+	ErrTodoCompleted           = &APIError{Status: http.StatusConflict, Code: "TODO_COMPLETED", Message: "Todo is already completed"}
+	ErrRecurrenceEnded         = &APIError{Status: http.StatusConflict, Code: "RECURRENCE_ENDED", Message: "Recurrence has no more occurrences"}
+	ErrWebhookNotFound         = &APIError{Status: http.StatusNotFound, Code: "WEBHOOK_NOT_FOUND", Message: "Specified webhook not found"}
+	ErrDeliveryNotFound        = &APIError{Status: http.StatusNotFound, Code: "DELIVERY_NOT_FOUND", Message: "Specified webhook delivery not found"}
+	ErrInvalidSyncToken        = &APIError{Status: http.StatusBadRequest, Code: "INVALID_SYNC_TOKEN", Message: "Invalid sync token"}
+	ErrInvalidSyncCursor       = &APIError{Status: http.StatusBadRequest, Code: "INVALID_SYNC_CURSOR", Message: "Invalid sync cursor"}
+	ErrInvalidCalendarToken    = &APIError{Status: http.StatusUnauthorized, Code: "INVALID_CALENDAR_TOKEN", Message: "Invalid calendar feed token"}
+	ErrCalendarTokenNotFound   = &APIError{Status: http.StatusNotFound, Code: "CALENDAR_TOKEN_NOT_FOUND", Message: "Calendar feed token has not been issued"}
+	ErrInvalidCalendarScope    = &APIError{Status: http.StatusBadRequest, Code: "VALIDATION_ERROR", Message: "exactly one of filter and allTodos is required"}
+	ErrInvalidReminder         = &APIError{Status: http.StatusBadRequest, Code: "VALIDATION_ERROR", Message: "exactly one of remindAt and offsetMinutes is required"}
+	ErrInvalidReminderOffset   = &APIError{Status: http.StatusBadRequest, Code: "VALIDATION_ERROR", Message: "offsetMinutes must be between -527040 and 527040"}
+	ErrReminderNotFound        = &APIError{Status: http.StatusNotFound, Code: "REMINDER_NOT_FOUND", Message: "Specified reminder not found"}
+	ErrSyncNotSupported        = &APIError{Status: http.StatusNotImplemented, Code: "SYNC_NOT_SUPPORTED", Message: "Sync is not supported by the SQLite database"}
+	ErrDatabase                = &APIError{Status: http.StatusInternalServerError, Code: "DB_ERROR", Message: "Database error occurred"}
+)
+
+// AsAPIError は err を APIError に変換する。APIError 以外のエラーはデータベースエラーとして扱う
+func AsAPIError(err error) *APIError {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	return ErrDatabase
+}
+
+// SendAPIError は err をエラーレスポンスとして送信する。APIError 以外のエラーはログに出力する
+func SendAPIError(w http.ResponseWriter, err error) {
+	apiErr := AsAPIError(err)
+	if apiErr == ErrDatabase {
+		log.Printf("Database error: %v", err)
+	}
+	if len(apiErr.Details) == 0 {
+		SendErrorResponse(w, apiErr.Status, apiErr.Code, apiErr.Message)
+		return
+	}
+	errResp := types.ErrorResponse{Error: types.ErrorDetail{Code: apiErr.Code, Message: apiErr.Message, Details: &apiErr.Details}}
+	SendJSONResponse(w, apiErr.Status, errResp)
+}
+
+// NewValidationError は項目ごとの検証エラーから VALIDATION_ERROR の APIError を作成する
+// メッセージには全ての項目のエラーを「項目: 内容」の形式で含める
+func NewValidationError(details []types.FieldError) *APIError {
+	messages := make([]string, len(details))
+	for i, d := range details {
+		messages[i] = d.Field + ": " + d.Message
+	}
+	return &APIError{
+		Status:  http.StatusBadRequest,
+		Code:    "VALIDATION_ERROR",
+		Message: strings.Join(messages, "; "),
+		Details: details,
+	}
+}
+
+// NewInvalidParameterError はクエリパラメータなどの検証エラーから INVALID_PARAMETER の APIError を作成する
+func NewInvalidParameterError(message string) *APIError {
+	return &APIError{Status: http.StatusBadRequest, Code: "INVALID_PARAMETER", Message: message}
+}
@@ -5,8 +5,9 @@ import (
 	"log"
 	"net/http"
 
-	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/api"
 	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/webhookdelivery"
 	"github.com/t-okuji/go-openapi-todo-demo/types"
 )
 
@@ -27,22 +28,13 @@ func SendErrorResponse(w http.ResponseWriter, status int, code string, message s
 	SendJSONResponse(w, status, errResp)
 }
 
-// ParseUUID は文字列をUUIDとしてパースし、エラーがあればエラーレスポンスを送信する
-func ParseUUID(w http.ResponseWriter, uuidStr string) (uuid.UUID, bool) {
-	id, err := uuid.Parse(uuidStr)
-	if err != nil {
-		SendErrorResponse(w, http.StatusBadRequest, "INVALID_UUID", "Invalid UUID format")
-		return uuid.UUID{}, false
-	}
-	return id, true
-}
-
 // ConvertToTodoResponse は Ent の Todo エンティティを TodoResponse に変換する
 func ConvertToTodoResponse(todo *ent.Todo) types.TodoResponse {
 	response := types.TodoResponse{
-		ID:        todo.ID.String(),
+		ID:        todo.ID,
 		Title:     todo.Title,
 		Completed: todo.Completed,
+		Version:   todo.Version,
 		CreatedAt: todo.CreatedAt,
 	}
 
@@ -52,8 +44,21 @@ func ConvertToTodoResponse(todo *ent.Todo) types.TodoResponse {
 	}
 
 	if todo.CategoryID != nil {
-		categoryID := todo.CategoryID.String()
-		response.CategoryID = &categoryID
+		response.CategoryID = todo.CategoryID
+	}
+
+	if todo.DueAt != nil {
+		response.DueAt = todo.DueAt
+	}
+
+	if todo.Recurrence != "" {
+		response.Recurrence = &todo.Recurrence
+		response.TimeZone = &todo.RecurrenceTimeZone
+	}
+
+	if todo.SeriesID != nil {
+		response.SeriesID = todo.SeriesID
+		response.Occurrence = todo.Occurrence
 	}
 
 	if !todo.UpdatedAt.IsZero() {
@@ -66,7 +71,7 @@ func ConvertToTodoResponse(todo *ent.Todo) types.TodoResponse {
 // ConvertToCategoryResponse は Ent の Category エンティティを CategoryResponse に変換する
 func ConvertToCategoryResponse(category *ent.Category) types.CategoryResponse {
 	response := types.CategoryResponse{
-		ID:        category.ID.String(),
+		ID:        category.ID,
 		Name:      category.Name,
 		Color:     category.Color,
 		CreatedAt: category.CreatedAt,
@@ -83,3 +88,89 @@ func ConvertToCategoryResponse(category *ent.Category) types.CategoryResponse {
 
 	return response
 }
+
+// ConvertToAuditEventResponse は Ent の AuditEvent エンティティを AuditEventResponse に変換する
+func ConvertToAuditEventResponse(event *ent.AuditEvent) types.AuditEventResponse {
+	response := types.AuditEventResponse{
+		ID:         int64(event.ID),
+		Actor:      event.Actor,
+		Operation:  api.AuditEventOperation(event.Operation),
+		EntityType: api.AuditEventEntityType(event.EntityType),
+		EntityID:   event.EntityID,
+		Changes:    event.Changes,
+		CreatedAt:  event.CreatedAt,
+	}
+
+	// オプションフィールドの処理
+	if event.RequestID != "" {
+		response.RequestID = &event.RequestID
+	}
+
+	return response
+}
+
+// ConvertToWebhookResponse は Ent の WebhookSubscription エンティティを WebhookResponse に変換する
+func ConvertToWebhookResponse(subscription *ent.WebhookSubscription) types.WebhookResponse {
+	response := types.WebhookResponse{
+		ID:         subscription.ID,
+		URL:        subscription.URL,
+		EventTypes: make([]api.EventType, len(subscription.EventTypes)),
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt,
+	}
+
+	for i, eventType := range subscription.EventTypes {
+		response.EventTypes[i] = api.EventType(eventType)
+	}
+
+	// オプションフィールドの処理
+	if !subscription.UpdatedAt.IsZero() {
+		response.UpdatedAt = &subscription.UpdatedAt
+	}
+
+	return response
+}
+
+// ConvertToWebhookDeliveryResponse は Ent の WebhookDelivery エンティティを WebhookDeliveryResponse に変換する
+func ConvertToWebhookDeliveryResponse(delivery *ent.WebhookDelivery) types.WebhookDeliveryResponse {
+	response := types.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		WebhookID:      delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      api.EventType(delivery.EventType),
+		Status:         api.WebhookDeliveryStatus(delivery.Status),
+		Attempts:       delivery.Attempts,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+	// 配信内容は送信時と同じ WebhookPayload の JSON として保存している
+	if err := json.Unmarshal(delivery.Payload, &response.Payload); err != nil {
+		log.Printf("Webhook delivery %s payload decode error: %v", delivery.ID, err)
+	}
+
+	// 次回の送信予定は送信待ちの場合のみ返す
+	if delivery.Status == webhookdelivery.StatusPending {
+		response.NextAttemptAt = &delivery.NextAttemptAt
+	}
+
+	return response
+}
+
+// ConvertToReminderResponse は Ent の Reminder エンティティを ReminderResponse に変換する
+func ConvertToReminderResponse(r *ent.Reminder) types.ReminderResponse {
+	return types.ReminderResponse{
+		ID:            r.ID,
+		TodoID:        r.TodoID,
+		RemindAt:      r.RemindAt,
+		OffsetMinutes: r.OffsetMinutes,
+		FireAt:        r.FireAt,
+		Status:        api.ReminderStatus(r.Status),
+		Attempts:      r.Attempts,
+		LastError:     r.LastError,
+		SentAt:        r.SentAt,
+		CreatedAt:     r.CreatedAt,
+	}
+}
@@ -0,0 +1,233 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/webhookdelivery"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/webhooksubscription"
+	"github.com/t-okuji/go-openapi-todo-demo/worker"
+)
+
+const (
+	// MaxAttempts は配信を諦めて dead とするまでの送信回数
+	MaxAttempts = 10
+
+	pollInterval   = 1 * time.Second
+	batchSize      = 20
+	concurrency    = 8
+	requestTimeout = 10 * time.Second
+
+	// claimLease は送信中の配信を他のレプリカが取得しないよう次回送信予定を延ばす期間
+	// 送信中にプロセスが停止した場合は、この期間の経過後に再送される
+	claimLease = 2 * time.Minute
+)
+
+// retryBackoff は送信に失敗した配信を再送するまでの待ち時間
+var retryBackoff = worker.Backoff{Base: 10 * time.Second, Max: time.Hour}
+
+// Dispatcher は送信待ちの配信記録（アウトボックス）を読み出して Webhook を送信する
+//
+// 複数のレプリカで実行しても、各配信記録は同時にひとつのレプリカだけが送信する。
+// 送信に失敗した配信は指数バックオフで再送し、MaxAttempts 回失敗すると dead とする。
+type Dispatcher struct {
+	client     *ent.Client
+	httpClient *http.Client
+}
+
+// NewDispatcher は client の配信記録を送信する Dispatcher を作成する
+//
+// 内部ネットワークへのリクエストに利用されないよう、送信先が公開アドレス以外に解決された場合は
+// 接続せずに失敗とし（checkPublicAddress）、リダイレクトにも従わない。
+func NewDispatcher(client *ent.Client) *Dispatcher {
+	return &Dispatcher{
+		client:     client,
+		httpClient: newHTTPClient(checkPublicAddress),
+	}
+}
+
+// ErrForbiddenAddress は送信先が公開アドレス以外に解決された場合のエラー
+var ErrForbiddenAddress = errors.New("webhook destination resolves to a non-public address")
+
+// forbiddenPrefixes は netip.Addr のメソッドで判定できない、送信を禁止するアドレス範囲
+var forbiddenPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "このネットワーク"
+	netip.MustParsePrefix("100.64.0.0/10"), // キャリアグレード NAT（一部のクラウドのメタデータサービスを含む）
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF プロトコル割り当て
+	netip.MustParsePrefix("198.18.0.0/15"), // ベンチマーク用
+}
+
+// newHTTPClient は接続直前に control で接続先のアドレスを検証し、リダイレクトに従わない HTTP クライアントを作成する
+// control が nil の場合は接続先を検証しない
+func newHTTPClient(control func(network, address string, c syscall.RawConn) error) *http.Client {
+	dialer := &net.Dialer{Timeout: requestTimeout, Control: control}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// プロキシを経由すると接続先の検証がプロキシのアドレスに対して行われるため使用しない
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   requestTimeout,
+		Transport: transport,
+		// リダイレクトのレスポンスは 2xx 以外のレスポンスとして失敗にする
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// checkPublicAddress は名前解決後の接続先が公開アドレスであることを検証する
+//
+// ループバック・プライベート・リンクローカル（169.254.169.254 などのメタデータサービスを含む）・
+// マルチキャスト・未指定のアドレスへの接続を拒否する。名前解決の結果に対して検証するため、
+// DNS リバインディングでも内部ネットワークに接続できない。
+func checkPublicAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	addr = addr.Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, addr)
+	}
+	for _, prefix := range forbiddenPrefixes {
+		if prefix.Contains(addr) {
+			return fmt.Errorf("%w: %s", ErrForbiddenAddress, addr)
+		}
+	}
+	return nil
+}
+
+// Run は ctx が終了するまで送信待ちの配信記録を定期的に送信する
+func (d *Dispatcher) Run(ctx context.Context) {
+	worker.Poll(ctx, "Webhook dispatch", pollInterval, batchSize, d.dispatchDue)
+}
+
+// dispatchDue は送信予定時刻を過ぎた配信記録を取得して送信し、取得した件数を返す
+// 無効にした Webhook の配信記録は、無効にする前に作成されたものも送信しない
+func (d *Dispatcher) dispatchDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	due, err := d.client.WebhookDelivery.Query().
+		Where(
+			webhookdelivery.StatusEQ(webhookdelivery.StatusPending),
+			webhookdelivery.NextAttemptAtLTE(now),
+			webhookdelivery.HasSubscriptionWith(webhooksubscription.Active(true)),
+		).
+		Order(ent.Asc(webhookdelivery.FieldNextAttemptAt)).
+		Limit(batchSize).
+		WithSubscription().
+		All(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	claimed := make([]*ent.WebhookDelivery, 0, len(due))
+	for _, delivery := range due {
+		// 次回送信予定を延ばして配信を確保する。他のレプリカが先に確保した場合はスキップする
+		n, err := d.client.WebhookDelivery.Update().
+			Where(
+				webhookdelivery.ID(delivery.ID),
+				webhookdelivery.StatusEQ(webhookdelivery.StatusPending),
+				webhookdelivery.NextAttemptAt(delivery.NextAttemptAt),
+			).
+			SetNextAttemptAt(now.Add(claimLease)).
+			Save(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if n == 1 {
+			claimed = append(claimed, delivery)
+		}
+	}
+
+	worker.Each(claimed, concurrency, func(delivery *ent.WebhookDelivery) {
+		if err := d.deliver(ctx, delivery); err != nil {
+			log.Printf("Webhook delivery %s update error: %v", delivery.ID, err)
+		}
+	})
+
+	return len(due), nil
+}
+
+// deliver は Webhook をひとつ送信し、結果を配信記録に書き込む
+func (d *Dispatcher) deliver(ctx context.Context, delivery *ent.WebhookDelivery) error {
+	subscription := delivery.Edges.Subscription
+	now := time.Now()
+	attempts := delivery.Attempts + 1
+
+	responseStatus, sendErr := d.send(ctx, subscription, delivery, now)
+
+	update := d.client.WebhookDelivery.UpdateOneID(delivery.ID).
+		SetAttempts(attempts).
+		SetLastAttemptAt(now)
+	if responseStatus != 0 {
+		update.SetResponseStatus(responseStatus)
+	} else {
+		update.ClearResponseStatus()
+	}
+
+	switch {
+	case sendErr == nil:
+		update.
+			SetStatus(webhookdelivery.StatusSucceeded).
+			SetDeliveredAt(now).
+			ClearLastError()
+	case attempts >= MaxAttempts:
+		update.
+			SetStatus(webhookdelivery.StatusDead).
+			SetLastError(worker.ErrorMessage(sendErr))
+	default:
+		update.
+			SetNextAttemptAt(now.Add(retryBackoff.Delay(attempts))).
+			SetLastError(worker.ErrorMessage(sendErr))
+	}
+
+	// 送信処理の終了時に ctx がキャンセルされていても結果は記録する
+	return update.Exec(context.WithoutCancel(ctx))
+}
+
+// send は Webhook の HTTP リクエストを送信し、レスポンスのステータスコードを返す
+// 2xx 以外のレスポンスはエラーとする
+func (d *Dispatcher) send(ctx context.Context, subscription *ent.WebhookSubscription, delivery *ent.WebhookDelivery, now time.Time) (int, error) {
+	body := []byte(delivery.Payload)
+	timestamp := now.Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "go-openapi-todo-demo-webhooks/1.0")
+	req.Header.Set(DeliveryHeader, delivery.ID.String())
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(subscription.Secret, timestamp, body))
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// コネクションを再利用できるようレスポンスボディを読み捨てる
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
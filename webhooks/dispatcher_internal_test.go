@@ -0,0 +1,202 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/t-okuji/go-openapi-todo-demo/database"
+	"github.com/t-okuji/go-openapi-todo-demo/ent"
+	"github.com/t-okuji/go-openapi-todo-demo/ent/webhookdelivery"
+)
+
+const testSecret = "whsec_test_secret_value"
+
+// newDelivery はテストごとのデータベースに url を送信先とする送信待ちの配信記録を作成する
+func newDelivery(t *testing.T, url string) (*ent.Client, *ent.WebhookDelivery) {
+	t.Helper()
+	ctx := context.Background()
+	db, err := database.Open(ctx, database.DriverSQLite, "file:"+t.Name()+"?mode=memory&cache=shared")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	subscription := db.Client.WebhookSubscription.Create().
+		SetURL(url).
+		SetEventTypes([]string{"todo.created"}).
+		SetSecret(testSecret).
+		SaveX(ctx)
+	delivery := db.Client.WebhookDelivery.Create().
+		SetSubscriptionID(subscription.ID).
+		SetEventID(uuid.New()).
+		SetEventType("todo.created").
+		SetPayload([]byte(`{"type":"todo.created","data":{"title":"a"}}`)).
+		SetNextAttemptAt(time.Now().Add(-time.Second)).
+		SaveX(ctx)
+	return db.Client, delivery
+}
+
+// dispatch は送信予定時刻を過ぎた配信記録を送信し、配信記録を読み直す
+func dispatch(t *testing.T, d *Dispatcher, id uuid.UUID) *ent.WebhookDelivery {
+	t.Helper()
+	ctx := context.Background()
+	if _, err := d.dispatchDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+	return d.client.WebhookDelivery.GetX(ctx, id)
+}
+
+func TestDispatcherSignedDelivery(t *testing.T) {
+	var received atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		timestamp, err := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+		if err != nil || time.Since(time.Unix(timestamp, 0)) > time.Minute {
+			t.Errorf("timestamp header = %q", r.Header.Get(TimestampHeader))
+		}
+		if !hmac.Equal([]byte(r.Header.Get(SignatureHeader)), []byte(Sign(testSecret, timestamp, body))) {
+			t.Errorf("signature %q does not match the body", r.Header.Get(SignatureHeader))
+		}
+		if r.Header.Get(EventHeader) != "todo.created" || r.Header.Get(DeliveryHeader) == "" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("headers = %v", r.Header)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	client, delivery := newDelivery(t, ts.URL)
+	d := &Dispatcher{client: client, httpClient: newHTTPClient(nil)}
+	got := dispatch(t, d, delivery.ID)
+	if got.Status != webhookdelivery.StatusSucceeded || got.Attempts != 1 || got.ResponseStatus == nil || *got.ResponseStatus != http.StatusNoContent || got.DeliveredAt == nil || got.LastError != nil {
+		t.Errorf("delivery = %s, %d attempts, response %v, delivered %v, error %v", got.Status, got.Attempts, got.ResponseStatus, got.DeliveredAt, got.LastError)
+	}
+
+	// 送信済みの配信は再送しない
+	dispatch(t, d, delivery.ID)
+	if n := received.Load(); n != 1 {
+		t.Errorf("received %d requests, want 1", n)
+	}
+}
+
+func TestDispatcherRetry(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	client, delivery := newDelivery(t, ts.URL)
+	d := &Dispatcher{client: client, httpClient: newHTTPClient(nil)}
+
+	// 失敗した配信は待ち時間を空けて再送する
+	before := time.Now()
+	got := dispatch(t, d, delivery.ID)
+	if got.Status != webhookdelivery.StatusPending || got.Attempts != 1 || got.ResponseStatus == nil || *got.ResponseStatus != http.StatusInternalServerError {
+		t.Fatalf("delivery = %s, %d attempts, response %v", got.Status, got.Attempts, got.ResponseStatus)
+	}
+	if wait := got.NextAttemptAt.Sub(before); wait < retryBackoff.Base || wait > retryBackoff.Base*11/10+time.Second {
+		t.Errorf("next attempt in %s, want about %s", wait, retryBackoff.Base)
+	}
+	if got.LastError == nil || !strings.Contains(*got.LastError, "500") {
+		t.Errorf("last error = %v", got.LastError)
+	}
+
+	// 待ち時間が経過するまでは送信しない
+	if got = dispatch(t, d, delivery.ID); got.Attempts != 1 {
+		t.Errorf("attempts before retry delay = %d, want 1", got.Attempts)
+	}
+
+	// MaxAttempts 回失敗すると dead とし、以降は送信しない
+	client.WebhookDelivery.UpdateOneID(delivery.ID).
+		SetAttempts(MaxAttempts - 1).
+		SetNextAttemptAt(time.Now().Add(-time.Second)).
+		ExecX(context.Background())
+	if got = dispatch(t, d, delivery.ID); got.Status != webhookdelivery.StatusDead || got.Attempts != MaxAttempts {
+		t.Errorf("delivery after %d attempts = %s", got.Attempts, got.Status)
+	}
+}
+
+func TestDispatcherSkipsInactiveWebhooks(t *testing.T) {
+	var received atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Add(1)
+	}))
+	defer ts.Close()
+
+	// 無効にする前に作成された配信記録も送信しない
+	client, delivery := newDelivery(t, ts.URL)
+	ctx := context.Background()
+	client.WebhookSubscription.UpdateOneID(delivery.SubscriptionID).SetActive(false).ExecX(ctx)
+	d := &Dispatcher{client: client, httpClient: newHTTPClient(nil)}
+	if got := dispatch(t, d, delivery.ID); got.Status != webhookdelivery.StatusPending || got.Attempts != 0 {
+		t.Errorf("delivery of inactive webhook = %s, %d attempts, want pending without attempts", got.Status, got.Attempts)
+	}
+	if n := received.Load(); n != 0 {
+		t.Errorf("received %d requests for an inactive webhook", n)
+	}
+
+	// 再び有効にすると送信する
+	client.WebhookSubscription.UpdateOneID(delivery.SubscriptionID).SetActive(true).ExecX(ctx)
+	if got := dispatch(t, d, delivery.ID); got.Status != webhookdelivery.StatusSucceeded || received.Load() != 1 {
+		t.Errorf("delivery after reactivation = %s, %d requests", got.Status, received.Load())
+	}
+}
+
+func TestDispatcherRefusesRedirects(t *testing.T) {
+	var redirected atomic.Bool
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected.Store(true)
+	}))
+	defer target.Close()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer ts.Close()
+
+	client, delivery := newDelivery(t, ts.URL)
+	d := &Dispatcher{client: client, httpClient: newHTTPClient(nil)}
+	got := dispatch(t, d, delivery.ID)
+	if got.Status != webhookdelivery.StatusPending || got.ResponseStatus == nil || *got.ResponseStatus != http.StatusTemporaryRedirect || redirected.Load() {
+		t.Errorf("delivery = %s, response %v, redirected %t", got.Status, got.ResponseStatus, redirected.Load())
+	}
+}
+
+func TestDispatcherRefusesPrivateAddresses(t *testing.T) {
+	var received atomic.Bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received.Store(true)
+	}))
+	defer ts.Close()
+
+	// NewDispatcher の HTTP クライアントはループバックアドレスの受信側に接続しない
+	client, delivery := newDelivery(t, ts.URL)
+	got := dispatch(t, NewDispatcher(client), delivery.ID)
+	if received.Load() || got.ResponseStatus != nil || got.LastError == nil || !strings.Contains(*got.LastError, ErrForbiddenAddress.Error()) {
+		t.Errorf("delivery = response %v, error %v, received %t", got.ResponseStatus, got.LastError, received.Load())
+	}
+
+	for _, address := range []string{
+		"127.0.0.1:80", "[::1]:443", "10.0.0.5:80", "172.16.3.4:80", "192.168.1.1:80",
+		"169.254.169.254:80", "[fd00:ec2::254]:80", "100.100.100.200:80", "0.0.0.0:80",
+		"[::ffff:127.0.0.1]:80", "[fe80::1]:80", "224.0.0.1:80", "[::]:80",
+	} {
+		if err := checkPublicAddress("tcp", address, nil); !errors.Is(err, ErrForbiddenAddress) {
+			t.Errorf("checkPublicAddress(%s) = %v, want ErrForbiddenAddress", address, err)
+		}
+	}
+	for _, address := range []string{"93.184.216.34:443", "[2606:4700:4700::1111]:443", "8.8.8.8:80"} {
+		if err := checkPublicAddress("tcp", address, nil); err != nil {
+			t.Errorf("checkPublicAddress(%s) = %v, want nil", address, err)
+		}
+	}
+}
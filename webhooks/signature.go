@@ -0,0 +1,42 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// Webhook のリクエストヘッダー名
+const (
+	// DeliveryHeader は配信記録のID（再送時も同じ値）
+	DeliveryHeader = "X-Webhook-Delivery"
+	// EventHeader は変更イベントの種別
+	EventHeader = "X-Webhook-Event"
+	// TimestampHeader は送信時刻（Unix 秒）
+	TimestampHeader = "X-Webhook-Timestamp"
+	// SignatureHeader は "sha256=" に続く HMAC-SHA256 署名（16進数）
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+// secretPrefix は自動生成したシークレットの接頭辞
+const secretPrefix = "whsec_"
+
+// Sign は "タイムスタンプ.リクエストボディ" に対する HMAC-SHA256 署名を SignatureHeader の形式で返す
+//
+// 受信側は同じ方法で計算した署名と hmac.Equal で比較し、タイムスタンプが古すぎないことを確認する。
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// GenerateSecret は署名用のランダムなシークレットを生成する
+func GenerateSecret() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return secretPrefix + hex.EncodeToString(b)
+}
@@ -0,0 +1,102 @@
+// Package worker はデータベースの送信待ちの記録を定期的に処理するバックグラウンド処理の共通部分を提供する
+//
+// Webhook の配信（webhooks.Dispatcher）とリマインダーの送信（reminders.Scheduler）が、
+// ポーリングのループ・並行数を制限した送信・失敗時の再送の待ち時間・エラー内容の切り詰めを共有する。
+package worker
+
+import (
+	"context"
+	"log"
+	"math/rand/v2"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxErrorMessage は記録するエラー内容の最大バイト数
+const MaxErrorMessage = 1024
+
+// Poll は ctx が終了するまで interval ごとに process を呼び出す
+//
+// process は処理した件数を返す。batchSize 件を処理した場合は残りがあるものとして待たずに続けて呼び出す。
+// process のエラーは name を付けてログに出力し、次の周期で再び呼び出す。
+func Poll(ctx context.Context, name string, interval time.Duration, batchSize int, process func(context.Context) (int, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// 処理対象が残っている間は待たずに続けて処理する
+		for {
+			n, err := process(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("%s error: %v", name, err)
+				}
+				break
+			}
+			if n < batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Each は items の各要素に対して fn を最大 concurrency 個ずつ並行に実行し、全ての完了を待つ
+func Each[T any](items []T, concurrency int, fn func(T)) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for _, item := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(item)
+		}()
+	}
+	wg.Wait()
+}
+
+// Backoff は送信に失敗した後の再送までの待ち時間（指数バックオフ）
+type Backoff struct {
+	// Base は1回目の失敗の後の待ち時間
+	Base time.Duration
+	// Max は待ち時間の上限
+	Max time.Duration
+}
+
+// Delay は attempts 回目の送信に失敗した後、次に送信するまでの待ち時間を返す
+//
+// 待ち時間は失敗するごとに倍になり（最大 Max）、同時に失敗した送信の再送が
+// 集中しないよう最大 10% のゆらぎを加える。
+func (b Backoff) Delay(attempts int) time.Duration {
+	delay := b.Max
+	if attempts < 20 {
+		delay = min(b.Base<<(max(attempts, 1)-1), b.Max)
+	}
+	return delay + rand.N(delay/10+1)
+}
+
+// ErrorMessage は記録用に err の内容を MaxErrorMessage バイト以内に切り詰めて返す
+func ErrorMessage(err error) string {
+	return truncate(err.Error(), MaxErrorMessage)
+}
+
+// truncate は s を n バイト以内に切り詰める。UTF-8 の文字の途中では切らない
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,110 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	backoff := Backoff{Base: 10 * time.Second, Max: time.Hour}
+	tests := []struct {
+		attempts int
+		base     time.Duration
+	}{
+		{0, 10 * time.Second},
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{5, 160 * time.Second},
+		{9, 2560 * time.Second},
+		{10, time.Hour},
+		{64, time.Hour},
+	}
+	for _, tt := range tests {
+		for range 20 {
+			if got := backoff.Delay(tt.attempts); got < tt.base || got > tt.base+tt.base/10 {
+				t.Errorf("Delay(%d) = %s, want %s to %s", tt.attempts, got, tt.base, tt.base+tt.base/10)
+			}
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"abc", 3, "abc"},
+		{"abcd", 3, "abc"},
+		{"あいう", 9, "あいう"},
+		// 3 バイトの文字の途中では切らない
+		{"あいう", 8, "あい"},
+		{"あいう", 7, "あい"},
+		{"aあ", 2, "a"},
+		{"あ", 2, ""},
+	} {
+		if got := truncate(tt.s, tt.n); got != tt.want || !utf8.ValidString(got) {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+	}
+
+	long := errors.New(strings.Repeat("エラー", MaxErrorMessage))
+	if got := ErrorMessage(long); len(got) > MaxErrorMessage || !utf8.ValidString(got) {
+		t.Errorf("ErrorMessage of a long error: %d bytes, valid UTF-8 %t", len(got), utf8.ValidString(got))
+	}
+}
+
+func TestEach(t *testing.T) {
+	var running, peak, sum atomic.Int32
+	Each([]int32{1, 2, 3, 4, 5, 6, 7, 8}, 3, func(n int32) {
+		current := running.Add(1)
+		for {
+			p := peak.Load()
+			if current <= p || peak.CompareAndSwap(p, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		sum.Add(n)
+		running.Add(-1)
+	})
+	if sum.Load() != 36 || peak.Load() > 3 {
+		t.Errorf("sum %d, peak concurrency %d, want 36 with at most 3", sum.Load(), peak.Load())
+	}
+}
+
+func TestPoll(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// batchSize 件を処理した間は待たずに続けて呼び出し、それより少なければ次の周期まで待つ
+	var calls atomic.Int32
+	results := []int{2, 2, 1, 0}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Poll(ctx, "test", time.Hour, 2, func(context.Context) (int, error) {
+			i := int(calls.Add(1)) - 1
+			if i == 2 {
+				cancel()
+			}
+			if i < len(results) {
+				return results[i], nil
+			}
+			return 0, errors.New("called after a partial batch")
+		})
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Poll did not return after ctx was canceled")
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("process called %d times, want 3", n)
+	}
+}